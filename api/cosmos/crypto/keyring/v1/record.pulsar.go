@@ -2,6 +2,7 @@
 package keyringv1
 
 import (
+	v1beta1 "cosmossdk.io/api/cosmos/base/v1beta1"
 	v1 "cosmossdk.io/api/cosmos/crypto/hd/v1"
 	fmt "fmt"
 	runtime "github.com/cosmos/cosmos-proto/runtime"
@@ -23,6 +24,8 @@ var (
 	fd_Record_ledger  protoreflect.FieldDescriptor
 	fd_Record_multi   protoreflect.FieldDescriptor
 	fd_Record_offline protoreflect.FieldDescriptor
+	fd_Record_remote  protoreflect.FieldDescriptor
+	fd_Record_policy  protoreflect.FieldDescriptor
 )
 
 func init() {
@@ -34,6 +37,8 @@ func init() {
 	fd_Record_ledger = md_Record.Fields().ByName("ledger")
 	fd_Record_multi = md_Record.Fields().ByName("multi")
 	fd_Record_offline = md_Record.Fields().ByName("offline")
+	fd_Record_remote = md_Record.Fields().ByName("remote")
+	fd_Record_policy = md_Record.Fields().ByName("policy")
 }
 
 var _ protoreflect.Message = (*fastReflection_Record)(nil)
@@ -139,6 +144,18 @@ func (x *fastReflection_Record) Range(f func(protoreflect.FieldDescriptor, proto
 			if !f(fd_Record_offline, value) {
 				return
 			}
+		case *Record_Remote_:
+			v := o.Remote
+			value := protoreflect.ValueOfMessage(v.ProtoReflect())
+			if !f(fd_Record_remote, value) {
+				return
+			}
+		}
+	}
+	if x.Policy != nil {
+		value := protoreflect.ValueOfMessage(x.Policy.ProtoReflect())
+		if !f(fd_Record_policy, value) {
+			return
 		}
 	}
 }
@@ -192,6 +209,16 @@ func (x *fastReflection_Record) Has(fd protoreflect.FieldDescriptor) bool {
 		} else {
 			return false
 		}
+	case "cosmos.crypto.keyring.v1.Record.remote":
+		if x.Item == nil {
+			return false
+		} else if _, ok := x.Item.(*Record_Remote_); ok {
+			return true
+		} else {
+			return false
+		}
+	case "cosmos.crypto.keyring.v1.Record.policy":
+		return x.Policy != nil
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record"))
@@ -220,6 +247,10 @@ func (x *fastReflection_Record) Clear(fd protoreflect.FieldDescriptor) {
 		x.Item = nil
 	case "cosmos.crypto.keyring.v1.Record.offline":
 		x.Item = nil
+	case "cosmos.crypto.keyring.v1.Record.remote":
+		x.Item = nil
+	case "cosmos.crypto.keyring.v1.Record.policy":
+		x.Policy = nil
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record"))
@@ -274,6 +305,17 @@ func (x *fastReflection_Record) Get(descriptor protoreflect.FieldDescriptor) pro
 		} else {
 			return protoreflect.ValueOfMessage((*Record_Offline)(nil).ProtoReflect())
 		}
+	case "cosmos.crypto.keyring.v1.Record.remote":
+		if x.Item == nil {
+			return protoreflect.ValueOfMessage((*Record_Remote)(nil).ProtoReflect())
+		} else if v, ok := x.Item.(*Record_Remote_); ok {
+			return protoreflect.ValueOfMessage(v.Remote.ProtoReflect())
+		} else {
+			return protoreflect.ValueOfMessage((*Record_Remote)(nil).ProtoReflect())
+		}
+	case "cosmos.crypto.keyring.v1.Record.policy":
+		value := x.Policy
+		return protoreflect.ValueOfMessage(value.ProtoReflect())
 	default:
 		if descriptor.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record"))
@@ -310,6 +352,11 @@ func (x *fastReflection_Record) Set(fd protoreflect.FieldDescriptor, value proto
 	case "cosmos.crypto.keyring.v1.Record.offline":
 		cv := value.Message().Interface().(*Record_Offline)
 		x.Item = &Record_Offline_{Offline: cv}
+	case "cosmos.crypto.keyring.v1.Record.remote":
+		cv := value.Message().Interface().(*Record_Remote)
+		x.Item = &Record_Remote_{Remote: cv}
+	case "cosmos.crypto.keyring.v1.Record.policy":
+		x.Policy = value.Message().Interface().(*KeyPolicy)
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record"))
@@ -399,6 +446,27 @@ func (x *fastReflection_Record) Mutable(fd protoreflect.FieldDescriptor) protore
 			x.Item = oneofValue
 			return protoreflect.ValueOfMessage(value.ProtoReflect())
 		}
+	case "cosmos.crypto.keyring.v1.Record.remote":
+		if x.Item == nil {
+			value := &Record_Remote{}
+			oneofValue := &Record_Remote_{Remote: value}
+			x.Item = oneofValue
+			return protoreflect.ValueOfMessage(value.ProtoReflect())
+		}
+		switch m := x.Item.(type) {
+		case *Record_Remote_:
+			return protoreflect.ValueOfMessage(m.Remote.ProtoReflect())
+		default:
+			value := &Record_Remote{}
+			oneofValue := &Record_Remote_{Remote: value}
+			x.Item = oneofValue
+			return protoreflect.ValueOfMessage(value.ProtoReflect())
+		}
+	case "cosmos.crypto.keyring.v1.Record.policy":
+		if x.Policy == nil {
+			x.Policy = new(KeyPolicy)
+		}
+		return protoreflect.ValueOfMessage(x.Policy.ProtoReflect())
 	case "cosmos.crypto.keyring.v1.Record.name":
 		panic(fmt.Errorf("field name of message cosmos.crypto.keyring.v1.Record is not mutable"))
 	default:
@@ -431,6 +499,12 @@ func (x *fastReflection_Record) NewField(fd protoreflect.FieldDescriptor) protor
 	case "cosmos.crypto.keyring.v1.Record.offline":
 		value := &Record_Offline{}
 		return protoreflect.ValueOfMessage(value.ProtoReflect())
+	case "cosmos.crypto.keyring.v1.Record.remote":
+		value := &Record_Remote{}
+		return protoreflect.ValueOfMessage(value.ProtoReflect())
+	case "cosmos.crypto.keyring.v1.Record.policy":
+		m := new(KeyPolicy)
+		return protoreflect.ValueOfMessage(m.ProtoReflect())
 	default:
 		if fd.IsExtension() {
 			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record"))
@@ -457,6 +531,8 @@ func (x *fastReflection_Record) WhichOneof(d protoreflect.OneofDescriptor) proto
 			return x.Descriptor().Fields().ByName("multi")
 		case *Record_Offline_:
 			return x.Descriptor().Fields().ByName("offline")
+		case *Record_Remote_:
+			return x.Descriptor().Fields().ByName("remote")
 		}
 	default:
 		panic(fmt.Errorf("%s is not a oneof field in cosmos.crypto.keyring.v1.Record", d.FullName()))
@@ -547,6 +623,16 @@ func (x *fastReflection_Record) ProtoMethods() *protoiface.Methods {
 			}
 			l = options.Size(x.Offline)
 			n += 1 + l + runtime.Sov(uint64(l))
+		case *Record_Remote_:
+			if x == nil {
+				break
+			}
+			l = options.Size(x.Remote)
+			n += 1 + l + runtime.Sov(uint64(l))
+		}
+		if x.Policy != nil {
+			l = options.Size(x.Policy)
+			n += 1 + l + runtime.Sov(uint64(l))
 		}
 		if x.unknownFields != nil {
 			n += len(x.unknownFields)
@@ -630,6 +716,33 @@ func (x *fastReflection_Record) ProtoMethods() *protoiface.Methods {
 			i = runtime.EncodeVarint(dAtA, i, uint64(len(encoded)))
 			i--
 			dAtA[i] = 0x32
+		case *Record_Remote_:
+			encoded, err := options.Marshal(x.Remote)
+			if err != nil {
+				return protoiface.MarshalOutput{
+					NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+					Buf:               input.Buf,
+				}, err
+			}
+			i -= len(encoded)
+			copy(dAtA[i:], encoded)
+			i = runtime.EncodeVarint(dAtA, i, uint64(len(encoded)))
+			i--
+			dAtA[i] = 0x3a
+		}
+		if x.Policy != nil {
+			encoded, err := options.Marshal(x.Policy)
+			if err != nil {
+				return protoiface.MarshalOutput{
+					NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+					Buf:               input.Buf,
+				}, err
+			}
+			i -= len(encoded)
+			copy(dAtA[i:], encoded)
+			i = runtime.EncodeVarint(dAtA, i, uint64(len(encoded)))
+			i--
+			dAtA[i] = 0x42
 		}
 		if x.PubKey != nil {
 			encoded, err := options.Marshal(x.PubKey)
@@ -909,6 +1022,77 @@ func (x *fastReflection_Record) ProtoMethods() *protoiface.Methods {
 				}
 				x.Item = &Record_Offline_{v}
 				iNdEx = postIndex
+			case 7:
+				if wireType != 2 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field Remote", wireType)
+				}
+				var msglen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					msglen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if msglen < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				postIndex := iNdEx + msglen
+				if postIndex < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if postIndex > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				v := &Record_Remote{}
+				if err := options.Unmarshal(dAtA[iNdEx:postIndex], v); err != nil {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, err
+				}
+				x.Item = &Record_Remote_{v}
+				iNdEx = postIndex
+			case 8:
+				if wireType != 2 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+				}
+				var msglen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					msglen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if msglen < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				postIndex := iNdEx + msglen
+				if postIndex < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if postIndex > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				if x.Policy == nil {
+					x.Policy = &KeyPolicy{}
+				}
+				if err := options.Unmarshal(dAtA[iNdEx:postIndex], x.Policy); err != nil {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, err
+				}
+				iNdEx = postIndex
 			default:
 				iNdEx = preIndex
 				skippy, err := runtime.Skip(dAtA[iNdEx:])
@@ -964,7 +1148,7 @@ func (x *Record_Local) ProtoReflect() protoreflect.Message {
 }
 
 func (x *Record_Local) slowProtoReflect() protoreflect.Message {
-	mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[1]
+	mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1399,7 +1583,7 @@ func (x *Record_Ledger) ProtoReflect() protoreflect.Message {
 }
 
 func (x *Record_Ledger) slowProtoReflect() protoreflect.Message {
-	mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[2]
+	mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1832,7 +2016,7 @@ func (x *Record_Multi) ProtoReflect() protoreflect.Message {
 }
 
 func (x *Record_Multi) slowProtoReflect() protoreflect.Message {
-	mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[3]
+	mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2188,7 +2372,7 @@ func (x *Record_Offline) ProtoReflect() protoreflect.Message {
 }
 
 func (x *Record_Offline) slowProtoReflect() protoreflect.Message {
-	mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[4]
+	mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2526,112 +2710,1355 @@ func (x *fastReflection_Record_Offline) ProtoMethods() *protoiface.Methods {
 	}
 }
 
-// Since: cosmos-sdk 0.46
+var (
+	md_Record_Remote          protoreflect.MessageDescriptor
+	fd_Record_Remote_endpoint protoreflect.FieldDescriptor
+	fd_Record_Remote_key_id   protoreflect.FieldDescriptor
+)
 
-// Code generated by protoc-gen-go. DO NOT EDIT.
-// versions:
-// 	protoc-gen-go v1.27.0
-// 	protoc        (unknown)
-// source: cosmos/crypto/keyring/v1/record.proto
+func init() {
+	file_cosmos_crypto_keyring_v1_record_proto_init()
+	md_Record_Remote = File_cosmos_crypto_keyring_v1_record_proto.Messages().ByName("Record").Messages().ByName("Remote")
+	fd_Record_Remote_endpoint = md_Record_Remote.Fields().ByName("endpoint")
+	fd_Record_Remote_key_id = md_Record_Remote.Fields().ByName("key_id")
+}
 
-const (
-	// Verify that this generated code is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
-	// Verify that runtime/protoimpl is sufficiently up-to-date.
-	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
-)
+var _ protoreflect.Message = (*fastReflection_Record_Remote)(nil)
 
-// Record is used for representing a key in the keyring.
-type Record struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+type fastReflection_Record_Remote Record_Remote
 
-	// name represents a name of Record
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// pub_key represents a public key in any format
-	PubKey *anypb.Any `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
-	// Record contains one of the following items
-	//
-	// Types that are assignable to Item:
-	//
-	//	*Record_Local_
-	//	*Record_Ledger_
-	//	*Record_Multi_
-	//	*Record_Offline_
-	Item isRecord_Item `protobuf_oneof:"item"`
+func (x *Record_Remote) ProtoReflect() protoreflect.Message {
+	return (*fastReflection_Record_Remote)(x)
 }
 
-func (x *Record) Reset() {
-	*x = Record{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[0]
+func (x *Record_Remote) slowProtoReflect() protoreflect.Message {
+	mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
+	return mi.MessageOf(x)
 }
 
-func (x *Record) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
+var _fastReflection_Record_Remote_messageType fastReflection_Record_Remote_messageType
+var _ protoreflect.MessageType = fastReflection_Record_Remote_messageType{}
 
-func (*Record) ProtoMessage() {}
+type fastReflection_Record_Remote_messageType struct{}
 
-// Deprecated: Use Record.ProtoReflect.Descriptor instead.
-func (*Record) Descriptor() ([]byte, []int) {
-	return file_cosmos_crypto_keyring_v1_record_proto_rawDescGZIP(), []int{0}
+func (x fastReflection_Record_Remote_messageType) Zero() protoreflect.Message {
+	return (*fastReflection_Record_Remote)(nil)
+}
+func (x fastReflection_Record_Remote_messageType) New() protoreflect.Message {
+	return new(fastReflection_Record_Remote)
+}
+func (x fastReflection_Record_Remote_messageType) Descriptor() protoreflect.MessageDescriptor {
+	return md_Record_Remote
 }
 
-func (x *Record) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+// Descriptor returns message descriptor, which contains only the protobuf
+// type information for the message.
+func (x *fastReflection_Record_Remote) Descriptor() protoreflect.MessageDescriptor {
+	return md_Record_Remote
 }
 
-func (x *Record) GetPubKey() *anypb.Any {
-	if x != nil {
-		return x.PubKey
-	}
-	return nil
+// Type returns the message type, which encapsulates both Go and protobuf
+// type information. If the Go type information is not needed,
+// it is recommended that the message descriptor be used instead.
+func (x *fastReflection_Record_Remote) Type() protoreflect.MessageType {
+	return _fastReflection_Record_Remote_messageType
 }
 
-func (x *Record) GetItem() isRecord_Item {
-	if x != nil {
-		return x.Item
-	}
-	return nil
+// New returns a newly allocated and mutable empty message.
+func (x *fastReflection_Record_Remote) New() protoreflect.Message {
+	return new(fastReflection_Record_Remote)
 }
 
-func (x *Record) GetLocal() *Record_Local {
-	if x, ok := x.GetItem().(*Record_Local_); ok {
-		return x.Local
-	}
-	return nil
+// Interface unwraps the message reflection interface and
+// returns the underlying ProtoMessage interface.
+func (x *fastReflection_Record_Remote) Interface() protoreflect.ProtoMessage {
+	return (*Record_Remote)(x)
 }
 
-func (x *Record) GetLedger() *Record_Ledger {
-	if x, ok := x.GetItem().(*Record_Ledger_); ok {
-		return x.Ledger
+// Range iterates over every populated field in an undefined order,
+// calling f for each field descriptor and value encountered.
+// Range returns immediately if f returns false.
+// While iterating, mutating operations may only be performed
+// on the current field descriptor.
+func (x *fastReflection_Record_Remote) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
+	if x.Endpoint != "" {
+		value := protoreflect.ValueOfString(x.Endpoint)
+		if !f(fd_Record_Remote_endpoint, value) {
+			return
+		}
+	}
+	if x.KeyId != "" {
+		value := protoreflect.ValueOfString(x.KeyId)
+		if !f(fd_Record_Remote_key_id, value) {
+			return
+		}
 	}
-	return nil
 }
 
-func (x *Record) GetMulti() *Record_Multi {
-	if x, ok := x.GetItem().(*Record_Multi_); ok {
-		return x.Multi
+// Has reports whether a field is populated.
+//
+// Some fields have the property of nullability where it is possible to
+// distinguish between the default value of a field and whether the field
+// was explicitly populated with the default value. Singular message fields,
+// member fields of a oneof, and proto2 scalar fields are nullable. Such
+// fields are populated only if explicitly set.
+//
+// In other cases (aside from the nullable cases above),
+// a proto3 scalar field is populated if it contains a non-zero value, and
+// a repeated field is populated if it is non-empty.
+func (x *fastReflection_Record_Remote) Has(fd protoreflect.FieldDescriptor) bool {
+	switch fd.FullName() {
+	case "cosmos.crypto.keyring.v1.Record.Remote.endpoint":
+		return x.Endpoint != ""
+	case "cosmos.crypto.keyring.v1.Record.Remote.key_id":
+		return x.KeyId != ""
+	default:
+		if fd.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record.Remote"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.Record.Remote does not contain field %s", fd.FullName()))
 	}
-	return nil
 }
 
-func (x *Record) GetOffline() *Record_Offline {
-	if x, ok := x.GetItem().(*Record_Offline_); ok {
-		return x.Offline
+// Clear clears the field such that a subsequent Has call reports false.
+//
+// Clearing an extension field clears both the extension type and value
+// associated with the given field number.
+//
+// Clear is a mutating operation and unsafe for concurrent use.
+func (x *fastReflection_Record_Remote) Clear(fd protoreflect.FieldDescriptor) {
+	switch fd.FullName() {
+	case "cosmos.crypto.keyring.v1.Record.Remote.endpoint":
+		x.Endpoint = ""
+	case "cosmos.crypto.keyring.v1.Record.Remote.key_id":
+		x.KeyId = ""
+	default:
+		if fd.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record.Remote"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.Record.Remote does not contain field %s", fd.FullName()))
 	}
-	return nil
 }
 
-type isRecord_Item interface {
+// Get retrieves the value for a field.
+//
+// For unpopulated scalars, it returns the default value, where
+// the default value of a bytes scalar is guaranteed to be a copy.
+// For unpopulated composite types, it returns an empty, read-only view
+// of the value; to obtain a mutable reference, use Mutable.
+func (x *fastReflection_Record_Remote) Get(descriptor protoreflect.FieldDescriptor) protoreflect.Value {
+	switch descriptor.FullName() {
+	case "cosmos.crypto.keyring.v1.Record.Remote.endpoint":
+		value := x.Endpoint
+		return protoreflect.ValueOfString(value)
+	case "cosmos.crypto.keyring.v1.Record.Remote.key_id":
+		value := x.KeyId
+		return protoreflect.ValueOfString(value)
+	default:
+		if descriptor.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record.Remote"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.Record.Remote does not contain field %s", descriptor.FullName()))
+	}
+}
+
+// Set stores the value for a field.
+//
+// For a field belonging to a oneof, it implicitly clears any other field
+// that may be currently set within the same oneof.
+// For extension fields, it implicitly stores the provided ExtensionType.
+// When setting a composite type, it is unspecified whether the stored value
+// aliases the source's memory in any way. If the composite value is an
+// empty, read-only value, then it panics.
+//
+// Set is a mutating operation and unsafe for concurrent use.
+func (x *fastReflection_Record_Remote) Set(fd protoreflect.FieldDescriptor, value protoreflect.Value) {
+	switch fd.FullName() {
+	case "cosmos.crypto.keyring.v1.Record.Remote.endpoint":
+		x.Endpoint = value.Interface().(string)
+	case "cosmos.crypto.keyring.v1.Record.Remote.key_id":
+		x.KeyId = value.Interface().(string)
+	default:
+		if fd.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record.Remote"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.Record.Remote does not contain field %s", fd.FullName()))
+	}
+}
+
+// Mutable returns a mutable reference to a composite type.
+//
+// If the field is unpopulated, it may allocate a composite value.
+// For a field belonging to a oneof, it implicitly clears any other field
+// that may be currently set within the same oneof.
+// For extension fields, it implicitly stores the provided ExtensionType
+// if not already stored.
+// It panics if the field does not contain a composite type.
+//
+// Mutable is a mutating operation and unsafe for concurrent use.
+func (x *fastReflection_Record_Remote) Mutable(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.FullName() {
+	case "cosmos.crypto.keyring.v1.Record.Remote.endpoint":
+		panic(fmt.Errorf("field endpoint of message cosmos.crypto.keyring.v1.Record.Remote is not mutable"))
+	case "cosmos.crypto.keyring.v1.Record.Remote.key_id":
+		panic(fmt.Errorf("field key_id of message cosmos.crypto.keyring.v1.Record.Remote is not mutable"))
+	default:
+		if fd.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record.Remote"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.Record.Remote does not contain field %s", fd.FullName()))
+	}
+}
+
+// NewField returns a new value that is assignable to the field
+// for the given descriptor. For scalars, this returns the default value.
+// For lists, maps, and messages, this returns a new, empty, mutable value.
+func (x *fastReflection_Record_Remote) NewField(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.FullName() {
+	case "cosmos.crypto.keyring.v1.Record.Remote.endpoint":
+		return protoreflect.ValueOfString("")
+	case "cosmos.crypto.keyring.v1.Record.Remote.key_id":
+		return protoreflect.ValueOfString("")
+	default:
+		if fd.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.Record.Remote"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.Record.Remote does not contain field %s", fd.FullName()))
+	}
+}
+
+// WhichOneof reports which field within the oneof is populated,
+// returning nil if none are populated.
+// It panics if the oneof descriptor does not belong to this message.
+func (x *fastReflection_Record_Remote) WhichOneof(d protoreflect.OneofDescriptor) protoreflect.FieldDescriptor {
+	switch d.FullName() {
+	default:
+		panic(fmt.Errorf("%s is not a oneof field in cosmos.crypto.keyring.v1.Record.Remote", d.FullName()))
+	}
+	panic("unreachable")
+}
+
+// GetUnknown retrieves the entire list of unknown fields.
+// The caller may only mutate the contents of the RawFields
+// if the mutated bytes are stored back into the message with SetUnknown.
+func (x *fastReflection_Record_Remote) GetUnknown() protoreflect.RawFields {
+	return x.unknownFields
+}
+
+// SetUnknown stores an entire list of unknown fields.
+// The raw fields must be syntactically valid according to the wire format.
+// An implementation may panic if this is not the case.
+// Once stored, the caller must not mutate the content of the RawFields.
+// An empty RawFields may be passed to clear the fields.
+//
+// SetUnknown is a mutating operation and unsafe for concurrent use.
+func (x *fastReflection_Record_Remote) SetUnknown(fields protoreflect.RawFields) {
+	x.unknownFields = fields
+}
+
+// IsValid reports whether the message is valid.
+//
+// An invalid message is an empty, read-only value.
+//
+// An invalid message often corresponds to a nil pointer of the concrete
+// message type, but the details are implementation dependent.
+// Validity is not part of the protobuf data model, and may not
+// be preserved in marshaling or other operations.
+func (x *fastReflection_Record_Remote) IsValid() bool {
+	return x != nil
+}
+
+// ProtoMethods returns optional fastReflectionFeature-path implementations of various operations.
+// This method may return nil.
+//
+// The returned methods type is identical to
+// "google.golang.org/protobuf/runtime/protoiface".Methods.
+// Consult the protoiface package documentation for details.
+func (x *fastReflection_Record_Remote) ProtoMethods() *protoiface.Methods {
+	size := func(input protoiface.SizeInput) protoiface.SizeOutput {
+		x := input.Message.Interface().(*Record_Remote)
+		if x == nil {
+			return protoiface.SizeOutput{
+				NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+				Size:              0,
+			}
+		}
+		options := runtime.SizeInputToOptions(input)
+		_ = options
+		var n int
+		var l int
+		_ = l
+		l = len(x.Endpoint)
+		if l > 0 {
+			n += 1 + l + runtime.Sov(uint64(l))
+		}
+		l = len(x.KeyId)
+		if l > 0 {
+			n += 1 + l + runtime.Sov(uint64(l))
+		}
+		if x.unknownFields != nil {
+			n += len(x.unknownFields)
+		}
+		return protoiface.SizeOutput{
+			NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+			Size:              n,
+		}
+	}
+
+	marshal := func(input protoiface.MarshalInput) (protoiface.MarshalOutput, error) {
+		x := input.Message.Interface().(*Record_Remote)
+		if x == nil {
+			return protoiface.MarshalOutput{
+				NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+				Buf:               input.Buf,
+			}, nil
+		}
+		options := runtime.MarshalInputToOptions(input)
+		_ = options
+		size := options.Size(x)
+		dAtA := make([]byte, size)
+		i := len(dAtA)
+		_ = i
+		var l int
+		_ = l
+		if x.unknownFields != nil {
+			i -= len(x.unknownFields)
+			copy(dAtA[i:], x.unknownFields)
+		}
+		if len(x.KeyId) > 0 {
+			i -= len(x.KeyId)
+			copy(dAtA[i:], x.KeyId)
+			i = runtime.EncodeVarint(dAtA, i, uint64(len(x.KeyId)))
+			i--
+			dAtA[i] = 0x12
+		}
+		if len(x.Endpoint) > 0 {
+			i -= len(x.Endpoint)
+			copy(dAtA[i:], x.Endpoint)
+			i = runtime.EncodeVarint(dAtA, i, uint64(len(x.Endpoint)))
+			i--
+			dAtA[i] = 0xa
+		}
+		if input.Buf != nil {
+			input.Buf = append(input.Buf, dAtA...)
+		} else {
+			input.Buf = dAtA
+		}
+		return protoiface.MarshalOutput{
+			NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+			Buf:               input.Buf,
+		}, nil
+	}
+	unmarshal := func(input protoiface.UnmarshalInput) (protoiface.UnmarshalOutput, error) {
+		x := input.Message.Interface().(*Record_Remote)
+		if x == nil {
+			return protoiface.UnmarshalOutput{
+				NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+				Flags:             input.Flags,
+			}, nil
+		}
+		options := runtime.UnmarshalInputToOptions(input)
+		_ = options
+		dAtA := input.Buf
+		l := len(dAtA)
+		iNdEx := 0
+		for iNdEx < l {
+			preIndex := iNdEx
+			var wire uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				wire |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			fieldNum := int32(wire >> 3)
+			wireType := int(wire & 0x7)
+			if wireType == 4 {
+				return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: Record_Remote: wiretype end group for non-group")
+			}
+			if fieldNum <= 0 {
+				return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: Record_Remote: illegal tag %d (wire type %d)", fieldNum, wire)
+			}
+			switch fieldNum {
+			case 1:
+				if wireType != 2 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+				}
+				var stringLen uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLen |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLen := int(stringLen)
+				if intStringLen < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				postIndex := iNdEx + intStringLen
+				if postIndex < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if postIndex > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				x.Endpoint = string(dAtA[iNdEx:postIndex])
+				iNdEx = postIndex
+			case 2:
+				if wireType != 2 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field KeyId", wireType)
+				}
+				var stringLen uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLen |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLen := int(stringLen)
+				if intStringLen < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				postIndex := iNdEx + intStringLen
+				if postIndex < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if postIndex > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				x.KeyId = string(dAtA[iNdEx:postIndex])
+				iNdEx = postIndex
+			default:
+				iNdEx = preIndex
+				skippy, err := runtime.Skip(dAtA[iNdEx:])
+				if err != nil {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, err
+				}
+				if (skippy < 0) || (iNdEx+skippy) < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if (iNdEx + skippy) > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				if !options.DiscardUnknown {
+					x.unknownFields = append(x.unknownFields, dAtA[iNdEx:iNdEx+skippy]...)
+				}
+				iNdEx += skippy
+			}
+		}
+
+		if iNdEx > l {
+			return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+		}
+		return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, nil
+	}
+	return &protoiface.Methods{
+		NoUnkeyedLiterals: struct{}{},
+		Flags:             protoiface.SupportMarshalDeterministic | protoiface.SupportUnmarshalDiscardUnknown,
+		Size:              size,
+		Marshal:           marshal,
+		Unmarshal:         unmarshal,
+		Merge:             nil,
+		CheckInitialized:  nil,
+	}
+}
+
+var _ protoreflect.List = (*_KeyPolicy_1_list)(nil)
+
+type _KeyPolicy_1_list struct {
+	list *[]string
+}
+
+func (x *_KeyPolicy_1_list) Len() int {
+	if x.list == nil {
+		return 0
+	}
+	return len(*x.list)
+}
+
+func (x *_KeyPolicy_1_list) Get(i int) protoreflect.Value {
+	return protoreflect.ValueOfString((*x.list)[i])
+}
+
+func (x *_KeyPolicy_1_list) Set(i int, value protoreflect.Value) {
+	valueUnwrapped := value.String()
+	concreteValue := valueUnwrapped
+	(*x.list)[i] = concreteValue
+}
+
+func (x *_KeyPolicy_1_list) Append(value protoreflect.Value) {
+	valueUnwrapped := value.String()
+	concreteValue := valueUnwrapped
+	*x.list = append(*x.list, concreteValue)
+}
+
+func (x *_KeyPolicy_1_list) AppendMutable() protoreflect.Value {
+	panic(fmt.Errorf("AppendMutable can not be called on message KeyPolicy at list field AllowedMsgTypeUrls as it is not of Message kind"))
+}
+
+func (x *_KeyPolicy_1_list) Truncate(n int) {
+	*x.list = (*x.list)[:n]
+}
+
+func (x *_KeyPolicy_1_list) NewElement() protoreflect.Value {
+	v := ""
+	return protoreflect.ValueOfString(v)
+}
+
+func (x *_KeyPolicy_1_list) IsValid() bool {
+	return x.list != nil
+}
+
+var _ protoreflect.List = (*_KeyPolicy_2_list)(nil)
+
+type _KeyPolicy_2_list struct {
+	list *[]*v1beta1.Coin
+}
+
+func (x *_KeyPolicy_2_list) Len() int {
+	if x.list == nil {
+		return 0
+	}
+	return len(*x.list)
+}
+
+func (x *_KeyPolicy_2_list) Get(i int) protoreflect.Value {
+	return protoreflect.ValueOfMessage((*x.list)[i].ProtoReflect())
+}
+
+func (x *_KeyPolicy_2_list) Set(i int, value protoreflect.Value) {
+	valueUnwrapped := value.Message()
+	concreteValue := valueUnwrapped.Interface().(*v1beta1.Coin)
+	(*x.list)[i] = concreteValue
+}
+
+func (x *_KeyPolicy_2_list) Append(value protoreflect.Value) {
+	valueUnwrapped := value.Message()
+	concreteValue := valueUnwrapped.Interface().(*v1beta1.Coin)
+	*x.list = append(*x.list, concreteValue)
+}
+
+func (x *_KeyPolicy_2_list) AppendMutable() protoreflect.Value {
+	v := new(v1beta1.Coin)
+	*x.list = append(*x.list, v)
+	return protoreflect.ValueOfMessage(v.ProtoReflect())
+}
+
+func (x *_KeyPolicy_2_list) Truncate(n int) {
+	for i := n; i < len(*x.list); i++ {
+		(*x.list)[i] = nil
+	}
+	*x.list = (*x.list)[:n]
+}
+
+func (x *_KeyPolicy_2_list) NewElement() protoreflect.Value {
+	v := new(v1beta1.Coin)
+	return protoreflect.ValueOfMessage(v.ProtoReflect())
+}
+
+func (x *_KeyPolicy_2_list) IsValid() bool {
+	return x.list != nil
+}
+
+var _ protoreflect.List = (*_KeyPolicy_3_list)(nil)
+
+type _KeyPolicy_3_list struct {
+	list *[]string
+}
+
+func (x *_KeyPolicy_3_list) Len() int {
+	if x.list == nil {
+		return 0
+	}
+	return len(*x.list)
+}
+
+func (x *_KeyPolicy_3_list) Get(i int) protoreflect.Value {
+	return protoreflect.ValueOfString((*x.list)[i])
+}
+
+func (x *_KeyPolicy_3_list) Set(i int, value protoreflect.Value) {
+	valueUnwrapped := value.String()
+	concreteValue := valueUnwrapped
+	(*x.list)[i] = concreteValue
+}
+
+func (x *_KeyPolicy_3_list) Append(value protoreflect.Value) {
+	valueUnwrapped := value.String()
+	concreteValue := valueUnwrapped
+	*x.list = append(*x.list, concreteValue)
+}
+
+func (x *_KeyPolicy_3_list) AppendMutable() protoreflect.Value {
+	panic(fmt.Errorf("AppendMutable can not be called on message KeyPolicy at list field AllowedChainIds as it is not of Message kind"))
+}
+
+func (x *_KeyPolicy_3_list) Truncate(n int) {
+	*x.list = (*x.list)[:n]
+}
+
+func (x *_KeyPolicy_3_list) NewElement() protoreflect.Value {
+	v := ""
+	return protoreflect.ValueOfString(v)
+}
+
+func (x *_KeyPolicy_3_list) IsValid() bool {
+	return x.list != nil
+}
+
+var (
+	md_KeyPolicy                       protoreflect.MessageDescriptor
+	fd_KeyPolicy_allowed_msg_type_urls protoreflect.FieldDescriptor
+	fd_KeyPolicy_daily_limit           protoreflect.FieldDescriptor
+	fd_KeyPolicy_allowed_chain_ids     protoreflect.FieldDescriptor
+)
+
+func init() {
+	file_cosmos_crypto_keyring_v1_record_proto_init()
+	md_KeyPolicy = File_cosmos_crypto_keyring_v1_record_proto.Messages().ByName("KeyPolicy")
+	fd_KeyPolicy_allowed_msg_type_urls = md_KeyPolicy.Fields().ByName("allowed_msg_type_urls")
+	fd_KeyPolicy_daily_limit = md_KeyPolicy.Fields().ByName("daily_limit")
+	fd_KeyPolicy_allowed_chain_ids = md_KeyPolicy.Fields().ByName("allowed_chain_ids")
+}
+
+var _ protoreflect.Message = (*fastReflection_KeyPolicy)(nil)
+
+type fastReflection_KeyPolicy KeyPolicy
+
+func (x *KeyPolicy) ProtoReflect() protoreflect.Message {
+	return (*fastReflection_KeyPolicy)(x)
+}
+
+func (x *KeyPolicy) slowProtoReflect() protoreflect.Message {
+	mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+var _fastReflection_KeyPolicy_messageType fastReflection_KeyPolicy_messageType
+var _ protoreflect.MessageType = fastReflection_KeyPolicy_messageType{}
+
+type fastReflection_KeyPolicy_messageType struct{}
+
+func (x fastReflection_KeyPolicy_messageType) Zero() protoreflect.Message {
+	return (*fastReflection_KeyPolicy)(nil)
+}
+func (x fastReflection_KeyPolicy_messageType) New() protoreflect.Message {
+	return new(fastReflection_KeyPolicy)
+}
+func (x fastReflection_KeyPolicy_messageType) Descriptor() protoreflect.MessageDescriptor {
+	return md_KeyPolicy
+}
+
+// Descriptor returns message descriptor, which contains only the protobuf
+// type information for the message.
+func (x *fastReflection_KeyPolicy) Descriptor() protoreflect.MessageDescriptor {
+	return md_KeyPolicy
+}
+
+// Type returns the message type, which encapsulates both Go and protobuf
+// type information. If the Go type information is not needed,
+// it is recommended that the message descriptor be used instead.
+func (x *fastReflection_KeyPolicy) Type() protoreflect.MessageType {
+	return _fastReflection_KeyPolicy_messageType
+}
+
+// New returns a newly allocated and mutable empty message.
+func (x *fastReflection_KeyPolicy) New() protoreflect.Message {
+	return new(fastReflection_KeyPolicy)
+}
+
+// Interface unwraps the message reflection interface and
+// returns the underlying ProtoMessage interface.
+func (x *fastReflection_KeyPolicy) Interface() protoreflect.ProtoMessage {
+	return (*KeyPolicy)(x)
+}
+
+// Range iterates over every populated field in an undefined order,
+// calling f for each field descriptor and value encountered.
+// Range returns immediately if f returns false.
+// While iterating, mutating operations may only be performed
+// on the current field descriptor.
+func (x *fastReflection_KeyPolicy) Range(f func(protoreflect.FieldDescriptor, protoreflect.Value) bool) {
+	if len(x.AllowedMsgTypeUrls) != 0 {
+		value := protoreflect.ValueOfList(&_KeyPolicy_1_list{list: &x.AllowedMsgTypeUrls})
+		if !f(fd_KeyPolicy_allowed_msg_type_urls, value) {
+			return
+		}
+	}
+	if len(x.DailyLimit) != 0 {
+		value := protoreflect.ValueOfList(&_KeyPolicy_2_list{list: &x.DailyLimit})
+		if !f(fd_KeyPolicy_daily_limit, value) {
+			return
+		}
+	}
+	if len(x.AllowedChainIds) != 0 {
+		value := protoreflect.ValueOfList(&_KeyPolicy_3_list{list: &x.AllowedChainIds})
+		if !f(fd_KeyPolicy_allowed_chain_ids, value) {
+			return
+		}
+	}
+}
+
+// Has reports whether a field is populated.
+//
+// Some fields have the property of nullability where it is possible to
+// distinguish between the default value of a field and whether the field
+// was explicitly populated with the default value. Singular message fields,
+// member fields of a oneof, and proto2 scalar fields are nullable. Such
+// fields are populated only if explicitly set.
+//
+// In other cases (aside from the nullable cases above),
+// a proto3 scalar field is populated if it contains a non-zero value, and
+// a repeated field is populated if it is non-empty.
+func (x *fastReflection_KeyPolicy) Has(fd protoreflect.FieldDescriptor) bool {
+	switch fd.FullName() {
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_msg_type_urls":
+		return len(x.AllowedMsgTypeUrls) != 0
+	case "cosmos.crypto.keyring.v1.KeyPolicy.daily_limit":
+		return len(x.DailyLimit) != 0
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_chain_ids":
+		return len(x.AllowedChainIds) != 0
+	default:
+		if fd.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.KeyPolicy"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.KeyPolicy does not contain field %s", fd.FullName()))
+	}
+}
+
+// Clear clears the field such that a subsequent Has call reports false.
+//
+// Clearing an extension field clears both the extension type and value
+// associated with the given field number.
+//
+// Clear is a mutating operation and unsafe for concurrent use.
+func (x *fastReflection_KeyPolicy) Clear(fd protoreflect.FieldDescriptor) {
+	switch fd.FullName() {
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_msg_type_urls":
+		x.AllowedMsgTypeUrls = nil
+	case "cosmos.crypto.keyring.v1.KeyPolicy.daily_limit":
+		x.DailyLimit = nil
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_chain_ids":
+		x.AllowedChainIds = nil
+	default:
+		if fd.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.KeyPolicy"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.KeyPolicy does not contain field %s", fd.FullName()))
+	}
+}
+
+// Get retrieves the value for a field.
+//
+// For unpopulated scalars, it returns the default value, where
+// the default value of a bytes scalar is guaranteed to be a copy.
+// For unpopulated composite types, it returns an empty, read-only view
+// of the value; to obtain a mutable reference, use Mutable.
+func (x *fastReflection_KeyPolicy) Get(descriptor protoreflect.FieldDescriptor) protoreflect.Value {
+	switch descriptor.FullName() {
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_msg_type_urls":
+		if len(x.AllowedMsgTypeUrls) == 0 {
+			return protoreflect.ValueOfList(&_KeyPolicy_1_list{})
+		}
+		listValue := &_KeyPolicy_1_list{list: &x.AllowedMsgTypeUrls}
+		return protoreflect.ValueOfList(listValue)
+	case "cosmos.crypto.keyring.v1.KeyPolicy.daily_limit":
+		if len(x.DailyLimit) == 0 {
+			return protoreflect.ValueOfList(&_KeyPolicy_2_list{})
+		}
+		listValue := &_KeyPolicy_2_list{list: &x.DailyLimit}
+		return protoreflect.ValueOfList(listValue)
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_chain_ids":
+		if len(x.AllowedChainIds) == 0 {
+			return protoreflect.ValueOfList(&_KeyPolicy_3_list{})
+		}
+		listValue := &_KeyPolicy_3_list{list: &x.AllowedChainIds}
+		return protoreflect.ValueOfList(listValue)
+	default:
+		if descriptor.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.KeyPolicy"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.KeyPolicy does not contain field %s", descriptor.FullName()))
+	}
+}
+
+// Set stores the value for a field.
+//
+// For a field belonging to a oneof, it implicitly clears any other field
+// that may be currently set within the same oneof.
+// For extension fields, it implicitly stores the provided ExtensionType.
+// When setting a composite type, it is unspecified whether the stored value
+// aliases the source's memory in any way. If the composite value is an
+// empty, read-only value, then it panics.
+//
+// Set is a mutating operation and unsafe for concurrent use.
+func (x *fastReflection_KeyPolicy) Set(fd protoreflect.FieldDescriptor, value protoreflect.Value) {
+	switch fd.FullName() {
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_msg_type_urls":
+		lv := value.List()
+		clv := lv.(*_KeyPolicy_1_list)
+		x.AllowedMsgTypeUrls = *clv.list
+	case "cosmos.crypto.keyring.v1.KeyPolicy.daily_limit":
+		lv := value.List()
+		clv := lv.(*_KeyPolicy_2_list)
+		x.DailyLimit = *clv.list
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_chain_ids":
+		lv := value.List()
+		clv := lv.(*_KeyPolicy_3_list)
+		x.AllowedChainIds = *clv.list
+	default:
+		if fd.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.KeyPolicy"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.KeyPolicy does not contain field %s", fd.FullName()))
+	}
+}
+
+// Mutable returns a mutable reference to a composite type.
+//
+// If the field is unpopulated, it may allocate a composite value.
+// For a field belonging to a oneof, it implicitly clears any other field
+// that may be currently set within the same oneof.
+// For extension fields, it implicitly stores the provided ExtensionType
+// if not already stored.
+// It panics if the field does not contain a composite type.
+//
+// Mutable is a mutating operation and unsafe for concurrent use.
+func (x *fastReflection_KeyPolicy) Mutable(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.FullName() {
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_msg_type_urls":
+		if x.AllowedMsgTypeUrls == nil {
+			x.AllowedMsgTypeUrls = []string{}
+		}
+		value := &_KeyPolicy_1_list{list: &x.AllowedMsgTypeUrls}
+		return protoreflect.ValueOfList(value)
+	case "cosmos.crypto.keyring.v1.KeyPolicy.daily_limit":
+		if x.DailyLimit == nil {
+			x.DailyLimit = []*v1beta1.Coin{}
+		}
+		value := &_KeyPolicy_2_list{list: &x.DailyLimit}
+		return protoreflect.ValueOfList(value)
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_chain_ids":
+		if x.AllowedChainIds == nil {
+			x.AllowedChainIds = []string{}
+		}
+		value := &_KeyPolicy_3_list{list: &x.AllowedChainIds}
+		return protoreflect.ValueOfList(value)
+	default:
+		if fd.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.KeyPolicy"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.KeyPolicy does not contain field %s", fd.FullName()))
+	}
+}
+
+// NewField returns a new value that is assignable to the field
+// for the given descriptor. For scalars, this returns the default value.
+// For lists, maps, and messages, this returns a new, empty, mutable value.
+func (x *fastReflection_KeyPolicy) NewField(fd protoreflect.FieldDescriptor) protoreflect.Value {
+	switch fd.FullName() {
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_msg_type_urls":
+		list := []string{}
+		return protoreflect.ValueOfList(&_KeyPolicy_1_list{list: &list})
+	case "cosmos.crypto.keyring.v1.KeyPolicy.daily_limit":
+		list := []*v1beta1.Coin{}
+		return protoreflect.ValueOfList(&_KeyPolicy_2_list{list: &list})
+	case "cosmos.crypto.keyring.v1.KeyPolicy.allowed_chain_ids":
+		list := []string{}
+		return protoreflect.ValueOfList(&_KeyPolicy_3_list{list: &list})
+	default:
+		if fd.IsExtension() {
+			panic(fmt.Errorf("proto3 declared messages do not support extensions: cosmos.crypto.keyring.v1.KeyPolicy"))
+		}
+		panic(fmt.Errorf("message cosmos.crypto.keyring.v1.KeyPolicy does not contain field %s", fd.FullName()))
+	}
+}
+
+// WhichOneof reports which field within the oneof is populated,
+// returning nil if none are populated.
+// It panics if the oneof descriptor does not belong to this message.
+func (x *fastReflection_KeyPolicy) WhichOneof(d protoreflect.OneofDescriptor) protoreflect.FieldDescriptor {
+	switch d.FullName() {
+	default:
+		panic(fmt.Errorf("%s is not a oneof field in cosmos.crypto.keyring.v1.KeyPolicy", d.FullName()))
+	}
+	panic("unreachable")
+}
+
+// GetUnknown retrieves the entire list of unknown fields.
+// The caller may only mutate the contents of the RawFields
+// if the mutated bytes are stored back into the message with SetUnknown.
+func (x *fastReflection_KeyPolicy) GetUnknown() protoreflect.RawFields {
+	return x.unknownFields
+}
+
+// SetUnknown stores an entire list of unknown fields.
+// The raw fields must be syntactically valid according to the wire format.
+// An implementation may panic if this is not the case.
+// Once stored, the caller must not mutate the content of the RawFields.
+// An empty RawFields may be passed to clear the fields.
+//
+// SetUnknown is a mutating operation and unsafe for concurrent use.
+func (x *fastReflection_KeyPolicy) SetUnknown(fields protoreflect.RawFields) {
+	x.unknownFields = fields
+}
+
+// IsValid reports whether the message is valid.
+//
+// An invalid message is an empty, read-only value.
+//
+// An invalid message often corresponds to a nil pointer of the concrete
+// message type, but the details are implementation dependent.
+// Validity is not part of the protobuf data model, and may not
+// be preserved in marshaling or other operations.
+func (x *fastReflection_KeyPolicy) IsValid() bool {
+	return x != nil
+}
+
+// ProtoMethods returns optional fastReflectionFeature-path implementations of various operations.
+// This method may return nil.
+//
+// The returned methods type is identical to
+// "google.golang.org/protobuf/runtime/protoiface".Methods.
+// Consult the protoiface package documentation for details.
+func (x *fastReflection_KeyPolicy) ProtoMethods() *protoiface.Methods {
+	size := func(input protoiface.SizeInput) protoiface.SizeOutput {
+		x := input.Message.Interface().(*KeyPolicy)
+		if x == nil {
+			return protoiface.SizeOutput{
+				NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+				Size:              0,
+			}
+		}
+		options := runtime.SizeInputToOptions(input)
+		_ = options
+		var n int
+		var l int
+		_ = l
+		if len(x.AllowedMsgTypeUrls) > 0 {
+			for _, s := range x.AllowedMsgTypeUrls {
+				l = len(s)
+				n += 1 + l + runtime.Sov(uint64(l))
+			}
+		}
+		if len(x.DailyLimit) > 0 {
+			for _, e := range x.DailyLimit {
+				l = options.Size(e)
+				n += 1 + l + runtime.Sov(uint64(l))
+			}
+		}
+		if len(x.AllowedChainIds) > 0 {
+			for _, s := range x.AllowedChainIds {
+				l = len(s)
+				n += 1 + l + runtime.Sov(uint64(l))
+			}
+		}
+		if x.unknownFields != nil {
+			n += len(x.unknownFields)
+		}
+		return protoiface.SizeOutput{
+			NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+			Size:              n,
+		}
+	}
+
+	marshal := func(input protoiface.MarshalInput) (protoiface.MarshalOutput, error) {
+		x := input.Message.Interface().(*KeyPolicy)
+		if x == nil {
+			return protoiface.MarshalOutput{
+				NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+				Buf:               input.Buf,
+			}, nil
+		}
+		options := runtime.MarshalInputToOptions(input)
+		_ = options
+		size := options.Size(x)
+		dAtA := make([]byte, size)
+		i := len(dAtA)
+		_ = i
+		var l int
+		_ = l
+		if x.unknownFields != nil {
+			i -= len(x.unknownFields)
+			copy(dAtA[i:], x.unknownFields)
+		}
+		if len(x.AllowedChainIds) > 0 {
+			for iNdEx := len(x.AllowedChainIds) - 1; iNdEx >= 0; iNdEx-- {
+				i -= len(x.AllowedChainIds[iNdEx])
+				copy(dAtA[i:], x.AllowedChainIds[iNdEx])
+				i = runtime.EncodeVarint(dAtA, i, uint64(len(x.AllowedChainIds[iNdEx])))
+				i--
+				dAtA[i] = 0x1a
+			}
+		}
+		if len(x.DailyLimit) > 0 {
+			for iNdEx := len(x.DailyLimit) - 1; iNdEx >= 0; iNdEx-- {
+				encoded, err := options.Marshal(x.DailyLimit[iNdEx])
+				if err != nil {
+					return protoiface.MarshalOutput{
+						NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+						Buf:               input.Buf,
+					}, err
+				}
+				i -= len(encoded)
+				copy(dAtA[i:], encoded)
+				i = runtime.EncodeVarint(dAtA, i, uint64(len(encoded)))
+				i--
+				dAtA[i] = 0x12
+			}
+		}
+		if len(x.AllowedMsgTypeUrls) > 0 {
+			for iNdEx := len(x.AllowedMsgTypeUrls) - 1; iNdEx >= 0; iNdEx-- {
+				i -= len(x.AllowedMsgTypeUrls[iNdEx])
+				copy(dAtA[i:], x.AllowedMsgTypeUrls[iNdEx])
+				i = runtime.EncodeVarint(dAtA, i, uint64(len(x.AllowedMsgTypeUrls[iNdEx])))
+				i--
+				dAtA[i] = 0xa
+			}
+		}
+		if input.Buf != nil {
+			input.Buf = append(input.Buf, dAtA...)
+		} else {
+			input.Buf = dAtA
+		}
+		return protoiface.MarshalOutput{
+			NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+			Buf:               input.Buf,
+		}, nil
+	}
+	unmarshal := func(input protoiface.UnmarshalInput) (protoiface.UnmarshalOutput, error) {
+		x := input.Message.Interface().(*KeyPolicy)
+		if x == nil {
+			return protoiface.UnmarshalOutput{
+				NoUnkeyedLiterals: input.NoUnkeyedLiterals,
+				Flags:             input.Flags,
+			}, nil
+		}
+		options := runtime.UnmarshalInputToOptions(input)
+		_ = options
+		dAtA := input.Buf
+		l := len(dAtA)
+		iNdEx := 0
+		for iNdEx < l {
+			preIndex := iNdEx
+			var wire uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+				}
+				if iNdEx >= l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				wire |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			fieldNum := int32(wire >> 3)
+			wireType := int(wire & 0x7)
+			if wireType == 4 {
+				return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: KeyPolicy: wiretype end group for non-group")
+			}
+			if fieldNum <= 0 {
+				return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: KeyPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+			}
+			switch fieldNum {
+			case 1:
+				if wireType != 2 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field AllowedMsgTypeUrls", wireType)
+				}
+				var stringLen uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLen |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLen := int(stringLen)
+				if intStringLen < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				postIndex := iNdEx + intStringLen
+				if postIndex < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if postIndex > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				x.AllowedMsgTypeUrls = append(x.AllowedMsgTypeUrls, string(dAtA[iNdEx:postIndex]))
+				iNdEx = postIndex
+			case 2:
+				if wireType != 2 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field DailyLimit", wireType)
+				}
+				var msglen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					msglen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if msglen < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				postIndex := iNdEx + msglen
+				if postIndex < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if postIndex > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				x.DailyLimit = append(x.DailyLimit, &v1beta1.Coin{})
+				if err := options.Unmarshal(dAtA[iNdEx:postIndex], x.DailyLimit[len(x.DailyLimit)-1]); err != nil {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, err
+				}
+				iNdEx = postIndex
+			case 3:
+				if wireType != 2 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, fmt.Errorf("proto: wrong wireType = %d for field AllowedChainIds", wireType)
+				}
+				var stringLen uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrIntOverflow
+					}
+					if iNdEx >= l {
+						return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					stringLen |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				intStringLen := int(stringLen)
+				if intStringLen < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				postIndex := iNdEx + intStringLen
+				if postIndex < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if postIndex > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				x.AllowedChainIds = append(x.AllowedChainIds, string(dAtA[iNdEx:postIndex]))
+				iNdEx = postIndex
+			default:
+				iNdEx = preIndex
+				skippy, err := runtime.Skip(dAtA[iNdEx:])
+				if err != nil {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, err
+				}
+				if (skippy < 0) || (iNdEx+skippy) < 0 {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, runtime.ErrInvalidLength
+				}
+				if (iNdEx + skippy) > l {
+					return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+				}
+				if !options.DiscardUnknown {
+					x.unknownFields = append(x.unknownFields, dAtA[iNdEx:iNdEx+skippy]...)
+				}
+				iNdEx += skippy
+			}
+		}
+
+		if iNdEx > l {
+			return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, io.ErrUnexpectedEOF
+		}
+		return protoiface.UnmarshalOutput{NoUnkeyedLiterals: input.NoUnkeyedLiterals, Flags: input.Flags}, nil
+	}
+	return &protoiface.Methods{
+		NoUnkeyedLiterals: struct{}{},
+		Flags:             protoiface.SupportMarshalDeterministic | protoiface.SupportUnmarshalDiscardUnknown,
+		Size:              size,
+		Marshal:           marshal,
+		Unmarshal:         unmarshal,
+		Merge:             nil,
+		CheckInitialized:  nil,
+	}
+}
+
+// Since: cosmos-sdk 0.46
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.0
+// 	protoc        (unknown)
+// source: cosmos/crypto/keyring/v1/record.proto
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Record is used for representing a key in the keyring.
+type Record struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name represents a name of Record
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// pub_key represents a public key in any format
+	PubKey *anypb.Any `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	// Record contains one of the following items
+	//
+	// Types that are assignable to Item:
+	//	*Record_Local_
+	//	*Record_Ledger_
+	//	*Record_Multi_
+	//	*Record_Offline_
+	//	*Record_Remote_
+	Item isRecord_Item `protobuf_oneof:"item"`
+	// policy restricts the transactions signed with the key by the keyring, if
+	// set.
+	Policy *KeyPolicy `protobuf:"bytes,8,opt,name=policy,proto3" json:"policy,omitempty"`
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_cosmos_crypto_keyring_v1_record_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Record) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Record) GetPubKey() *anypb.Any {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *Record) GetItem() isRecord_Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *Record) GetLocal() *Record_Local {
+	if x, ok := x.GetItem().(*Record_Local_); ok {
+		return x.Local
+	}
+	return nil
+}
+
+func (x *Record) GetLedger() *Record_Ledger {
+	if x, ok := x.GetItem().(*Record_Ledger_); ok {
+		return x.Ledger
+	}
+	return nil
+}
+
+func (x *Record) GetMulti() *Record_Multi {
+	if x, ok := x.GetItem().(*Record_Multi_); ok {
+		return x.Multi
+	}
+	return nil
+}
+
+func (x *Record) GetOffline() *Record_Offline {
+	if x, ok := x.GetItem().(*Record_Offline_); ok {
+		return x.Offline
+	}
+	return nil
+}
+
+func (x *Record) GetRemote() *Record_Remote {
+	if x, ok := x.GetItem().(*Record_Remote_); ok {
+		return x.Remote
+	}
+	return nil
+}
+
+func (x *Record) GetPolicy() *KeyPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type isRecord_Item interface {
 	isRecord_Item()
 }
 
@@ -2655,6 +4082,11 @@ type Record_Offline_ struct {
 	Offline *Record_Offline `protobuf:"bytes,6,opt,name=offline,proto3,oneof"`
 }
 
+type Record_Remote_ struct {
+	// remote stores the information about a key held by a remote signer.
+	Remote *Record_Remote `protobuf:"bytes,7,opt,name=remote,proto3,oneof"`
+}
+
 func (*Record_Local_) isRecord_Item() {}
 
 func (*Record_Ledger_) isRecord_Item() {}
@@ -2663,6 +4095,67 @@ func (*Record_Multi_) isRecord_Item() {}
 
 func (*Record_Offline_) isRecord_Item() {}
 
+func (*Record_Remote_) isRecord_Item() {}
+
+// KeyPolicy restricts the transactions signed with a key by the keyring, e.g.
+// to put guardrails on hot keys. An empty field doesn't restrict anything.
+type KeyPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// allowed_msg_type_urls are the type URLs of the messages that the
+	// transactions may contain.
+	AllowedMsgTypeUrls []string `protobuf:"bytes,1,rep,name=allowed_msg_type_urls,json=allowedMsgTypeUrls,proto3" json:"allowed_msg_type_urls,omitempty"`
+	// daily_limit is the maximum amount, per denom, of the coins of the messages
+	// and the fees of the transactions signed within 24 hours. The denoms absent
+	// from it are not limited.
+	DailyLimit []*v1beta1.Coin `protobuf:"bytes,2,rep,name=daily_limit,json=dailyLimit,proto3" json:"daily_limit,omitempty"`
+	// allowed_chain_ids are the chain IDs of the transactions.
+	AllowedChainIds []string `protobuf:"bytes,3,rep,name=allowed_chain_ids,json=allowedChainIds,proto3" json:"allowed_chain_ids,omitempty"`
+}
+
+func (x *KeyPolicy) Reset() {
+	*x = KeyPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeyPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyPolicy) ProtoMessage() {}
+
+// Deprecated: Use KeyPolicy.ProtoReflect.Descriptor instead.
+func (*KeyPolicy) Descriptor() ([]byte, []int) {
+	return file_cosmos_crypto_keyring_v1_record_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KeyPolicy) GetAllowedMsgTypeUrls() []string {
+	if x != nil {
+		return x.AllowedMsgTypeUrls
+	}
+	return nil
+}
+
+func (x *KeyPolicy) GetDailyLimit() []*v1beta1.Coin {
+	if x != nil {
+		return x.DailyLimit
+	}
+	return nil
+}
+
+func (x *KeyPolicy) GetAllowedChainIds() []string {
+	if x != nil {
+		return x.AllowedChainIds
+	}
+	return nil
+}
+
 // Item is a keyring item stored in a keyring backend.
 // Local item
 type Record_Local struct {
@@ -2676,7 +4169,7 @@ type Record_Local struct {
 func (x *Record_Local) Reset() {
 	*x = Record_Local{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[1]
+		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2712,7 +4205,7 @@ type Record_Ledger struct {
 func (x *Record_Ledger) Reset() {
 	*x = Record_Ledger{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[2]
+		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2746,7 +4239,7 @@ type Record_Multi struct {
 func (x *Record_Multi) Reset() {
 	*x = Record_Multi{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[3]
+		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2773,7 +4266,7 @@ type Record_Offline struct {
 func (x *Record_Offline) Reset() {
 	*x = Record_Offline{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[4]
+		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2790,6 +4283,53 @@ func (*Record_Offline) Descriptor() ([]byte, []int) {
 	return file_cosmos_crypto_keyring_v1_record_proto_rawDescGZIP(), []int{0, 3}
 }
 
+// Remote item
+type Record_Remote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// endpoint is the gRPC endpoint of the remote signer, e.g. localhost:9091
+	// or unix:///run/signer.sock.
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// key_id identifies the key in the remote signer.
+	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+}
+
+func (x *Record_Remote) Reset() {
+	*x = Record_Remote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cosmos_crypto_keyring_v1_record_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Record_Remote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record_Remote) ProtoMessage() {}
+
+// Deprecated: Use Record_Remote.ProtoReflect.Descriptor instead.
+func (*Record_Remote) Descriptor() ([]byte, []int) {
+	return file_cosmos_crypto_keyring_v1_record_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *Record_Remote) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Record_Remote) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
 var File_cosmos_crypto_keyring_v1_record_proto protoreflect.FileDescriptor
 
 var file_cosmos_crypto_keyring_v1_record_proto_rawDesc = []byte{
@@ -2802,7 +4342,9 @@ var file_cosmos_crypto_keyring_v1_record_proto_rawDesc = []byte{
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x1a, 0x1c, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74,
 	0x6f, 0x2f, 0x68, 0x64, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0xea, 0x03, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x1a, 0x1e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x62, 0x61, 0x73, 0x65, 0x2f, 0x76, 0x31,
+	0x62, 0x65, 0x74, 0x61, 0x31, 0x2f, 0x63, 0x6f, 0x69, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xa7, 0x05, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
 	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
 	0x2d, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
@@ -2823,7 +4365,15 @@ var file_cosmos_crypto_keyring_v1_record_proto_rawDesc = []byte{
 	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x63, 0x72, 0x79, 0x70,
 	0x74, 0x6f, 0x2e, 0x6b, 0x65, 0x79, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
 	0x63, 0x6f, 0x72, 0x64, 0x2e, 0x4f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x48, 0x00, 0x52, 0x07,
-	0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x1a, 0x38, 0x0a, 0x05, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
+	0x6f, 0x66, 0x66, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x41, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73,
+	0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x6b, 0x65, 0x79, 0x72, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x73,
+	0x6d, 0x6f, 0x73, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x6b, 0x65, 0x79, 0x72, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x1a, 0x38, 0x0a, 0x05, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
 	0x12, 0x2f, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x76, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x4b, 0x65,
@@ -2832,23 +4382,29 @@ var file_cosmos_crypto_keyring_v1_record_proto_rawDesc = []byte{
 	0x6f, 0x73, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x68, 0x64, 0x2e, 0x76, 0x31, 0x2e,
 	0x42, 0x49, 0x50, 0x34, 0x34, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x04, 0x70, 0x61, 0x74,
 	0x68, 0x1a, 0x07, 0x0a, 0x05, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x1a, 0x09, 0x0a, 0x07, 0x4f, 0x66,
-	0x66, 0x6c, 0x69, 0x6e, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x42, 0xeb, 0x01,
-	0x0a, 0x1c, 0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x63, 0x72, 0x79,
-	0x70, 0x74, 0x6f, 0x2e, 0x6b, 0x65, 0x79, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x42, 0x0b,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x33, 0x63,
-	0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x73, 0x64, 0x6b, 0x2e, 0x69, 0x6f, 0x2f, 0x61, 0x70, 0x69, 0x2f,
-	0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x6b, 0x65,
-	0x79, 0x72, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x6b, 0x65, 0x79, 0x72, 0x69, 0x6e, 0x67,
-	0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x43, 0x4b, 0xaa, 0x02, 0x18, 0x43, 0x6f, 0x73, 0x6d, 0x6f,
-	0x73, 0x2e, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x4b, 0x65, 0x79, 0x72, 0x69, 0x6e, 0x67,
-	0x2e, 0x56, 0x31, 0xca, 0x02, 0x18, 0x43, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x5c, 0x43, 0x72, 0x79,
-	0x70, 0x74, 0x6f, 0x5c, 0x4b, 0x65, 0x79, 0x72, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0xe2, 0x02,
-	0x24, 0x43, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x5c, 0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x5c, 0x4b,
-	0x65, 0x79, 0x72, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x1b, 0x43, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x3a, 0x3a,
-	0x43, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x3a, 0x3a, 0x4b, 0x65, 0x79, 0x72, 0x69, 0x6e, 0x67, 0x3a,
-	0x3a, 0x56, 0x31, 0xc8, 0xe1, 0x1e, 0x00, 0x98, 0xe3, 0x1e, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x66, 0x6c, 0x69, 0x6e, 0x65, 0x1a, 0x3b, 0x0a, 0x06, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x6b,
+	0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79,
+	0x49, 0x64, 0x42, 0x06, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0xd8, 0x01, 0x0a, 0x09, 0x4b,
+	0x65, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x31, 0x0a, 0x15, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x67, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x75, 0x72, 0x6c,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64,
+	0x4d, 0x73, 0x67, 0x54, 0x79, 0x70, 0x65, 0x55, 0x72, 0x6c, 0x73, 0x12, 0x6c, 0x0a, 0x0b, 0x64,
+	0x61, 0x69, 0x6c, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2e, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x76,
+	0x31, 0x62, 0x65, 0x74, 0x61, 0x31, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x42, 0x30, 0xc8, 0xde, 0x1f,
+	0x00, 0xaa, 0xdf, 0x1f, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2f, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2d, 0x73, 0x64,
+	0x6b, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x6f, 0x69, 0x6e, 0x73, 0x52, 0x0a, 0x64,
+	0x61, 0x69, 0x6c, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x65, 0x64, 0x5f, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x43, 0x68, 0x61,
+	0x69, 0x6e, 0x49, 0x64, 0x73, 0x42, 0x35, 0xc8, 0xe1, 0x1e, 0x00, 0x98, 0xe3, 0x1e, 0x00, 0x5a,
+	0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x73, 0x6d,
+	0x6f, 0x73, 0x2f, 0x63, 0x6f, 0x73, 0x6d, 0x6f, 0x73, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x2f, 0x6b, 0x65, 0x79, 0x72, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2863,29 +4419,35 @@ func file_cosmos_crypto_keyring_v1_record_proto_rawDescGZIP() []byte {
 	return file_cosmos_crypto_keyring_v1_record_proto_rawDescData
 }
 
-var file_cosmos_crypto_keyring_v1_record_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_cosmos_crypto_keyring_v1_record_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_cosmos_crypto_keyring_v1_record_proto_goTypes = []interface{}{
 	(*Record)(nil),         // 0: cosmos.crypto.keyring.v1.Record
-	(*Record_Local)(nil),   // 1: cosmos.crypto.keyring.v1.Record.Local
-	(*Record_Ledger)(nil),  // 2: cosmos.crypto.keyring.v1.Record.Ledger
-	(*Record_Multi)(nil),   // 3: cosmos.crypto.keyring.v1.Record.Multi
-	(*Record_Offline)(nil), // 4: cosmos.crypto.keyring.v1.Record.Offline
-	(*anypb.Any)(nil),      // 5: google.protobuf.Any
-	(*v1.BIP44Params)(nil), // 6: cosmos.crypto.hd.v1.BIP44Params
+	(*KeyPolicy)(nil),      // 1: cosmos.crypto.keyring.v1.KeyPolicy
+	(*Record_Local)(nil),   // 2: cosmos.crypto.keyring.v1.Record.Local
+	(*Record_Ledger)(nil),  // 3: cosmos.crypto.keyring.v1.Record.Ledger
+	(*Record_Multi)(nil),   // 4: cosmos.crypto.keyring.v1.Record.Multi
+	(*Record_Offline)(nil), // 5: cosmos.crypto.keyring.v1.Record.Offline
+	(*Record_Remote)(nil),  // 6: cosmos.crypto.keyring.v1.Record.Remote
+	(*anypb.Any)(nil),      // 7: google.protobuf.Any
+	(*v1beta1.Coin)(nil),   // 8: cosmos.base.v1beta1.Coin
+	(*v1.BIP44Params)(nil), // 9: cosmos.crypto.hd.v1.BIP44Params
 }
 var file_cosmos_crypto_keyring_v1_record_proto_depIdxs = []int32{
-	5, // 0: cosmos.crypto.keyring.v1.Record.pub_key:type_name -> google.protobuf.Any
-	1, // 1: cosmos.crypto.keyring.v1.Record.local:type_name -> cosmos.crypto.keyring.v1.Record.Local
-	2, // 2: cosmos.crypto.keyring.v1.Record.ledger:type_name -> cosmos.crypto.keyring.v1.Record.Ledger
-	3, // 3: cosmos.crypto.keyring.v1.Record.multi:type_name -> cosmos.crypto.keyring.v1.Record.Multi
-	4, // 4: cosmos.crypto.keyring.v1.Record.offline:type_name -> cosmos.crypto.keyring.v1.Record.Offline
-	5, // 5: cosmos.crypto.keyring.v1.Record.Local.priv_key:type_name -> google.protobuf.Any
-	6, // 6: cosmos.crypto.keyring.v1.Record.Ledger.path:type_name -> cosmos.crypto.hd.v1.BIP44Params
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	7,  // 0: cosmos.crypto.keyring.v1.Record.pub_key:type_name -> google.protobuf.Any
+	2,  // 1: cosmos.crypto.keyring.v1.Record.local:type_name -> cosmos.crypto.keyring.v1.Record.Local
+	3,  // 2: cosmos.crypto.keyring.v1.Record.ledger:type_name -> cosmos.crypto.keyring.v1.Record.Ledger
+	4,  // 3: cosmos.crypto.keyring.v1.Record.multi:type_name -> cosmos.crypto.keyring.v1.Record.Multi
+	5,  // 4: cosmos.crypto.keyring.v1.Record.offline:type_name -> cosmos.crypto.keyring.v1.Record.Offline
+	6,  // 5: cosmos.crypto.keyring.v1.Record.remote:type_name -> cosmos.crypto.keyring.v1.Record.Remote
+	1,  // 6: cosmos.crypto.keyring.v1.Record.policy:type_name -> cosmos.crypto.keyring.v1.KeyPolicy
+	8,  // 7: cosmos.crypto.keyring.v1.KeyPolicy.daily_limit:type_name -> cosmos.base.v1beta1.Coin
+	7,  // 8: cosmos.crypto.keyring.v1.Record.Local.priv_key:type_name -> google.protobuf.Any
+	9,  // 9: cosmos.crypto.keyring.v1.Record.Ledger.path:type_name -> cosmos.crypto.hd.v1.BIP44Params
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_cosmos_crypto_keyring_v1_record_proto_init() }
@@ -2907,7 +4469,7 @@ func file_cosmos_crypto_keyring_v1_record_proto_init() {
 			}
 		}
 		file_cosmos_crypto_keyring_v1_record_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Record_Local); i {
+			switch v := v.(*KeyPolicy); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2919,7 +4481,7 @@ func file_cosmos_crypto_keyring_v1_record_proto_init() {
 			}
 		}
 		file_cosmos_crypto_keyring_v1_record_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Record_Ledger); i {
+			switch v := v.(*Record_Local); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2931,7 +4493,7 @@ func file_cosmos_crypto_keyring_v1_record_proto_init() {
 			}
 		}
 		file_cosmos_crypto_keyring_v1_record_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Record_Multi); i {
+			switch v := v.(*Record_Ledger); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2943,6 +4505,18 @@ func file_cosmos_crypto_keyring_v1_record_proto_init() {
 			}
 		}
 		file_cosmos_crypto_keyring_v1_record_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Record_Multi); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cosmos_crypto_keyring_v1_record_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Record_Offline); i {
 			case 0:
 				return &v.state
@@ -2954,12 +4528,25 @@ func file_cosmos_crypto_keyring_v1_record_proto_init() {
 				return nil
 			}
 		}
+		file_cosmos_crypto_keyring_v1_record_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Record_Remote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_cosmos_crypto_keyring_v1_record_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*Record_Local_)(nil),
 		(*Record_Ledger_)(nil),
 		(*Record_Multi_)(nil),
 		(*Record_Offline_)(nil),
+		(*Record_Remote_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2967,7 +4554,7 @@ func file_cosmos_crypto_keyring_v1_record_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cosmos_crypto_keyring_v1_record_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
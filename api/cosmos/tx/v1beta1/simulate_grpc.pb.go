@@ -0,0 +1,115 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: cosmos/tx/v1beta1/simulate.proto
+
+package txv1beta1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SimulateService_SimulateWithOverrides_FullMethodName = "/cosmos.tx.v1beta1.SimulateService/SimulateWithOverrides"
+)
+
+// SimulateServiceClient is the client API for SimulateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SimulateServiceClient interface {
+	// SimulateWithOverrides simulates executing a transaction against the state
+	// at a given height after applying hypothetical state overrides, and returns
+	// the state changes of the transaction.
+	SimulateWithOverrides(ctx context.Context, in *SimulateWithOverridesRequest, opts ...grpc.CallOption) (*SimulateWithOverridesResponse, error)
+}
+
+type simulateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSimulateServiceClient(cc grpc.ClientConnInterface) SimulateServiceClient {
+	return &simulateServiceClient{cc}
+}
+
+func (c *simulateServiceClient) SimulateWithOverrides(ctx context.Context, in *SimulateWithOverridesRequest, opts ...grpc.CallOption) (*SimulateWithOverridesResponse, error) {
+	out := new(SimulateWithOverridesResponse)
+	err := c.cc.Invoke(ctx, SimulateService_SimulateWithOverrides_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SimulateServiceServer is the server API for SimulateService service.
+// All implementations must embed UnimplementedSimulateServiceServer
+// for forward compatibility
+type SimulateServiceServer interface {
+	// SimulateWithOverrides simulates executing a transaction against the state
+	// at a given height after applying hypothetical state overrides, and returns
+	// the state changes of the transaction.
+	SimulateWithOverrides(context.Context, *SimulateWithOverridesRequest) (*SimulateWithOverridesResponse, error)
+	mustEmbedUnimplementedSimulateServiceServer()
+}
+
+// UnimplementedSimulateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSimulateServiceServer struct {
+}
+
+func (UnimplementedSimulateServiceServer) SimulateWithOverrides(context.Context, *SimulateWithOverridesRequest) (*SimulateWithOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateWithOverrides not implemented")
+}
+func (UnimplementedSimulateServiceServer) mustEmbedUnimplementedSimulateServiceServer() {}
+
+// UnsafeSimulateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimulateServiceServer will
+// result in compilation errors.
+type UnsafeSimulateServiceServer interface {
+	mustEmbedUnimplementedSimulateServiceServer()
+}
+
+func RegisterSimulateServiceServer(s grpc.ServiceRegistrar, srv SimulateServiceServer) {
+	s.RegisterService(&SimulateService_ServiceDesc, srv)
+}
+
+func _SimulateService_SimulateWithOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateWithOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulateServiceServer).SimulateWithOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulateService_SimulateWithOverrides_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulateServiceServer).SimulateWithOverrides(ctx, req.(*SimulateWithOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SimulateService_ServiceDesc is the grpc.ServiceDesc for SimulateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SimulateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cosmos.tx.v1beta1.SimulateService",
+	HandlerType: (*SimulateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SimulateWithOverrides",
+			Handler:    _SimulateService_SimulateWithOverrides_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cosmos/tx/v1beta1/simulate.proto",
+}
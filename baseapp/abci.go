@@ -743,6 +743,10 @@ func (app *BaseApp) internalFinalizeBlock(ctx context.Context, req *abci.Request
 			LastCommit:      req.DecidedLastCommit,
 		}))
 
+	if app.profiler != nil {
+		app.finalizeBlockState.SetContext(app.finalizeBlockState.Context().WithProfiler(app.profiler))
+	}
+
 	// GasMeter must be set after we get a context with updated consensus params.
 	gasMeter := app.getBlockGasMeter(app.finalizeBlockState.Context())
 	app.finalizeBlockState.SetContext(app.finalizeBlockState.Context().WithBlockGasMeter(gasMeter))
@@ -785,34 +789,48 @@ func (app *BaseApp) internalFinalizeBlock(ctx context.Context, req *abci.Request
 	//
 	// NOTE: Not all raw transactions may adhere to the sdk.Tx interface, e.g.
 	// vote extensions, so skip those.
-	txResults := make([]*abci.ExecTxResult, 0, len(req.Txs))
-	for _, rawTx := range req.Txs {
-		var response *abci.ExecTxResult
-
-		if _, err := app.txDecoder(rawTx); err == nil {
-			response = app.deliverTx(rawTx)
-		} else {
-			// In the case where a transaction included in a block proposal is malformed,
-			// we still want to return a default response to comet. This is because comet
-			// expects a response for each transaction included in a block proposal.
-			response = sdkerrors.ResponseExecTxResultWithEvents(
-				sdkerrors.ErrTxDecode,
-				0,
-				0,
-				nil,
-				false,
-			)
+	var txResults []*abci.ExecTxResult
+	if app.txExecutor != nil && !app.finalizeBlockState.ms.TracingEnabled() {
+		txResults, err = app.txExecutor(
+			ctx,
+			req.Txs,
+			app.finalizeBlockState.ms,
+			app.finalizeBlockState.Context().BlockGasMeter(),
+			app.deliverTxWithMultiStore,
+		)
+		if err != nil {
+			return nil, err
 		}
+	} else {
+		txResults = make([]*abci.ExecTxResult, 0, len(req.Txs))
+		for _, rawTx := range req.Txs {
+			var response *abci.ExecTxResult
+
+			if _, err := app.txDecoder(rawTx); err == nil {
+				response = app.deliverTx(rawTx)
+			} else {
+				// In the case where a transaction included in a block proposal is malformed,
+				// we still want to return a default response to comet. This is because comet
+				// expects a response for each transaction included in a block proposal.
+				response = sdkerrors.ResponseExecTxResultWithEvents(
+					sdkerrors.ErrTxDecode,
+					0,
+					0,
+					nil,
+					false,
+				)
+			}
 
-		// check after every tx if we should abort
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-			// continue
-		}
+			// check after every tx if we should abort
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+				// continue
+			}
 
-		txResults = append(txResults, response)
+			txResults = append(txResults, response)
+		}
 	}
 
 	if app.finalizeBlockState.ms.TracingEnabled() {
@@ -937,11 +955,16 @@ func (app *BaseApp) Commit() (*abci.ResponseCommit, error) {
 	}
 
 	abciListeners := app.streamingManager.ABCIListeners
-	if len(abciListeners) > 0 {
+	if len(abciListeners) > 0 || app.appHashDumper != nil {
 		ctx := app.finalizeBlockState.Context()
 		blockHeight := ctx.BlockHeight()
 		changeSet := app.cms.PopStateCache()
 
+		if app.appHashDumper != nil {
+			app.dumpAppHash(header.Height, changeSet)
+			changeSet = app.streamedChangeSet(changeSet)
+		}
+
 		for _, abciListener := range abciListeners {
 			if err := abciListener.ListenCommit(ctx, *resp, changeSet); err != nil {
 				app.logger.Error("Commit listening hook failed", "height", blockHeight, "err", err)
@@ -964,6 +987,8 @@ func (app *BaseApp) Commit() (*abci.ResponseCommit, error) {
 	// The SnapshotIfApplicable method will create the snapshot by starting the goroutine
 	app.snapshotManager.SnapshotIfApplicable(header.Height)
 
+	app.syncMempoolJournal()
+
 	return resp, nil
 }
 
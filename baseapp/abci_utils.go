@@ -15,6 +15,7 @@ import (
 	"github.com/cosmos/gogoproto/proto" //nolint: gci // ignore this line for this linter
 
 	"cosmossdk.io/core/comet"
+	"cosmossdk.io/math"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/mempool"
@@ -212,7 +213,12 @@ type (
 		txVerifier       ProposalTxVerifier
 		txSelector       TxSelector
 		signerExtAdapter mempool.SignerExtractionAdapter
+		minGasPricesFn   MinGasPricesFn
 	}
+
+	// MinGasPricesFn returns the gas prices under which mempool txs are not
+	// included in a proposal, e.g. the base fee of a fee market.
+	MinGasPricesFn func(ctx sdk.Context) (sdk.DecCoins, error)
 )
 
 func NewDefaultProposalHandler(mp mempool.Mempool, txVerifier ProposalTxVerifier) *DefaultProposalHandler {
@@ -229,6 +235,13 @@ func (h *DefaultProposalHandler) SetTxSelector(ts TxSelector) {
 	h.txSelector = ts
 }
 
+// SetMinGasPricesFn sets the function returning the min gas prices of the
+// proposals. Mempool txs paying less than these for their gas limit are not
+// included and are evicted from the mempool.
+func (h *DefaultProposalHandler) SetMinGasPricesFn(fn MinGasPricesFn) {
+	h.minGasPricesFn = fn
+}
+
 // PrepareProposalHandler returns the default implementation for processing an
 // ABCI proposal. The application's mempool is enumerated and all valid
 // transactions are added to the proposal. Transactions are valid if they:
@@ -249,6 +262,10 @@ func (h *DefaultProposalHandler) SetTxSelector(ts TxSelector) {
 // - If no mempool is set or if the mempool is a no-op mempool, the transactions
 // requested from CometBFT will simply be returned, which, by default, are in
 // FIFO order.
+//
+// - If the mempool is a LanedMempool, its lanes are enumerated in priority
+// order, and the transactions of each lane are added within the lane's share
+// of RequestPrepareProposal.MaxTxBytes and of the block max gas.
 func (h *DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHandler {
 	return func(ctx sdk.Context, req *abci.RequestPrepareProposal) (*abci.ResponsePrepareProposal, error) {
 		var maxBlockGas uint64
@@ -279,77 +296,125 @@ func (h *DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHan
 			return &abci.ResponsePrepareProposal{Txs: h.txSelector.SelectedTxs(ctx)}, nil
 		}
 
+		var minGasPrices sdk.DecCoins
+		if h.minGasPricesFn != nil {
+			var err error
+			minGasPrices, err = h.minGasPricesFn(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		lanes := []mempool.Lane{{Name: "default", Mempool: h.mempool}}
+		if laned, ok := lanedMempool(h.mempool); ok {
+			lanes = laned.Lanes()
+		}
+
 		selectedTxsSignersSeqs := make(map[string]uint64)
 		var (
 			resError        error
 			selectedTxsNums int
+			totalTxBytes    uint64
+			totalTxGas      uint64
 			invalidTxs      []sdk.Tx // invalid txs to be removed out of the loop to avoid dead lock
 		)
-		mempool.SelectBy(ctx, h.mempool, req.Txs, func(memTx sdk.Tx) bool {
-			signerData, err := h.signerExtAdapter.GetSigners(memTx)
-			if err != nil {
-				// propagate the error to the caller
-				resError = err
-				return false
+		// The lanes are filled in priority order, each within its share of the
+		// block. The txs of a lane are selected with the cumulative limits of
+		// the txs already selected plus the share of the lane.
+		for _, lane := range lanes {
+			laneMaxTxBytes := min(uint64(req.MaxTxBytes), totalTxBytes+lane.Space(uint64(req.MaxTxBytes)))
+			laneMaxBlockGas := maxBlockGas
+			if maxBlockGas > 0 {
+				laneMaxBlockGas = min(maxBlockGas, totalTxGas+lane.Space(maxBlockGas))
+			}
+			if laneMaxTxBytes <= totalTxBytes || (maxBlockGas > 0 && laneMaxBlockGas <= totalTxGas) {
+				continue
 			}
 
-			// If the signers aren't in selectedTxsSignersSeqs then we haven't seen them before
-			// so we add them and continue given that we don't need to check the sequence.
-			shouldAdd := true
-			txSignersSeqs := make(map[string]uint64)
-			for _, signer := range signerData {
-				seq, ok := selectedTxsSignersSeqs[signer.Signer.String()]
-				if !ok {
-					txSignersSeqs[signer.Signer.String()] = signer.Sequence
-					continue
+			mempool.SelectBy(ctx, lane.Mempool, req.Txs, func(memTx sdk.Tx) bool {
+				signerData, err := h.signerExtAdapter.GetSigners(memTx)
+				if err != nil {
+					// propagate the error to the caller
+					resError = err
+					return false
 				}
 
-				// If we have seen this signer before in this block, we must make
-				// sure that the current sequence is seq+1; otherwise is invalid
-				// and we skip it.
-				if seq+1 != signer.Sequence {
-					shouldAdd = false
-					break
+				// If the signers aren't in selectedTxsSignersSeqs then we haven't seen them before
+				// so we add them and continue given that we don't need to check the sequence.
+				shouldAdd := true
+				txSignersSeqs := make(map[string]uint64)
+				for _, signer := range signerData {
+					seq, ok := selectedTxsSignersSeqs[signer.Signer.String()]
+					if !ok {
+						txSignersSeqs[signer.Signer.String()] = signer.Sequence
+						continue
+					}
+
+					// If we have seen this signer before in this block, we must make
+					// sure that the current sequence is seq+1; otherwise is invalid
+					// and we skip it.
+					if seq+1 != signer.Sequence {
+						shouldAdd = false
+						break
+					}
+					txSignersSeqs[signer.Signer.String()] = signer.Sequence
+				}
+				if !shouldAdd {
+					return true
 				}
-				txSignersSeqs[signer.Signer.String()] = signer.Sequence
-			}
-			if !shouldAdd {
-				return true
-			}
 
-			// NOTE: Since transaction verification was already executed in CheckTx,
-			// which calls mempool.Insert, in theory everything in the pool should be
-			// valid. But some mempool implementations may insert invalid txs, so we
-			// check again.
-			txBz, err := h.txVerifier.PrepareProposalVerifyTx(memTx)
-			if err != nil {
-				invalidTxs = append(invalidTxs, memTx)
-			} else {
-				stop := h.txSelector.SelectTxForProposal(ctx, uint64(req.MaxTxBytes), maxBlockGas, memTx, txBz)
-				if stop {
-					return false
+				// The min gas prices may have risen since the tx was inserted, evict
+				// it before verifying it.
+				if !paysMinGasPrices(memTx, minGasPrices) {
+					invalidTxs = append(invalidTxs, memTx)
+					return true
 				}
 
-				txsLen := len(h.txSelector.SelectedTxs(ctx))
-				for sender, seq := range txSignersSeqs {
-					// If txsLen != selectedTxsNums is true, it means that we've
-					// added a new tx to the selected txs, so we need to update
-					// the sequence of the sender.
+				// NOTE: Since transaction verification was already executed in CheckTx,
+				// which calls mempool.Insert, in theory everything in the pool should be
+				// valid. But some mempool implementations may insert invalid txs, so we
+				// check again.
+				txBz, err := h.txVerifier.PrepareProposalVerifyTx(memTx)
+				if err != nil {
+					invalidTxs = append(invalidTxs, memTx)
+				} else {
+					stop := h.txSelector.SelectTxForProposal(ctx, laneMaxTxBytes, laneMaxBlockGas, memTx, txBz)
+
+					txsLen := len(h.txSelector.SelectedTxs(ctx))
 					if txsLen != selectedTxsNums {
-						selectedTxsSignersSeqs[sender] = seq
-					} else if _, ok := selectedTxsSignersSeqs[sender]; !ok {
-						// The transaction hasn't been added but it passed the
-						// verification, so we know that the sequence is correct.
-						// So we set this sender's sequence to seq-1, in order
-						// to avoid unnecessary calls to PrepareProposalVerifyTx.
-						selectedTxsSignersSeqs[sender] = seq - 1
+						totalTxBytes += uint64(len(txBz))
+						if gasTx, ok := memTx.(GasTx); ok {
+							totalTxGas += gasTx.GetGas()
+						}
+					}
+					for sender, seq := range txSignersSeqs {
+						// If txsLen != selectedTxsNums is true, it means that we've
+						// added a new tx to the selected txs, so we need to update
+						// the sequence of the sender.
+						if txsLen != selectedTxsNums {
+							selectedTxsSignersSeqs[sender] = seq
+						} else if _, ok := selectedTxsSignersSeqs[sender]; !ok {
+							// The transaction hasn't been added but it passed the
+							// verification, so we know that the sequence is correct.
+							// So we set this sender's sequence to seq-1, in order
+							// to avoid unnecessary calls to PrepareProposalVerifyTx.
+							selectedTxsSignersSeqs[sender] = seq - 1
+						}
+					}
+					selectedTxsNums = txsLen
+
+					if stop {
+						return false
 					}
 				}
-				selectedTxsNums = txsLen
-			}
 
-			return true
-		})
+				return true
+			})
+
+			if resError != nil {
+				break
+			}
+		}
 
 		if resError != nil {
 			return nil, resError
@@ -366,12 +431,39 @@ func (h *DefaultProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHan
 	}
 }
 
+// paysMinGasPrices returns true if the fee of the tx is at least the min gas
+// prices multiplied by its gas limit, in any of their denoms.
+func paysMinGasPrices(tx sdk.Tx, minGasPrices sdk.DecCoins) bool {
+	if minGasPrices.IsZero() {
+		return true
+	}
+
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return true
+	}
+
+	gasLimit := math.LegacyNewDecFromInt(math.NewIntFromUint64(feeTx.GetGas()))
+	requiredFees := make(sdk.Coins, len(minGasPrices))
+	for i, gp := range minGasPrices {
+		requiredFees[i] = sdk.NewCoin(gp.Denom, gp.Amount.Mul(gasLimit).Ceil().RoundInt())
+	}
+
+	return feeTx.GetFee().IsAnyGTE(requiredFees)
+}
+
 // ProcessProposalHandler returns the default implementation for processing an
 // ABCI proposal. Every transaction in the proposal must pass 2 conditions:
 //
 // 1. The transaction bytes must decode to a valid transaction.
 // 2. The transaction must be valid (i.e. pass runTx, AnteHandler only)
 //
+// If the mempool is a LanedMempool, the transactions must also be ordered by
+// lane, and the transactions of each lane must fit in the lane's share of the
+// block max bytes and max gas. The lanes are set up by the application code
+// with NewLanedMempool, so all the validators running the same application
+// enforce the same lanes.
+//
 // If any transaction fails to pass either condition, the proposal is rejected.
 // Note that step (2) is identical to the validation step performed in
 // DefaultPrepareProposal. It is very important that the same validation logic
@@ -388,9 +480,15 @@ func (h *DefaultProposalHandler) ProcessProposalHandler() sdk.ProcessProposalHan
 	return func(ctx sdk.Context, req *abci.RequestProcessProposal) (*abci.ResponseProcessProposal, error) {
 		var totalTxGas uint64
 
-		var maxBlockGas int64
+		var maxBlockGas, maxBlockBytes int64
 		if b := ctx.ConsensusParams().Block; b != nil {
 			maxBlockGas = b.MaxGas
+			maxBlockBytes = b.MaxBytes
+		}
+
+		var lanes *proposalLanes
+		if laned, ok := lanedMempool(h.mempool); ok {
+			lanes = &proposalLanes{mempool: laned, maxBlockGas: maxBlockGas, maxBlockBytes: maxBlockBytes}
 		}
 
 		for _, txBytes := range req.Txs {
@@ -399,6 +497,10 @@ func (h *DefaultProposalHandler) ProcessProposalHandler() sdk.ProcessProposalHan
 				return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}, nil
 			}
 
+			if lanes != nil && !lanes.add(ctx, tx, txBytes) {
+				return &abci.ResponseProcessProposal{Status: abci.ResponseProcessProposal_REJECT}, nil
+			}
+
 			if maxBlockGas > 0 {
 				gasTx, ok := tx.(GasTx)
 				if ok {
@@ -415,6 +517,63 @@ func (h *DefaultProposalHandler) ProcessProposalHandler() sdk.ProcessProposalHan
 	}
 }
 
+// lanedMempool returns the LanedMempool the mempool is or wraps, e.g. when
+// its txs are journaled.
+func lanedMempool(mp mempool.Mempool) (*mempool.LanedMempool, bool) {
+	for {
+		switch m := mp.(type) {
+		case *mempool.LanedMempool:
+			return m, true
+		case interface{ Unwrap() mempool.Mempool }:
+			mp = m.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+}
+
+// proposalLanes verifies that the txs of a proposal are ordered by lane and
+// that the txs of each lane fit in the lane's share of the block.
+type proposalLanes struct {
+	mempool       *mempool.LanedMempool
+	maxBlockGas   int64
+	maxBlockBytes int64
+
+	// lane is the lane of the last tx, laneGas and laneBytes the gas and bytes
+	// used by the txs of that lane.
+	lane      int
+	laneGas   uint64
+	laneBytes uint64
+}
+
+// add returns true if the tx can follow the previous txs of the proposal: its
+// lane must not come before the lane of the previous tx, and the txs of its
+// lane must fit in the share of the block max gas and max bytes of the lane.
+func (pl *proposalLanes) add(ctx sdk.Context, tx sdk.Tx, txBytes []byte) bool {
+	txLane := pl.mempool.LaneIndex(ctx, tx)
+	switch {
+	case txLane < pl.lane:
+		return false
+	case txLane > pl.lane:
+		pl.lane, pl.laneGas, pl.laneBytes = txLane, 0, 0
+	}
+
+	pl.laneBytes += uint64(len(txBytes))
+	if gasTx, ok := tx.(GasTx); ok {
+		pl.laneGas += gasTx.GetGas()
+	}
+
+	lane := pl.mempool.Lanes()[txLane]
+	if pl.maxBlockGas > 0 && pl.laneGas > lane.Space(uint64(pl.maxBlockGas)) {
+		return false
+	}
+	if pl.maxBlockBytes > 0 && pl.laneBytes > lane.Space(uint64(pl.maxBlockBytes)) {
+		return false
+	}
+
+	return true
+}
+
 // NoOpPrepareProposal defines a no-op PrepareProposal handler. It will always
 // return the transactions sent by the client's request.
 func NoOpPrepareProposal() sdk.PrepareProposalHandler {
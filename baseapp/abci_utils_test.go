@@ -2,6 +2,8 @@ package baseapp_test
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"sort"
 	"testing"
 
@@ -20,6 +22,7 @@ import (
 	"cosmossdk.io/core/comet"
 	"cosmossdk.io/core/header"
 	"cosmossdk.io/log"
+	"cosmossdk.io/math"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	baseapptestutil "github.com/cosmos/cosmos-sdk/baseapp/testutil"
@@ -692,6 +695,156 @@ func (s *ABCIUtilsTestSuite) TestDefaultProposalHandler_PriorityNonceMempoolTxSe
 	}
 }
 
+func (s *ABCIUtilsTestSuite) TestDefaultProposalHandler_MinGasPricesEviction() {
+	cdc := codectestutil.CodecOptions{}.NewCodec()
+	baseapptestutil.RegisterInterfaces(cdc.InterfaceRegistry())
+	txConfig := authtx.NewTxConfig(cdc, authtx.DefaultSignModes)
+
+	buildFeeTx := func(secret []byte, fee int64) sdk.Tx {
+		builder := txConfig.NewTxBuilder()
+		s.Require().NoError(builder.SetMsgs(&baseapptestutil.MsgKeyValue{Value: secret}))
+		builder.SetGasLimit(100)
+		builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin("stake", fee)))
+		privKey := secp256k1.GenPrivKeyFromSecret(secret)
+		setTxSignatureWithSecret(s.T(), builder, signingtypes.SignatureV2{
+			PubKey:   privKey.PubKey(),
+			Sequence: 1,
+			Data:     &signingtypes.SingleSignatureData{},
+		})
+		return builder.GetTx()
+	}
+
+	// the base fee is 1.5stake per gas unit, so txs must pay at least 150stake
+	txs := []sdk.Tx{
+		buildFeeTx([]byte("secret1"), 200),
+		buildFeeTx([]byte("secret2"), 149),
+		buildFeeTx([]byte("secret3"), 150),
+	}
+
+	ctrl := gomock.NewController(s.T())
+	app := mock.NewMockProposalTxVerifier(ctrl)
+	mp := mempool.NewPriorityMempool(mempool.DefaultPriorityNonceMempoolConfig())
+
+	ph := baseapp.NewDefaultProposalHandler(mp, app)
+	ph.SetMinGasPricesFn(func(sdk.Context) (sdk.DecCoins, error) {
+		return sdk.NewDecCoins(sdk.NewDecCoinFromDec("stake", math.LegacyNewDecWithPrec(15, 1))), nil
+	})
+
+	var expTxs [][]byte
+	for i, tx := range txs {
+		bz, err := txConfig.TxEncoder()(tx)
+		s.Require().NoError(err)
+		if i != 1 {
+			app.EXPECT().PrepareProposalVerifyTx(tx).Return(bz, nil)
+			expTxs = append(expTxs, bz)
+		}
+		s.Require().NoError(mp.Insert(s.ctx.WithPriority(int64(len(txs)-i)), tx))
+	}
+
+	resp, err := ph.PrepareProposalHandler()(s.ctx, &abci.RequestPrepareProposal{MaxTxBytes: 1_000_000})
+	s.Require().NoError(err)
+	s.Require().Equal(expTxs, resp.Txs)
+
+	// the tx below the base fee has been evicted
+	s.Require().Equal(2, mp.CountTx())
+}
+
+func (s *ABCIUtilsTestSuite) TestDefaultProposalHandler_Lanes() {
+	cdc := codectestutil.CodecOptions{}.NewCodec()
+	baseapptestutil.RegisterInterfaces(cdc.InterfaceRegistry())
+	txConfig := authtx.NewTxConfig(cdc, authtx.DefaultSignModes)
+
+	isOracleTx := func(_ context.Context, tx sdk.Tx) bool {
+		msg, ok := tx.GetMsgs()[0].(*baseapptestutil.MsgKeyValue)
+		return ok && bytes.HasPrefix(msg.Value, []byte("oracle"))
+	}
+	newMempool := func() *mempool.LanedMempool {
+		return mempool.NewLanedMempool(
+			mempool.Lane{
+				Name:          "oracle",
+				Mempool:       mempool.DefaultPriorityMempool(),
+				Match:         isOracleTx,
+				MaxBlockSpace: math.LegacyNewDecWithPrec(5, 1),
+			},
+			mempool.Lane{Name: "default", Mempool: mempool.DefaultPriorityMempool()},
+		)
+	}
+
+	// all txs have the same size
+	var (
+		txs   []sdk.Tx
+		txsBz [][]byte
+	)
+	for i, value := range []string{"oracle1", "oracle2", "oracle3", "normal1", "normal2"} {
+		tx := buildMsg(s.T(), txConfig, []byte(value), [][]byte{[]byte(fmt.Sprintf("secret%d", i))}, []uint64{1})
+		bz, err := txConfig.TxEncoder()(tx)
+		s.Require().NoError(err)
+		txs = append(txs, tx)
+		txsBz = append(txsBz, bz)
+	}
+	txSize := int64(len(txsBz[0]))
+
+	s.Run("prepare proposal fills the lanes in order within their share", func() {
+		ctrl := gomock.NewController(s.T())
+		app := mock.NewMockProposalTxVerifier(ctrl)
+		mp := newMempool()
+		ph := baseapp.NewDefaultProposalHandler(mp, app)
+
+		// the default txs have the highest priorities
+		for i, tx := range txs {
+			app.EXPECT().PrepareProposalVerifyTx(tx).Return(txsBz[i], nil).AnyTimes()
+			s.Require().NoError(mp.Insert(s.ctx.WithPriority(int64(i)), tx))
+		}
+
+		resp, err := ph.PrepareProposalHandler()(s.ctx, &abci.RequestPrepareProposal{MaxTxBytes: 4*txSize + 1})
+		s.Require().NoError(err)
+		s.Require().Equal([][]byte{txsBz[2], txsBz[1], txsBz[4], txsBz[3]}, resp.Txs)
+	})
+
+	testCases := map[string]struct {
+		txs       []int
+		expStatus abci.ResponseProcessProposal_ProposalStatus
+	}{
+		"lanes in order": {
+			txs:       []int{0, 1, 3, 4},
+			expStatus: abci.ResponseProcessProposal_ACCEPT,
+		},
+		"default lane only": {
+			txs:       []int{3, 4},
+			expStatus: abci.ResponseProcessProposal_ACCEPT,
+		},
+		"oracle tx after a default tx": {
+			txs:       []int{0, 3, 1},
+			expStatus: abci.ResponseProcessProposal_REJECT,
+		},
+		"oracle lane over its share": {
+			txs:       []int{0, 1, 2},
+			expStatus: abci.ResponseProcessProposal_REJECT,
+		},
+	}
+
+	for name, tc := range testCases {
+		s.Run(name, func() {
+			ctrl := gomock.NewController(s.T())
+			app := mock.NewMockProposalTxVerifier(ctrl)
+			ph := baseapp.NewDefaultProposalHandler(newMempool(), app)
+
+			req := &abci.RequestProcessProposal{}
+			for _, i := range tc.txs {
+				app.EXPECT().ProcessProposalVerifyTx(txsBz[i]).Return(txs[i], nil).AnyTimes()
+				req.Txs = append(req.Txs, txsBz[i])
+			}
+
+			ctx := s.ctx.WithConsensusParams(cmtproto.ConsensusParams{
+				Block: &cmtproto.BlockParams{MaxBytes: 4*txSize + 1, MaxGas: -1},
+			})
+			resp, err := ph.ProcessProposalHandler()(ctx, req)
+			s.Require().NoError(err)
+			s.Require().Equal(tc.expStatus, resp.Status)
+		})
+	}
+}
+
 func marshalDelimitedFn(msg proto.Message) ([]byte, error) {
 	var buf bytes.Buffer
 	if err := protoio.NewDelimitedWriter(&buf).WriteMsg(msg); err != nil {
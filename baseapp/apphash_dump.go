@@ -0,0 +1,282 @@
+package baseapp
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+)
+
+// AppHashDump holds the per-store commit hashes and the state changes of a
+// committed block. Comparing the dumps written by two nodes at the same height
+// points to the store, and the keys, responsible for an app hash mismatch.
+type AppHashDump struct {
+	Height      int64              `json:"height"`
+	AppHash     string             `json:"app_hash"`
+	StoreHashes []AppHashDumpStore `json:"store_hashes"`
+	ChangeSet   []AppHashDumpKV    `json:"change_set"`
+}
+
+// AppHashDumpStore is the commit hash of a single store.
+type AppHashDumpStore struct {
+	Name string `json:"name"`
+	Hash string `json:"hash"`
+}
+
+// AppHashDumpKV is a single write of a block. Keys and values are hex encoded.
+type AppHashDumpKV struct {
+	Store  string `json:"store"`
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Delete bool   `json:"delete,omitempty"`
+}
+
+// AppHashDumpDivergence describes the first difference between two dumps.
+type AppHashDumpDivergence struct {
+	Height int64 `json:"height"`
+	// Store is the first store, by name, whose hash differs.
+	Store string `json:"store"`
+	HashA string `json:"hash_a"`
+	HashB string `json:"hash_b"`
+	// WritesA and WritesB hold the writes to Store that are not identical in
+	// both dumps, sorted by key.
+	WritesA []AppHashDumpKV `json:"writes_a"`
+	WritesB []AppHashDumpKV `json:"writes_b"`
+}
+
+// appHashDumper writes an AppHashDump for each committed block.
+type appHashDumper struct {
+	dir        string
+	keepRecent uint64
+	// dumpOnlyStores holds the stores listened to only for the dumps, whose
+	// writes must not be forwarded to the streaming listeners.
+	dumpOnlyStores map[string]struct{}
+}
+
+// enableAppHashDumpListeners enables listening on all persisted stores so that
+// the change set of every block can be dumped.
+func (app *BaseApp) enableAppHashDumpListeners() {
+	if app.appHashDumper == nil {
+		return
+	}
+
+	rms, ok := app.cms.(*rootmulti.Store)
+	if !ok {
+		app.logger.Error("app hash dumps require a rootmulti store", "store", fmt.Sprintf("%T", app.cms))
+		app.appHashDumper = nil
+		return
+	}
+
+	app.appHashDumper.dumpOnlyStores = make(map[string]struct{})
+	for name, key := range rms.StoreKeysByName() {
+		storeType := rms.GetCommitKVStore(key).GetStoreType()
+		if storeType == storetypes.StoreTypeTransient || storeType == storetypes.StoreTypeMemory {
+			continue
+		}
+		if app.cms.ListeningEnabled(key) {
+			continue
+		}
+
+		app.cms.AddListeners([]storetypes.StoreKey{key})
+		app.appHashDumper.dumpOnlyStores[name] = struct{}{}
+	}
+}
+
+// streamedChangeSet returns the part of the change set the streaming
+// listeners subscribed to.
+func (app *BaseApp) streamedChangeSet(changeSet []*storetypes.StoreKVPair) []*storetypes.StoreKVPair {
+	if app.appHashDumper == nil || len(app.appHashDumper.dumpOnlyStores) == 0 {
+		return changeSet
+	}
+
+	streamed := make([]*storetypes.StoreKVPair, 0, len(changeSet))
+	for _, pair := range changeSet {
+		if _, ok := app.appHashDumper.dumpOnlyStores[pair.StoreKey]; !ok {
+			streamed = append(streamed, pair)
+		}
+	}
+
+	return streamed
+}
+
+// dumpAppHash writes the dump of the block committed at the given height and
+// removes the dumps older than the configured number of recent heights.
+// Failures are logged and never halt the node.
+func (app *BaseApp) dumpAppHash(height int64, changeSet []*storetypes.StoreKVPair) {
+	rms, ok := app.cms.(*rootmulti.Store)
+	if !ok {
+		return
+	}
+
+	commitInfo, err := rms.GetCommitInfo(height)
+	if err != nil {
+		app.logger.Error("failed to load commit info for app hash dump", "height", height, "err", err)
+		return
+	}
+
+	dump := NewAppHashDump(commitInfo, changeSet)
+	if err := WriteAppHashDump(app.appHashDumper.dir, dump); err != nil {
+		app.logger.Error("failed to write app hash dump", "height", height, "err", err)
+		return
+	}
+
+	keepRecent := int64(app.appHashDumper.keepRecent)
+	if keepRecent > 0 && height > keepRecent {
+		err := os.Remove(AppHashDumpPath(app.appHashDumper.dir, height-keepRecent))
+		if err != nil && !os.IsNotExist(err) {
+			app.logger.Error("failed to remove app hash dump", "height", height-keepRecent, "err", err)
+		}
+	}
+}
+
+// NewAppHashDump builds the dump of a block from its commit info and change set.
+func NewAppHashDump(commitInfo *storetypes.CommitInfo, changeSet []*storetypes.StoreKVPair) AppHashDump {
+	dump := AppHashDump{
+		Height:      commitInfo.Version,
+		AppHash:     hex.EncodeToString(commitInfo.Hash()),
+		StoreHashes: make([]AppHashDumpStore, 0, len(commitInfo.StoreInfos)),
+		ChangeSet:   make([]AppHashDumpKV, 0, len(changeSet)),
+	}
+
+	for _, storeInfo := range commitInfo.StoreInfos {
+		dump.StoreHashes = append(dump.StoreHashes, AppHashDumpStore{
+			Name: storeInfo.Name,
+			Hash: hex.EncodeToString(storeInfo.CommitId.Hash),
+		})
+	}
+	sort.SliceStable(dump.StoreHashes, func(i, j int) bool {
+		return dump.StoreHashes[i].Name < dump.StoreHashes[j].Name
+	})
+
+	for _, pair := range changeSet {
+		dump.ChangeSet = append(dump.ChangeSet, AppHashDumpKV{
+			Store:  pair.StoreKey,
+			Key:    hex.EncodeToString(pair.Key),
+			Value:  hex.EncodeToString(pair.Value),
+			Delete: pair.Delete,
+		})
+	}
+
+	return dump
+}
+
+// AppHashDumpPath returns the path of the dump of the given height.
+func AppHashDumpPath(dir string, height int64) string {
+	return filepath.Join(dir, fmt.Sprintf("%d.json", height))
+}
+
+// WriteAppHashDump writes the dump as JSON in the given directory.
+func WriteAppHashDump(dir string, dump AppHashDump) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	bz, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(AppHashDumpPath(dir, dump.Height), bz, 0o600)
+}
+
+// ReadAppHashDump reads a dump written by WriteAppHashDump.
+func ReadAppHashDump(path string) (AppHashDump, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return AppHashDump{}, err
+	}
+
+	var dump AppHashDump
+	if err := json.Unmarshal(bz, &dump); err != nil {
+		return AppHashDump{}, fmt.Errorf("failed to decode app hash dump %s: %w", path, err)
+	}
+
+	return dump, nil
+}
+
+// CompareAppHashDumps returns the first store whose hash differs between the
+// two dumps together with the diverging writes to that store, or nil if the
+// app hashes and all store hashes are equal.
+func CompareAppHashDumps(a, b AppHashDump) (*AppHashDumpDivergence, error) {
+	if a.Height != b.Height {
+		return nil, fmt.Errorf("dumps are for different heights: %d and %d", a.Height, b.Height)
+	}
+
+	hashesB := make(map[string]string, len(b.StoreHashes))
+	for _, store := range b.StoreHashes {
+		hashesB[store.Name] = store.Hash
+	}
+	hashesA := make(map[string]string, len(a.StoreHashes))
+	for _, store := range a.StoreHashes {
+		hashesA[store.Name] = store.Hash
+	}
+
+	names := make([]string, 0, len(hashesA)+len(hashesB))
+	for name := range hashesA {
+		names = append(names, name)
+	}
+	for name := range hashesB {
+		if _, ok := hashesA[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if hashesA[name] == hashesB[name] {
+			continue
+		}
+
+		writesA, writesB := divergingWrites(name, a.ChangeSet, b.ChangeSet)
+		return &AppHashDumpDivergence{
+			Height:  a.Height,
+			Store:   name,
+			HashA:   hashesA[name],
+			HashB:   hashesB[name],
+			WritesA: writesA,
+			WritesB: writesB,
+		}, nil
+	}
+
+	if a.AppHash != b.AppHash {
+		return nil, fmt.Errorf("app hashes %s and %s differ while all store hashes are equal", a.AppHash, b.AppHash)
+	}
+
+	return nil, nil
+}
+
+// divergingWrites returns the last write of each key of the given store that
+// is not identical in both change sets.
+func divergingWrites(store string, changeSetA, changeSetB []AppHashDumpKV) (writesA, writesB []AppHashDumpKV) {
+	lastWrites := func(changeSet []AppHashDumpKV) map[string]AppHashDumpKV {
+		writes := make(map[string]AppHashDumpKV)
+		for _, pair := range changeSet {
+			if pair.Store == store {
+				writes[pair.Key] = pair
+			}
+		}
+		return writes
+	}
+
+	lastA, lastB := lastWrites(changeSetA), lastWrites(changeSetB)
+	for key, pairA := range lastA {
+		if pairB, ok := lastB[key]; !ok || pairA != pairB {
+			writesA = append(writesA, pairA)
+		}
+	}
+	for key, pairB := range lastB {
+		if pairA, ok := lastA[key]; !ok || pairA != pairB {
+			writesB = append(writesB, pairB)
+		}
+	}
+
+	sort.Slice(writesA, func(i, j int) bool { return writesA[i].Key < writesA[j].Key })
+	sort.Slice(writesB, func(i, j int) bool { return writesB[i].Key < writesB[j].Key })
+
+	return writesA, writesB
+}
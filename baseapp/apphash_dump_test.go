@@ -0,0 +1,119 @@
+package baseapp_test
+
+import (
+	"encoding/hex"
+	"os"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+)
+
+func TestABCI_AppHashDump(t *testing.T) {
+	dumpDir := t.TempDir()
+	mockListener := NewMockABCIListener("lis_1")
+	streamingManager := storetypes.StreamingManager{ABCIListeners: []storetypes.ABCIListener{&mockListener}}
+	opts := []func(*baseapp.BaseApp){
+		baseapp.SetAppHashDump(dumpDir, 2),
+		func(bapp *baseapp.BaseApp) { bapp.MountStores(distKey1) },
+		func(bapp *baseapp.BaseApp) { bapp.SetStreamingManager(streamingManager) },
+		func(bapp *baseapp.BaseApp) { bapp.CommitMultiStore().AddListeners([]storetypes.StoreKey{distKey1}) },
+	}
+	suite := NewBaseAppSuite(t, opts...)
+
+	_, err := suite.baseApp.InitChain(&abci.RequestInitChain{
+		ConsensusParams: &tmproto.ConsensusParams{},
+	})
+	require.NoError(t, err)
+
+	for height := int64(1); height <= 3; height++ {
+		_, err := suite.baseApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: height})
+		require.NoError(t, err)
+
+		ctx := getFinalizeBlockStateCtx(suite.baseApp)
+		ctx.KVStore(capKey1).Set([]byte("cap"), []byte{byte(height)})
+		ctx.KVStore(distKey1).Set([]byte("dist"), []byte{byte(height)})
+
+		res, err := suite.baseApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: height})
+		require.NoError(t, err)
+		_, err = suite.baseApp.Commit()
+		require.NoError(t, err)
+
+		dump, err := baseapp.ReadAppHashDump(baseapp.AppHashDumpPath(dumpDir, height))
+		require.NoError(t, err)
+		require.Equal(t, height, dump.Height)
+		require.Equal(t, hex.EncodeToString(res.AppHash), dump.AppHash)
+		require.Len(t, dump.StoreHashes, 3)
+		require.Equal(t, []baseapp.AppHashDumpKV{
+			{Store: distKey1.Name(), Key: hex.EncodeToString([]byte("dist")), Value: hex.EncodeToString([]byte{byte(height)})},
+			{Store: capKey1.Name(), Key: hex.EncodeToString([]byte("cap")), Value: hex.EncodeToString([]byte{byte(height)})},
+		}, dump.ChangeSet)
+
+		// streaming listeners only receive the stores they subscribed to
+		require.Equal(t, []*storetypes.StoreKVPair{
+			{StoreKey: distKey1.Name(), Key: []byte("dist"), Value: []byte{byte(height)}},
+		}, mockListener.ChangeSet)
+	}
+
+	// only the most recent dumps are kept
+	_, err = os.Stat(baseapp.AppHashDumpPath(dumpDir, 1))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestCompareAppHashDumps(t *testing.T) {
+	dumpA := baseapp.AppHashDump{
+		Height:  10,
+		AppHash: "aa",
+		StoreHashes: []baseapp.AppHashDumpStore{
+			{Name: "acc", Hash: "01"},
+			{Name: "bank", Hash: "02"},
+			{Name: "staking", Hash: "03"},
+		},
+		ChangeSet: []baseapp.AppHashDumpKV{
+			{Store: "bank", Key: "01", Value: "0a"},
+			{Store: "bank", Key: "02", Value: "0b"},
+			{Store: "staking", Key: "01", Value: "0c"},
+		},
+	}
+
+	divergence, err := baseapp.CompareAppHashDumps(dumpA, dumpA)
+	require.NoError(t, err)
+	require.Nil(t, divergence)
+
+	dumpB := dumpA
+	dumpB.AppHash = "bb"
+	dumpB.StoreHashes = []baseapp.AppHashDumpStore{
+		{Name: "acc", Hash: "01"},
+		{Name: "bank", Hash: "ff"},
+		{Name: "staking", Hash: "ff"},
+	}
+	dumpB.ChangeSet = []baseapp.AppHashDumpKV{
+		{Store: "bank", Key: "01", Value: "0a"},
+		{Store: "bank", Key: "02", Value: "ff"},
+		{Store: "bank", Key: "03", Delete: true},
+		{Store: "staking", Key: "01", Value: "ff"},
+	}
+
+	divergence, err = baseapp.CompareAppHashDumps(dumpA, dumpB)
+	require.NoError(t, err)
+	require.Equal(t, &baseapp.AppHashDumpDivergence{
+		Height:  10,
+		Store:   "bank",
+		HashA:   "02",
+		HashB:   "ff",
+		WritesA: []baseapp.AppHashDumpKV{{Store: "bank", Key: "02", Value: "0b"}},
+		WritesB: []baseapp.AppHashDumpKV{
+			{Store: "bank", Key: "02", Value: "ff"},
+			{Store: "bank", Key: "03", Delete: true},
+		},
+	}, divergence)
+
+	dumpB.Height = 11
+	_, err = baseapp.CompareAppHashDumps(dumpA, dumpB)
+	require.Error(t, err)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"os"
 	"sort"
 	"strconv"
+	"sync"
 
 	"github.com/cockroachdb/errors"
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -21,6 +23,7 @@ import (
 	"cosmossdk.io/log"
 	"cosmossdk.io/store"
 	storemetrics "cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
 	"cosmossdk.io/store/snapshots"
 	storetypes "cosmossdk.io/store/types"
 
@@ -179,6 +182,28 @@ type BaseApp struct {
 	// streamingManager for managing instances and configuration of ABCIListener services
 	streamingManager storetypes.StreamingManager
 
+	// appHashDumper writes the store hashes and change set of every committed
+	// block to disk when enabled, to help diagnosing app hash mismatches.
+	appHashDumper *appHashDumper
+
+	// spillStore is set when the block state spills its dirty writes to disk,
+	// see SetCacheKVSpill.
+	spillStore *rootmulti.Store
+
+	// mempoolJournal configures the journal recording the mempool txs so that
+	// they survive a restart, it is disabled if nil.
+	mempoolJournal *mempoolJournalConfig
+
+	// profiler records the gas and time spent by the ante decorators, the Msg
+	// handlers and the modules' block hooks in FinalizeBlock, it is disabled if
+	// nil.
+	profiler *Profiler
+
+	// balanceOverrider and sequenceOverrider apply the balance and sequence
+	// overrides of SimulateWithOverrides.
+	balanceOverrider  BalanceOverrider
+	sequenceOverrider SequenceOverrider
+
 	chainID string
 
 	cdc codec.Codec
@@ -188,12 +213,23 @@ type BaseApp struct {
 	// by developers.
 	optimisticExec *oe.OptimisticExecution
 
+	// oeAbortPolicy is the abort policy of the optimistic execution.
+	oeAbortPolicy oe.AbortPolicy
+
 	// disableBlockGasMeter will disable the block gas meter if true, block gas meter is tricky to support
 	// when executing transactions in parallel.
 	// when disabled, the block gas meter in context is a noop one.
 	//
 	// SAFETY: it's safe to do if validators validate the total gas wanted in the `ProcessProposal`, which is the case in the default handler.
 	disableBlockGasMeter bool
+
+	// txExecutor executes the txs of a block in FinalizeBlock, they are
+	// executed sequentially if nil.
+	txExecutor TxExecutor
+
+	// finalizeMempoolMtx serializes the removal of delivered txs from the
+	// mempool when they are executed concurrently by the txExecutor.
+	finalizeMempoolMtx *sync.Mutex
 }
 
 // NewBaseApp returns a reference to an initialized BaseApp. It accepts a
@@ -214,6 +250,8 @@ func NewBaseApp(
 		fauxMerkleMode:   false,
 		sigverifyTx:      true,
 		queryGasLimit:    math.MaxUint64,
+
+		finalizeMempoolMtx: &sync.Mutex{},
 	}
 
 	for _, option := range options {
@@ -223,6 +261,7 @@ func NewBaseApp(
 	if app.mempool == nil {
 		app.SetMempool(mempool.NoOpMempool{})
 	}
+	app.journalMempool()
 
 	abciProposalHandler := NewDefaultProposalHandler(app.mempool, app)
 
@@ -432,6 +471,8 @@ func (app *BaseApp) Init() error {
 		return errors.New("commit multi-store must not be nil")
 	}
 
+	app.enableAppHashDumpListeners()
+
 	return app.cms.GetPruning().Validate()
 }
 
@@ -447,6 +488,35 @@ func (app *BaseApp) setHaltTime(haltTime uint64) {
 	app.haltTime = haltTime
 }
 
+func (app *BaseApp) setCacheKVSpill(threshold uint64, dir string) {
+	if threshold == 0 {
+		return
+	}
+
+	rms, ok := app.cms.(*rootmulti.Store)
+	if !ok {
+		app.logger.Error("cachekv spilling requires a rootmulti store", "store", fmt.Sprintf("%T", app.cms))
+		return
+	}
+
+	// runs left behind by a previous process, e.g. on a crash, are never read
+	if err := os.RemoveAll(dir); err != nil {
+		app.logger.Error("failed to remove stale cachekv spill runs", "dir", dir, "err", err)
+	}
+
+	rms.SetCacheKVSpill(threshold, dir)
+	app.spillStore = rms
+}
+
+func (app *BaseApp) setAppHashDump(dir string, keepRecent uint64) {
+	if dir == "" {
+		app.appHashDumper = nil
+		return
+	}
+
+	app.appHashDumper = &appHashDumper{dir: dir, keepRecent: keepRecent}
+}
+
 func (app *BaseApp) setMinRetainBlocks(minRetainBlocks uint64) {
 	app.minRetainBlocks = minRetainBlocks
 }
@@ -477,7 +547,14 @@ func (app *BaseApp) IsSealed() bool { return app.sealed }
 // multi-store (i.e. a CacheMultiStore) and a new Context with the same
 // multi-store branch, and provided header.
 func (app *BaseApp) setState(mode execMode, h cmtproto.Header) {
-	ms := app.cms.CacheMultiStore()
+	var ms storetypes.CacheMultiStore
+	if mode == execModeFinalize && app.spillStore != nil {
+		// only the block state spills, the other states are discarded or
+		// only hold the writes of a single tx.
+		ms = app.spillStore.CacheMultiStoreWithSpill()
+	} else {
+		ms = app.cms.CacheMultiStore()
+	}
 	headerInfo := header.Info{
 		Height:  h.Height,
 		Time:    h.Time,
@@ -664,7 +741,13 @@ func (app *BaseApp) getContextForTx(mode execMode, txBytes []byte) sdk.Context {
 	if modeState == nil {
 		panic(fmt.Sprintf("state is nil for mode %v", mode))
 	}
-	ctx := modeState.Context().
+	return app.newContextForTx(modeState.Context(), mode, txBytes)
+}
+
+// newContextForTx returns the context of a tx derived from the given state
+// context.
+func (app *BaseApp) newContextForTx(ctx sdk.Context, mode execMode, txBytes []byte) sdk.Context {
+	ctx = ctx.
 		WithTxBytes(txBytes).
 		WithGasMeter(storetypes.NewInfiniteGasMeter())
 	// WithVoteInfos(app.voteInfos) // TODO: identify if this is needed
@@ -753,6 +836,27 @@ func (app *BaseApp) beginBlock(_ *abci.RequestFinalizeBlock) (sdk.BeginBlock, er
 }
 
 func (app *BaseApp) deliverTx(tx []byte) *abci.ExecTxResult {
+	return app.deliverTxWithContext(app.getContextForTx(execModeFinalize, tx), tx)
+}
+
+// deliverTxWithMultiStore executes the tx against the given multistore and
+// block gas meter instead of those of the finalize block state. It is the
+// DeliverTxFunc of the TxExecutor.
+func (app *BaseApp) deliverTxWithMultiStore(tx []byte, ms storetypes.MultiStore, blockGasMeter storetypes.GasMeter) *abci.ExecTxResult {
+	if _, err := app.txDecoder(tx); err != nil {
+		// same response as for malformed txs executed sequentially
+		return sdkerrors.ResponseExecTxResultWithEvents(sdkerrors.ErrTxDecode, 0, 0, nil, false)
+	}
+
+	ctx := app.finalizeBlockState.Context().
+		WithMultiStore(ms).
+		WithBlockGasMeter(blockGasMeter).
+		WithEventManager(sdk.NewEventManager())
+
+	return app.deliverTxWithContext(app.newContextForTx(ctx, execModeFinalize, tx), tx)
+}
+
+func (app *BaseApp) deliverTxWithContext(ctx sdk.Context, tx []byte) *abci.ExecTxResult {
 	gInfo := sdk.GasInfo{}
 	resultStr := "successful"
 
@@ -765,7 +869,7 @@ func (app *BaseApp) deliverTx(tx []byte) *abci.ExecTxResult {
 		telemetry.SetGauge(float32(gInfo.GasWanted), "tx", "gas", "wanted")
 	}()
 
-	gInfo, result, anteEvents, err := app.runTx(execModeFinalize, tx)
+	gInfo, result, anteEvents, err := app.runTxWithContext(execModeFinalize, ctx, tx)
 	if err != nil {
 		resultStr = "failed"
 		resp = sdkerrors.ResponseExecTxResultWithEvents(
@@ -823,12 +927,16 @@ func (app *BaseApp) endBlock(_ context.Context) (sdk.EndBlock, error) {
 // returned if the tx does not run out of gas and if all the messages are valid
 // and execute successfully. An error is returned otherwise.
 func (app *BaseApp) runTx(mode execMode, txBytes []byte) (gInfo sdk.GasInfo, result *sdk.Result, anteEvents []abci.Event, err error) {
+	return app.runTxWithContext(mode, app.getContextForTx(mode, txBytes), txBytes)
+}
+
+// runTxWithContext is runTx with the tx context provided by the caller.
+func (app *BaseApp) runTxWithContext(mode execMode, ctx sdk.Context, txBytes []byte) (gInfo sdk.GasInfo, result *sdk.Result, anteEvents []abci.Event, err error) {
 	// NOTE: GasWanted should be returned by the AnteHandler. GasUsed is
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter, so we initialize upfront.
 	var gasWanted uint64
 
-	ctx := app.getContextForTx(mode, txBytes)
 	ms := ctx.MultiStore()
 
 	// only run the tx if there is block gas remaining
@@ -939,7 +1047,10 @@ func (app *BaseApp) runTx(mode execMode, txBytes []byte) (gInfo sdk.GasInfo, res
 			return gInfo, nil, anteEvents, err
 		}
 	} else if mode == execModeFinalize {
+		// txs may be delivered concurrently by the TxExecutor
+		app.finalizeMempoolMtx.Lock()
 		err = app.mempool.Remove(tx)
+		app.finalizeMempoolMtx.Unlock()
 		if err != nil && !errors.Is(err, mempool.ErrTxNotFound) {
 			return gInfo, nil, anteEvents,
 				fmt.Errorf("failed to remove tx from mempool: %w", err)
@@ -985,6 +1096,10 @@ func (app *BaseApp) runTx(mode execMode, txBytes []byte) (gInfo sdk.GasInfo, res
 			// When block gas exceeds, it'll panic and won't commit the cached store.
 			consumeBlockGas()
 
+			msCache.Write()
+		} else if mode == execModeSimulate {
+			// the simulation context is a branch discarded by the caller,
+			// writing to it exposes the state diff to SimulateWithOverrides
 			msCache.Write()
 		}
 
@@ -1018,7 +1133,11 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, msgsV2 []protov2.Me
 		}
 
 		// ADR 031 request type routing
-		msgResult, err := handler(ctx, msg)
+		var msgResult *sdk.Result
+		err := sdk.ProfileFunc(ctx, sdk.ProfileCategoryMsg, sdk.MsgTypeURL(msg), func() (err error) {
+			msgResult, err = handler(ctx, msg)
+			return err
+		})
 		if err != nil {
 			return nil, errorsmod.Wrapf(err, "failed to execute message; message index: %d", i)
 		}
@@ -1169,5 +1288,11 @@ func (app *BaseApp) Close() error {
 		}
 	}
 
+	if journaled, ok := app.mempool.(*mempool.JournaledMempool); ok {
+		if err := journaled.Journal().Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	return errors.Join(errs...)
 }
@@ -14,6 +14,8 @@ import (
 
 	errorsmod "cosmossdk.io/errors"
 
+	"github.com/cosmos/cosmos-sdk/baseapp/oe"
+	"github.com/cosmos/cosmos-sdk/baseapp/profiler"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
@@ -96,4 +98,12 @@ func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 
 		server.RegisterService(newDesc, data.handler)
 	}
+
+	if app.profiler != nil {
+		profiler.RegisterProfilerServiceServer(server, profilerService{profiler: app.profiler})
+	}
+
+	if app.optimisticExec != nil {
+		oe.RegisterOptimisticExecutionServiceServer(server, oeService{oe: app.optimisticExec})
+	}
 }
@@ -0,0 +1,89 @@
+package baseapp
+
+import (
+	"errors"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+)
+
+// mempoolJournalConfig configures the journal of the app-side mempool.
+type mempoolJournalConfig struct {
+	path   string
+	maxAge time.Duration
+}
+
+func (app *BaseApp) setMempoolJournal(path string, maxAge time.Duration) {
+	if path == "" {
+		app.mempoolJournal = nil
+		return
+	}
+
+	app.mempoolJournal = &mempoolJournalConfig{path: path, maxAge: maxAge}
+}
+
+// journalMempool wraps the mempool so that its txs are recorded in the
+// configured journal. Failures are logged and leave the mempool in memory
+// only.
+func (app *BaseApp) journalMempool() {
+	if app.mempoolJournal == nil {
+		return
+	}
+	if _, isNoOp := app.mempool.(mempool.NoOpMempool); isNoOp {
+		return
+	}
+
+	journal, err := mempool.OpenJournal(app.mempoolJournal.path)
+	if err != nil {
+		app.logger.Error("failed to open mempool journal", "path", app.mempoolJournal.path, "err", err)
+		return
+	}
+
+	// the tx encoder may be set after the options are applied, resolve it on use
+	app.mempool = mempool.NewJournaledMempool(app.mempool, journal, app.TxEncode)
+}
+
+// syncMempoolJournal flushes the mempool journal to disk, so that the txs
+// inserted and removed up to the committed block survive a crash. Failures
+// are logged, the journal is synced again on the next commit.
+func (app *BaseApp) syncMempoolJournal() {
+	journaled, ok := app.mempool.(*mempool.JournaledMempool)
+	if !ok {
+		return
+	}
+
+	if err := journaled.Journal().Sync(); err != nil {
+		app.logger.Error("failed to sync mempool journal", "err", err)
+	}
+}
+
+// ReplayMempoolJournal inserts the txs pending in the mempool journal back
+// into the mempool through CheckTx. Txs older than the configured max age or
+// rejected by CheckTx are dropped. It is a no-op if the mempool journal is not
+// enabled, and must be called once the latest version is loaded and before
+// CometBFT starts.
+func (app *BaseApp) ReplayMempoolJournal() error {
+	journaled, ok := app.mempool.(*mempool.JournaledMempool)
+	if !ok {
+		return nil
+	}
+
+	replayed, err := journaled.Replay(app.mempoolJournal.maxAge, time.Now(), func(txBz []byte) error {
+		res, err := app.CheckTx(&abci.RequestCheckTx{Tx: txBz, Type: abci.CheckTxType_New})
+		if err != nil {
+			return err
+		}
+		if res.Code != abci.CodeTypeOK {
+			return errors.New(res.Log)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	app.logger.Info("replayed mempool journal", "txs", replayed, "mempool_size", app.mempool.CountTx())
+	return nil
+}
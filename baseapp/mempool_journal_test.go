@@ -0,0 +1,63 @@
+package baseapp_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	baseapptestutil "github.com/cosmos/cosmos-sdk/baseapp/testutil"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+)
+
+func TestMempoolJournal(t *testing.T) {
+	journalPath := filepath.Join(t.TempDir(), "mempool.journal")
+	anteKey := []byte("ante-key")
+
+	newSuite := func(pool mempool.Mempool) *BaseAppSuite {
+		anteOpt := func(bapp *baseapp.BaseApp) {
+			bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey))
+		}
+		suite := NewBaseAppSuite(t, anteOpt, baseapp.SetMempool(pool), baseapp.SetMempoolJournal(journalPath, 0))
+		baseapptestutil.RegisterCounterServer(suite.baseApp.MsgServiceRouter(), NoopCounterServerImpl{})
+
+		_, err := suite.baseApp.InitChain(&abci.RequestInitChain{
+			ConsensusParams: &cmtproto.ConsensusParams{},
+		})
+		require.NoError(t, err)
+
+		return suite
+	}
+
+	pool := mempool.NewSenderNonceMempool(mempool.SenderNonceMaxTxOpt(5000))
+	suite := newSuite(pool)
+
+	var txs [][]byte
+	for i := int64(0); i < 3; i++ {
+		txBytes, err := suite.txConfig.TxEncoder()(newTxCounter(t, suite.txConfig, i, 1))
+		require.NoError(t, err)
+		txs = append(txs, txBytes)
+
+		res, err := suite.baseApp.CheckTx(&abci.RequestCheckTx{Tx: txBytes, Type: abci.CheckTxType_New})
+		require.NoError(t, err)
+		require.True(t, res.IsOK(), res.Log)
+	}
+	require.Equal(t, 3, pool.CountTx())
+
+	// the last tx is delivered and removed from the mempool
+	tx, err := suite.txConfig.TxDecoder()(txs[2])
+	require.NoError(t, err)
+	require.NoError(t, suite.baseApp.Mempool().Remove(tx))
+	require.NoError(t, suite.baseApp.Close())
+
+	// the pending txs are replayed after a restart
+	pool = mempool.NewSenderNonceMempool(mempool.SenderNonceMaxTxOpt(5000))
+	suite = newSuite(pool)
+	require.Zero(t, pool.CountTx())
+	require.NoError(t, suite.baseApp.ReplayMempoolJournal())
+	require.Equal(t, 2, pool.CountTx())
+	require.NoError(t, suite.baseApp.Close())
+}
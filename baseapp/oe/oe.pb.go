@@ -0,0 +1,850 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/base/oe/v1alpha1/oe.proto
+
+package oe
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// StatsRequest is the request type for the Stats RPC method.
+type StatsRequest struct {
+}
+
+func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
+func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
+func (*StatsRequest) ProtoMessage()    {}
+func (*StatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e8f6142871de4324, []int{0}
+}
+func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StatsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatsRequest.Merge(m, src)
+}
+func (m *StatsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatsRequest proto.InternalMessageInfo
+
+// StatsResponse is the response type for the Stats RPC method.
+type StatsResponse struct {
+	// executions is the number of optimistic executions started.
+	Executions uint64 `protobuf:"varint,1,opt,name=executions,proto3" json:"executions,omitempty"`
+	// hits is the number of optimistic execution results returned by
+	// FinalizeBlock.
+	Hits uint64 `protobuf:"varint,2,opt,name=hits,proto3" json:"hits,omitempty"`
+	// aborts is the number of optimistic executions aborted by reason.
+	Aborts map[string]uint64 `protobuf:"bytes,3,rep,name=aborts,proto3" json:"aborts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// consecutive_aborts is the number of optimistic executions aborted since
+	// the last hit.
+	ConsecutiveAborts uint64 `protobuf:"varint,4,opt,name=consecutive_aborts,json=consecutiveAborts,proto3" json:"consecutive_aborts,omitempty"`
+	// time_saved is the total execution time that FinalizeBlock didn't wait for
+	// thanks to the hits.
+	TimeSaved time.Duration `protobuf:"bytes,5,opt,name=time_saved,json=timeSaved,proto3,stdduration" json:"time_saved"`
+	// disabled is true if the optimistic execution was disabled by its abort
+	// policy.
+	Disabled bool `protobuf:"varint,6,opt,name=disabled,proto3" json:"disabled,omitempty"`
+}
+
+func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
+func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
+func (*StatsResponse) ProtoMessage()    {}
+func (*StatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e8f6142871de4324, []int{1}
+}
+func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatsResponse.Merge(m, src)
+}
+func (m *StatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *StatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatsResponse proto.InternalMessageInfo
+
+func (m *StatsResponse) GetExecutions() uint64 {
+	if m != nil {
+		return m.Executions
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetHits() uint64 {
+	if m != nil {
+		return m.Hits
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetAborts() map[string]uint64 {
+	if m != nil {
+		return m.Aborts
+	}
+	return nil
+}
+
+func (m *StatsResponse) GetConsecutiveAborts() uint64 {
+	if m != nil {
+		return m.ConsecutiveAborts
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetTimeSaved() time.Duration {
+	if m != nil {
+		return m.TimeSaved
+	}
+	return 0
+}
+
+func (m *StatsResponse) GetDisabled() bool {
+	if m != nil {
+		return m.Disabled
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*StatsRequest)(nil), "cosmos.base.oe.v1alpha1.StatsRequest")
+	proto.RegisterType((*StatsResponse)(nil), "cosmos.base.oe.v1alpha1.StatsResponse")
+	proto.RegisterMapType((map[string]uint64)(nil), "cosmos.base.oe.v1alpha1.StatsResponse.AbortsEntry")
+}
+
+func init() { proto.RegisterFile("cosmos/base/oe/v1alpha1/oe.proto", fileDescriptor_e8f6142871de4324) }
+
+var fileDescriptor_e8f6142871de4324 = []byte{
+	// 419 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xbf, 0x8e, 0xd3, 0x40,
+	0x10, 0xc6, 0xb3, 0xf9, 0xa7, 0xdc, 0x04, 0x10, 0xac, 0x4e, 0xc2, 0xb8, 0xf0, 0x59, 0x27, 0x01,
+	0x69, 0x6e, 0x57, 0x17, 0x1a, 0xa0, 0xbb, 0x88, 0x6b, 0x68, 0x90, 0x1c, 0x89, 0x82, 0xe6, 0xb4,
+	0xb6, 0x07, 0x67, 0x75, 0x49, 0xc6, 0x78, 0xd7, 0x16, 0xf7, 0x16, 0x94, 0x3c, 0xd2, 0x95, 0x29,
+	0xa1, 0x01, 0x94, 0xbc, 0x08, 0xf2, 0xda, 0x41, 0xa1, 0x40, 0x5c, 0xe5, 0x6f, 0x67, 0xbe, 0x6f,
+	0x66, 0xf5, 0xf3, 0x42, 0x98, 0x90, 0x59, 0x91, 0x91, 0xb1, 0x32, 0x28, 0x09, 0x65, 0x75, 0xae,
+	0x96, 0xf9, 0x42, 0x9d, 0x4b, 0x42, 0x91, 0x17, 0x64, 0x89, 0x3f, 0x6e, 0x1c, 0xa2, 0x76, 0x08,
+	0x42, 0xb1, 0x77, 0xf8, 0x41, 0x46, 0x94, 0x2d, 0x51, 0x3a, 0x5b, 0x5c, 0x7e, 0x94, 0x69, 0x59,
+	0x28, 0xab, 0x69, 0xdd, 0x04, 0xfd, 0xe3, 0x8c, 0x32, 0x72, 0x52, 0xd6, 0xaa, 0xa9, 0x9e, 0x3e,
+	0x80, 0x7b, 0x73, 0xab, 0xac, 0x89, 0xf0, 0x53, 0x89, 0xc6, 0x9e, 0x7e, 0xef, 0xc2, 0xfd, 0xb6,
+	0x60, 0x72, 0x5a, 0x1b, 0xe4, 0x01, 0x00, 0x7e, 0xc6, 0xa4, 0xac, 0x47, 0x19, 0x8f, 0x85, 0x6c,
+	0xd2, 0x8f, 0x0e, 0x2a, 0x9c, 0x43, 0x7f, 0xa1, 0xad, 0xf1, 0xba, 0xae, 0xe3, 0x34, 0x7f, 0x0b,
+	0x43, 0x15, 0x53, 0x61, 0x8d, 0xd7, 0x0b, 0x7b, 0x93, 0xf1, 0x74, 0x2a, 0xfe, 0x71, 0x6b, 0xf1,
+	0xd7, 0x2e, 0x71, 0xe1, 0x42, 0x97, 0x6b, 0x5b, 0xdc, 0x44, 0xed, 0x04, 0x7e, 0x06, 0x3c, 0xa9,
+	0x9b, 0xf5, 0xbe, 0x0a, 0xaf, 0xda, 0xb9, 0x7d, 0xb7, 0xed, 0xd1, 0x41, 0xa7, 0xc9, 0xf2, 0x19,
+	0x80, 0xd5, 0x2b, 0xbc, 0x32, 0xaa, 0xc2, 0xd4, 0x1b, 0x84, 0x6c, 0x32, 0x9e, 0x3e, 0x11, 0x0d,
+	0x1b, 0xb1, 0x67, 0x23, 0xde, 0xb4, 0x6c, 0x66, 0xa3, 0xdb, 0x1f, 0x27, 0x9d, 0xaf, 0x3f, 0x4f,
+	0x58, 0x74, 0x54, 0xc7, 0xe6, 0x75, 0x8a, 0xfb, 0x30, 0x4a, 0xb5, 0x51, 0xf1, 0x12, 0x53, 0x6f,
+	0x18, 0xb2, 0xc9, 0x28, 0xfa, 0x73, 0xf6, 0x5f, 0xc1, 0xf8, 0xe0, 0x96, 0xfc, 0x21, 0xf4, 0xae,
+	0xf1, 0xc6, 0x61, 0x39, 0x8a, 0x6a, 0xc9, 0x8f, 0x61, 0x50, 0xa9, 0x65, 0x89, 0x2d, 0x90, 0xe6,
+	0xf0, 0xba, 0xfb, 0x92, 0x4d, 0x2d, 0xf8, 0xef, 0x72, 0xab, 0x57, 0xda, 0x58, 0x9d, 0x5c, 0xee,
+	0x09, 0xce, 0xb1, 0xa8, 0x74, 0x82, 0xfc, 0x3d, 0x0c, 0x1c, 0x0c, 0xfe, 0xf4, 0x7f, 0xb0, 0xdc,
+	0x9f, 0xf2, 0x9f, 0xdd, 0x8d, 0xe9, 0xec, 0xe2, 0x76, 0x1b, 0xb0, 0xcd, 0x36, 0x60, 0xbf, 0xb6,
+	0x01, 0xfb, 0xb2, 0x0b, 0x3a, 0x9b, 0x5d, 0xd0, 0xf9, 0xb6, 0x0b, 0x3a, 0x1f, 0x9e, 0x67, 0xda,
+	0x2e, 0xca, 0x58, 0x24, 0xb4, 0x92, 0xed, 0xbb, 0x6b, 0x3e, 0x67, 0x26, 0xbd, 0x76, 0x4f, 0x50,
+	0xe5, 0xb9, 0x24, 0x8c, 0x87, 0x8e, 0xdb, 0x8b, 0xdf, 0x03, 0x00, 0x40, 0x8d, 0x8c, 0x57, 0x9e,
+	0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// OptimisticExecutionServiceClient is the client API for OptimisticExecutionService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type OptimisticExecutionServiceClient interface {
+	// Stats returns the statistics of the optimistic execution since the node
+	// started.
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+}
+
+type optimisticExecutionServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewOptimisticExecutionServiceClient(cc grpc1.ClientConn) OptimisticExecutionServiceClient {
+	return &optimisticExecutionServiceClient{cc}
+}
+
+func (c *optimisticExecutionServiceClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.base.oe.v1alpha1.OptimisticExecutionService/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OptimisticExecutionServiceServer is the server API for OptimisticExecutionService service.
+type OptimisticExecutionServiceServer interface {
+	// Stats returns the statistics of the optimistic execution since the node
+	// started.
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+}
+
+// UnimplementedOptimisticExecutionServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedOptimisticExecutionServiceServer struct {
+}
+
+func (*UnimplementedOptimisticExecutionServiceServer) Stats(ctx context.Context, req *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+
+func RegisterOptimisticExecutionServiceServer(s grpc1.Server, srv OptimisticExecutionServiceServer) {
+	s.RegisterService(&_OptimisticExecutionService_serviceDesc, srv)
+}
+
+func _OptimisticExecutionService_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimisticExecutionServiceServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.base.oe.v1alpha1.OptimisticExecutionService/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimisticExecutionServiceServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var OptimisticExecutionService_serviceDesc = _OptimisticExecutionService_serviceDesc
+var _OptimisticExecutionService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cosmos.base.oe.v1alpha1.OptimisticExecutionService",
+	HandlerType: (*OptimisticExecutionServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Stats",
+			Handler:    _OptimisticExecutionService_Stats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cosmos/base/oe/v1alpha1/oe.proto",
+}
+
+func (m *StatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *StatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Disabled {
+		i--
+		if m.Disabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	n1, err1 := github_com_cosmos_gogoproto_types.StdDurationMarshalTo(m.TimeSaved, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.TimeSaved):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintOe(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0x2a
+	if m.ConsecutiveAborts != 0 {
+		i = encodeVarintOe(dAtA, i, uint64(m.ConsecutiveAborts))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Aborts) > 0 {
+		for k := range m.Aborts {
+			v := m.Aborts[k]
+			baseI := i
+			i = encodeVarintOe(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintOe(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintOe(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Hits != 0 {
+		i = encodeVarintOe(dAtA, i, uint64(m.Hits))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Executions != 0 {
+		i = encodeVarintOe(dAtA, i, uint64(m.Executions))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintOe(dAtA []byte, offset int, v uint64) int {
+	offset -= sovOe(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *StatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *StatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Executions != 0 {
+		n += 1 + sovOe(uint64(m.Executions))
+	}
+	if m.Hits != 0 {
+		n += 1 + sovOe(uint64(m.Hits))
+	}
+	if len(m.Aborts) > 0 {
+		for k, v := range m.Aborts {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovOe(uint64(len(k))) + 1 + sovOe(uint64(v))
+			n += mapEntrySize + 1 + sovOe(uint64(mapEntrySize))
+		}
+	}
+	if m.ConsecutiveAborts != 0 {
+		n += 1 + sovOe(uint64(m.ConsecutiveAborts))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdDuration(m.TimeSaved)
+	n += 1 + l + sovOe(uint64(l))
+	if m.Disabled {
+		n += 2
+	}
+	return n
+}
+
+func sovOe(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozOe(x uint64) (n int) {
+	return sovOe(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *StatsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOe
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOe(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOe
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOe
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Executions", wireType)
+			}
+			m.Executions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOe
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Executions |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hits", wireType)
+			}
+			m.Hits = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOe
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Hits |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Aborts", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOe
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOe
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOe
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Aborts == nil {
+				m.Aborts = make(map[string]uint64)
+			}
+			var mapkey string
+			var mapvalue uint64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOe
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOe
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthOe
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthOe
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOe
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipOe(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthOe
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Aborts[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsecutiveAborts", wireType)
+			}
+			m.ConsecutiveAborts = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOe
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConsecutiveAborts |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeSaved", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOe
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOe
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOe
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdDurationUnmarshal(&m.TimeSaved, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Disabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOe
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Disabled = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOe(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthOe
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipOe(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowOe
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOe
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowOe
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthOe
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupOe
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthOe
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthOe        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowOe          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupOe = fmt.Errorf("proto: unexpected end of group")
+)
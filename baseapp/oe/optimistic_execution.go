@@ -9,19 +9,71 @@ import (
 	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/hashicorp/go-metrics"
 
 	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
 )
 
 // FinalizeBlockFunc is the function that is called by the OE to finalize the
 // block. It is the same as the one in the ABCI app.
 type FinalizeBlockFunc func(context.Context, *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error)
 
+// AbortReason is the reason why an OE was aborted, its block being then
+// executed again by FinalizeBlock.
+type AbortReason string
+
+const (
+	// AbortReasonHashMismatch means that the block finalized is not the one
+	// executed optimistically.
+	AbortReasonHashMismatch AbortReason = "hash_mismatch"
+	// AbortReasonTimeout means that the OE ran for longer than the timeout of
+	// the AbortPolicy before FinalizeBlock was called.
+	AbortReasonTimeout AbortReason = "timeout"
+	// AbortReasonNewProposal means that another proposal was processed, in a
+	// later round, before the block was finalized.
+	AbortReasonNewProposal AbortReason = "new_proposal"
+	// AbortReasonAbortRate means that the OE was aborted by the test abort
+	// rate.
+	AbortReasonAbortRate AbortReason = "abort_rate"
+)
+
+// AbortPolicy defines when the OE is aborted or stops being run, besides when
+// the finalized block differs from the one executed optimistically.
+type AbortPolicy struct {
+	// Timeout aborts the OE running for longer than Timeout while FinalizeBlock
+	// wasn't called yet. Zero disables the timeout.
+	Timeout time.Duration
+	// MaxConsecutiveAborts disables the OE for the lifetime of the process
+	// after this many consecutive aborts, as the node then executes most
+	// blocks twice. Zero never disables the OE.
+	MaxConsecutiveAborts uint64
+}
+
+// Stats are the statistics of the OE since the node started.
+type Stats struct {
+	// Executions is the number of OEs started.
+	Executions uint64 `json:"executions"`
+	// Hits is the number of OE results returned by FinalizeBlock.
+	Hits uint64 `json:"hits"`
+	// Aborts is the number of OEs aborted by reason.
+	Aborts map[AbortReason]uint64 `json:"aborts"`
+	// ConsecutiveAborts is the number of OEs aborted since the last hit.
+	ConsecutiveAborts uint64 `json:"consecutive_aborts"`
+	// TimeSaved is the total execution time that FinalizeBlock didn't wait for
+	// thanks to the hits.
+	TimeSaved time.Duration `json:"time_saved"`
+	// Disabled is true if the OE was disabled by the AbortPolicy.
+	Disabled bool `json:"disabled"`
+}
+
 // OptimisticExecution is a struct that contains the OE context. It is used to
 // run the FinalizeBlock function in a goroutine, and to abort it if needed.
 type OptimisticExecution struct {
 	finalizeBlockFunc FinalizeBlockFunc // ABCI FinalizeBlock function with a context
 	logger            log.Logger
+	policy            AbortPolicy
 
 	mtx         sync.Mutex
 	stopCh      chan struct{}
@@ -29,7 +81,14 @@ type OptimisticExecution struct {
 	response    *abci.ResponseFinalizeBlock
 	err         error
 	cancelFunc  func() // cancel function for the context
-	initialized bool   // A boolean value indicating whether the struct has been initialized
+	timer       *time.Timer
+	initialized bool        // A boolean value indicating whether the struct has been initialized
+	finalizing  bool        // FinalizeBlock was called for the current OE
+	done        bool        // the current OE was either aborted or its result was returned
+	abortReason AbortReason // the reason why the current OE was aborted, empty if it wasn't
+	startTime   time.Time
+	finishTime  time.Time
+	stats       Stats
 
 	// debugging/testing options
 	abortRate int // number from 0 to 100 that determines the percentage of OE that should be aborted
@@ -38,7 +97,11 @@ type OptimisticExecution struct {
 // NewOptimisticExecution initializes the Optimistic Execution context but does not start it.
 func NewOptimisticExecution(logger log.Logger, fn FinalizeBlockFunc, opts ...func(*OptimisticExecution)) *OptimisticExecution {
 	logger = logger.With(log.ModuleKey, "oe")
-	oe := &OptimisticExecution{logger: logger, finalizeBlockFunc: fn}
+	oe := &OptimisticExecution{
+		logger:            logger,
+		finalizeBlockFunc: fn,
+		stats:             Stats{Aborts: make(map[AbortReason]uint64)},
+	}
 	for _, opt := range opts {
 		opt(oe)
 	}
@@ -54,6 +117,20 @@ func WithAbortRate(rate int) func(*OptimisticExecution) {
 	}
 }
 
+// WithAbortPolicy sets the abort policy of the OE.
+func WithAbortPolicy(policy AbortPolicy) func(*OptimisticExecution) {
+	return func(oe *OptimisticExecution) {
+		oe.SetAbortPolicy(policy)
+	}
+}
+
+// SetAbortPolicy sets the abort policy of the OE, it applies from the next OE.
+func (oe *OptimisticExecution) SetAbortPolicy(policy AbortPolicy) {
+	oe.mtx.Lock()
+	defer oe.mtx.Unlock()
+	oe.policy = policy
+}
+
 // Reset resets the OE context. Must be called whenever we want to invalidate
 // the current OE.
 func (oe *OptimisticExecution) Reset() {
@@ -65,8 +142,16 @@ func (oe *OptimisticExecution) Reset() {
 	oe.initialized = false
 }
 
+// Enabled returns true if the OE is set and wasn't disabled by its abort
+// policy.
 func (oe *OptimisticExecution) Enabled() bool {
-	return oe != nil
+	if oe == nil {
+		return false
+	}
+	oe.mtx.Lock()
+	defer oe.mtx.Unlock()
+
+	return !oe.stats.Disabled
 }
 
 // Initialized returns true if the OE was initialized, meaning that it contains
@@ -81,6 +166,20 @@ func (oe *OptimisticExecution) Initialized() bool {
 	return oe.initialized
 }
 
+// Stats returns the statistics of the OE.
+func (oe *OptimisticExecution) Stats() Stats {
+	oe.mtx.Lock()
+	defer oe.mtx.Unlock()
+
+	stats := oe.stats
+	stats.Aborts = make(map[AbortReason]uint64, len(oe.stats.Aborts))
+	for reason, count := range oe.stats.Aborts {
+		stats.Aborts[reason] = count
+	}
+
+	return stats
+}
+
 // Execute initializes the OE and starts it in a goroutine.
 func (oe *OptimisticExecution) Execute(req *abci.RequestProcessProposal) {
 	oe.mtx.Lock()
@@ -102,22 +201,67 @@ func (oe *OptimisticExecution) Execute(req *abci.RequestProcessProposal) {
 	ctx, cancel := context.WithCancel(context.Background())
 	oe.cancelFunc = cancel
 	oe.initialized = true
+	oe.finalizing = false
+	oe.done = false
+	oe.abortReason = ""
+	oe.startTime = time.Now()
+	oe.stats.Executions++
+	telemetry.IncrCounter(1, "oe", "executions")
+
+	stopCh, request := oe.stopCh, oe.request
+	if oe.policy.Timeout > 0 {
+		oe.timer = time.AfterFunc(oe.policy.Timeout, func() {
+			oe.mtx.Lock()
+			defer oe.mtx.Unlock()
+
+			// the OE may have been replaced by then
+			if oe.stopCh != stopCh || oe.finalizing || oe.done {
+				return
+			}
+			oe.logger.Error("OE aborted due to timeout", "timeout", oe.policy.Timeout.String(), "height", request.Height)
+			oe.abort(AbortReasonTimeout)
+		})
+	}
 
 	go func() {
-		start := time.Now()
-		resp, err := oe.finalizeBlockFunc(ctx, oe.request)
+		resp, err := oe.finalizeBlockFunc(ctx, request)
 
 		oe.mtx.Lock()
 
-		executionTime := time.Since(start)
-		oe.logger.Debug("OE finished", "duration", executionTime.String(), "height", oe.request.Height, "hash", hex.EncodeToString(oe.request.Hash))
+		oe.finishTime = time.Now()
+		executionTime := oe.finishTime.Sub(oe.startTime)
+		oe.logger.Debug("OE finished", "duration", executionTime.String(), "height", request.Height, "hash", hex.EncodeToString(request.Hash))
+		telemetry.MeasureSince(oe.startTime, "oe", "execution_time")
 		oe.response, oe.err = resp, err
+		if oe.timer != nil {
+			oe.timer.Stop()
+		}
 
-		close(oe.stopCh)
+		close(stopCh)
 		oe.mtx.Unlock()
 	}()
 }
 
+// abort cancels the current OE and records it as aborted for the given
+// reason. It must be called with the lock held.
+func (oe *OptimisticExecution) abort(reason AbortReason) {
+	oe.cancelFunc()
+	if oe.done {
+		return
+	}
+	oe.done = true
+	oe.abortReason = reason
+
+	oe.stats.Aborts[reason]++
+	oe.stats.ConsecutiveAborts++
+	telemetry.IncrCounterWithLabels([]string{"oe", "aborts"}, 1, []metrics.Label{telemetry.NewLabel("reason", string(reason))})
+
+	if limit := oe.policy.MaxConsecutiveAborts; limit > 0 && oe.stats.ConsecutiveAborts >= limit && !oe.stats.Disabled {
+		oe.stats.Disabled = true
+		oe.logger.Error("OE disabled due to consecutive aborts", "aborts", oe.stats.ConsecutiveAborts)
+	}
+}
+
 // AbortIfNeeded aborts the OE if the request hash is not the same as the one in
 // the running OE. Returns true if the OE was aborted.
 func (oe *OptimisticExecution) AbortIfNeeded(reqHash []byte) bool {
@@ -128,15 +272,23 @@ func (oe *OptimisticExecution) AbortIfNeeded(reqHash []byte) bool {
 	oe.mtx.Lock()
 	defer oe.mtx.Unlock()
 
+	oe.finalizing = true
+	if oe.timer != nil {
+		oe.timer.Stop()
+	}
+
 	if !bytes.Equal(oe.request.Hash, reqHash) {
 		oe.logger.Error("OE aborted due to hash mismatch", "oe_hash", hex.EncodeToString(oe.request.Hash), "req_hash", hex.EncodeToString(reqHash), "oe_height", oe.request.Height, "req_height", oe.request.Height)
-		oe.cancelFunc()
+		oe.abort(AbortReasonHashMismatch)
+		return true
+	} else if oe.abortReason != "" {
+		// the OE was aborted before FinalizeBlock was called, i.e. by its timeout
 		return true
 	} else if oe.abortRate > 0 && rand.Intn(100) < oe.abortRate {
 		// this is for test purposes only, we can emulate a certain percentage of
 		// OE needed to be aborted.
-		oe.cancelFunc()
 		oe.logger.Error("OE aborted due to test abort rate")
+		oe.abort(AbortReasonAbortRate)
 		return true
 	}
 
@@ -149,12 +301,40 @@ func (oe *OptimisticExecution) Abort() {
 		return
 	}
 
-	oe.cancelFunc()
-	<-oe.stopCh
+	oe.mtx.Lock()
+	if oe.initialized && !oe.done {
+		oe.abort(AbortReasonNewProposal)
+	} else {
+		oe.cancelFunc()
+	}
+	stopCh := oe.stopCh
+	oe.mtx.Unlock()
+
+	<-stopCh
 }
 
 // WaitResult waits for the OE to finish and returns the result.
 func (oe *OptimisticExecution) WaitResult() (*abci.ResponseFinalizeBlock, error) {
+	waitStart := time.Now()
 	<-oe.stopCh
+
+	oe.mtx.Lock()
+	defer oe.mtx.Unlock()
+
+	if !oe.done {
+		oe.done = true
+
+		// the time saved is the execution time which FinalizeBlock didn't wait for
+		saved := oe.finishTime.Sub(oe.startTime)
+		if waited := oe.finishTime.Sub(waitStart); waited > 0 {
+			saved -= waited
+		}
+		oe.stats.Hits++
+		oe.stats.ConsecutiveAborts = 0
+		oe.stats.TimeSaved += saved
+		telemetry.IncrCounter(1, "oe", "hits")
+		telemetry.AddSample(float32(saved.Milliseconds()), "oe", "time_saved")
+	}
+
 	return oe.response, oe.err
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/stretchr/testify/assert"
@@ -32,3 +33,45 @@ func TestOptimisticExecution(t *testing.T) {
 
 	oe.Reset()
 }
+
+func TestOptimisticExecutionStats(t *testing.T) {
+	oe := NewOptimisticExecution(log.NewNopLogger(), testFinalizeBlock, WithAbortPolicy(AbortPolicy{MaxConsecutiveAborts: 2}))
+
+	oe.Execute(&abci.RequestProcessProposal{Hash: []byte("test")})
+	assert.False(t, oe.AbortIfNeeded([]byte("test")))
+	_, _ = oe.WaitResult()
+
+	oe.Execute(&abci.RequestProcessProposal{Hash: []byte("test")})
+	assert.True(t, oe.AbortIfNeeded([]byte("wrong_hash")))
+	_, _ = oe.WaitResult()
+	assert.True(t, oe.Enabled())
+
+	oe.Execute(&abci.RequestProcessProposal{Hash: []byte("test")})
+	oe.Abort()
+
+	stats := oe.Stats()
+	assert.Equal(t, uint64(3), stats.Executions)
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, map[AbortReason]uint64{AbortReasonHashMismatch: 1, AbortReasonNewProposal: 1}, stats.Aborts)
+	assert.Equal(t, uint64(2), stats.ConsecutiveAborts)
+	assert.True(t, stats.Disabled)
+	assert.False(t, oe.Enabled())
+}
+
+func TestOptimisticExecutionTimeout(t *testing.T) {
+	blockingFinalizeBlock := func(ctx context.Context, _ *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	oe := NewOptimisticExecution(log.NewNopLogger(), blockingFinalizeBlock, WithAbortPolicy(AbortPolicy{Timeout: time.Millisecond}))
+
+	oe.Execute(&abci.RequestProcessProposal{Hash: []byte("test")})
+	assert.Eventually(t, func() bool {
+		return oe.Stats().Aborts[AbortReasonTimeout] == 1
+	}, time.Second, time.Millisecond)
+
+	assert.True(t, oe.AbortIfNeeded([]byte("test")))
+	_, err := oe.WaitResult()
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, uint64(0), oe.Stats().Hits)
+}
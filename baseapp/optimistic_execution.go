@@ -0,0 +1,42 @@
+package baseapp
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/baseapp/oe"
+)
+
+// OptimisticExecutionStats returns the statistics of the optimistic execution
+// and true, or false if the optimistic execution is disabled.
+func (app *BaseApp) OptimisticExecutionStats() (oe.Stats, bool) {
+	if app.optimisticExec == nil {
+		return oe.Stats{}, false
+	}
+
+	return app.optimisticExec.Stats(), true
+}
+
+var _ oe.OptimisticExecutionServiceServer = oeService{}
+
+// oeService is the server of the OptimisticExecutionService.
+type oeService struct {
+	oe *oe.OptimisticExecution
+}
+
+// Stats implements oe.OptimisticExecutionServiceServer.
+func (s oeService) Stats(context.Context, *oe.StatsRequest) (*oe.StatsResponse, error) {
+	stats := s.oe.Stats()
+	aborts := make(map[string]uint64, len(stats.Aborts))
+	for reason, count := range stats.Aborts {
+		aborts[string(reason)] = count
+	}
+
+	return &oe.StatsResponse{
+		Executions:        stats.Executions,
+		Hits:              stats.Hits,
+		Aborts:            aborts,
+		ConsecutiveAborts: stats.ConsecutiveAborts,
+		TimeSaved:         stats.TimeSaved,
+		Disabled:          stats.Disabled,
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"time"
 
 	dbm "github.com/cosmos/cosmos-db"
 
@@ -64,6 +65,31 @@ func SetMinRetainBlocks(minRetainBlocks uint64) func(*BaseApp) {
 	return func(bapp *BaseApp) { bapp.setMinRetainBlocks(minRetainBlocks) }
 }
 
+// SetAppHashDump returns a BaseApp option function that writes the store
+// hashes and the change set of every committed block to the given directory,
+// keeping the dumps of the last keepRecent heights (all if zero). An empty
+// directory disables the dumps.
+func SetAppHashDump(dir string, keepRecent uint64) func(*BaseApp) {
+	return func(bapp *BaseApp) { bapp.setAppHashDump(dir, keepRecent) }
+}
+
+// SetMempoolJournal returns a BaseApp option function that records the txs
+// inserted into and removed from the mempool in a journal at the given path,
+// so that ReplayMempoolJournal inserts them back on startup if they are not
+// older than maxAge (no limit if zero). The mempool must be set with the
+// SetMempool option. An empty path disables the journal.
+func SetMempoolJournal(path string, maxAge time.Duration) func(*BaseApp) {
+	return func(bapp *BaseApp) { bapp.setMempoolJournal(path, maxAge) }
+}
+
+// SetProfiler sets the profiler recording the gas and time spent by the ante
+// decorators, the Msg handlers and the modules' block hooks in FinalizeBlock.
+// Its entries are served by the ProfilerService gRPC service. A nil profiler
+// disables profiling.
+func SetProfiler(p *Profiler) func(*BaseApp) {
+	return func(bapp *BaseApp) { bapp.setProfiler(p) }
+}
+
 // SetTrace will turn on or off trace flag
 func SetTrace(trace bool) func(*BaseApp) {
 	return func(app *BaseApp) { app.setTrace(trace) }
@@ -84,6 +110,15 @@ func SetIAVLDisableFastNode(disable bool) func(*BaseApp) {
 	return func(bapp *BaseApp) { bapp.cms.SetIAVLDisableFastNode(disable) }
 }
 
+// SetCacheKVSpill provides a BaseApp option function that makes the block
+// state spill its dirty writes to a temporary sorted run in dir once they
+// exceed threshold bytes per store. The check, proposal and query states never
+// spill. A zero threshold disables spilling. dir must be dedicated to the runs,
+// its content is removed on startup.
+func SetCacheKVSpill(threshold uint64, dir string) func(*BaseApp) {
+	return func(bapp *BaseApp) { bapp.setCacheKVSpill(threshold, dir) }
+}
+
 // SetInterBlockCache provides a BaseApp option function that sets the
 // inter-block cache.
 func SetInterBlockCache(cache storetypes.MultiStorePersistentCache) func(*BaseApp) {
@@ -113,10 +148,29 @@ func SetStoreLoader(loader StoreLoader) func(*BaseApp) {
 // SetOptimisticExecution enables optimistic execution.
 func SetOptimisticExecution(opts ...func(*oe.OptimisticExecution)) func(*BaseApp) {
 	return func(app *BaseApp) {
+		opts = append([]func(*oe.OptimisticExecution){oe.WithAbortPolicy(app.oeAbortPolicy)}, opts...)
 		app.optimisticExec = oe.NewOptimisticExecution(app.logger, app.internalFinalizeBlock, opts...)
 	}
 }
 
+// SetOptimisticExecutionAbortPolicy sets the abort policy of the optimistic
+// execution. It can be set before or after SetOptimisticExecution and has no
+// effect if the optimistic execution is disabled.
+func SetOptimisticExecutionAbortPolicy(policy oe.AbortPolicy) func(*BaseApp) {
+	return func(app *BaseApp) {
+		app.oeAbortPolicy = policy
+		if app.optimisticExec != nil {
+			app.optimisticExec.SetAbortPolicy(policy)
+		}
+	}
+}
+
+// SetTxExecutor sets the executor running the txs of a block in FinalizeBlock,
+// e.g. a ParallelTxExecutor. Txs are executed sequentially by default.
+func SetTxExecutor(executor TxExecutor) func(*BaseApp) {
+	return func(app *BaseApp) { app.SetTxExecutor(executor) }
+}
+
 // DisableBlockGasMeter disables the block gas meter.
 func DisableBlockGasMeter() func(*BaseApp) {
 	return func(app *BaseApp) { app.SetDisableBlockGasMeter(true) }
@@ -373,6 +427,15 @@ func (app *BaseApp) SetStreamingManager(manager storetypes.StreamingManager) {
 	app.streamingManager = manager
 }
 
+// SetTxExecutor sets the executor running the txs of a block in FinalizeBlock.
+func (app *BaseApp) SetTxExecutor(executor TxExecutor) {
+	if app.sealed {
+		panic("SetTxExecutor() on sealed BaseApp")
+	}
+
+	app.txExecutor = executor
+}
+
 // SetDisableBlockGasMeter sets the disableBlockGasMeter flag for the BaseApp.
 func (app *BaseApp) SetDisableBlockGasMeter(disableBlockGasMeter bool) {
 	app.disableBlockGasMeter = disableBlockGasMeter
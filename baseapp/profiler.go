@@ -0,0 +1,106 @@
+package baseapp
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/baseapp/profiler"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ sdk.Profiler = (*Profiler)(nil)
+
+type profileKey struct {
+	category string
+	name     string
+}
+
+// Profiler is a sdk.Profiler aggregating the records of FinalizeBlock by
+// category and name. It is enabled with the SetProfiler option, and its
+// entries are served by the cosmos.base.profiler.v1alpha1.ProfilerService of
+// the gRPC server.
+type Profiler struct {
+	mtx     sync.Mutex
+	entries map[profileKey]*profiler.ProfileEntry
+}
+
+// NewProfiler returns an empty Profiler.
+func NewProfiler() *Profiler {
+	return &Profiler{entries: make(map[profileKey]*profiler.ProfileEntry)}
+}
+
+// Record implements sdk.Profiler.
+func (p *Profiler) Record(category, name string, gas uint64, elapsed time.Duration) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	key := profileKey{category: category, name: name}
+	entry, ok := p.entries[key]
+	if !ok {
+		entry = &profiler.ProfileEntry{Category: category, Name: name}
+		p.entries[key] = entry
+	}
+	entry.Count++
+	entry.GasUsed += gas
+	entry.Duration += elapsed
+}
+
+// Entries returns the recorded entries, the slowest first.
+func (p *Profiler) Entries() []profiler.ProfileEntry {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	entries := make([]profiler.ProfileEntry, 0, len(p.entries))
+	for _, entry := range p.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Duration != entries[j].Duration {
+			return entries[i].Duration > entries[j].Duration
+		}
+		if entries[i].Category != entries[j].Category {
+			return entries[i].Category < entries[j].Category
+		}
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries
+}
+
+// Reset drops all the recorded entries.
+func (p *Profiler) Reset() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.entries = make(map[profileKey]*profiler.ProfileEntry)
+}
+
+func (app *BaseApp) setProfiler(p *Profiler) {
+	app.profiler = p
+}
+
+// Profiler returns the profiler of the application, nil if profiling is
+// disabled.
+func (app *BaseApp) Profiler() *Profiler {
+	return app.profiler
+}
+
+var _ profiler.ProfilerServiceServer = profilerService{}
+
+// profilerService is the server of the ProfilerService.
+type profilerService struct {
+	profiler *Profiler
+}
+
+// Profile implements profiler.ProfilerServiceServer.
+func (s profilerService) Profile(context.Context, *profiler.ProfileRequest) (*profiler.ProfileResponse, error) {
+	return &profiler.ProfileResponse{Entries: s.profiler.Entries()}, nil
+}
+
+// Reset implements profiler.ProfilerServiceServer.
+func (s profilerService) Reset(context.Context, *profiler.ResetRequest) (*profiler.ResetResponse, error) {
+	s.profiler.Reset()
+	return &profiler.ResetResponse{}, nil
+}
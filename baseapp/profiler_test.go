@@ -0,0 +1,66 @@
+package baseapp_test
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/baseapp/profiler"
+	baseapptestutil "github.com/cosmos/cosmos-sdk/baseapp/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestProfiler(t *testing.T) {
+	profiler := baseapp.NewProfiler()
+	anteKey := []byte("ante-key")
+	anteOpt := func(bapp *baseapp.BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) }
+	suite := NewBaseAppSuite(t, anteOpt, baseapp.SetProfiler(profiler))
+	baseapptestutil.RegisterCounterServer(suite.baseApp.MsgServiceRouter(), CounterServerImpl{t, capKey1, []byte("deliver-key")})
+
+	_, err := suite.baseApp.InitChain(&abci.RequestInitChain{
+		ConsensusParams: &cmtproto.ConsensusParams{},
+	})
+	require.NoError(t, err)
+
+	var txs [][]byte
+	for i := int64(0); i < 2; i++ {
+		txBytes, err := suite.txConfig.TxEncoder()(newTxCounter(t, suite.txConfig, i, i))
+		require.NoError(t, err)
+		txs = append(txs, txBytes)
+	}
+
+	// txs are only profiled in FinalizeBlock
+	res, err := suite.baseApp.CheckTx(&abci.RequestCheckTx{Tx: txs[0], Type: abci.CheckTxType_New})
+	require.NoError(t, err)
+	require.True(t, res.IsOK(), res.Log)
+	require.Empty(t, profiler.Entries())
+
+	_, err = suite.baseApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: 1, Txs: txs})
+	require.NoError(t, err)
+
+	entries := profiler.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, sdk.ProfileCategoryMsg, entries[0].Category)
+	require.Equal(t, sdk.MsgTypeURL(&baseapptestutil.MsgCounter{}), entries[0].Name)
+	require.Equal(t, uint64(2), entries[0].Count)
+	require.NotZero(t, entries[0].GasUsed)
+	require.NotZero(t, entries[0].Duration)
+
+	profiler.Reset()
+	require.Empty(t, profiler.Entries())
+}
+
+func TestProfilerEntries(t *testing.T) {
+	p := baseapp.NewProfiler()
+	p.Record(sdk.ProfileCategoryBeginBlock, "bank", 10, 5)
+	p.Record(sdk.ProfileCategoryEndBlock, "staking", 20, 30)
+	p.Record(sdk.ProfileCategoryBeginBlock, "bank", 15, 10)
+
+	require.Equal(t, []profiler.ProfileEntry{
+		{Category: sdk.ProfileCategoryEndBlock, Name: "staking", Count: 1, GasUsed: 20, Duration: 30},
+		{Category: sdk.ProfileCategoryBeginBlock, Name: "bank", Count: 2, GasUsed: 25, Duration: 15},
+	}, p.Entries())
+}
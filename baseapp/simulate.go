@@ -0,0 +1,204 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// BalanceOverrider replaces the balances of an account, e.g. the x/bank
+// keeper.BalanceOverrider. It is called with a simulation context only.
+type BalanceOverrider interface {
+	OverrideBalance(ctx context.Context, addr sdk.AccAddress, coins sdk.Coins) error
+}
+
+// SequenceOverrider sets the sequence of an account, e.g. the x/auth
+// keeper.SequenceOverrider. It is called with a simulation context only.
+type SequenceOverrider interface {
+	OverrideSequence(ctx context.Context, addr sdk.AccAddress, sequence uint64) error
+}
+
+// SetStateOverriders sets the keepers applying the balance and sequence
+// overrides of SimulateWithOverrides. Either may be nil, in which case the
+// corresponding overrides are rejected.
+func (app *BaseApp) SetStateOverriders(balances BalanceOverrider, sequences SequenceOverrider) {
+	if app.sealed {
+		panic("SetStateOverriders() on sealed BaseApp")
+	}
+
+	app.balanceOverrider = balances
+	app.sequenceOverrider = sequences
+}
+
+// SimulateWithOverrides executes a tx in simulate mode against the state at
+// the given height, or the latest check state if height is zero, after
+// applying the overrides. It returns the gas info and result of the tx and the
+// store keys it changed, sorted by store name and key. The state is left
+// untouched.
+func (app *BaseApp) SimulateWithOverrides(
+	txBytes []byte,
+	height int64,
+	overrides txtypes.StateOverrides,
+) (sdk.GasInfo, *sdk.Result, []txtypes.StoreChange, error) {
+	ctx, err := app.simulationContext(height)
+	if err != nil {
+		return sdk.GasInfo{}, nil, nil, err
+	}
+
+	keysByName, err := app.storeKeysByName()
+	if err != nil {
+		return sdk.GasInfo{}, nil, nil, err
+	}
+
+	// the overriders only operate on simulation contexts
+	overridden := ctx.MultiStore().CacheMultiStore()
+	overrideCtx := ctx.WithMultiStore(overridden).WithExecMode(sdk.ExecModeSimulate)
+	if err := app.applyStateOverrides(overrideCtx, keysByName, overrides); err != nil {
+		return sdk.GasInfo{}, nil, nil, err
+	}
+
+	// the tx runs against a branch recording its writes, which runTx writes to
+	// in simulate mode
+	branch := newTxMultiStore(&blockState{ms: overridden})
+	txCtx := app.newContextForTx(ctx.WithMultiStore(branch), execModeCheck, txBytes).
+		WithExecMode(sdk.ExecMode(execModeSimulate))
+
+	gasInfo, result, _, err := app.runTxWithContext(execModeSimulate, txCtx, txBytes)
+	if err != nil {
+		return gasInfo, nil, nil, err
+	}
+	branch.Write()
+
+	return gasInfo, result, stateDiff(overridden, branch.trackers), nil
+}
+
+// simulationContext returns a context over a branch of the state at the given
+// height, or of the check state if height is zero.
+func (app *BaseApp) simulationContext(height int64) (sdk.Context, error) {
+	ctx := app.checkState.Context()
+	if height == 0 {
+		return ctx.WithMultiStore(ctx.MultiStore().CacheMultiStore()), nil
+	}
+
+	if err := checkNegativeHeight(height); err != nil {
+		return sdk.Context{}, err
+	}
+	if lastHeight := app.LastBlockHeight(); height > lastHeight {
+		return sdk.Context{}, errorsmod.Wrapf(sdkerrors.ErrInvalidHeight, "cannot simulate at height %d, latest height is %d", height, lastHeight)
+	}
+
+	ms, err := app.cms.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return sdk.Context{}, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "failed to load state at height %d: %s", height, err)
+	}
+	ctx = ctx.WithMultiStore(ms).WithBlockHeight(height + 1)
+
+	if rms, ok := app.cms.(*rootmulti.Store); ok {
+		if cInfo, err := rms.GetCommitInfo(height); err == nil && cInfo != nil {
+			ctx = ctx.WithBlockTime(cInfo.Timestamp)
+		}
+	}
+
+	return ctx, nil
+}
+
+func (app *BaseApp) storeKeysByName() (map[string]storetypes.StoreKey, error) {
+	rms, ok := app.cms.(interface {
+		StoreKeysByName() map[string]storetypes.StoreKey
+	})
+	if !ok {
+		return nil, fmt.Errorf("store overrides are not supported by %T", app.cms)
+	}
+
+	return rms.StoreKeysByName(), nil
+}
+
+func (app *BaseApp) applyStateOverrides(ctx sdk.Context, keysByName map[string]storetypes.StoreKey, overrides txtypes.StateOverrides) error {
+	addressCodec := app.interfaceRegistry.SigningContext().AddressCodec()
+
+	for _, o := range overrides.Balances {
+		if app.balanceOverrider == nil {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "balance overrides are not supported")
+		}
+		addr, err := addressCodec.StringToBytes(o.Address)
+		if err != nil {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidAddress, "invalid balance override address %s: %s", o.Address, err)
+		}
+		if err := app.balanceOverrider.OverrideBalance(ctx, addr, o.Coins); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range overrides.Sequences {
+		if app.sequenceOverrider == nil {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "sequence overrides are not supported")
+		}
+		addr, err := addressCodec.StringToBytes(o.Address)
+		if err != nil {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidAddress, "invalid sequence override address %s: %s", o.Address, err)
+		}
+		if err := app.sequenceOverrider.OverrideSequence(ctx, addr, o.Sequence); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range overrides.Stores {
+		key, ok := keysByName[o.Store]
+		if !ok {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "unknown store %s", o.Store)
+		}
+		if len(o.Key) == 0 {
+			return errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "empty key in override of store %s", o.Store)
+		}
+
+		store := ctx.MultiStore().GetKVStore(key)
+		if o.Delete {
+			store.Delete(o.Key)
+		} else {
+			store.Set(o.Key, o.Value)
+		}
+	}
+
+	return nil
+}
+
+// stateDiff returns the keys written by a tx whose value differs from their
+// value in ms.
+func stateDiff(ms storetypes.MultiStore, trackers map[storetypes.StoreKey]*txStore) []txtypes.StoreChange {
+	var changes []txtypes.StoreChange
+	for _, key := range sortedStoreKeys(trackers) {
+		writes := trackers[key].writes
+		keys := make([]string, 0, len(writes))
+		for k := range writes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		store := ms.GetKVStore(key)
+		for _, k := range keys {
+			oldValue, newValue := store.Get([]byte(k)), writes[k]
+			if bytes.Equal(oldValue, newValue) && (oldValue == nil) == (newValue == nil) {
+				continue
+			}
+
+			changes = append(changes, txtypes.StoreChange{
+				Store:    key.Name(),
+				Key:      []byte(k),
+				OldValue: oldValue,
+				NewValue: newValue,
+				Deleted:  newValue == nil,
+			})
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,112 @@
+package baseapp_test
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	baseapptestutil "github.com/cosmos/cosmos-sdk/baseapp/testutil"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+)
+
+func varintBytes(i int64) []byte {
+	bz := make([]byte, binary.MaxVarintLen64)
+	return bz[:binary.PutVarint(bz, i)]
+}
+
+func TestSimulateWithOverrides(t *testing.T) {
+	anteKey := []byte("ante-key")
+	deliverKey := []byte("deliver-key")
+	anteOpt := func(bapp *baseapp.BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, anteKey)) }
+	suite := NewBaseAppSuite(t, anteOpt)
+	baseapptestutil.RegisterCounterServer(suite.baseApp.MsgServiceRouter(), CounterServerImpl{t, capKey1, deliverKey})
+
+	_, err := suite.baseApp.InitChain(&abci.RequestInitChain{
+		ConsensusParams: &cmtproto.ConsensusParams{},
+	})
+	require.NoError(t, err)
+
+	encodeTx := func(counter int64) []byte {
+		txBytes, err := suite.txConfig.TxEncoder()(newTxCounter(t, suite.txConfig, counter, counter))
+		require.NoError(t, err)
+		return txBytes
+	}
+
+	for height := int64(1); height <= 2; height++ {
+		_, err = suite.baseApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: height, Txs: [][]byte{encodeTx(height - 1)}})
+		require.NoError(t, err)
+		_, err = suite.baseApp.Commit()
+		require.NoError(t, err)
+	}
+
+	// the counters are at 2, the overrides set them to 5
+	overrides := txtypes.StateOverrides{
+		Stores: []txtypes.StoreOverride{
+			{Store: capKey1.Name(), Key: anteKey, Value: varintBytes(5)},
+			{Store: capKey1.Name(), Key: deliverKey, Value: varintBytes(5)},
+		},
+	}
+	gasInfo, res, diff, err := suite.baseApp.SimulateWithOverrides(encodeTx(5), 0, overrides)
+	require.NoError(t, err)
+	require.NotZero(t, gasInfo.GasUsed)
+	require.NotEmpty(t, res.Events)
+	require.Len(t, res.MsgResponses, 1)
+	require.Equal(t, []txtypes.StoreChange{
+		{Store: capKey1.Name(), Key: anteKey, OldValue: varintBytes(5), NewValue: varintBytes(6)},
+		{Store: capKey1.Name(), Key: deliverKey, OldValue: varintBytes(5), NewValue: varintBytes(6)},
+	}, diff)
+
+	// the state is left untouched
+	_, _, diff, err = suite.baseApp.SimulateWithOverrides(encodeTx(2), 0, txtypes.StateOverrides{})
+	require.NoError(t, err)
+	require.Len(t, diff, 2)
+	require.Equal(t, varintBytes(2), diff[0].OldValue)
+
+	// the tx runs against the state of a past height
+	_, _, diff, err = suite.baseApp.SimulateWithOverrides(encodeTx(1), 1, txtypes.StateOverrides{})
+	require.NoError(t, err)
+	require.Len(t, diff, 2)
+	require.Equal(t, varintBytes(2), diff[0].NewValue)
+
+	_, _, _, err = suite.baseApp.SimulateWithOverrides(encodeTx(2), 3, txtypes.StateOverrides{})
+	require.ErrorContains(t, err, "cannot simulate at height 3")
+
+	_, _, _, err = suite.baseApp.SimulateWithOverrides(encodeTx(2), 0, txtypes.StateOverrides{
+		Stores: []txtypes.StoreOverride{{Store: "unknown", Key: anteKey}},
+	})
+	require.ErrorContains(t, err, "unknown store unknown")
+
+	_, _, _, err = suite.baseApp.SimulateWithOverrides(encodeTx(2), 0, txtypes.StateOverrides{
+		Sequences: []txtypes.SequenceOverride{{Address: "cosmos1", Sequence: 1}},
+	})
+	require.ErrorContains(t, err, "sequence overrides are not supported")
+
+	// the simulate service
+	simServer := authtx.NewSimulateServer(suite.baseApp.SimulateWithOverrides)
+	simRes, err := simServer.SimulateWithOverrides(context.Background(), &txtypes.SimulateWithOverridesRequest{TxBytes: encodeTx(5), Overrides: overrides})
+	require.NoError(t, err)
+	require.Equal(t, gasInfo, *simRes.GasInfo)
+	require.Len(t, simRes.StateDiff, 2)
+	require.Len(t, simRes.Result.MsgResponses, 1)
+
+	_, err = simServer.SimulateWithOverrides(context.Background(), &txtypes.SimulateWithOverridesRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the service isn't shadowed by the files of the api module in the merged
+	// registry
+	registry, err := gogoproto.MergedRegistry()
+	require.NoError(t, err)
+	_, err = registry.FindDescriptorByName("cosmos.tx.v1beta1.SimulateService.SimulateWithOverrides")
+	require.NoError(t, err)
+	_, err = registry.FindDescriptorByName("cosmos.tx.v1beta1.StateOverrides")
+	require.NoError(t, err)
+}
@@ -1,6 +1,7 @@
 package baseapp
 
 import (
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
@@ -20,6 +21,9 @@ const (
 	StreamingABCIPluginTomlKey        = "plugin"
 	StreamingABCIKeysTomlKey          = "keys"
 	StreamingABCIStopNodeOnErrTomlKey = "stop-node-on-err"
+	StreamingFiltersTomlKey           = "filters"
+	StreamingFilterAllowTomlKey       = "allow-prefixes"
+	StreamingFilterDenyTomlKey        = "deny-prefixes"
 )
 
 // RegisterStreamingServices registers streaming services with the BaseApp.
@@ -55,8 +59,7 @@ func (app *BaseApp) registerStreamingPlugin(
 		return fmt.Errorf("unexpected plugin type %T", v)
 	}
 
-	app.registerABCIListenerPlugin(appOpts, keys, v)
-	return nil
+	return app.registerABCIListenerPlugin(appOpts, keys, v)
 }
 
 // registerABCIListenerPlugin registers plugins that implement the ABCIListener interface.
@@ -64,19 +67,73 @@ func (app *BaseApp) registerABCIListenerPlugin(
 	appOpts servertypes.AppOptions,
 	keys map[string]*storetypes.KVStoreKey,
 	abciListener storetypes.ABCIListener,
-) {
+) error {
 	stopNodeOnErrKey := fmt.Sprintf("%s.%s.%s", StreamingTomlKey, StreamingABCITomlKey, StreamingABCIStopNodeOnErrTomlKey)
 	stopNodeOnErr := cast.ToBool(appOpts.Get(stopNodeOnErrKey))
 	keysKey := fmt.Sprintf("%s.%s.%s", StreamingTomlKey, StreamingABCITomlKey, StreamingABCIKeysTomlKey)
 	exposeKeysStr := cast.ToStringSlice(appOpts.Get(keysKey))
 	exposedKeys := exposeStoreKeysSorted(exposeKeysStr, keys)
 	app.cms.AddListeners(exposedKeys)
+	if err := app.setStreamingFilters(appOpts, exposedKeys); err != nil {
+		return err
+	}
 	app.SetStreamingManager(
 		storetypes.StreamingManager{
 			ABCIListeners: []storetypes.ABCIListener{abciListener},
 			StopNodeOnErr: stopNodeOnErr,
 		},
 	)
+	return nil
+}
+
+// setStreamingFilters applies the key prefix filters configured for the
+// exposed stores. Filters configured for stores that are not exposed are
+// ignored.
+func (app *BaseApp) setStreamingFilters(appOpts servertypes.AppOptions, exposedKeys []storetypes.StoreKey) error {
+	filtersKey := fmt.Sprintf("%s.%s", StreamingTomlKey, StreamingFiltersTomlKey)
+	filtersCfg := cast.ToStringMap(appOpts.Get(filtersKey))
+	if len(filtersCfg) == 0 {
+		return nil
+	}
+
+	for _, key := range exposedKeys {
+		if _, ok := filtersCfg[key.Name()]; !ok {
+			continue
+		}
+
+		allow, err := decodeKeyPrefixes(appOpts.Get(fmt.Sprintf("%s.%s.%s", filtersKey, key.Name(), StreamingFilterAllowTomlKey)))
+		if err != nil {
+			return fmt.Errorf("invalid streaming filter for store %s: %w", key.Name(), err)
+		}
+		deny, err := decodeKeyPrefixes(appOpts.Get(fmt.Sprintf("%s.%s.%s", filtersKey, key.Name(), StreamingFilterDenyTomlKey)))
+		if err != nil {
+			return fmt.Errorf("invalid streaming filter for store %s: %w", key.Name(), err)
+		}
+
+		if err := app.cms.SetListenerFilter(key, &storetypes.KeyPrefixFilter{Allow: allow, Deny: deny}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// decodeKeyPrefixes decodes a list of hex encoded key prefixes.
+func decodeKeyPrefixes(v interface{}) ([][]byte, error) {
+	strs := cast.ToStringSlice(v)
+	prefixes := make([][]byte, 0, len(strs))
+	for _, str := range strs {
+		prefix, err := hex.DecodeString(strings.TrimSpace(str))
+		if err != nil {
+			return nil, fmt.Errorf("invalid key prefix %q: %w", str, err)
+		}
+		if len(prefix) == 0 {
+			return nil, fmt.Errorf("empty key prefix")
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes, nil
 }
 
 func exposeAll(list []string) bool {
@@ -144,3 +144,42 @@ func Test_Ctx_with_StreamingManager(t *testing.T) {
 		suite.baseApp.Commit()
 	}
 }
+
+func TestABCI_Listener_FilteredStateChanges(t *testing.T) {
+	mockListener := NewMockABCIListener("lis_1")
+	streamingManager := storetypes.StreamingManager{ABCIListeners: []storetypes.ABCIListener{&mockListener}}
+	streamingManagerOpt := func(bapp *baseapp.BaseApp) { bapp.SetStreamingManager(streamingManager) }
+	distOpt := func(bapp *baseapp.BaseApp) { bapp.MountStores(distKey1) }
+	addListenerOpt := func(bapp *baseapp.BaseApp) {
+		bapp.CommitMultiStore().AddListeners([]storetypes.StoreKey{distKey1})
+		err := bapp.CommitMultiStore().SetListenerFilter(distKey1, &storetypes.KeyPrefixFilter{
+			Allow: [][]byte{[]byte("balances/")},
+			Deny:  [][]byte{[]byte("balances/skip")},
+		})
+		require.NoError(t, err)
+	}
+	suite := NewBaseAppSuite(t, distOpt, streamingManagerOpt, addListenerOpt)
+
+	suite.baseApp.InitChain(&abci.RequestInitChain{
+		ConsensusParams: &tmproto.ConsensusParams{},
+	})
+
+	_, err := suite.baseApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: 1})
+	require.NoError(t, err)
+
+	store := getFinalizeBlockStateCtx(suite.baseApp).KVStore(distKey1)
+	store.Set([]byte("balances/alice"), []byte("1"))
+	store.Set([]byte("balances/skip"), []byte("2"))
+	store.Set([]byte("supply/stake"), []byte("3"))
+
+	// writes are flushed to the listeners when the block is finalized
+	_, err = suite.baseApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: 1})
+	require.NoError(t, err)
+	_, err = suite.baseApp.Commit()
+	require.NoError(t, err)
+
+	expectedChangeSet := []*storetypes.StoreKVPair{
+		{StoreKey: distKey1.Name(), Key: []byte("balances/alice"), Value: []byte("1")},
+	}
+	require.Equal(t, expectedChangeSet, mockListener.ChangeSet)
+}
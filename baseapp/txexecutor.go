@@ -0,0 +1,743 @@
+package baseapp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	"cosmossdk.io/store/cachekv"
+	"cosmossdk.io/store/tracekv"
+	storetypes "cosmossdk.io/store/types"
+)
+
+// TxExecutor executes the txs of a block in FinalizeBlock. It must return the
+// results of the txs in block order and leave ms and blockGasMeter in the same
+// state as the sequential execution of the txs would. deliverTx executes a
+// single tx against a branch of ms and a block gas meter.
+type TxExecutor func(
+	ctx context.Context,
+	txs [][]byte,
+	ms storetypes.MultiStore,
+	blockGasMeter storetypes.GasMeter,
+	deliverTx DeliverTxFunc,
+) ([]*abci.ExecTxResult, error)
+
+// DeliverTxFunc executes a tx against the given multistore and block gas meter.
+type DeliverTxFunc func(tx []byte, ms storetypes.MultiStore, blockGasMeter storetypes.GasMeter) *abci.ExecTxResult
+
+// TxAccumulator designates keys of a store which the txs of a block only
+// update by adding to their value, e.g. the balances of the fee collector,
+// credited with the fee of every tx. A ParallelTxExecutor doesn't execute
+// again a tx whose only conflicts with the previous txs are on accumulated
+// keys: it adds to their value in the block state the difference between the
+// value written by the tx and the one it read, as long as the gas of the tx
+// doesn't depend on the difference.
+//
+// The txs must not depend on the accumulated values other than through the
+// gas of their accesses, e.g. no tx must fail on a low fee collector balance.
+type TxAccumulator struct {
+	// StoreName is the name of the key of the store holding the accumulated
+	// keys.
+	StoreName string
+	// Prefix is the prefix of the accumulated keys.
+	Prefix []byte
+	// Add returns value plus the difference between to and from. A nil value
+	// is a zero value, and a zero result must be returned as nil.
+	Add func(value, from, to []byte) ([]byte, error)
+}
+
+// ParallelTxExecutor returns a TxExecutor running the txs of a block
+// optimistically in parallel on the given number of workers, or
+// runtime.NumCPU() if not positive.
+//
+// Each tx is first executed against its own branch of the block state, which
+// records the keys and ranges the tx reads and buffers the keys it writes.
+// The executions are then validated in block order: a tx which read a key
+// written by a previous tx of the block, or whose gas no longer fits in the
+// block gas meter, is executed again against the current block state. The
+// writes of all other txs are applied as is, but for the accumulated keys of
+// the given accumulators. When the gas of a tx depends on the value of an
+// accumulated key written by the previous txs, e.g. when its length grows, the
+// next txs are all executed again in parallel against the block state once
+// the tx is applied. The results and app hashes are identical to the
+// sequential execution of the block.
+//
+// Txs must only share state through the stores: modules keeping state in
+// memory across the txs of a block, or reading the block gas meter in txs,
+// must not be used with this executor.
+func ParallelTxExecutor(workers int, accumulators ...TxAccumulator) TxExecutor {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	return func(
+		ctx context.Context,
+		txs [][]byte,
+		ms storetypes.MultiStore,
+		blockGasMeter storetypes.GasMeter,
+		deliverTx DeliverTxFunc,
+	) ([]*abci.ExecTxResult, error) {
+		state := &blockState{ms: ms, accumulators: accumulators}
+		executions := make([]*txExecution, len(txs))
+
+		// execute runs the txs from the given index against the current
+		// block state.
+		execute := func(from int) error {
+			jobs := make(chan int)
+			var wg sync.WaitGroup
+			for w := 0; w < workers && w < len(txs)-from; w++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := range jobs {
+						executions[i] = executeTx(txs[i], from, state, storetypes.NewInfiniteGasMeter(), deliverTx)
+					}
+				}()
+			}
+
+		schedule:
+			for i := from; i < len(txs); i++ {
+				select {
+				case <-ctx.Done():
+					break schedule
+				case jobs <- i:
+				}
+			}
+			close(jobs)
+			wg.Wait()
+
+			return ctx.Err()
+		}
+
+		// with accumulators, the first tx likely credits empty accumulated
+		// keys, e.g. the fee collector emptied in BeginBlock, which changes the
+		// gas of the next txs: it is executed alone, as a stale execution
+		if len(accumulators) == 0 {
+			if err := execute(0); err != nil {
+				return nil, err
+			}
+		}
+
+		results := make([]*abci.ExecTxResult, len(txs))
+		written := make(blockWrites)
+		for i := range txs {
+			execution := executions[i]
+			merge := mergeStale
+			if execution != nil {
+				merge = execution.merge(state, written)
+			}
+
+			if merge == mergeOK && fitsBlockGas(blockGasMeter, execution.blockGas) {
+				blockGasMeter.ConsumeGas(execution.blockGas, "block gas meter")
+			} else {
+				execution = executeTx(txs[i], i, state, blockGasMeter, deliverTx)
+			}
+
+			execution.apply(ms)
+			written.add(i, execution)
+			results[i] = execution.result
+
+			// the next txs likely read the same accumulated keys
+			if merge == mergeStale && i+1 < len(txs) {
+				if err := execute(i + 1); err != nil {
+					return nil, err
+				}
+			}
+
+			// check after every tx if we should abort
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+				// continue
+			}
+		}
+
+		return results, nil
+	}
+}
+
+// blockState is the state of the block shared by the executions of its txs.
+type blockState struct {
+	ms           storetypes.MultiStore
+	accumulators []TxAccumulator
+
+	// locks holds the locks serializing the reads of the stores which are not
+	// safe for concurrent use.
+	locks sync.Map
+}
+
+// locker returns the lock serializing the reads of a store of the block
+// state. The cachekv stores of the block state are safe for concurrent use
+// and read without lock, the other stores, e.g. wrapped for tracing, are read
+// by one tx at a time.
+func (s *blockState) locker(key storetypes.StoreKey, store storetypes.KVStore) sync.Locker {
+	if _, ok := store.(*cachekv.Store); ok {
+		return noopLocker{}
+	}
+
+	mtx, _ := s.locks.LoadOrStore(key, &sync.Mutex{})
+	return mtx.(sync.Locker)
+}
+
+// accumulated returns true if some keys of the store are accumulated.
+func (s *blockState) accumulated(key storetypes.StoreKey) bool {
+	for _, acc := range s.accumulators {
+		if acc.StoreName == key.Name() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// accumulator returns the accumulator of a key, or nil if the key is not
+// accumulated.
+func (s *blockState) accumulator(key storetypes.StoreKey, k []byte) *TxAccumulator {
+	for i, acc := range s.accumulators {
+		if acc.StoreName == key.Name() && bytes.HasPrefix(k, acc.Prefix) {
+			return &s.accumulators[i]
+		}
+	}
+
+	return nil
+}
+
+type noopLocker struct{}
+
+func (noopLocker) Lock()   {}
+func (noopLocker) Unlock() {}
+
+// fitsBlockGas returns true if consuming gas on the block gas meter doesn't
+// change the outcome of a tx, i.e. the meter is neither out of gas before the
+// tx nor exceeded by it.
+func fitsBlockGas(blockGasMeter storetypes.GasMeter, gas storetypes.Gas) bool {
+	return !blockGasMeter.IsOutOfGas() && gas <= blockGasMeter.Limit()-blockGasMeter.GasConsumed()
+}
+
+// txExecution is the result of the execution of a tx against a branch of the
+// block state, together with the accesses of the tx to the block state.
+type txExecution struct {
+	// base is the number of txs of the block applied to the block state the
+	// tx was executed against.
+	base     int
+	result   *abci.ExecTxResult
+	blockGas storetypes.Gas
+	stores   map[storetypes.StoreKey]*txStore
+	// accumulated holds the values of the accumulated keys read and written
+	// by the tx, per store.
+	accumulated map[storetypes.StoreKey]map[string][][]byte
+}
+
+func executeTx(
+	tx []byte,
+	base int,
+	state *blockState,
+	blockGasMeter storetypes.GasMeter,
+	deliverTx DeliverTxFunc,
+) *txExecution {
+	branch := newTxMultiStore(state)
+	gasBefore := blockGasMeter.GasConsumed()
+	result := deliverTx(tx, branch, blockGasMeter)
+	branch.Write()
+
+	return &txExecution{
+		base:        base,
+		result:      result,
+		blockGas:    blockGasMeter.GasConsumed() - gasBefore,
+		stores:      branch.trackers,
+		accumulated: branch.accumulated,
+	}
+}
+
+// mergeResult is the outcome of the validation of an optimistic execution.
+type mergeResult int
+
+const (
+	// mergeOK means the writes of the execution can be applied.
+	mergeOK mergeResult = iota
+	// mergeConflict means the tx read keys written by the previous txs and
+	// must be executed again.
+	mergeConflict
+	// mergeStale means the gas of the tx depends on the values of accumulated
+	// keys written by the previous txs, which the next txs likely read too:
+	// the tx must be executed again, and then the next ones.
+	mergeStale
+)
+
+// merge prepares the writes of the optimistic execution of a tx to be applied
+// to the block state, adding the difference between the values written and
+// read by the tx to the accumulated keys written by the txs applied since its
+// execution.
+func (e *txExecution) merge(state *blockState, written blockWrites) mergeResult {
+	for key, tracker := range e.stores {
+		writes := written[key]
+		if writes == nil {
+			continue
+		}
+
+		for k := range tracker.reads {
+			if !writes.writtenSince(k, e.base) {
+				continue
+			}
+
+			acc := state.accumulator(key, []byte(k))
+			if acc == nil {
+				return mergeConflict
+			}
+
+			to := state.ms.GetKVStore(key).Get([]byte(k))
+			if result := tracker.accumulate(acc, k, to, e.accumulated[key][k]); result != mergeOK {
+				return result
+			}
+		}
+
+		for _, r := range tracker.ranges {
+			if writes.rangeWrittenSince(r, e.base) {
+				return mergeConflict
+			}
+		}
+	}
+
+	return mergeOK
+}
+
+// apply writes the writes of the tx to the block state.
+func (e *txExecution) apply(ms storetypes.MultiStore) {
+	for _, key := range sortedStoreKeys(e.stores) {
+		store := ms.GetKVStore(key)
+		tracker := e.stores[key]
+
+		keys := make([]string, 0, len(tracker.writes))
+		for k := range tracker.writes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if value := tracker.writes[k]; value != nil {
+				store.Set([]byte(k), value)
+			} else {
+				store.Delete([]byte(k))
+			}
+		}
+	}
+}
+
+func sortedStoreKeys(stores map[storetypes.StoreKey]*txStore) []storetypes.StoreKey {
+	keys := make([]storetypes.StoreKey, 0, len(stores))
+	for key := range stores {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name() < keys[j].Name()
+	})
+
+	return keys
+}
+
+// blockWrites holds the keys written by the txs already applied to the block
+// state, per store.
+type blockWrites map[storetypes.StoreKey]*storeWrites
+
+// storeWrites holds the sorted keys written to a store, and the index of the
+// last tx which wrote each of them.
+type storeWrites struct {
+	keys    []string
+	writers map[string]int
+}
+
+func (w blockWrites) add(index int, e *txExecution) {
+	for key, tracker := range e.stores {
+		writes := w[key]
+		if writes == nil {
+			writes = &storeWrites{writers: make(map[string]int)}
+			w[key] = writes
+		}
+
+		for k := range tracker.writes {
+			if _, ok := writes.writers[k]; !ok {
+				i := sort.SearchStrings(writes.keys, k)
+				writes.keys = append(writes.keys, "")
+				copy(writes.keys[i+1:], writes.keys[i:])
+				writes.keys[i] = k
+			}
+			writes.writers[k] = index
+		}
+	}
+}
+
+// writtenSince returns true if the key was written by the txs from the given
+// index.
+func (w *storeWrites) writtenSince(k string, index int) bool {
+	writer, ok := w.writers[k]
+	return ok && writer >= index
+}
+
+// rangeWrittenSince returns true if a key of the range was written by the txs
+// from the given index.
+func (w *storeWrites) rangeWrittenSince(r keyRange, index int) bool {
+	for i := sort.SearchStrings(w.keys, string(r.start)); i < len(w.keys); i++ {
+		if r.end != nil && w.keys[i] >= string(r.end) {
+			break
+		}
+		if w.writers[w.keys[i]] >= index {
+			return true
+		}
+	}
+
+	return false
+}
+
+// txMultiStore is a branch of a multistore whose stores are branched on first
+// use. The branch given to a tx also tracks the accesses of the tx to the
+// underlying block state, its nested branches don't. All of them record the
+// values of the accumulated keys read and written by the tx.
+type txMultiStore struct {
+	parent storetypes.MultiStore
+	state  *blockState
+	stores map[storetypes.StoreKey]storetypes.CacheKVStore
+
+	trackers    map[storetypes.StoreKey]*txStore
+	accumulated map[storetypes.StoreKey]map[string][][]byte
+}
+
+var _ storetypes.CacheMultiStore = (*txMultiStore)(nil)
+
+// newTxMultiStore returns the branch of the block state given to a tx.
+func newTxMultiStore(state *blockState) *txMultiStore {
+	return &txMultiStore{
+		parent:      state.ms,
+		state:       state,
+		stores:      make(map[storetypes.StoreKey]storetypes.CacheKVStore),
+		trackers:    make(map[storetypes.StoreKey]*txStore),
+		accumulated: make(map[storetypes.StoreKey]map[string][][]byte),
+	}
+}
+
+// GetStoreType implements Store.
+func (b *txMultiStore) GetStoreType() storetypes.StoreType {
+	return storetypes.StoreTypeMulti
+}
+
+// CacheWrap implements CacheWrapper.
+func (b *txMultiStore) CacheWrap() storetypes.CacheWrap {
+	return b.CacheMultiStore().(storetypes.CacheWrap)
+}
+
+// CacheWrapWithTrace implements CacheWrapper.
+func (b *txMultiStore) CacheWrapWithTrace(_ io.Writer, _ storetypes.TraceContext) storetypes.CacheWrap {
+	return b.CacheWrap()
+}
+
+// CacheMultiStore implements MultiStore.
+func (b *txMultiStore) CacheMultiStore() storetypes.CacheMultiStore {
+	return &txMultiStore{
+		parent:      b,
+		state:       b.state,
+		stores:      make(map[storetypes.StoreKey]storetypes.CacheKVStore),
+		accumulated: b.accumulated,
+	}
+}
+
+// CacheMultiStoreWithVersion implements MultiStore.
+func (b *txMultiStore) CacheMultiStoreWithVersion(_ int64) (storetypes.CacheMultiStore, error) {
+	return nil, errors.New("cannot branch cached multi-store with a version")
+}
+
+// GetStore implements MultiStore.
+func (b *txMultiStore) GetStore(key storetypes.StoreKey) storetypes.Store {
+	return b.GetKVStore(key)
+}
+
+// GetKVStore implements MultiStore.
+func (b *txMultiStore) GetKVStore(key storetypes.StoreKey) storetypes.KVStore {
+	if store, ok := b.stores[key]; ok {
+		return store
+	}
+
+	parent := b.parent.GetKVStore(key)
+	if b.trackers != nil {
+		tracker := &txStore{
+			parent:      parent,
+			key:         key,
+			state:       b.state,
+			mtx:         b.state.locker(key, parent),
+			reads:       make(map[string]struct{}),
+			writes:      make(map[string][]byte),
+			accumulated: make(map[string][]byte),
+		}
+		b.trackers[key] = tracker
+		parent = tracker
+	}
+
+	var store storetypes.CacheKVStore = cachekv.NewStore(parent)
+	if b.state.accumulated(key) {
+		if b.accumulated[key] == nil {
+			b.accumulated[key] = make(map[string][][]byte)
+		}
+		store = &accumulatedStore{CacheKVStore: store, key: key, state: b.state, values: b.accumulated[key]}
+	}
+	b.stores[key] = store
+	return store
+}
+
+// TracingEnabled implements MultiStore.
+func (b *txMultiStore) TracingEnabled() bool {
+	return false
+}
+
+// SetTracer implements MultiStore.
+func (b *txMultiStore) SetTracer(_ io.Writer) storetypes.MultiStore {
+	return b
+}
+
+// SetTracingContext implements MultiStore.
+func (b *txMultiStore) SetTracingContext(_ storetypes.TraceContext) storetypes.MultiStore {
+	return b
+}
+
+// LatestVersion implements MultiStore.
+func (b *txMultiStore) LatestVersion() int64 {
+	return b.parent.LatestVersion()
+}
+
+// Write implements CacheMultiStore.
+func (b *txMultiStore) Write() {
+	for _, store := range b.stores {
+		store.Write()
+	}
+}
+
+// txStore records the keys and ranges a tx reads from a store of the block
+// state, and buffers the writes of the tx instead of applying them.
+type txStore struct {
+	parent storetypes.KVStore
+	key    storetypes.StoreKey
+	state  *blockState
+	mtx    sync.Locker
+
+	reads  map[string]struct{}
+	ranges []keyRange
+	// writes holds the written values, nil for deleted keys.
+	writes map[string][]byte
+	// accumulated holds the values read from the accumulated keys.
+	accumulated map[string][]byte
+}
+
+type keyRange struct {
+	start, end []byte
+}
+
+var _ storetypes.KVStore = (*txStore)(nil)
+
+// GetStoreType implements Store.
+func (s *txStore) GetStoreType() storetypes.StoreType {
+	return s.parent.GetStoreType()
+}
+
+// CacheWrap implements CacheWrapper.
+func (s *txStore) CacheWrap() storetypes.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+// CacheWrapWithTrace implements CacheWrapper.
+func (s *txStore) CacheWrapWithTrace(w io.Writer, tc storetypes.TraceContext) storetypes.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
+
+// Get implements KVStore.
+func (s *txStore) Get(key []byte) []byte {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.reads[string(key)] = struct{}{}
+	value := s.parent.Get(key)
+	if s.state.accumulator(s.key, key) != nil {
+		s.accumulated[string(key)] = value
+	}
+
+	return value
+}
+
+// Has implements KVStore.
+func (s *txStore) Has(key []byte) bool {
+	if s.state.accumulator(s.key, key) != nil {
+		return s.Get(key) != nil
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.reads[string(key)] = struct{}{}
+	return s.parent.Has(key)
+}
+
+// accumulate adds to the value written by the tx to an accumulated key the
+// difference between the current value of the key, to, and the value the tx
+// read. values are the values of the key read and written by the tx, the
+// execution is stale if the length of one of them changes with the
+// difference, as the gas of the tx does.
+func (s *txStore) accumulate(acc *TxAccumulator, key string, to []byte, values [][]byte) mergeResult {
+	from := s.accumulated[key]
+	if (from == nil) != (to == nil) {
+		return mergeStale
+	}
+
+	for _, value := range values {
+		updated, err := acc.Add(value, from, to)
+		if err != nil {
+			return mergeConflict
+		}
+		if len(updated) != len(value) {
+			return mergeStale
+		}
+	}
+
+	if value, ok := s.writes[key]; ok {
+		updated, err := acc.Add(value, from, to)
+		if err != nil {
+			return mergeConflict
+		}
+		if len(updated) != len(value) {
+			return mergeStale
+		}
+		s.writes[key] = updated
+	}
+
+	return mergeOK
+}
+
+// Set implements KVStore.
+func (s *txStore) Set(key, value []byte) {
+	storetypes.AssertValidKey(key)
+	storetypes.AssertValidValue(value)
+	s.writes[string(key)] = value
+}
+
+// Delete implements KVStore.
+func (s *txStore) Delete(key []byte) {
+	storetypes.AssertValidKey(key)
+	s.writes[string(key)] = nil
+}
+
+// Iterator implements KVStore.
+func (s *txStore) Iterator(start, end []byte) storetypes.Iterator {
+	return s.iterator(start, end, true)
+}
+
+// ReverseIterator implements KVStore.
+func (s *txStore) ReverseIterator(start, end []byte) storetypes.Iterator {
+	return s.iterator(start, end, false)
+}
+
+func (s *txStore) iterator(start, end []byte, ascending bool) storetypes.Iterator {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// the whole domain is recorded, regardless of how far it is iterated
+	s.ranges = append(s.ranges, keyRange{start: start, end: end})
+
+	var parent storetypes.Iterator
+	if ascending {
+		parent = s.parent.Iterator(start, end)
+	} else {
+		parent = s.parent.ReverseIterator(start, end)
+	}
+
+	return &lockedIterator{Iterator: parent, mtx: s.mtx}
+}
+
+// lockedIterator serializes the reads of an iterator over the block state.
+type lockedIterator struct {
+	storetypes.Iterator
+	mtx sync.Locker
+}
+
+// Valid implements Iterator.
+func (it *lockedIterator) Valid() bool {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	return it.Iterator.Valid()
+}
+
+// Next implements Iterator.
+func (it *lockedIterator) Next() {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	it.Iterator.Next()
+}
+
+// Key implements Iterator.
+func (it *lockedIterator) Key() []byte {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	return it.Iterator.Key()
+}
+
+// Value implements Iterator.
+func (it *lockedIterator) Value() []byte {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	return it.Iterator.Value()
+}
+
+// Close implements Iterator.
+func (it *lockedIterator) Close() error {
+	it.mtx.Lock()
+	defer it.mtx.Unlock()
+	return it.Iterator.Close()
+}
+
+// accumulatedStore records the values of the accumulated keys read and
+// written through a store of a tx, which are the values the gas of the tx
+// depends on.
+type accumulatedStore struct {
+	storetypes.CacheKVStore
+	key   storetypes.StoreKey
+	state *blockState
+
+	values map[string][][]byte
+}
+
+// record records the value of the key if it is accumulated.
+func (s *accumulatedStore) record(key, value []byte) {
+	if s.state.accumulator(s.key, key) != nil {
+		s.values[string(key)] = append(s.values[string(key)], value)
+	}
+}
+
+// CacheWrap implements CacheWrapper.
+func (s *accumulatedStore) CacheWrap() storetypes.CacheWrap {
+	return cachekv.NewStore(s)
+}
+
+// CacheWrapWithTrace implements CacheWrapper.
+func (s *accumulatedStore) CacheWrapWithTrace(w io.Writer, tc storetypes.TraceContext) storetypes.CacheWrap {
+	return cachekv.NewStore(tracekv.NewStore(s, w, tc))
+}
+
+// Get implements KVStore.
+func (s *accumulatedStore) Get(key []byte) []byte {
+	value := s.CacheKVStore.Get(key)
+	s.record(key, value)
+	return value
+}
+
+// Set implements KVStore.
+func (s *accumulatedStore) Set(key, value []byte) {
+	s.CacheKVStore.Set(key, value)
+	s.record(key, value)
+}
+
+// Delete implements KVStore.
+func (s *accumulatedStore) Delete(key []byte) {
+	s.CacheKVStore.Delete(key)
+	s.record(key, nil)
+}
@@ -0,0 +1,267 @@
+package baseapp_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	baseapptestutil "github.com/cosmos/cosmos-sdk/baseapp/testutil"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// appendKeyValueImpl appends the value to the one stored at the key, making
+// txs writing the same key depend on each other. The "count" value stores the
+// number of keys in the store instead, and "fail" fails the message.
+type appendKeyValueImpl struct{}
+
+func (m appendKeyValueImpl) Set(ctx context.Context, msg *baseapptestutil.MsgKeyValue) (*baseapptestutil.MsgCreateKeyValueResponse, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	store := sdkCtx.KVStore(capKey2)
+
+	switch string(msg.Value) {
+	case "fail":
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "message handler failure")
+	case "count":
+		count := 0
+		iter := store.Iterator(nil, nil)
+		for ; iter.Valid(); iter.Next() {
+			count++
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+		store.Set(msg.Key, []byte(strconv.Itoa(count)))
+	default:
+		store.Set(msg.Key, append(store.Get(msg.Key), msg.Value...))
+	}
+
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent("set", sdk.NewAttribute("value", string(store.Get(msg.Key)))))
+	return &baseapptestutil.MsgCreateKeyValueResponse{}, nil
+}
+
+func TestParallelTxExecutor(t *testing.T) {
+	_, _, addr := testdata.KeyTestPubAddr()
+
+	newTx := func(suite *BaseAppSuite, key, value string) []byte {
+		builder := suite.txConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(&baseapptestutil.MsgKeyValue{Key: []byte(key), Value: []byte(value), Signer: addr.String()}))
+		setTxSignature(t, builder, 0)
+
+		bz, err := suite.txConfig.TxEncoder()(builder.GetTx())
+		require.NoError(t, err)
+		return bz
+	}
+
+	newBlocks := func(suite *BaseAppSuite) [][][]byte {
+		var independent, conflicting [][]byte
+		for i := 0; i < 20; i++ {
+			independent = append(independent, newTx(suite, fmt.Sprintf("key%d", i), "v"))
+			conflicting = append(conflicting, newTx(suite, "shared", strconv.Itoa(i)))
+		}
+		conflicting = append(conflicting,
+			newTx(suite, "count", "count"),
+			newTx(suite, "key3", "fail"),
+			[]byte("not a tx"),
+			newTx(suite, "key3", "w"),
+		)
+
+		return [][][]byte{independent, conflicting}
+	}
+
+	testCases := map[string]struct {
+		maxGas      int64
+		expOutOfGas bool
+	}{
+		"no block gas limit": {maxGas: -1},
+		"block gas limit":    {maxGas: 40_000, expOutOfGas: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			run := func(opts ...func(*baseapp.BaseApp)) ([]*abci.ResponseFinalizeBlock, [][]byte) {
+				suite := NewBaseAppSuite(t, opts...)
+				baseapptestutil.RegisterKeyValueServer(suite.baseApp.MsgServiceRouter(), appendKeyValueImpl{})
+
+				_, err := suite.baseApp.InitChain(&abci.RequestInitChain{
+					ConsensusParams: &cmtproto.ConsensusParams{
+						Block: &cmtproto.BlockParams{MaxGas: tc.maxGas},
+					},
+				})
+				require.NoError(t, err)
+
+				var (
+					responses []*abci.ResponseFinalizeBlock
+					appHashes [][]byte
+				)
+				for height, txs := range newBlocks(suite) {
+					res, err := suite.baseApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: int64(height) + 1, Txs: txs})
+					require.NoError(t, err)
+					require.Len(t, res.TxResults, len(txs))
+
+					commit, err := suite.baseApp.Commit()
+					require.NoError(t, err)
+					require.NotNil(t, commit)
+
+					responses = append(responses, res)
+					appHashes = append(appHashes, res.AppHash)
+				}
+
+				return responses, appHashes
+			}
+
+			expResponses, expAppHashes := run()
+			responses, appHashes := run(baseapp.SetTxExecutor(baseapp.ParallelTxExecutor(4)))
+
+			require.Equal(t, expAppHashes, appHashes)
+			for i := range expResponses {
+				require.Equal(t, expResponses[i].TxResults, responses[i].TxResults)
+			}
+
+			var outOfGas int
+			for _, res := range expResponses {
+				for _, txResult := range res.TxResults {
+					if txResult.Code == sdkerrors.ErrOutOfGas.ABCICode() {
+						outOfGas++
+					}
+				}
+			}
+			require.Equal(t, tc.expOutOfGas, outOfGas > 0)
+		})
+	}
+}
+
+// feesKey is the key of capKey2 the ante handler of TestParallelTxExecutorAccumulator
+// adds the fee of every tx to, as a fee collector balance.
+var feesKey = []byte("fees/collector")
+
+// addFees adds the difference between two fee amounts to a fee amount, the
+// amounts being decimal strings and nil a zero amount.
+func addFees(value, from, to []byte) ([]byte, error) {
+	amount := func(bz []byte) (int, error) {
+		if bz == nil {
+			return 0, nil
+		}
+		return strconv.Atoi(string(bz))
+	}
+
+	var sum int
+	for i, bz := range [][]byte{value, from, to} {
+		n, err := amount(bz)
+		if err != nil {
+			return nil, err
+		}
+		if i == 1 {
+			n = -n
+		}
+		sum += n
+	}
+
+	if sum == 0 {
+		return nil, nil
+	}
+	return []byte(strconv.Itoa(sum)), nil
+}
+
+func TestParallelTxExecutorAccumulator(t *testing.T) {
+	_, _, addr := testdata.KeyTestPubAddr()
+
+	var executions atomic.Int64
+	feeAnteHandler := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) {
+		executions.Add(1)
+
+		store := ctx.KVStore(capKey2)
+		fees, err := addFees(store.Get(feesKey), nil, []byte("10"))
+		if err != nil {
+			return ctx, err
+		}
+		store.Set(feesKey, fees)
+		return ctx, nil
+	}
+
+	newTx := func(suite *BaseAppSuite, key, value string) []byte {
+		builder := suite.txConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(&baseapptestutil.MsgKeyValue{Key: []byte(key), Value: []byte(value), Signer: addr.String()}))
+		setTxSignature(t, builder, 0)
+
+		bz, err := suite.txConfig.TxEncoder()(builder.GetTx())
+		require.NoError(t, err)
+		return bz
+	}
+
+	// every tx of the blocks pays a fee, the first block starts with no fees
+	// and the others with the fees of the previous blocks
+	newBlocks := func(suite *BaseAppSuite) [][][]byte {
+		var first, independent, conflicting [][]byte
+		for i := 0; i < 20; i++ {
+			first = append(first, newTx(suite, fmt.Sprintf("first%d", i), "v"))
+			independent = append(independent, newTx(suite, fmt.Sprintf("key%d", i), "v"))
+			conflicting = append(conflicting, newTx(suite, "shared", strconv.Itoa(i)))
+		}
+		conflicting = append(conflicting,
+			newTx(suite, "count", "count"),
+			newTx(suite, "key3", "fail"),
+			newTx(suite, "key3", "w"),
+		)
+
+		return [][][]byte{first, conflicting, independent}
+	}
+
+	run := func(opts ...func(*baseapp.BaseApp)) ([]*abci.ResponseFinalizeBlock, []int64) {
+		opts = append(opts, func(app *baseapp.BaseApp) { app.SetAnteHandler(feeAnteHandler) })
+		suite := NewBaseAppSuite(t, opts...)
+		baseapptestutil.RegisterKeyValueServer(suite.baseApp.MsgServiceRouter(), appendKeyValueImpl{})
+
+		_, err := suite.baseApp.InitChain(&abci.RequestInitChain{
+			ConsensusParams: &cmtproto.ConsensusParams{Block: &cmtproto.BlockParams{MaxGas: -1}},
+		})
+		require.NoError(t, err)
+
+		var (
+			responses []*abci.ResponseFinalizeBlock
+			counts    []int64
+		)
+		for height, txs := range newBlocks(suite) {
+			executions.Store(0)
+			res, err := suite.baseApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: int64(height) + 1, Txs: txs})
+			require.NoError(t, err)
+			_, err = suite.baseApp.Commit()
+			require.NoError(t, err)
+
+			responses = append(responses, res)
+			counts = append(counts, executions.Load())
+		}
+
+		return responses, counts
+	}
+
+	accumulator := baseapp.TxAccumulator{StoreName: capKey2.Name(), Prefix: []byte("fees/"), Add: addFees}
+
+	expResponses, _ := run()
+	responses, counts := run(baseapp.SetTxExecutor(baseapp.ParallelTxExecutor(4, accumulator)))
+	for i := range expResponses {
+		require.Equal(t, expResponses[i].AppHash, responses[i].AppHash)
+		require.Equal(t, expResponses[i].TxResults, responses[i].TxResults)
+	}
+
+	// the first tx credits the empty fees before the next txs are executed,
+	// which are executed again after the tenth, as the fees reach 3 digits
+	// and their gas changes
+	require.Equal(t, int64(1+19+1+10), counts[0])
+	// the independent txs are executed once
+	require.Equal(t, int64(20), counts[2])
+
+	// without accumulator, every tx after the first is executed again
+	_, counts = run(baseapp.SetTxExecutor(baseapp.ParallelTxExecutor(4)))
+	require.Equal(t, int64(20+19), counts[2])
+}
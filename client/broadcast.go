@@ -20,6 +20,9 @@ import (
 // based on the context parameters. The result of the broadcast is parsed into
 // an intermediate structure which is logged if the context has a logger
 // defined.
+//
+// If the context waits for the transactions, the response of a transaction
+// accepted in the mempool is the one of its inclusion in a block.
 func (ctx Context) BroadcastTx(txBytes []byte) (res *sdk.TxResponse, err error) {
 	switch ctx.BroadcastMode {
 	case flags.BroadcastSync:
@@ -32,7 +35,27 @@ func (ctx Context) BroadcastTx(txBytes []byte) (res *sdk.TxResponse, err error)
 		return nil, fmt.Errorf("unsupported return type %s; supported types: sync, async", ctx.BroadcastMode)
 	}
 
-	return res, err
+	if err != nil || res.Code != 0 || !ctx.Wait {
+		return res, err
+	}
+
+	goCtx := ctx.CmdContext
+	if goCtx == nil {
+		goCtx = context.Background()
+	}
+	timeout := ctx.WaitTimeout
+	if timeout <= 0 {
+		timeout = flags.DefaultWaitTimeout
+	}
+	goCtx, cancel := context.WithTimeout(goCtx, timeout)
+	defer cancel()
+
+	result, err := ctx.WaitForTx(goCtx, cmttypes.Tx(txBytes).Hash())
+	if err != nil {
+		return res, err
+	}
+
+	return result.TxResponse, nil
 }
 
 // Deprecated: Use CheckCometError instead.
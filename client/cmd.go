@@ -239,6 +239,12 @@ func readTxCommandFlags(clientCtx Context, flagSet *pflag.FlagSet) (Context, err
 		clientCtx = clientCtx.WithBroadcastMode(bMode)
 	}
 
+	if !clientCtx.Wait || flagSet.Changed(flags.FlagWait) {
+		wait, _ := flagSet.GetBool(flags.FlagWait)
+		waitTimeout, _ := flagSet.GetDuration(flags.FlagWaitTimeout)
+		clientCtx = clientCtx.WithWait(wait, waitTimeout)
+	}
+
 	if !clientCtx.SkipConfirm || flagSet.Changed(flags.FlagSkipConfirmation) {
 		skipConfirm, _ := flagSet.GetBool(flags.FlagSkipConfirmation)
 		clientCtx = clientCtx.WithSkipConfirmation(skipConfirm)
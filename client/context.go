@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/cosmos/gogoproto/proto"
 	"github.com/spf13/viper"
@@ -22,6 +23,15 @@ import (
 // PreprocessTxFn defines a hook by which chains can preprocess transactions before broadcasting
 type PreprocessTxFn func(chainID string, key keyring.KeyType, tx TxBuilder) error
 
+// SimulateFn defines a hook simulating transactions locally, e.g. the Simulate
+// method of an in-memory app, used to estimate the gas in offline mode.
+type SimulateFn func(txBytes []byte) (sdk.GasInfo, *sdk.Result, error)
+
+// GasPricesFn defines a hook returning the gas prices of the fees derived with
+// --fees=auto, e.g. from a fee market module. The minimum gas prices of the
+// node are used by default.
+type GasPricesFn func(ctx Context) (sdk.DecCoins, error)
+
 // Context implements a typical context created in SDK modules for transaction
 // handling and queries.
 type Context struct {
@@ -41,6 +51,8 @@ type Context struct {
 	KeyringDir        string
 	From              string
 	BroadcastMode     string
+	Wait              bool
+	WaitTimeout       time.Duration
 	FromName          string
 	SignModeStr       string
 	UseLedger         bool
@@ -56,6 +68,8 @@ type Context struct {
 	Viper             *viper.Viper
 	LedgerHasProtobuf bool
 	PreprocessTxHook  PreprocessTxFn
+	Simulator         SimulateFn
+	GasPricesHook     GasPricesFn
 
 	// IsAux is true when the signer is an auxiliary signer (e.g. the tipper).
 	IsAux bool
@@ -230,6 +244,14 @@ func (ctx Context) WithBroadcastMode(mode string) Context {
 	return ctx
 }
 
+// WithWait returns a copy of the context which waits for the broadcast
+// transactions to be included in a block, for at most the given timeout.
+func (ctx Context) WithWait(wait bool, timeout time.Duration) Context {
+	ctx.Wait = wait
+	ctx.WaitTimeout = timeout
+	return ctx
+}
+
 // WithSignModeStr returns a copy of the context with an updated SignMode
 // value.
 func (ctx Context) WithSignModeStr(signModeStr string) Context {
@@ -292,6 +314,21 @@ func (ctx Context) WithPreprocessTxHook(preprocessFn PreprocessTxFn) Context {
 	return ctx
 }
 
+// WithSimulator returns the context with the provided local simulator, which
+// estimates the gas of the transactions in offline mode.
+func (ctx Context) WithSimulator(simulator SimulateFn) Context {
+	ctx.Simulator = simulator
+	return ctx
+}
+
+// WithGasPricesHook returns the context with the provided gas prices hook,
+// which enables chains to derive the fees with --fees=auto from their own fee
+// market.
+func (ctx Context) WithGasPricesHook(gasPricesFn GasPricesFn) Context {
+	ctx.GasPricesHook = gasPricesFn
+	return ctx
+}
+
 // PrintString prints the raw string to ctx.Output if it's defined, otherwise to os.Stdout
 func (ctx Context) PrintString(str string) error {
 	return ctx.PrintBytes([]byte(str))
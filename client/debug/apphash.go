@@ -0,0 +1,53 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/version"
+)
+
+// CompareAppHashDumpsCmd creates and returns a new cmd comparing two app hash
+// dumps written by different nodes at the same height.
+func CompareAppHashDumpsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "compare-app-hash-dumps [dump-a] [dump-b]",
+		Short: "Compare two app hash dumps and report the first diverging store and keys",
+		Long: fmt.Sprintf(`Compare two app hash dumps written at the same height by nodes started with --app-hash-dump-dir.
+The first store whose hash differs is reported, together with the writes to that store that differ between both nodes.
+
+Example:
+$ %s debug compare-app-hash-dumps node-a/app-hash-dumps/1042.json node-b/app-hash-dumps/1042.json
+			`, version.AppName),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dumpA, err := baseapp.ReadAppHashDump(args[0])
+			if err != nil {
+				return err
+			}
+			dumpB, err := baseapp.ReadAppHashDump(args[1])
+			if err != nil {
+				return err
+			}
+
+			divergence, err := baseapp.CompareAppHashDumps(dumpA, dumpB)
+			if err != nil {
+				return err
+			}
+			if divergence == nil {
+				cmd.Printf("No divergence at height %d, app hash %s\n", dumpA.Height, dumpA.AppHash)
+				return nil
+			}
+
+			bz, err := json.MarshalIndent(divergence, "", "  ")
+			if err != nil {
+				return err
+			}
+			cmd.Println(string(bz))
+			return nil
+		},
+	}
+}
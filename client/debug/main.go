@@ -40,6 +40,7 @@ func Cmd() *cobra.Command {
 	cmd.AddCommand(AddrCmd())
 	cmd.AddCommand(RawBytesCmd())
 	cmd.AddCommand(PrefixesCmd())
+	cmd.AddCommand(CompareAppHashDumpsCmd())
 
 	return cmd
 }
@@ -3,6 +3,7 @@ package flags
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -17,6 +18,12 @@ const (
 	DefaultGasAdjustment = 1.0
 	DefaultGasLimit      = 200000
 	GasFlagAuto          = "auto"
+	// DefaultFeeAdjustment is applied to the fees derived from the minimum gas
+	// prices with --fees=auto. The margin keeps the tx valid when the gas
+	// prices rise before it is included, e.g. a fee market base fee growing by
+	// up to 12.5% per block by default.
+	DefaultFeeAdjustment = 1.5
+	FeesFlagAuto         = "auto"
 
 	// DefaultKeyringBackend
 	DefaultKeyringBackend = keyring.BackendOS
@@ -27,6 +34,9 @@ const (
 	// BroadcastAsync defines a tx broadcasting mode where the client returns
 	// immediately.
 	BroadcastAsync = "async"
+	// DefaultWaitTimeout is the time the client waits for a tx broadcast with
+	// --wait to be included in a block.
+	DefaultWaitTimeout = time.Minute
 
 	// SignModeDirect is the value of the --sign-mode flag for SIGN_MODE_DIRECT
 	SignModeDirect = "direct"
@@ -57,9 +67,13 @@ const (
 	FlagSequence         = "sequence"
 	FlagNote             = "note"
 	FlagFees             = "fees"
+	FlagFeeAdjustment    = "fee-adjustment"
+	FlagFeeDenom         = "fee-denom"
 	FlagGas              = "gas"
 	FlagGasPrices        = "gas-prices"
 	FlagBroadcastMode    = "broadcast-mode"
+	FlagWait             = "wait"
+	FlagWaitTimeout      = "wait-timeout"
 	FlagDryRun           = "dry-run"
 	FlagGenerateOnly     = "generate-only"
 	FlagOffline          = "offline"
@@ -74,6 +88,8 @@ const (
 	FlagOffset           = "offset"
 	FlagCountTotal       = "count-total"
 	FlagTimeoutHeight    = "timeout-height"
+	FlagUnordered        = "unordered"
+	FlagTimeoutDuration  = "timeout-duration"
 	FlagKeyAlgorithm     = "algo"
 	FlagKeyType          = "key-type"
 	FlagFeePayer         = "fee-payer"
@@ -124,18 +140,24 @@ func AddTxFlagsToCmd(cmd *cobra.Command) {
 	f.Uint64P(FlagAccountNumber, "a", 0, "The account number of the signing account (offline mode only)")
 	f.Uint64P(FlagSequence, "s", 0, "The sequence number of the signing account (offline mode only)")
 	f.String(FlagNote, "", "Note to add a description to the transaction (previously --memo)")
-	f.String(FlagFees, "", "Fees to pay along with transaction; eg: 10uatom")
+	f.String(FlagFees, "", fmt.Sprintf("Fees to pay along with transaction; eg: 10uatom, or %q to derive them from the gas limit and the minimum gas prices of the node", FeesFlagAuto))
+	f.Float64(FlagFeeAdjustment, DefaultFeeAdjustment, fmt.Sprintf("adjustment factor to be multiplied against the fees derived with --%s=%s", FlagFees, FeesFlagAuto))
+	f.String(FlagFeeDenom, "", fmt.Sprintf("Denom of the fees derived with --%s=%s; defaults to the first denom of the minimum gas prices", FlagFees, FeesFlagAuto))
 	f.String(FlagGasPrices, "", "Gas prices in decimal format to determine the transaction fee (e.g. 0.1uatom)")
 	f.String(FlagNode, "tcp://localhost:26657", "<host>:<port> to CometBFT rpc interface for this chain")
 	f.Bool(FlagUseLedger, false, "Use a connected Ledger device")
 	f.Float64(FlagGasAdjustment, DefaultGasAdjustment, "adjustment factor to be multiplied against the estimate returned by the tx simulation; if the gas limit is set manually this flag is ignored ")
 	f.StringP(FlagBroadcastMode, "b", BroadcastSync, "Transaction broadcasting mode (sync|async)")
+	f.Bool(FlagWait, false, "Wait for the transaction to be included in a block and print its result")
+	f.Duration(FlagWaitTimeout, DefaultWaitTimeout, fmt.Sprintf("Time to wait for the transaction to be included in a block with --%s", FlagWait))
 	f.Bool(FlagDryRun, false, "ignore the --gas flag and perform a simulation of a transaction, but don't broadcast it (when enabled, the local Keybase is not accessible)")
 	f.Bool(FlagGenerateOnly, false, "Build an unsigned transaction and write it to STDOUT (when enabled, the local Keybase only accessed when providing a key name)")
 	f.Bool(FlagOffline, false, "Offline mode (does not allow any online functionality)")
 	f.BoolP(FlagSkipConfirmation, "y", false, "Skip tx broadcasting prompt confirmation")
 	f.String(FlagSignMode, "", "Choose sign mode (direct|amino-json|direct-aux|textual), this is an advanced feature")
 	f.Uint64(FlagTimeoutHeight, 0, "Set a block timeout height to prevent the tx from being committed past a certain height")
+	f.Bool(FlagUnordered, false, "Mark the tx as unordered; it is not bound to the signer's sequence and must set --timeout-duration instead")
+	f.Duration(FlagTimeoutDuration, 0, "Duration after which an unordered tx expires and can no longer be included in a block (e.g. 5m); only valid with --unordered")
 	f.String(FlagFeePayer, "", "Fee payer pays fees for the transaction instead of deducting from the signer")
 	f.String(FlagFeeGranter, "", "Fee granter grants fees for the transaction")
 	f.String(FlagTip, "", "Tip is the amount that is going to be transferred to the fee payer on the target chain. This flag is only valid when used with --aux, and is ignored if the target chain didn't enable the TipDecorator")
@@ -151,7 +173,7 @@ func AddTxFlagsToCmd(cmd *cobra.Command) {
 // AddKeyringFlags sets common keyring flags
 func AddKeyringFlags(flags *pflag.FlagSet) {
 	flags.String(FlagKeyringDir, "", "The client Keyring directory; if omitted, the default 'home' directory will be used")
-	flags.String(FlagKeyringBackend, DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test|memory)")
+	flags.String(FlagKeyringBackend, DefaultKeyringBackend, "Select keyring's backend (os|file|kwallet|pass|test|memory|remote)")
 }
 
 // AddPaginationFlagsToCmd adds common pagination flags to cmd
@@ -38,6 +38,8 @@ const (
 	flagHDPath       = "hd-path"
 	flagPubKeyBase64 = "pubkey-base64"
 	flagMnemonicSrc  = "source"
+	flagRemote       = "remote"
+	flagRemoteKeyID  = "remote-key-id"
 
 	// DefaultKeyPass contains the default key password for genesis transactions
 	DefaultKeyPass = "12345678"
@@ -71,6 +73,15 @@ sorted by address, unless the flag --nosort is set.
 Example:
 
     keys add mymultisig --multisig "keyname1,keyname2,keyname3" --multisig-threshold 2
+
+You can store a reference to a key held by a remote signer, e.g. in front of an HSM, with
+the --remote flag, giving the gRPC endpoint of the signer. The signatures of the key are then
+delegated to the signer. The key is identified in the signer by --remote-key-id, or by the
+name of the key if not set. The keyring authenticates to the signer with the token of the
+REMOTE_SIGNER_TOKEN environment variable. The remote keyring backend only stores such references.
+Example:
+
+    keys add myremote --remote localhost:9091 --remote-key-id validator-1 --keyring-backend remote
 `,
 		Args: cobra.ExactArgs(1),
 		RunE: runAddCmdPrepare,
@@ -92,6 +103,8 @@ Example:
 	f.Uint32(flagIndex, 0, "Address index number for HD derivation (less than equal 2147483647)")
 	f.String(flags.FlagKeyType, string(hd.Secp256k1Type), "Key signing algorithm to generate keys for")
 	f.String(flagMnemonicSrc, "", "Import mnemonic from a file (only usable when recover or interactive is passed)")
+	f.String(flagRemote, "", "Store a reference to a key held by the remote signer at this gRPC endpoint")
+	f.String(flagRemoteKeyID, "", "ID of the key in the remote signer, the name of the key by default (only usable with --remote)")
 
 	// support old flags name for backwards compatibility
 	f.SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
@@ -252,6 +265,20 @@ func runAddCmd(ctx client.Context, cmd *cobra.Command, args []string, inBuf *buf
 		return printCreate(cmd, k, false, "", outputFormat)
 	}
 
+	if endpoint, _ := cmd.Flags().GetString(flagRemote); endpoint != "" {
+		keyID, _ := cmd.Flags().GetString(flagRemoteKeyID)
+		if keyID == "" {
+			keyID = name
+		}
+
+		k, err := kb.SaveRemoteKey(name, endpoint, keyID)
+		if err != nil {
+			return err
+		}
+
+		return printCreate(cmd, k, false, "", outputFormat)
+	}
+
 	coinType, _ := cmd.Flags().GetUint32(flagCoinType)
 	account, _ := cmd.Flags().GetUint32(flagAccount)
 	index, _ := cmd.Flags().GetUint32(flagIndex)
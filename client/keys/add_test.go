@@ -5,19 +5,23 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"testing"
 
 	"github.com/cosmos/go-bip39"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/testutil"
 	"github.com/cosmos/cosmos-sdk/testutil/testdata"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 )
 
 func Test_runAddCmdBasic(t *testing.T) {
@@ -119,6 +123,58 @@ func Test_runAddCmdBasic(t *testing.T) {
 	require.Error(t, cmd.ExecuteContext(ctx))
 }
 
+func Test_runAddCmdRemote(t *testing.T) {
+	cdc := moduletestutil.MakeTestEncodingConfig().Codec
+	signer := keyring.NewInMemory(cdc)
+	signerKey, _, err := signer.NewMnemonic("validator-1", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer(grpc.ForceServerCodec(codec.NewProtoCodec(cdc.InterfaceRegistry()).GRPCCodec()))
+	server, err := keyring.NewRemoteSignerServer(signer, "secret")
+	require.NoError(t, err)
+	keyring.RegisterRemoteSignerServer(srv, server)
+	t.Setenv(keyring.RemoteSignerTokenEnv, "secret")
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	cmd := AddKeyCommand()
+	cmd.Flags().AddFlagSet(Commands().PersistentFlags())
+	mockIn := testutil.ApplyMockIODiscardOutErr(cmd)
+	kbHome := t.TempDir()
+
+	clientCtx := client.Context{}.WithKeyringDir(kbHome).WithInput(mockIn).WithCodec(cdc)
+	ctx := context.WithValue(context.Background(), client.ClientContextKey, &clientCtx)
+
+	cmd.SetArgs([]string{
+		"remote",
+		fmt.Sprintf("--%s=%s", flagRemote, listener.Addr().String()),
+		fmt.Sprintf("--%s=%s", flagRemoteKeyID, "validator-1"),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringDir, kbHome),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringBackend, keyring.BackendRemote),
+	})
+	require.NoError(t, cmd.ExecuteContext(ctx))
+
+	kb, err := keyring.New(sdk.KeyringServiceName(), keyring.BackendRemote, kbHome, mockIn, cdc)
+	require.NoError(t, err)
+	k, err := kb.Key("remote")
+	require.NoError(t, err)
+	require.Equal(t, keyring.TypeRemote, k.GetType())
+	addr, err := k.GetAddress()
+	require.NoError(t, err)
+	signerAddr, err := signerKey.GetAddress()
+	require.NoError(t, err)
+	require.Equal(t, signerAddr, addr)
+
+	ko, err := MkAccKeyOutput(k)
+	require.NoError(t, err)
+	require.Equal(t, "remote", ko.Type)
+
+	_, _, err = kb.Sign("remote", []byte("sign bytes"), signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+}
+
 func Test_runAddCmdDryRun(t *testing.T) {
 	pubkey1 := `{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"AtObiFVE4s+9+RX5SP8TN9r2mxpoaT4eGj9CJfK7VRzN"}`
 	pubkey2 := `{"@type":"/cosmos.crypto.secp256k1.PubKey","key":"A/se1vkqgdQ7VJQCM4mxN+L+ciGhnnJ4XYsQCRBMrdRi"}`
@@ -0,0 +1,107 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagAllowedMsgs     = "allowed-msgs"
+	flagAllowedChainIDs = "allowed-chain-ids"
+	flagDailyLimit      = "daily-limit"
+)
+
+// SetPolicyCommand sets the policy restricting the transactions signed with a
+// key.
+func SetPolicyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-policy <name>",
+		Short: "Restrict the transactions signed with a key",
+		Long: `Set the policy restricting the transactions signed with a key by the keyring,
+e.g. to put guardrails on the hot keys of relayers:
+
+    $ keys set-policy relayer --allowed-msgs /ibc.core.client.v1.MsgUpdateClient,/ibc.core.channel.v1.MsgRecvPacket \
+        --allowed-chain-ids cosmoshub-4 --daily-limit 10000000uatom
+
+The daily limit applies to the sum of the coins of the messages and of the fees of the transactions
+signed within 24 hours, per denom. The denoms absent from it are not limited. A key with a policy
+only signs transactions in the direct sign mode.
+
+Every signing attempt, allowed or rejected, is recorded in the audit log of the keyring directory,
+see the audit-log command. Running the command without any restriction removes the policy of the key.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			policy := &keyring.KeyPolicy{}
+			policy.AllowedMsgTypeUrls, _ = cmd.Flags().GetStringSlice(flagAllowedMsgs)
+			policy.AllowedChainIds, _ = cmd.Flags().GetStringSlice(flagAllowedChainIDs)
+			dailyLimit, _ := cmd.Flags().GetString(flagDailyLimit)
+			if policy.DailyLimit, err = sdk.ParseCoinsNormalized(dailyLimit); err != nil {
+				return err
+			}
+
+			if _, err := clientCtx.Keyring.SetPolicy(args[0], policy); err != nil {
+				return err
+			}
+
+			if policy.IsEmpty() {
+				cmd.PrintErrln(fmt.Sprintf("Policy of %s removed", args[0]))
+				return nil
+			}
+
+			return clientCtx.WithOutput(cmd.OutOrStdout()).PrintProto(policy)
+		},
+	}
+
+	cmd.Flags().StringSlice(flagAllowedMsgs, nil, "Comma separated type URLs of the messages the transactions may contain")
+	cmd.Flags().StringSlice(flagAllowedChainIDs, nil, "Comma separated chain IDs of the transactions")
+	cmd.Flags().String(flagDailyLimit, "", "Maximum amount of coins signed within 24 hours, e.g. 10000000uatom")
+
+	return cmd
+}
+
+// AuditLogCommand verifies and prints the signing audit log of the keyring.
+func AuditLogCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "audit-log",
+		Short: "Verify and print the signing audit log",
+		Long: `Verify and print the log of the signing attempts of the keyring, allowed or rejected by the
+policies of the keys. The log is a hash chain of JSON lines: the command fails if an entry has been
+edited or removed, except for the last ones.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			entries, err := keyring.NewAuditLog(filepath.Join(clientCtx.KeyringDir, keyring.AuditLogFileName)).Entries()
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range entries {
+				bz, err := json.Marshal(entry)
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bz))
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package keys
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+)
+
+func Test_runSetPolicyCmd(t *testing.T) {
+	kbHome := t.TempDir()
+	cmd := SetPolicyCommand()
+	cmd.Flags().AddFlagSet(Commands().PersistentFlags())
+	mockIn, mockOut := testutil.ApplyMockIO(cmd)
+
+	cdc := moduletestutil.MakeTestEncodingConfig().Codec
+	kb, err := keyring.New(sdk.KeyringServiceName(), keyring.BackendTest, kbHome, mockIn, cdc)
+	require.NoError(t, err)
+	_, err = kb.NewAccount("relayer", testdata.TestMnemonic, "", sdk.GetConfig().GetFullBIP44Path(), hd.Secp256k1)
+	require.NoError(t, err)
+
+	clientCtx := client.Context{}.
+		WithKeyringDir(kbHome).
+		WithCodec(cdc)
+	ctx := context.WithValue(context.Background(), client.ClientContextKey, &clientCtx)
+
+	cmd.SetArgs([]string{
+		"relayer",
+		fmt.Sprintf("--%s=%s", flagAllowedMsgs, "/cosmos.bank.v1beta1.MsgSend"),
+		fmt.Sprintf("--%s=%s", flagAllowedChainIDs, "test-chain,other-chain"),
+		fmt.Sprintf("--%s=%s", flagDailyLimit, "100stake"),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringDir, kbHome),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringBackend, keyring.BackendTest),
+	})
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	require.Contains(t, mockOut.String(), "100")
+
+	k, err := kb.Key("relayer")
+	require.NoError(t, err)
+	require.Equal(t, &keyring.KeyPolicy{
+		AllowedMsgTypeUrls: []string{"/cosmos.bank.v1beta1.MsgSend"},
+		DailyLimit:         sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		AllowedChainIds:    []string{"test-chain", "other-chain"},
+	}, k.Policy)
+
+	// without restrictions the policy is removed
+	cmd = SetPolicyCommand()
+	cmd.Flags().AddFlagSet(Commands().PersistentFlags())
+	testutil.ApplyMockIODiscardOutErr(cmd)
+	cmd.SetArgs([]string{
+		"relayer",
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringDir, kbHome),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringBackend, keyring.BackendTest),
+	})
+	require.NoError(t, cmd.ExecuteContext(ctx))
+	k, err = kb.Key("relayer")
+	require.NoError(t, err)
+	require.Nil(t, k.Policy)
+
+	cmd.SetArgs([]string{
+		"unknown",
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringDir, kbHome),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringBackend, keyring.BackendTest),
+	})
+	require.Error(t, cmd.ExecuteContext(ctx))
+}
@@ -18,9 +18,10 @@ func RenameKeyCommand() *cobra.Command {
 		Short: "Rename an existing key",
 		Long: `Rename a key from the Keybase backend.
 
-Note that renaming offline or ledger keys will rename
+Note that renaming offline, ledger or remote keys will rename
 only the public key references stored locally, i.e.
-private keys stored in a ledger device cannot be renamed with the CLI.
+private keys stored in a ledger device or a remote signer
+cannot be renamed with the CLI.
 `,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -51,7 +52,7 @@ private keys stored in a ledger device cannot be renamed with the CLI.
 				return err
 			}
 
-			if k.GetType() == keyring.TypeLedger || k.GetType() == keyring.TypeOffline {
+			if keyType := k.GetType(); keyType == keyring.TypeLedger || keyType == keyring.TypeOffline || keyType == keyring.TypeRemote {
 				cmd.PrintErrln("Public key reference renamed")
 				return nil
 			}
@@ -62,7 +63,7 @@ private keys stored in a ledger device cannot be renamed with the CLI.
 		},
 	}
 
-	cmd.Flags().BoolP(flagYes, "y", false, "Skip confirmation prompt when renaming offline or ledger key references")
+	cmd.Flags().BoolP(flagYes, "y", false, "Skip confirmation prompt when renaming offline, ledger or remote key references")
 
 	return cmd
 }
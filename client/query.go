@@ -48,6 +48,60 @@ func (ctx Context) QueryStore(key []byte, storeName string) ([]byte, int64, erro
 	return ctx.queryStore(key, storeName, "key")
 }
 
+// QueryStoreKeys performs a batch query of the given keys against the store
+// with the provided name and requests a proof for it. It returns the values of
+// the keys present in the store, along with the full response holding the
+// height and the batch proof, which can be checked with VerifyStoreKeysProof.
+func (ctx Context) QueryStoreKeys(keys [][]byte, storeName string) (map[string][]byte, abci.ResponseQuery, error) {
+	data, err := rootmulti.EncodeBatchQuery(keys)
+	if err != nil {
+		return nil, abci.ResponseQuery{}, err
+	}
+
+	resp, err := ctx.queryABCI(abci.RequestQuery{
+		Path:   fmt.Sprintf("/store/%s%s", storeName, rootmulti.BatchQueryPath),
+		Data:   data,
+		Height: ctx.Height,
+		Prove:  true,
+	})
+	if err != nil {
+		return nil, abci.ResponseQuery{}, err
+	}
+
+	values, err := rootmulti.DecodeBatchQueryResult(resp.Value)
+	if err != nil {
+		return nil, abci.ResponseQuery{}, err
+	}
+
+	return values, resp, nil
+}
+
+// VerifyStoreKeysProof verifies the response of a batch store query against a
+// trusted app hash and returns the proven values. The existence of every
+// returned value and the absence of every other requested key is verified.
+// Note that the app hash of the state at height H is committed in the header
+// of block H+1.
+func VerifyStoreKeysProof(appHash []byte, storeName string, keys [][]byte, resp abci.ResponseQuery) (map[string][]byte, error) {
+	values, err := rootmulti.DecodeBatchQueryResult(resp.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rootmulti.VerifyBatchProof(appHash, storeName, keys, values, resp.ProofOps); err != nil {
+		return nil, err
+	}
+
+	// only return the values of the requested keys, which were verified
+	verified := make(map[string][]byte, len(values))
+	for _, key := range keys {
+		if value, ok := values[string(key)]; ok {
+			verified[string(key)] = value
+		}
+	}
+
+	return verified, nil
+}
+
 // QueryABCI performs a query to a CometBFT node with the provide RequestQuery.
 // It returns the ResultQuery obtained from the query. The height used to perform
 // the query is the RequestQuery Height if it is non-zero, otherwise the context
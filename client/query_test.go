@@ -0,0 +1,58 @@
+package client_test
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+func TestVerifyStoreKeysProof(t *testing.T) {
+	storeKey := storetypes.NewKVStoreKey("bank")
+	cms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	cms.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, cms.LoadLatestVersion())
+
+	cms.GetKVStore(storeKey).Set([]byte("alice"), []byte("100"))
+	cms.GetKVStore(storeKey).Set([]byte("carol"), []byte("300"))
+	cid := cms.Commit()
+
+	keys := [][]byte{[]byte("alice"), []byte("bob"), []byte("carol")}
+	data, err := rootmulti.EncodeBatchQuery(keys)
+	require.NoError(t, err)
+
+	res, err := cms.Query(&storetypes.RequestQuery{Path: "/bank" + rootmulti.BatchQueryPath, Data: data, Prove: true})
+	require.NoError(t, err)
+	resp := abci.ResponseQuery{Value: res.Value, ProofOps: res.ProofOps, Height: res.Height}
+
+	values, err := client.VerifyStoreKeysProof(cid.Hash, "bank", keys, resp)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"alice": []byte("100"), "carol": []byte("300")}, values)
+
+	_, err = client.VerifyStoreKeysProof([]byte("invalid"), "bank", keys, resp)
+	require.Error(t, err)
+
+	_, err = client.VerifyStoreKeysProof(cid.Hash, "acc", keys, resp)
+	require.Error(t, err)
+
+	// inject the value of a key which was not requested
+	var pair []byte
+	pair = protowire.AppendTag(pair, 1, protowire.BytesType)
+	pair = protowire.AppendBytes(pair, []byte("mallory"))
+	pair = protowire.AppendTag(pair, 2, protowire.BytesType)
+	pair = protowire.AppendBytes(pair, []byte("1000"))
+	injected := protowire.AppendTag(append([]byte{}, resp.Value...), 1, protowire.BytesType)
+	injected = protowire.AppendBytes(injected, pair)
+
+	_, err = client.VerifyStoreKeysProof(cid.Hash, "bank", keys, abci.ResponseQuery{Value: injected, ProofOps: res.ProofOps, Height: res.Height})
+	require.Error(t, err)
+}
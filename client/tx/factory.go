@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/cosmos/go-bip39"
 	"github.com/spf13/pflag"
@@ -19,6 +20,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 )
 
@@ -32,6 +34,8 @@ type Factory struct {
 	sequence           uint64
 	gas                uint64
 	timeoutHeight      uint64
+	unordered          bool
+	timeoutDuration    time.Duration
 	gasAdjustment      float64
 	chainID            string
 	fromName           string
@@ -39,6 +43,9 @@ type Factory struct {
 	generateOnly       bool
 	memo               string
 	fees               sdk.Coins
+	autoFees           bool
+	feeAdjustment      float64
+	feeDenom           string
 	feeGranter         sdk.AccAddress
 	feePayer           sdk.AccAddress
 	gasPrices          sdk.DecCoins
@@ -77,6 +84,15 @@ func NewFactoryCLI(clientCtx client.Context, flagSet *pflag.FlagSet) (Factory, e
 	gasAdj, _ := flagSet.GetFloat64(flags.FlagGasAdjustment)
 	memo, _ := flagSet.GetString(flags.FlagNote)
 	timeoutHeight, _ := flagSet.GetUint64(flags.FlagTimeoutHeight)
+	unordered, _ := flagSet.GetBool(flags.FlagUnordered)
+	timeoutDuration, _ := flagSet.GetDuration(flags.FlagTimeoutDuration)
+
+	if unordered && timeoutDuration <= 0 {
+		return Factory{}, errors.New("timeout-duration must be set for unordered txs")
+	}
+	if !unordered && timeoutDuration != 0 {
+		return Factory{}, errors.New("timeout-duration is only valid for unordered txs")
+	}
 
 	gasStr, _ := flagSet.GetString(flags.FlagGas)
 	gasSetting, _ := flags.ParseGasSetting(gasStr)
@@ -94,6 +110,8 @@ func NewFactoryCLI(clientCtx client.Context, flagSet *pflag.FlagSet) (Factory, e
 		accountNumber:      accNum,
 		sequence:           accSeq,
 		timeoutHeight:      timeoutHeight,
+		unordered:          unordered,
+		timeoutDuration:    timeoutDuration,
 		gasAdjustment:      gasAdj,
 		memo:               memo,
 		signMode:           signMode,
@@ -102,7 +120,13 @@ func NewFactoryCLI(clientCtx client.Context, flagSet *pflag.FlagSet) (Factory, e
 	}
 
 	feesStr, _ := flagSet.GetString(flags.FlagFees)
-	f = f.WithFees(feesStr)
+	if feesStr == flags.FeesFlagAuto {
+		feeAdj, _ := flagSet.GetFloat64(flags.FlagFeeAdjustment)
+		feeDenom, _ := flagSet.GetString(flags.FlagFeeDenom)
+		f = f.WithAutoFees(true).WithFeeAdjustment(feeAdj).WithFeeDenom(feeDenom)
+	} else {
+		f = f.WithFees(feesStr)
+	}
 
 	gasPricesStr, _ := flagSet.GetString(flags.FlagGasPrices)
 	f = f.WithGasPrices(gasPricesStr)
@@ -120,9 +144,14 @@ func (f Factory) Keybase() keyring.Keyring                  { return f.keybase }
 func (f Factory) ChainID() string                           { return f.chainID }
 func (f Factory) Memo() string                              { return f.memo }
 func (f Factory) Fees() sdk.Coins                           { return f.fees }
+func (f Factory) AutoFees() bool                            { return f.autoFees }
+func (f Factory) FeeAdjustment() float64                    { return f.feeAdjustment }
+func (f Factory) FeeDenom() string                          { return f.feeDenom }
 func (f Factory) GasPrices() sdk.DecCoins                   { return f.gasPrices }
 func (f Factory) AccountRetriever() client.AccountRetriever { return f.accountRetriever }
 func (f Factory) TimeoutHeight() uint64                     { return f.timeoutHeight }
+func (f Factory) Unordered() bool                           { return f.unordered }
+func (f Factory) TimeoutDuration() time.Duration            { return f.timeoutDuration }
 func (f Factory) FromName() string                          { return f.fromName }
 
 // SimulateAndExecute returns the option to simulate and then execute the transaction
@@ -164,6 +193,26 @@ func (f Factory) WithFees(fees string) Factory {
 	return f
 }
 
+// WithAutoFees returns a copy of the Factory which derives the fees from the
+// gas limit and the gas prices of the chain, see WithEstimatedFees.
+func (f Factory) WithAutoFees(autoFees bool) Factory {
+	f.autoFees = autoFees
+	return f
+}
+
+// WithFeeAdjustment returns a copy of the Factory with an updated fee adjustment.
+func (f Factory) WithFeeAdjustment(feeAdj float64) Factory {
+	f.feeAdjustment = feeAdj
+	return f
+}
+
+// WithFeeDenom returns a copy of the Factory with an updated denom of the
+// estimated fees.
+func (f Factory) WithFeeDenom(denom string) Factory {
+	f.feeDenom = denom
+	return f
+}
+
 // WithGasPrices returns a copy of the Factory with updated gas prices.
 func (f Factory) WithGasPrices(gasPrices string) Factory {
 	parsedGasPrices, err := sdk.ParseDecCoins(gasPrices)
@@ -236,6 +285,14 @@ func (f Factory) WithTimeoutHeight(height uint64) Factory {
 	return f
 }
 
+// WithUnordered returns a copy of the Factory that builds unordered txs
+// expiring timeoutDuration after they are built.
+func (f Factory) WithUnordered(unordered bool, timeoutDuration time.Duration) Factory {
+	f.unordered = unordered
+	f.timeoutDuration = timeoutDuration
+	return f
+}
+
 // WithFeeGranter returns a copy of the Factory with an updated fee granter.
 func (f Factory) WithFeeGranter(fg sdk.AccAddress) Factory {
 	f.feeGranter = fg
@@ -341,8 +398,24 @@ func (f Factory) BuildUnsignedTx(msgs ...sdk.Msg) (client.TxBuilder, error) {
 	tx.SetFeePayer(f.feePayer)
 	tx.SetTimeoutHeight(f.TimeoutHeight())
 
+	extOptions := f.extOptions
+	if f.unordered {
+		if f.signMode == signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON {
+			return nil, errors.New("unordered txs cannot be signed in amino-json sign mode")
+		}
+
+		opt, err := txtypes.NewUnorderedExtensionOption(time.Now().Add(f.timeoutDuration))
+		if err != nil {
+			return nil, err
+		}
+
+		extOptions = append(append([]*codectypes.Any{}, extOptions...), opt)
+	}
+
 	if etx, ok := tx.(client.ExtendedTxBuilder); ok {
-		etx.SetExtensionOptions(f.extOptions...)
+		etx.SetExtensionOptions(extOptions...)
+	} else if f.unordered {
+		return nil, errors.New("tx builder does not support extension options required by unordered txs")
 	}
 
 	return tx, nil
@@ -354,10 +427,6 @@ func (f Factory) BuildUnsignedTx(msgs ...sdk.Msg) (client.TxBuilder, error) {
 // printed.
 func (f Factory) PrintUnsignedTx(clientCtx client.Context, msgs ...sdk.Msg) error {
 	if f.SimulateAndExecute() {
-		if clientCtx.Offline {
-			return errors.New("cannot estimate gas in offline mode")
-		}
-
 		// Prepare TxFactory with acc & seq numbers as CalculateGas requires
 		// account and sequence numbers to be set
 		preparedTxf, err := f.Prepare(clientCtx)
@@ -365,7 +434,7 @@ func (f Factory) PrintUnsignedTx(clientCtx client.Context, msgs ...sdk.Msg) erro
 			return err
 		}
 
-		_, adjusted, err := CalculateGas(clientCtx, preparedTxf, msgs...)
+		adjusted, err := estimateGas(clientCtx, preparedTxf, msgs...)
 		if err != nil {
 			return err
 		}
@@ -374,6 +443,13 @@ func (f Factory) PrintUnsignedTx(clientCtx client.Context, msgs ...sdk.Msg) erro
 		_, _ = fmt.Fprintf(os.Stderr, "%s\n", GasEstimateResponse{GasEstimate: f.Gas()})
 	}
 
+	if f.AutoFees() {
+		var err error
+		if f, err = f.WithEstimatedFees(clientCtx); err != nil {
+			return err
+		}
+	}
+
 	unsignedTx, err := f.BuildUnsignedTx(msgs...)
 	if err != nil {
 		return err
@@ -0,0 +1,120 @@
+package tx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// QueryMinGasPrices returns the minimum gas prices of the node, below which
+// it rejects the transactions from its mempool.
+func QueryMinGasPrices(clientCtx client.Context) (sdk.DecCoins, error) {
+	res, err := node.NewServiceClient(clientCtx).Config(context.Background(), &node.ConfigRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return sdk.ParseDecCoins(res.MinimumGasPrice)
+}
+
+// WithEstimatedFees returns a copy of the Factory with the fees derived from
+// its gas limit and the gas prices of the chain, multiplied by the fee
+// adjustment, in the fee denom or the first denom of the gas prices.
+//
+// The gas prices are, in order of precedence, the gas prices of the Factory,
+// the ones returned by the gas prices hook of the client context, e.g. from a
+// fee market, and the minimum gas prices of the node.
+func (f Factory) WithEstimatedFees(clientCtx client.Context) (Factory, error) {
+	gasPrices := f.gasPrices
+	if gasPrices.IsZero() {
+		var err error
+		switch {
+		case clientCtx.GasPricesHook != nil:
+			gasPrices, err = clientCtx.GasPricesHook(clientCtx)
+		case clientCtx.Offline:
+			return f, errors.New("cannot query the minimum gas prices in offline mode, set the gas prices instead")
+		default:
+			gasPrices, err = QueryMinGasPrices(clientCtx)
+		}
+		if err != nil {
+			return f, fmt.Errorf("failed to query the gas prices: %w", err)
+		}
+	}
+
+	feeAdj := f.feeAdjustment
+	if feeAdj == 0 {
+		feeAdj = flags.DefaultFeeAdjustment
+	}
+	if feeAdj < 0 {
+		return f, fmt.Errorf("invalid fee adjustment %v", feeAdj)
+	}
+	adjustment, err := math.LegacyNewDecFromStr(strconv.FormatFloat(feeAdj, 'f', math.LegacyPrecision, 64))
+	if err != nil {
+		return f, err
+	}
+
+	fees := sdk.NewCoins()
+	if !gasPrices.IsZero() {
+		gasPrice := gasPrices[0]
+		if f.feeDenom != "" {
+			gasPrice = sdk.NewDecCoinFromDec(f.feeDenom, gasPrices.AmountOf(f.feeDenom))
+			if gasPrice.IsZero() {
+				return f, fmt.Errorf("no gas price in %s, expected one of %s", f.feeDenom, gasPrices)
+			}
+		}
+
+		// fee = ceil(gasPrice * gasLimit * adjustment)
+		fee := gasPrice.Amount.Mul(math.LegacyNewDec(int64(f.gas))).Mul(adjustment)
+		fees = sdk.NewCoins(sdk.NewCoin(gasPrice.Denom, fee.Ceil().RoundInt()))
+	}
+
+	f.fees = fees
+	f.gasPrices = nil
+	f.autoFees = false
+	return f, nil
+}
+
+// estimateGas simulates the transaction against the node, or against the
+// local simulator of the client context in offline mode, and returns the
+// adjusted gas.
+func estimateGas(clientCtx client.Context, txf Factory, msgs ...sdk.Msg) (uint64, error) {
+	if !clientCtx.Offline {
+		_, adjusted, err := CalculateGas(clientCtx, txf, msgs...)
+		return adjusted, err
+	}
+
+	if clientCtx.Simulator == nil {
+		return 0, errors.New("cannot estimate gas in offline mode")
+	}
+	simRes, err := SimulateLocally(clientCtx.Simulator, txf, msgs...)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(txf.GasAdjustment() * float64(simRes.GasInfo.GasUsed)), nil
+}
+
+// SimulateLocally simulates the execution of a transaction with the given
+// local simulator, e.g. the Simulate method of an in-memory app.
+func SimulateLocally(simulate client.SimulateFn, txf Factory, msgs ...sdk.Msg) (*tx.SimulateResponse, error) {
+	txBytes, err := txf.BuildSimTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	gasInfo, result, err := simulate(txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx.SimulateResponse{GasInfo: &gasInfo, Result: result}, nil
+}
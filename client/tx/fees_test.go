@@ -0,0 +1,121 @@
+package tx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestWithEstimatedFees(t *testing.T) {
+	hook := func(client.Context) (sdk.DecCoins, error) {
+		return sdk.NewDecCoins(sdk.NewDecCoinFromDec("stake", math.LegacyMustNewDecFromStr("0.025")), sdk.NewInt64DecCoin("atom", 1)), nil
+	}
+
+	testCases := []struct {
+		name      string
+		clientCtx client.Context
+		txf       Factory
+		expFees   sdk.Coins
+		expErr    string
+	}{
+		{
+			"gas prices of the factory, with the default adjustment",
+			client.Context{}.WithOffline(true),
+			Factory{}.WithGas(100000).WithGasPrices("0.01stake"),
+			sdk.NewCoins(sdk.NewInt64Coin("stake", 1500)),
+			"",
+		},
+		{
+			"first denom of the hook, rounded up",
+			client.Context{}.WithGasPricesHook(hook),
+			Factory{}.WithGas(100001).WithFeeAdjustment(1),
+			sdk.NewCoins(sdk.NewInt64Coin("atom", 100001)),
+			"",
+		},
+		{
+			"fee denom and adjustment",
+			client.Context{}.WithGasPricesHook(hook),
+			Factory{}.WithGas(100001).WithFeeDenom("stake").WithFeeAdjustment(2),
+			sdk.NewCoins(sdk.NewInt64Coin("stake", 5001)),
+			"",
+		},
+		{
+			"unknown fee denom",
+			client.Context{}.WithGasPricesHook(hook),
+			Factory{}.WithGas(100000).WithFeeDenom("foo"),
+			nil,
+			"no gas price in foo",
+		},
+		{
+			"hook error",
+			client.Context{}.WithGasPricesHook(func(client.Context) (sdk.DecCoins, error) {
+				return nil, errors.New("fee market unavailable")
+			}),
+			Factory{}.WithGas(100000),
+			nil,
+			"fee market unavailable",
+		},
+		{
+			"offline without gas prices",
+			client.Context{}.WithOffline(true),
+			Factory{}.WithGas(100000),
+			nil,
+			"offline mode",
+		},
+		{
+			"negative fee adjustment",
+			client.Context{}.WithGasPricesHook(hook),
+			Factory{}.WithGas(100000).WithFeeAdjustment(-1),
+			nil,
+			"invalid fee adjustment",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			txf, err := tc.txf.WithAutoFees(true).WithEstimatedFees(tc.clientCtx)
+			if tc.expErr != "" {
+				require.ErrorContains(t, err, tc.expErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expFees, txf.Fees())
+			require.Empty(t, txf.GasPrices())
+			require.False(t, txf.AutoFees())
+		})
+	}
+}
+
+func TestEstimateGasOffline(t *testing.T) {
+	txCfg, _ := newTestTxConfig()
+	txf := mockTxFactory(txCfg).WithGasAdjustment(1.5)
+	msg := testdata.NewTestMsg(sdk.AccAddress("from"))
+
+	clientCtx := client.Context{}.WithOffline(true)
+	_, err := estimateGas(clientCtx, txf, msg)
+	require.ErrorContains(t, err, "cannot estimate gas in offline mode")
+
+	var simulated []byte
+	clientCtx = clientCtx.WithSimulator(func(txBytes []byte) (sdk.GasInfo, *sdk.Result, error) {
+		simulated = txBytes
+		return sdk.GasInfo{GasUsed: 1000}, &sdk.Result{}, nil
+	})
+	gas, err := estimateGas(clientCtx, txf, msg)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1500), gas)
+	require.NotEmpty(t, simulated)
+
+	clientCtx = clientCtx.WithSimulator(func([]byte) (sdk.GasInfo, *sdk.Result, error) {
+		return sdk.GasInfo{}, nil, errors.New("out of gas")
+	})
+	_, err = estimateGas(clientCtx, txf, msg)
+	require.ErrorContains(t, err, "out of gas")
+}
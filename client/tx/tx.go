@@ -77,11 +77,7 @@ func BroadcastTx(clientCtx client.Context, txf Factory, msgs ...sdk.Msg) error {
 	}
 
 	if txf.SimulateAndExecute() || clientCtx.Simulate {
-		if clientCtx.Offline {
-			return errors.New("cannot estimate gas in offline mode")
-		}
-
-		_, adjusted, err := CalculateGas(clientCtx, txf, msgs...)
+		adjusted, err := estimateGas(clientCtx, txf, msgs...)
 		if err != nil {
 			return err
 		}
@@ -90,6 +86,12 @@ func BroadcastTx(clientCtx client.Context, txf Factory, msgs ...sdk.Msg) error {
 		_, _ = fmt.Fprintf(os.Stderr, "%s\n", GasEstimateResponse{GasEstimate: txf.Gas()})
 	}
 
+	if txf.AutoFees() {
+		if txf, err = txf.WithEstimatedFees(clientCtx); err != nil {
+			return err
+		}
+	}
+
 	if clientCtx.Simulate {
 		return nil
 	}
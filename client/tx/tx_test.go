@@ -3,6 +3,7 @@ package tx
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -426,6 +427,69 @@ func TestPreprocessHook(t *testing.T) {
 	requireT.Equal(opt, extAny)
 }
 
+func TestSignWithKeyPolicy(t *testing.T) {
+	txConfig, cdc := newTestTxConfig()
+	// the keyring decodes the messages to check them
+	banktypes.RegisterInterfaces(cdc.InterfaceRegistry())
+	requireT := require.New(t)
+	dir := t.TempDir()
+	kb, err := keyring.New(t.Name(), "test", dir, nil, cdc)
+	requireT.NoError(err)
+
+	from := "relayer"
+	k, _, err := kb.NewMnemonic(from, keyring.English, hd.CreateHDPath(118, 0, 0).String(), keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	requireT.NoError(err)
+	addr, err := k.GetAddress()
+	requireT.NoError(err)
+	_, err = kb.SetPolicy(from, &keyring.KeyPolicy{
+		AllowedMsgTypeUrls: []string{sdk.MsgTypeURL(&banktypes.MsgSend{})},
+		DailyLimit:         sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		AllowedChainIds:    []string{"test-chain"},
+	})
+	requireT.NoError(err)
+
+	// fees of 50stake
+	txf := mockTxFactory(txConfig).
+		WithKeybase(kb).
+		WithSignMode(signingtypes.SignMode_SIGN_MODE_DIRECT)
+	send := func(amount int64) client.TxBuilder {
+		txb, err := txf.BuildUnsignedTx(banktypes.NewMsgSend(addr, sdk.AccAddress("to"), sdk.NewCoins(sdk.NewInt64Coin("stake", amount))))
+		requireT.NoError(err)
+		return txb
+	}
+
+	requireT.NoError(Sign(context.TODO(), txf, from, send(40), true))
+
+	// 90stake have been spent already
+	txb := send(20)
+	requireT.ErrorIs(Sign(context.TODO(), txf, from, txb, true), keyring.ErrKeyPolicyViolation)
+	sigs, err := txb.GetTx().GetSignaturesV2()
+	requireT.NoError(err)
+	requireT.Len(sigs, 1)
+	requireT.Nil(sigs[0].Data.(*signingtypes.SingleSignatureData).Signature)
+
+	requireT.ErrorIs(Sign(context.TODO(), txf.WithChainID("other-chain"), from, send(1), true), keyring.ErrKeyPolicyViolation)
+
+	txb, err = txf.BuildUnsignedTx(&banktypes.MsgMultiSend{})
+	requireT.NoError(err)
+	requireT.ErrorIs(Sign(context.TODO(), txf, from, txb, true), keyring.ErrKeyPolicyViolation)
+
+	// the amino JSON sign bytes can't be checked
+	requireT.ErrorIs(Sign(context.TODO(), txf.WithSignMode(signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON), from, send(1), true), keyring.ErrKeyPolicyViolation)
+
+	// the keyring records the signing attempts in its directory
+	entries, err := keyring.NewAuditLog(filepath.Join(dir, keyring.AuditLogFileName)).Entries()
+	requireT.NoError(err)
+	requireT.Len(entries, 5)
+	requireT.True(entries[0].Allowed)
+	requireT.Equal(sdk.NewCoins(sdk.NewInt64Coin("stake", 90)), entries[0].Amount)
+	requireT.Equal(addr.String(), entries[0].Address)
+	for _, entry := range entries[1:] {
+		requireT.False(entry.Allowed)
+		requireT.NotEmpty(entry.Reason)
+	}
+}
+
 func testSigners(require *require.Assertions, tr signing.Tx, pks ...cryptotypes.PubKey) []signingtypes.SignatureV2 {
 	sigs, err := tr.GetSignaturesV2()
 	require.Len(sigs, len(pks))
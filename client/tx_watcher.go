@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/proto"
+
+	errorsmod "cosmossdk.io/errors"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// txPollInitialBackoff is the first delay between two queries of a tx
+	// waited for, doubled after each attempt up to txPollMaxBackoff.
+	txPollInitialBackoff = 500 * time.Millisecond
+	txPollMaxBackoff     = 5 * time.Second
+
+	txWatcherSubscriber = "cosmos-sdk-tx-watcher"
+)
+
+// TxResult is the result of a transaction included in a block.
+type TxResult struct {
+	// TxResponse is the response of the transaction, as returned by the tx
+	// query.
+	TxResponse *sdk.TxResponse
+	// Events are the typed events emitted by the transaction. The events
+	// which don't match a registered proto message are left out.
+	Events []proto.Message
+	// Err is the reason for the failure of the transaction, nil if it
+	// succeeded. The errors registered by the modules can be matched with
+	// errors.Is.
+	Err error
+}
+
+// BroadcastTxAndWait broadcasts a transaction synchronously and waits until
+// it is included in a block, see WaitForTx. The result is nil when the
+// transaction is rejected by CheckTx, with the reason in the returned
+// TxResponse.
+func (ctx Context) BroadcastTxAndWait(goCtx context.Context, txBytes []byte) (*sdk.TxResponse, *TxResult, error) {
+	res, err := ctx.BroadcastTxSync(txBytes)
+	if err != nil || res.Code != 0 {
+		return res, nil, err
+	}
+
+	result, err := ctx.WaitForTx(goCtx, cmttypes.Tx(txBytes).Hash())
+	if err != nil {
+		return res, nil, err
+	}
+
+	return result.TxResponse, result, nil
+}
+
+// WaitForTx waits until the transaction with the given hash is included in a
+// block, or the given context is done. It subscribes to the events of the
+// transaction through the websocket of the node when the context has a node
+// URI, and polls the node with an exponential backoff otherwise, or when the
+// subscription fails.
+func (ctx Context) WaitForTx(goCtx context.Context, hash []byte) (*TxResult, error) {
+	events, unsubscribe := ctx.subscribeTx(goCtx, hash)
+	defer unsubscribe()
+
+	backoff := txPollInitialBackoff
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-goCtx.Done():
+			return nil, errTxWaitTimeout(hash, goCtx.Err())
+
+		case _, ok := <-events:
+			if !ok {
+				// the websocket is closed, only poll from now on
+				events = nil
+				continue
+			}
+			// the tx may not be indexed yet, query it as soon as possible
+			backoff = txPollInitialBackoff
+
+		case <-timer.C:
+		}
+
+		result, err := ctx.QueryTxResult(goCtx, hash)
+		if err == nil {
+			return result, nil
+		}
+		if goCtx.Err() != nil {
+			return nil, errTxWaitTimeout(hash, goCtx.Err())
+		}
+		if !isTxNotFound(err) {
+			return nil, err
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(backoff)
+		backoff = min(2*backoff, txPollMaxBackoff)
+	}
+}
+
+// QueryTxResult queries the transaction with the given hash, along with its
+// typed events and the reason for its failure.
+func (ctx Context) QueryTxResult(goCtx context.Context, hash []byte) (*TxResult, error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	resTx, err := node.Tx(goCtx, hash, false)
+	if err != nil {
+		return nil, err
+	}
+	resBlock, err := node.Block(goCtx, &resTx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	txb, err := ctx.TxConfig.TxDecoder()(resTx.Tx)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := txb.(interface{ AsAny() *codectypes.Any })
+	if !ok {
+		return nil, fmt.Errorf("expecting a type implementing AsAny, got: %T", txb)
+	}
+
+	result := &TxResult{
+		TxResponse: sdk.NewResponseResultTx(resTx, p.AsAny(), resBlock.Block.Time.Format(time.RFC3339)),
+	}
+	for _, event := range resTx.TxResult.Events {
+		if msg, err := sdk.ParseTypedEvent(event); err == nil {
+			result.Events = append(result.Events, msg)
+		}
+	}
+	if resTx.TxResult.Code != 0 {
+		result.Err = errorsmod.ABCIError(resTx.TxResult.Codespace, resTx.TxResult.Code, resTx.TxResult.Log)
+	}
+
+	return result, nil
+}
+
+// subscribeTx subscribes to the event of the inclusion of the transaction
+// with the given hash through the websocket of the node. The returned channel
+// is nil when the subscription fails.
+func (ctx Context) subscribeTx(goCtx context.Context, hash []byte) (<-chan coretypes.ResultEvent, func()) {
+	if ctx.NodeURI == "" {
+		return nil, func() {}
+	}
+
+	client, err := NewClientFromNode(ctx.NodeURI)
+	if err != nil {
+		return nil, func() {}
+	}
+	if err := client.Start(); err != nil {
+		return nil, func() {}
+	}
+
+	query := fmt.Sprintf("%s='%s' AND %s='%X'", cmttypes.EventTypeKey, cmttypes.EventTx, cmttypes.TxHashKey, hash)
+	events, err := client.Subscribe(goCtx, txWatcherSubscriber, query)
+	if err != nil {
+		_ = client.Stop()
+		return nil, func() {}
+	}
+
+	return events, func() { _ = client.Stop() }
+}
+
+// isTxNotFound returns whether the error of a tx query is due to the tx not
+// being indexed yet.
+func isTxNotFound(err error) bool {
+	return strings.Contains(err.Error(), "not found")
+}
+
+func errTxWaitTimeout(hash []byte, err error) error {
+	return fmt.Errorf("timed out waiting for tx %X to be included in a block: %w", hash, err)
+}
@@ -0,0 +1,123 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/rpc/client/mock"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/group"
+)
+
+// txWatcherClient is a mock CometBFT client indexing its txs after a number
+// of queries.
+type txWatcherClient struct {
+	mock.Client
+	txResult   abci.ExecTxResult
+	tx         cmttypes.Tx
+	notIndexed int32
+	queries    *atomic.Int32
+}
+
+func (c txWatcherClient) BroadcastTxSync(_ context.Context, tx cmttypes.Tx) (*coretypes.ResultBroadcastTx, error) {
+	return &coretypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+}
+
+func (c txWatcherClient) Tx(_ context.Context, hash []byte, _ bool) (*coretypes.ResultTx, error) {
+	if c.queries.Add(1) <= c.notIndexed {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+
+	return &coretypes.ResultTx{Hash: hash, Height: 10, Tx: c.tx, TxResult: c.txResult}, nil
+}
+
+func (c txWatcherClient) Block(_ context.Context, height *int64) (*coretypes.ResultBlock, error) {
+	return &coretypes.ResultBlock{Block: &cmttypes.Block{Header: cmttypes.Header{Height: *height, Time: time.Unix(1700000000, 0)}}}, nil
+}
+
+func TestWaitForTx(t *testing.T) {
+	encCfg := moduletestutil.MakeTestEncodingConfig(bank.AppModuleBasic{})
+	txBuilder := encCfg.TxConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(sdk.AccAddress("from"), sdk.AccAddress("to"), sdk.NewCoins(sdk.NewInt64Coin("stake", 10)))))
+	txBytes, err := encCfg.TxConfig.TxEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+	hash := cmttypes.Tx(txBytes).Hash()
+
+	event, err := sdk.TypedEventToEvent(&group.EventCreateGroup{GroupId: 1})
+	require.NoError(t, err)
+	events := []abci.Event{abci.Event(event), {Type: "message", Attributes: []abci.EventAttribute{{Key: "action", Value: "send"}}}}
+
+	newCtx := func(txResult abci.ExecTxResult, notIndexed int32) (client.Context, *atomic.Int32) {
+		queries := &atomic.Int32{}
+		return client.Context{}.
+			WithTxConfig(encCfg.TxConfig).
+			WithClient(txWatcherClient{tx: txBytes, txResult: txResult, notIndexed: notIndexed, queries: queries}), queries
+	}
+
+	t.Run("success after polling", func(t *testing.T) {
+		clientCtx, queries := newCtx(abci.ExecTxResult{GasUsed: 100, Events: events}, 2)
+		result, err := clientCtx.WaitForTx(context.Background(), hash)
+		require.NoError(t, err)
+		require.Equal(t, int32(3), queries.Load())
+		require.NoError(t, result.Err)
+		require.Equal(t, int64(10), result.TxResponse.Height)
+		require.Equal(t, fmt.Sprintf("%X", hash), result.TxResponse.TxHash)
+		require.Equal(t, "2023-11-14T22:13:20Z", result.TxResponse.Timestamp)
+		require.NotNil(t, result.TxResponse.Tx)
+		require.Len(t, result.Events, 1)
+		require.Equal(t, &group.EventCreateGroup{GroupId: 1}, result.Events[0])
+	})
+
+	t.Run("failure reason", func(t *testing.T) {
+		clientCtx, _ := newCtx(abci.ExecTxResult{
+			Code:      sdkerrors.ErrInsufficientFunds.ABCICode(),
+			Codespace: sdkerrors.ErrInsufficientFunds.Codespace(),
+			Log:       "spendable balance 0stake is smaller than 10stake",
+		}, 0)
+		result, err := clientCtx.WaitForTx(context.Background(), hash)
+		require.NoError(t, err)
+		require.True(t, errors.Is(result.Err, sdkerrors.ErrInsufficientFunds))
+		require.ErrorContains(t, result.Err, "spendable balance")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		clientCtx, _ := newCtx(abci.ExecTxResult{}, 1000)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := clientCtx.WaitForTx(ctx, hash)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("broadcast with wait", func(t *testing.T) {
+		clientCtx, _ := newCtx(abci.ExecTxResult{GasUsed: 100}, 0)
+		clientCtx = clientCtx.WithBroadcastMode(flags.BroadcastSync)
+		res, err := clientCtx.BroadcastTx(txBytes)
+		require.NoError(t, err)
+		require.Zero(t, res.Height)
+
+		res, err = clientCtx.WithWait(true, time.Second).BroadcastTx(txBytes)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), res.Height)
+		require.Equal(t, int64(100), res.GasUsed)
+
+		res, result, err := clientCtx.BroadcastTxAndWait(context.Background(), txBytes)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), res.Height)
+		require.Equal(t, res, result.TxResponse)
+	})
+}
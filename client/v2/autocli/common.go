@@ -11,6 +11,7 @@ import (
 	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
 	"cosmossdk.io/client/v2/internal/flags"
 	"cosmossdk.io/client/v2/internal/util"
+	"cosmossdk.io/core/appmodule"
 
 	"github.com/cosmos/cosmos-sdk/client"
 )
@@ -126,11 +127,12 @@ func (b *Builder) enhanceCommandCommon(
 
 	for moduleName, modOpts := range moduleOptions {
 		hasModuleOptions := modOpts != nil
+		builder := b.moduleBuilder(appOptions.Modules[moduleName])
 
 		// if we have an existing command skip adding one here
 		if subCmd := findSubCommand(cmd, moduleName); subCmd != nil {
 			if hasModuleOptions { // check if we need to enhance the existing command
-				if err := enhanceCustomCmd(b, subCmd, cmdType, modOpts); err != nil {
+				if err := enhanceCustomCmd(builder, subCmd, cmdType, modOpts); err != nil {
 					return err
 				}
 			}
@@ -143,14 +145,14 @@ func (b *Builder) enhanceCommandCommon(
 			// Custom may not be called the same as its module, so we need to have a separate check here
 			if subCmd := findSubCommand(cmd, custom.Name()); subCmd != nil {
 				if hasModuleOptions { // check if we need to enhance the existing command
-					if err := enhanceCustomCmd(b, subCmd, cmdType, modOpts); err != nil {
+					if err := enhanceCustomCmd(builder, subCmd, cmdType, modOpts); err != nil {
 						return err
 					}
 				}
 				continue
 			}
 			if hasModuleOptions { // check if we need to enhance the new command
-				if err := enhanceCustomCmd(b, custom, cmdType, modOpts); err != nil {
+				if err := enhanceCustomCmd(builder, custom, cmdType, modOpts); err != nil {
 					return err
 				}
 			}
@@ -166,11 +168,11 @@ func (b *Builder) enhanceCommandCommon(
 
 		switch cmdType {
 		case queryCmdType:
-			if err := enhanceQuery(b, moduleName, cmd, modOpts); err != nil {
+			if err := enhanceQuery(builder, moduleName, cmd, modOpts); err != nil {
 				return err
 			}
 		case msgCmdType:
-			if err := enhanceMsg(b, moduleName, cmd, modOpts); err != nil {
+			if err := enhanceMsg(builder, moduleName, cmd, modOpts); err != nil {
 				return err
 			}
 		}
@@ -179,6 +181,20 @@ func (b *Builder) enhanceCommandCommon(
 	return nil
 }
 
+// moduleBuilder returns the builder of the commands of a module, with the
+// custom flag types of the module if it has any.
+func (b *Builder) moduleBuilder(module appmodule.AppModule) *Builder {
+	flagTypesModule, ok := module.(HasCustomFlagTypes)
+	if !ok {
+		return b
+	}
+
+	builder := *b
+	builder.Builder = *b.Builder.Clone()
+	flagTypesModule.DefineFlagTypes(&builder.Builder)
+	return &builder
+}
+
 // enhanceQuery enhances the provided query command with the autocli commands for a module.
 func enhanceQuery(builder *Builder, moduleName string, cmd *cobra.Command, modOpts *autocliv1.ModuleOptions) error {
 	if queryCmdDesc := modOpts.Query; queryCmdDesc != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"strconv"
 
 	cosmos_proto "github.com/cosmos/cosmos-proto"
@@ -112,6 +113,16 @@ func (b *Builder) DefineScalarFlagType(scalarName string, flagType Type) {
 	b.scalarFlagTypes[scalarName] = flagType
 }
 
+// Clone returns a copy of the builder, whose custom flag types can be defined
+// without affecting the original builder, e.g. the flag types of a module.
+func (b *Builder) Clone() *Builder {
+	b.init()
+	c := *b
+	c.messageFlagTypes = maps.Clone(b.messageFlagTypes)
+	c.scalarFlagTypes = maps.Clone(b.scalarFlagTypes)
+	return &c
+}
+
 // AddMessageFlags adds flags for each field in the message to the flag set.
 func (b *Builder) AddMessageFlags(ctx *context.Context, flagSet *pflag.FlagSet, messageType protoreflect.MessageType, commandOptions *autocliv1.RpcCommandOptions) (*MessageBinder, error) {
 	return b.addMessageFlags(ctx, flagSet, messageType, commandOptions, namingOptions{})
@@ -251,6 +262,12 @@ func (b *Builder) addMessageFlags(ctx *context.Context, flagSet *pflag.FlagSet,
 			return nil, err
 		}
 
+		// repeated messages can also be read from a file
+		if fileValue := b.addMessageListFileFlag(flagSet, field, name); fileValue != nil {
+			flagOptsByFlagName[name+FileFlagSuffix] = flagOpts
+			hasValue = appendListValue{hasValue, fileValue}
+		}
+
 		messageBinder.flagBindings = append(messageBinder.flagBindings, fieldBinding{
 			hasValue: hasValue,
 			field:    field,
@@ -0,0 +1,184 @@
+package flag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"sigs.k8s.io/yaml"
+
+	"cosmossdk.io/client/v2/internal/util"
+	"cosmossdk.io/core/address"
+)
+
+// FileFlagSuffix is the suffix of the flags reading the repeated message
+// fields from a file, e.g. --validators-file for a validators field.
+const FileFlagSuffix = "-file"
+
+// addMessageListFileFlag adds a flag reading the elements of a repeated
+// message field from a JSON or YAML file, for the fields whose messages don't
+// have a custom flag type. It returns nil if the field isn't such a field.
+func (b *Builder) addMessageListFileFlag(flagSet *pflag.FlagSet, field protoreflect.FieldDescriptor, name string) HasValue {
+	if !field.IsList() || field.Kind() != protoreflect.MessageKind {
+		return nil
+	}
+	if typ, ok := b.resolveFlagType(field).(compositeListType); !ok {
+		return nil
+	} else if _, ok := typ.simpleType.(jsonMessageFlagType); !ok {
+		return nil
+	}
+
+	val := &messageListFileValue{
+		builder:     b,
+		messageType: util.ResolveMessageType(b.TypeResolver, field.Message()),
+	}
+	flagSet.AddFlag(&pflag.Flag{
+		Name:  name + FileFlagSuffix,
+		Usage: fmt.Sprintf("JSON or YAML file with the list of %s, appended to the ones of --%s", name, name),
+		Value: val,
+	})
+
+	return val
+}
+
+// messageListFileValue reads a list of messages from a JSON or YAML file.
+type messageListFileValue struct {
+	builder     *Builder
+	messageType protoreflect.MessageType
+
+	filename string
+	messages []protoreflect.Message
+}
+
+func (m *messageListFileValue) Get(mutable protoreflect.Value) (protoreflect.Value, error) {
+	list := mutable.List()
+	for _, msg := range m.messages {
+		list.Append(protoreflect.ValueOfMessage(msg))
+	}
+	return mutable, nil
+}
+
+func (m *messageListFileValue) String() string {
+	return m.filename
+}
+
+func (m *messageListFileValue) Set(filename string) error {
+	bz, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml":
+		if bz, err = yaml.YAMLToJSON(bz); err != nil {
+			return fmt.Errorf("%s: invalid YAML: %w", filename, err)
+		}
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(bz, &items); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return fmt.Errorf("%s: expected a list of %s, got a JSON %s", filename, m.messageType.Descriptor().FullName(), typeErr.Value)
+		}
+		return fmt.Errorf("%s: invalid JSON: %w", filename, err)
+	}
+
+	unmarshalOptions := protojson.UnmarshalOptions{Resolver: m.builder.TypeResolver}
+	messages := make([]protoreflect.Message, len(items))
+	for i, item := range items {
+		msg := m.messageType.New()
+		if err := unmarshalOptions.Unmarshal(item, msg.Interface()); err != nil {
+			return fmt.Errorf("%s: item %d: %w", filename, i, err)
+		}
+		if err := m.builder.validateMessage(msg, ""); err != nil {
+			return fmt.Errorf("%s: item %d: %w", filename, i, err)
+		}
+
+		messages[i] = msg
+	}
+
+	m.filename = filename
+	m.messages = messages
+	return nil
+}
+
+func (m *messageListFileValue) Type() string {
+	return "file"
+}
+
+// appendListValue binds the values of several flags to the same repeated
+// field, appending their elements in order.
+type appendListValue []HasValue
+
+func (a appendListValue) Get(mutable protoreflect.Value) (protoreflect.Value, error) {
+	for _, val := range a {
+		var err error
+		if mutable, err = val.Get(mutable); err != nil {
+			return mutable, err
+		}
+	}
+	return mutable, nil
+}
+
+// validateMessage validates the address fields of a message read from a
+// file, recursively, with the address codecs of the builder.
+func (b *Builder) validateMessage(msg protoreflect.Message, path string) (err error) {
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		fieldPath := path + string(field.Name())
+		switch {
+		case field.IsMap():
+			return true
+
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len() && err == nil; i++ {
+				err = b.validateValue(field, list.Get(i), fmt.Sprintf("%s[%d]", fieldPath, i))
+			}
+
+		default:
+			err = b.validateValue(field, value, fieldPath)
+		}
+
+		return err == nil
+	})
+
+	return err
+}
+
+func (b *Builder) validateValue(field protoreflect.FieldDescriptor, value protoreflect.Value, path string) error {
+	switch field.Kind() {
+	case protoreflect.MessageKind:
+		return b.validateMessage(value.Message(), path+".")
+
+	case protoreflect.StringKind:
+		scalar, ok := GetScalarType(field)
+		if !ok {
+			return nil
+		}
+
+		var codec address.Codec
+		switch scalar {
+		case AddressStringScalarType:
+			codec = b.AddressCodec
+		case ValidatorAddressStringScalarType:
+			codec = b.ValidatorAddressCodec
+		case ConsensusAddressStringScalarType:
+			codec = b.ConsensusAddressCodec
+		}
+		if codec == nil || value.String() == "" {
+			return nil
+		}
+		if _, err := codec.StringToBytes(value.String()); err != nil {
+			return fmt.Errorf("field %s: invalid %s %q: %w", path, scalar, value.String(), err)
+		}
+	}
+
+	return nil
+}
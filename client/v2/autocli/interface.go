@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
+	"cosmossdk.io/client/v2/autocli/flag"
 	"cosmossdk.io/core/appmodule"
 )
 
@@ -30,3 +31,14 @@ type HasCustomTxCommand interface {
 	// GetTxCmd returns a custom cobra tx command for this module.
 	GetTxCmd() *cobra.Command
 }
+
+// HasCustomFlagTypes is an AppModule extension interface for declaring custom
+// flag types for the messages and scalars of the module's autocli commands.
+type HasCustomFlagTypes interface {
+	appmodule.AppModule
+
+	// DefineFlagTypes defines the custom flag types of the module with
+	// DefineMessageFlagType and DefineScalarFlagType. They only apply to the
+	// commands of this module.
+	DefineFlagTypes(builder *flag.Builder)
+}
@@ -1,14 +1,19 @@
 package autocli
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -18,6 +23,8 @@ import (
 	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
 	queryv1beta1 "cosmossdk.io/api/cosmos/base/query/v1beta1"
 	basev1beta1 "cosmossdk.io/api/cosmos/base/v1beta1"
+	groupv1 "cosmossdk.io/api/cosmos/group/v1"
+	"cosmossdk.io/client/v2/autocli/flag"
 	"cosmossdk.io/client/v2/internal/testpb"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -488,6 +495,122 @@ func TestJSONParsing(t *testing.T) {
 	assert.DeepEqual(t, fixture.conn.lastRequest, fixture.conn.lastResponse.(*testpb.EchoResponse).Request, protocmp.Transform())
 }
 
+func TestMessageListFile(t *testing.T) {
+	fixture := initFixture(t)
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "messages.json")
+	assert.NilError(t, os.WriteFile(jsonFile, []byte(`[{"bar":"a"},{"baz":2}]`), 0o600))
+	yamlFile := filepath.Join(dir, "messages.yaml")
+	assert.NilError(t, os.WriteFile(yamlFile, []byte("- bar: b\n  baz: 3\n"), 0o600))
+
+	_, err := runCmd(fixture, buildModuleQueryCommand,
+		"echo",
+		"1", "abc", "1foo",
+		"--some-messages", `{"bar":"flag"}`,
+		"--some-messages-file", jsonFile,
+	)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fixture.conn.lastRequest.(*testpb.EchoRequest).SomeMessages, []*testpb.AMessage{
+		{Bar: "flag"}, {Bar: "a"}, {Baz: 2},
+	}, protocmp.Transform())
+
+	_, err = runCmd(fixture, buildModuleQueryCommand,
+		"echo",
+		"1", "abc", "1foo",
+		"--some-messages-file", yamlFile,
+	)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fixture.conn.lastRequest.(*testpb.EchoRequest).SomeMessages, []*testpb.AMessage{
+		{Bar: "b", Baz: 3},
+	}, protocmp.Transform())
+
+	invalidFile := filepath.Join(dir, "invalid.json")
+	assert.NilError(t, os.WriteFile(invalidFile, []byte(`[{"bar":"a"},{"foo":1}]`), 0o600))
+	_, err = runCmd(fixture, buildModuleQueryCommand,
+		"echo",
+		"1", "abc", "1foo",
+		"--some-messages-file", invalidFile,
+	)
+	assert.ErrorContains(t, err, "item 1: proto:")
+	assert.ErrorContains(t, err, `unknown field "foo"`)
+
+	assert.NilError(t, os.WriteFile(invalidFile, []byte(`{"bar":"a"}`), 0o600))
+	_, err = runCmd(fixture, buildModuleQueryCommand,
+		"echo",
+		"1", "abc", "1foo",
+		"--some-messages-file", invalidFile,
+	)
+	assert.ErrorContains(t, err, "expected a list of testpb.AMessage")
+
+	// the addresses of the messages are validated
+	flagSet := pflag.NewFlagSet("create-group", pflag.ContinueOnError)
+	ctx := context.Background()
+	binder, err := fixture.b.AddMessageFlags(&ctx, flagSet, (&groupv1.MsgCreateGroup{}).ProtoReflect().Type(), &autocliv1.RpcCommandOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, os.WriteFile(yamlFile, []byte("- address: cosmos1y74p8wyy4enfhfn342njve6cjmj5c8dtl6emdk\n  weight: \"1\"\n"), 0o600))
+	assert.NilError(t, flagSet.Set("members-file", yamlFile))
+	msg, err := binder.BuildMessage(nil)
+	assert.NilError(t, err)
+	assert.Equal(t, msg.Interface().(*groupv1.MsgCreateGroup).Members[0].Weight, "1")
+
+	assert.NilError(t, os.WriteFile(jsonFile, []byte(`[{"address":"cosmosvaloper1tnh2q55v8wyygtt9srz5safamzdengsn9dsd7z"}]`), 0o600))
+	assert.ErrorContains(t, flagSet.Set("members-file", jsonFile), "item 0: field address: invalid cosmos.AddressString")
+}
+
+type flagTypesModule struct{}
+
+func (flagTypesModule) IsOnePerModuleType() {}
+func (flagTypesModule) IsAppModule()        {}
+
+func (flagTypesModule) DefineFlagTypes(builder *flag.Builder) {
+	builder.DefineMessageFlagType("testpb.AMessage", aMessageFlagType{})
+}
+
+// aMessageFlagType parses a testpb.AMessage from a bar:baz string.
+type aMessageFlagType struct{}
+
+func (aMessageFlagType) NewValue(*context.Context, *flag.Builder) flag.Value {
+	return &aMessageFlagValue{}
+}
+func (aMessageFlagType) DefaultValue() string { return "" }
+
+type aMessageFlagValue struct{ msg *testpb.AMessage }
+
+func (v *aMessageFlagValue) Get(protoreflect.Value) (protoreflect.Value, error) {
+	return protoreflect.ValueOfMessage(v.msg.ProtoReflect()), nil
+}
+func (v *aMessageFlagValue) String() string { return "" }
+func (v *aMessageFlagValue) Type() string   { return "bar:baz" }
+
+func (v *aMessageFlagValue) Set(s string) error {
+	bar, baz, _ := strings.Cut(s, ":")
+	i, err := strconv.ParseInt(baz, 10, 32)
+	v.msg = &testpb.AMessage{Bar: bar, Baz: int32(i)}
+	return err
+}
+
+func TestModuleFlagTypes(t *testing.T) {
+	fixture := initFixture(t)
+
+	b := fixture.b.moduleBuilder(flagTypesModule{})
+	cmd := topLevelCmd(context.WithValue(context.Background(), client.ClientContextKey, &fixture.clientCtx), "test", "")
+	assert.NilError(t, b.AddQueryServiceCommands(cmd, testCmdDesc))
+	cmd.SetArgs([]string{"echo", "1", "abc", "1foo", "--some-messages", "a:1", "--some-messages", "b:2"})
+	cmd.SetOut(&bytes.Buffer{})
+	assert.NilError(t, cmd.Execute())
+	assert.DeepEqual(t, fixture.conn.lastRequest.(*testpb.EchoRequest).SomeMessages, []*testpb.AMessage{
+		{Bar: "a", Baz: 1}, {Bar: "b", Baz: 2},
+	}, protocmp.Transform())
+
+	// the flag types of the module don't leak to the other modules
+	echoCmd, _, err := cmd.Find([]string{"echo"})
+	assert.NilError(t, err)
+	assert.Assert(t, echoCmd.Flags().Lookup("some-messages-file") == nil)
+	_, err = runCmd(fixture, buildModuleQueryCommand, "echo", "1", "abc", "1foo", "--some-messages", "a:1")
+	assert.ErrorContains(t, err, "invalid argument")
+	assert.Equal(t, fixture.b.moduleBuilder(nil), fixture.b)
+}
+
 func TestOptions(t *testing.T) {
 	fixture := initFixture(t)
 
@@ -0,0 +1,218 @@
+package keyring
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AuditLogFileName is the name of the signing audit log in the keyring
+// directory.
+const AuditLogFileName = "signing-audit.log"
+
+// AuditEntry records a signing attempt, allowed or rejected by the policy of
+// its key. Every entry commits to the previous one through PrevHash, so that
+// editing or removing an entry breaks the chain of the following ones.
+type AuditEntry struct {
+	Time        time.Time `json:"time"`
+	Key         string    `json:"key"`
+	Address     string    `json:"address"`
+	ChainID     string    `json:"chain_id"`
+	MsgTypeURLs []string  `json:"msg_type_urls"`
+	Amount      sdk.Coins `json:"amount"`
+	Allowed     bool      `json:"allowed"`
+	Reason      string    `json:"reason,omitempty"`
+	PrevHash    string    `json:"prev_hash"`
+	Hash        string    `json:"hash"`
+}
+
+// hash returns the hex encoded SHA-256 of the entry without its hash, which
+// includes the hash of the previous entry.
+func (e AuditEntry) hash() (string, error) {
+	e.Hash = ""
+	bz, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(bz)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// AuditLog is a local append-only log of the signing attempts, stored as a
+// hash chain of JSON lines. It detects tampering, but an attacker able to
+// rewrite the whole file can still forge a consistent chain, so it should be
+// shipped to a remote store for stronger guarantees.
+type AuditLog struct {
+	path string
+	mu   sync.Mutex
+
+	// lastHash is the hash of the last entry of the log when it was size
+	// bytes long, as last verified or written by this instance.
+	lastHash string
+	size     int64
+	loaded   bool
+}
+
+// NewAuditLog returns the audit log stored at path, which is created on the
+// first append.
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{path: path}
+}
+
+// Path returns the path of the log file.
+func (l *AuditLog) Path() string {
+	return l.path
+}
+
+// Append chains entry to the last entry of the log and writes it. It returns
+// the written entry. The existing entries are verified on the first append,
+// and again only if the log has been changed by another writer since.
+func (l *AuditLog) Append(entry AuditEntry) (AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.loadLastHash(); err != nil {
+		return AuditEntry{}, err
+	}
+
+	var err error
+	entry.PrevHash = l.lastHash
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	entry.Time = entry.Time.UTC()
+	if entry.Hash, err = entry.hash(); err != nil {
+		return AuditEntry{}, err
+	}
+
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		return AuditEntry{}, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return AuditEntry{}, err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return AuditEntry{}, err
+	}
+	defer f.Close()
+
+	line := append(bz, '\n')
+	if _, err := f.Write(line); err != nil {
+		return AuditEntry{}, err
+	}
+	if err := f.Sync(); err != nil {
+		return AuditEntry{}, err
+	}
+
+	l.lastHash = entry.Hash
+	l.size += int64(len(line))
+
+	return entry, nil
+}
+
+// loadLastHash verifies the log and loads the hash of its last entry, unless
+// its size hasn't changed since it was last verified or written.
+func (l *AuditLog) loadLastHash() error {
+	info, err := os.Stat(l.path)
+	if os.IsNotExist(err) {
+		l.lastHash, l.size, l.loaded = "", 0, true
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if l.loaded && info.Size() == l.size {
+		return nil
+	}
+
+	entries, err := l.entries()
+	if err != nil {
+		return err
+	}
+
+	l.lastHash = ""
+	if len(entries) > 0 {
+		l.lastHash = entries[len(entries)-1].Hash
+	}
+	l.size, l.loaded = info.Size(), true
+
+	return nil
+}
+
+// Entries returns the entries of the log, or an ErrAuditLogTampered error if
+// they don't match their hash chain.
+func (l *AuditLog) Entries() ([]AuditEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.entries()
+}
+
+func (l *AuditLog) entries() ([]AuditEntry, error) {
+	f, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		entries  []AuditEntry
+		prevHash string
+	)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, errors.Wrapf(ErrAuditLogTampered, "line %d: %s", line, err)
+		}
+		hash, err := entry.hash()
+		if err != nil {
+			return nil, err
+		}
+		if entry.PrevHash != prevHash || entry.Hash != hash {
+			return nil, errors.Wrapf(ErrAuditLogTampered, "line %d", line)
+		}
+
+		entries = append(entries, entry)
+		prevHash = entry.Hash
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Spent returns the amount of the signing attempts allowed since the given
+// time for the key of the given address, whatever its name at the time.
+func (l *AuditLog) Spent(address string, since time.Time) (sdk.Coins, error) {
+	entries, err := l.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	spent := sdk.NewCoins()
+	for _, entry := range entries {
+		if entry.Address == address && entry.Allowed && !entry.Time.Before(since) {
+			spent = spent.Add(entry.Amount...)
+		}
+	}
+
+	return spent, nil
+}
@@ -0,0 +1,82 @@
+package keyring
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring-test", AuditLogFileName)
+	log := NewAuditLog(path)
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	now := time.Now()
+	appendEntry := func(address string, at time.Time, amount int64, allowed bool) AuditEntry {
+		entry, err := log.Append(AuditEntry{
+			Time:        at,
+			Key:         "relayer",
+			Address:     address,
+			ChainID:     "test-chain",
+			MsgTypeURLs: []string{"/cosmos.bank.v1beta1.MsgSend"},
+			Amount:      sdk.NewCoins(sdk.NewInt64Coin("stake", amount)),
+			Allowed:     allowed,
+		})
+		require.NoError(t, err)
+		return entry
+	}
+	first := appendEntry("addr1", now.Add(-25*time.Hour), 10, true)
+	require.Empty(t, first.PrevHash)
+	second := appendEntry("addr1", now.Add(-time.Hour), 20, true)
+	require.Equal(t, first.Hash, second.PrevHash)
+	appendEntry("addr1", now, 40, false)
+	appendEntry("addr2", now, 80, true)
+
+	entries, err = log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	require.Equal(t, second, entries[1])
+
+	spent, err := log.Spent("addr1", now.Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 20)), spent)
+
+	// the appends of another writer are chained to
+	other := NewAuditLog(path)
+	otherEntry, err := other.Append(AuditEntry{Key: "relayer"})
+	require.NoError(t, err)
+	require.Equal(t, entries[3].Hash, otherEntry.PrevHash)
+	last := appendEntry("addr2", now, 1, false)
+	require.Equal(t, otherEntry.Hash, last.PrevHash)
+	entries, err = log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// editing an entry breaks the chain
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(bz), `"amount":"20"`, `"amount":"2"`, 1)), 0o600))
+	_, err = log.Entries()
+	require.ErrorIs(t, err, ErrAuditLogTampered)
+	_, err = log.Append(AuditEntry{Key: "relayer"})
+	require.ErrorIs(t, err, ErrAuditLogTampered)
+
+	// so does removing one
+	lines := strings.SplitAfter(string(bz), "\n")
+	require.NoError(t, os.WriteFile(path, []byte(lines[0]+strings.Join(lines[2:], "")), 0o600))
+	_, err = log.Entries()
+	require.ErrorIs(t, err, ErrAuditLogTampered)
+}
@@ -39,4 +39,13 @@
 //		be unlocked and it should be used only for testing purposes.
 //	memory	Same instance as returned by NewInMemory. This backend uses a transient storage. Keys
 //		are discarded when the process terminates or the type instance is garbage collected.
+//	remote	This backend only stores references to keys held by remote signers, e.g. in front
+//		of an HSM or a KMS, added with SaveRemoteKey. Their signatures are delegated to the
+//		signers over the RemoteSigner gRPC service. It doesn't prompt for a password, as it
+//		stores no private keys. Remote keys can be added to the other backends as well.
+//
+// Keys may be restricted with a KeyPolicy, set with SetPolicy, to the transactions of some
+// message types and chain IDs, up to a daily amount. The keyring enforces the policies before
+// signing, and records every signing attempt in a hash-chained AuditLog. The keys with a policy
+// only sign SIGN_MODE_DIRECT transactions, whose sign bytes the keyring decodes to check them.
 package keyring
@@ -35,4 +35,24 @@ var (
 	ErrLegacyToRecord = errors.New("unable to convert LegacyInfo to Record")
 	// ErrUnknownLegacyType is raised when a LegacyInfo type is unknown.
 	ErrUnknownLegacyType = errors.New("unknown LegacyInfo type")
+	// ErrInvalidKeyShare is raised when saving a malformed FROST key share.
+	ErrInvalidKeyShare = errors.New("invalid FROST key share")
+	// ErrNotKeyShare is raised when a record doesn't hold a FROST key share.
+	ErrNotKeyShare = errors.New("not a FROST key share")
+	// ErrRemoteSigner is raised when a remote signer can't be reached or fails.
+	ErrRemoteSigner = errors.New("remote signer error")
+	// ErrRemoteInvalidSignature is raised when a remote signer returns an
+	// invalid signature.
+	ErrRemoteInvalidSignature = errors.New("remote signer returned an invalid signature")
+	// ErrLocalKeyRemoteBackend is raised when storing a private key in the
+	// remote backend, which only stores references to keys held elsewhere.
+	ErrLocalKeyRemoteBackend = errors.New("the remote keyring backend can't store private keys")
+	// ErrInvalidKeyPolicy is raised when setting a malformed key policy.
+	ErrInvalidKeyPolicy = errors.New("invalid key policy")
+	// ErrKeyPolicyViolation is raised when a transaction breaks the policy of
+	// its signing key.
+	ErrKeyPolicyViolation = errors.New("key policy violation")
+	// ErrAuditLogTampered is raised when the signing audit log doesn't match
+	// its hash chain.
+	ErrAuditLogTampered = errors.New("signing audit log has been tampered with")
 )
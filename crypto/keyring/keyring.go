@@ -21,6 +21,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/frost"
 	"github.com/cosmos/cosmos-sdk/crypto/ledger"
 	"github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -36,12 +37,14 @@ const (
 	BackendPass    = "pass"
 	BackendTest    = "test"
 	BackendMemory  = "memory"
+	BackendRemote  = "remote"
 )
 
 const (
-	keyringFileDirName = "keyring-file"
-	keyringTestDirName = "keyring-test"
-	passKeyringPrefix  = "keyring-%s"
+	keyringFileDirName   = "keyring-file"
+	keyringTestDirName   = "keyring-test"
+	keyringRemoteDirName = "keyring-remote"
+	passKeyringPrefix    = "keyring-%s"
 
 	// temporary pass phrase for exporting a key during a key rename
 	passPhrase = "temp"
@@ -56,7 +59,7 @@ var (
 
 // Keyring exposes operations over a backend supported by github.com/99designs/keyring.
 type Keyring interface {
-	// Get the backend type used in the keyring config: "file", "os", "kwallet", "pass", "test", "memory".
+	// Get the backend type used in the keyring config: "file", "os", "kwallet", "pass", "test", "memory", "remote".
 	Backend() string
 	// List all keys.
 	List() ([]*Record, error)
@@ -90,12 +93,25 @@ type Keyring interface {
 	// SaveLedgerKey retrieves a public key reference from a Ledger device and persists it.
 	SaveLedgerKey(uid string, algo SignatureAlgo, hrp string, coinType, account, index uint32) (*Record, error)
 
+	// SaveRemoteKey retrieves the public key of the key keyID from the remote
+	// signer at endpoint and persists a reference to it. The signatures of the
+	// key are then delegated to the remote signer.
+	SaveRemoteKey(uid, endpoint, keyID string) (*Record, error)
+
 	// SaveOfflineKey stores a public key and returns the persisted Info structure.
 	SaveOfflineKey(uid string, pubkey types.PubKey) (*Record, error)
 
 	// SaveMultisig stores and returns a new multsig (offline) key reference.
 	SaveMultisig(uid string, pubkey types.PubKey) (*Record, error)
 
+	// SaveKeyShare stores a FROST threshold key share as a local key under the
+	// address of its group, and returns the persisted record.
+	SaveKeyShare(uid string, share *frost.KeyShare) (*Record, error)
+
+	// SetPolicy sets the policy restricting the transactions signed with the
+	// key, or removes it if policy is nil or empty.
+	SetPolicy(uid string, policy *KeyPolicy) (*Record, error)
+
 	Signer
 
 	Importer
@@ -158,7 +174,7 @@ func NewInMemoryWithKeyring(kr keyring.Keyring, cdc codec.Codec, opts ...Option)
 
 // New creates a new instance of a keyring.
 // Keyring options can be applied when generating the new instance.
-// Available backends are "os", "file", "kwallet", "memory", "pass", "test".
+// Available backends are "os", "file", "kwallet", "memory", "pass", "test", "remote".
 func newKeyringGeneric(
 	appName, backend, rootDir string, userInput io.Reader, cdc codec.Codec, opts ...Option,
 ) (Keyring, error) {
@@ -180,6 +196,8 @@ func newKeyringGeneric(
 		db, err = keyring.Open(newKWalletBackendKeyringConfig(appName, rootDir, userInput))
 	case BackendPass:
 		db, err = keyring.Open(newPassBackendKeyringConfig(appName, rootDir, userInput))
+	case BackendRemote:
+		db, err = keyring.Open(newRemoteBackendKeyringConfig(appName, rootDir))
 	default:
 		return nil, errorsmod.Wrap(ErrUnknownBacked, backend)
 	}
@@ -188,6 +206,11 @@ func newKeyringGeneric(
 		return nil, err
 	}
 
+	// the keyrings stored in a directory keep their audit log alongside
+	if rootDir != "" {
+		opts = append([]Option{WithAuditLog(NewAuditLog(filepath.Join(rootDir, AuditLogFileName)))}, opts...)
+	}
+
 	return newKeystore(db, cdc, backend, opts...), nil
 }
 
@@ -373,6 +396,13 @@ func (ks keystore) Sign(uid string, msg []byte, signMode signing.SignMode) ([]by
 		return nil, nil, err
 	}
 
+	// multi and offline records can't sign
+	if k.GetMulti() == nil && k.GetOffline() == nil {
+		if err := ks.checkPolicy(k, msg, signMode); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	switch {
 	case k.GetLocal() != nil:
 		priv, err := extractPrivKeyFromLocal(k.GetLocal())
@@ -390,6 +420,9 @@ func (ks keystore) Sign(uid string, msg []byte, signMode signing.SignMode) ([]by
 	case k.GetLedger() != nil:
 		return SignWithLedger(k, msg, signMode)
 
+	case k.GetRemote() != nil:
+		return ks.signWithRemote(k, msg, signMode)
+
 		// multi or offline record
 	default:
 		pub, err := k.GetPubKey()
@@ -437,6 +470,14 @@ func (ks keystore) SaveMultisig(uid string, pubkey types.PubKey) (*Record, error
 	return ks.writeMultisigKey(uid, pubkey)
 }
 
+func (ks keystore) SaveKeyShare(uid string, share *frost.KeyShare) (*Record, error) {
+	if err := share.Validate(); err != nil {
+		return nil, errorsmod.Wrap(ErrInvalidKeyShare, err.Error())
+	}
+
+	return ks.writeLocalKey(uid, share)
+}
+
 func (ks keystore) SaveOfflineKey(uid string, pubkey types.PubKey) (*Record, error) {
 	return ks.writeOfflineKey(uid, pubkey)
 }
@@ -461,6 +502,22 @@ func (ks keystore) Rename(oldName, newName string) error {
 		return errorsmod.Wrap(ErrKeyAlreadyExists, fmt.Sprintf("rename failed, %s", newName))
 	}
 
+	k, err := ks.Key(oldName)
+	if err != nil {
+		return err
+	}
+
+	// the records without a private key, e.g. remote or ledger ones, only hold
+	// public references which are moved as is
+	if k.GetLocal() == nil {
+		if err := ks.Delete(oldName); err != nil {
+			return err
+		}
+
+		k.Name = newName
+		return ks.writeRecord(k)
+	}
+
 	armor, err := ks.ExportPrivKeyArmor(oldName, passPhrase)
 	if err != nil {
 		return err
@@ -474,6 +531,13 @@ func (ks keystore) Rename(oldName, newName string) error {
 		return err
 	}
 
+	// the policy isn't part of the exported key
+	if !k.Policy.IsEmpty() {
+		if _, err := ks.SetPolicy(newName, k.Policy); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -668,6 +732,19 @@ func newTestBackendKeyringConfig(appName, dir string) keyring.Config {
 	}
 }
 
+// newRemoteBackendKeyringConfig stores the records of the remote backend in
+// files, unencrypted like the test backend since they hold no private keys.
+func newRemoteBackendKeyringConfig(appName, dir string) keyring.Config {
+	return keyring.Config{
+		AllowedBackends: []keyring.BackendType{keyring.FileBackend},
+		ServiceName:     appName,
+		FileDir:         filepath.Join(dir, keyringRemoteDirName),
+		FilePasswordFunc: func(_ string) (string, error) {
+			return "remote", nil
+		},
+	}
+}
+
 func newKWalletBackendKeyringConfig(appName, _ string, _ io.Reader) keyring.Config {
 	return keyring.Config{
 		AllowedBackends: []keyring.BackendType{keyring.KWalletBackend},
@@ -782,6 +859,10 @@ func newRealPrompt(dir string, buf io.Reader) func(string) (string, error) {
 }
 
 func (ks keystore) writeLocalKey(name string, privKey types.PrivKey) (*Record, error) {
+	if ks.backend == BackendRemote {
+		return nil, ErrLocalKeyRemoteBackend
+	}
+
 	k, err := NewLocalRecord(name, privKey, privKey.PubKey())
 	if err != nil {
 		return nil, err
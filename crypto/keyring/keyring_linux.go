@@ -38,6 +38,15 @@ type Options struct {
 	// indicate whether Ledger should skip DER Conversion on signature,
 	// depending on which format (DER or BER) the Ledger app returns signatures
 	LedgerSigSkipDERConv bool
+	// RemoteSignerDialer connects to the remote signers of the remote keys,
+	// DialRemoteSigner by default
+	RemoteSignerDialer RemoteSignerDialer
+	// RemoteSignerToken authenticates the keyring to the remote signers, the
+	// RemoteSignerTokenEnv environment variable by default
+	RemoteSignerToken string
+	// AuditLog records the signing attempts and enables the key policies,
+	// the AuditLogFileName log of the keyring directory by default
+	AuditLog *AuditLog
 	// KeyctlScope defines the scope of the keyctl's keyring.
 	KeyctlScope string
 }
@@ -60,7 +69,7 @@ func newKeyctlBackendConfig(appName, _ string, _ io.Reader, opts ...Option) keyr
 
 // New creates a new instance of a keyring.
 // Keyring options can be applied when generating the new instance.
-// Available backends are "os", "file", "kwallet", "memory", "pass", "test", "keyctl".
+// Available backends are "os", "file", "kwallet", "memory", "pass", "test", "remote", "keyctl".
 func New(
 	appName, backend, rootDir string, userInput io.Reader, cdc codec.Codec, opts ...Option,
 ) (Keyring, error) {
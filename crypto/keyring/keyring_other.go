@@ -26,6 +26,15 @@ type Options struct {
 	// indicate whether Ledger should skip DER Conversion on signature,
 	// depending on which format (DER or BER) the Ledger app returns signatures
 	LedgerSigSkipDERConv bool
+	// RemoteSignerDialer connects to the remote signers of the remote keys,
+	// DialRemoteSigner by default
+	RemoteSignerDialer RemoteSignerDialer
+	// RemoteSignerToken authenticates the keyring to the remote signers, the
+	// RemoteSignerTokenEnv environment variable by default
+	RemoteSignerToken string
+	// AuditLog records the signing attempts and enables the key policies,
+	// the AuditLogFileName log of the keyring directory by default
+	AuditLog *AuditLog
 }
 
 func New(
@@ -23,6 +23,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	cosmosbcrypt "github.com/cosmos/cosmos-sdk/crypto/keys/bcrypt"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/frost"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	"github.com/cosmos/cosmos-sdk/crypto/types"
@@ -1833,6 +1834,36 @@ func TestAltKeyring_SaveMultisig(t *testing.T) {
 	}
 }
 
+func TestAltKeyring_SaveKeyShare(t *testing.T) {
+	cdc := getCodec()
+	kr, err := New(t.Name(), BackendTest, t.TempDir(), nil, cdc)
+	require.NoError(t, err)
+
+	shares, err := frost.GenerateKeyShares(2, 3)
+	require.NoError(t, err)
+
+	k, err := kr.SaveKeyShare("custody", shares[0])
+	require.NoError(t, err)
+	require.Equal(t, TypeLocal, k.GetType())
+	addr, err := k.GetAddress()
+	require.NoError(t, err)
+	require.Equal(t, sdk.AccAddress(shares[0].PubKey().Address()), addr)
+
+	k, err = kr.Key("custody")
+	require.NoError(t, err)
+	share, err := k.GetKeyShare()
+	require.NoError(t, err)
+	require.True(t, shares[0].Equals(share))
+
+	_, _, err = kr.Sign("custody", []byte("msg"), signing.SignMode_SIGN_MODE_DIRECT)
+	require.ErrorIs(t, err, frost.ErrKeyShareSign)
+
+	invalid := *shares[1]
+	invalid.Secret = shares[0].Secret
+	_, err = kr.SaveKeyShare("invalid", &invalid)
+	require.ErrorIs(t, err, ErrInvalidKeyShare)
+}
+
 // TODO: add more tests
 func TestAltKeyring_Sign(t *testing.T) {
 	cdc := getCodec()
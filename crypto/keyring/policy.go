@@ -0,0 +1,253 @@
+package keyring
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/99designs/keyring"
+	"github.com/cockroachdb/errors"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// WithAuditLog sets the audit log recording the signing attempts of the
+// keyring. The keyrings stored in a directory default to the AuditLogFileName
+// log of the directory.
+func WithAuditLog(auditLog *AuditLog) Option {
+	return func(options *Options) {
+		options.AuditLog = auditLog
+	}
+}
+
+// PolicyRequest describes a transaction to sign, as checked against the
+// policy of its signing key.
+type PolicyRequest struct {
+	ChainID     string
+	MsgTypeURLs []string
+	// Amount is the sum of the coins of the messages and of the fees.
+	Amount sdk.Coins
+}
+
+// IsEmpty returns true if the policy doesn't restrict anything.
+func (p *KeyPolicy) IsEmpty() bool {
+	return p == nil || (len(p.AllowedMsgTypeUrls) == 0 && len(p.DailyLimit) == 0 && len(p.AllowedChainIds) == 0)
+}
+
+// Validate performs a basic validation of the policy.
+func (p *KeyPolicy) Validate() error {
+	for _, typeURL := range p.AllowedMsgTypeUrls {
+		if typeURL == "" {
+			return errors.Wrap(ErrInvalidKeyPolicy, "empty message type URL")
+		}
+	}
+	for _, chainID := range p.AllowedChainIds {
+		if chainID == "" {
+			return errors.Wrap(ErrInvalidKeyPolicy, "empty chain ID")
+		}
+	}
+	if err := p.DailyLimit.Validate(); err != nil {
+		return errors.Wrapf(ErrInvalidKeyPolicy, "daily limit: %s", err)
+	}
+
+	return nil
+}
+
+// Check returns an ErrKeyPolicyViolation error if req breaks the policy,
+// given the amount spent by the key over the last 24 hours.
+func (p *KeyPolicy) Check(req PolicyRequest, spent sdk.Coins) error {
+	if p.IsEmpty() {
+		return nil
+	}
+
+	if len(p.AllowedChainIds) > 0 && !slices.Contains(p.AllowedChainIds, req.ChainID) {
+		return errors.Wrapf(ErrKeyPolicyViolation, "chain ID %q is not allowed", req.ChainID)
+	}
+	if len(p.AllowedMsgTypeUrls) > 0 {
+		for _, typeURL := range req.MsgTypeURLs {
+			if !slices.Contains(p.AllowedMsgTypeUrls, typeURL) {
+				return errors.Wrapf(ErrKeyPolicyViolation, "message %s is not allowed", typeURL)
+			}
+		}
+	}
+	for _, limit := range p.DailyLimit {
+		total := spent.AmountOf(limit.Denom).Add(req.Amount.AmountOf(limit.Denom))
+		if total.GT(limit.Amount) {
+			return errors.Wrapf(ErrKeyPolicyViolation, "daily limit of %s exceeded: %s spent within 24 hours",
+				limit, sdk.NewCoin(limit.Denom, total))
+		}
+	}
+
+	return nil
+}
+
+func (ks keystore) SetPolicy(uid string, policy *KeyPolicy) (*Record, error) {
+	k, err := ks.Key(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.IsEmpty() {
+		policy = nil
+	} else if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	k.Policy = policy
+
+	serializedRecord, err := ks.cdc.Marshal(k)
+	if err != nil {
+		return nil, errors.CombineErrors(ErrUnableToSerialize, err)
+	}
+
+	// the record keeps its name and address, so only its item is replaced
+	if err := ks.SetItem(keyring.Item{Key: infoKey(uid), Data: serializedRecord}); err != nil {
+		return nil, fmt.Errorf("unable to set the policy of %s: %w", uid, err)
+	}
+
+	return k, nil
+}
+
+// checkPolicy checks the sign bytes msg against the policy of its signing key
+// k, and records the signing attempt in the audit log of the keyring, if any.
+// msg must not be signed if it returns an error. The keys with a policy only
+// sign SIGN_MODE_DIRECT sign bytes, which the keyring decodes to check them.
+func (ks keystore) checkPolicy(k *Record, msg []byte, signMode signing.SignMode) error {
+	auditLog := ks.options.AuditLog
+	if auditLog == nil {
+		if k.Policy.IsEmpty() {
+			return nil
+		}
+		return errors.Wrap(ErrKeyPolicyViolation, "an audit log is required to sign with a key policy")
+	}
+
+	addr, err := k.GetAddress()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	req, decodeErr := ks.policyRequest(msg, signMode)
+	var policyErr error
+	switch {
+	case k.Policy.IsEmpty():
+	case decodeErr != nil:
+		policyErr = errors.Wrapf(ErrKeyPolicyViolation, "the sign bytes can't be checked: %s", decodeErr)
+	default:
+		spent := sdk.NewCoins()
+		if len(k.Policy.DailyLimit) > 0 {
+			spent, err = auditLog.Spent(addr.String(), now.Add(-24*time.Hour))
+			if err != nil {
+				return err
+			}
+		}
+		policyErr = k.Policy.Check(req, spent)
+	}
+
+	entry := AuditEntry{
+		Time:        now,
+		Key:         k.Name,
+		Address:     addr.String(),
+		ChainID:     req.ChainID,
+		MsgTypeURLs: req.MsgTypeURLs,
+		Amount:      req.Amount,
+		Allowed:     policyErr == nil,
+	}
+	if policyErr != nil {
+		entry.Reason = policyErr.Error()
+	}
+	if _, err := auditLog.Append(entry); err != nil {
+		return err
+	}
+
+	return policyErr
+}
+
+// policyRequest decodes the transaction of the SIGN_MODE_DIRECT sign bytes
+// msg. The messages must be registered in the interface registry of the
+// keyring to count their coins.
+func (ks keystore) policyRequest(msg []byte, signMode signing.SignMode) (PolicyRequest, error) {
+	if signMode != signing.SignMode_SIGN_MODE_DIRECT {
+		return PolicyRequest{}, fmt.Errorf("unsupported sign mode %s", signMode)
+	}
+
+	var (
+		signDoc  tx.SignDoc
+		body     tx.TxBody
+		authInfo tx.AuthInfo
+	)
+	if err := ks.cdc.Unmarshal(msg, &signDoc); err != nil {
+		return PolicyRequest{}, err
+	}
+	if err := ks.cdc.Unmarshal(signDoc.BodyBytes, &body); err != nil {
+		return PolicyRequest{}, err
+	}
+	if err := ks.cdc.Unmarshal(signDoc.AuthInfoBytes, &authInfo); err != nil {
+		return PolicyRequest{}, err
+	}
+
+	req := PolicyRequest{
+		ChainID:     signDoc.ChainId,
+		MsgTypeURLs: make([]string, len(body.Messages)),
+		Amount:      collectCoins(reflect.ValueOf(authInfo.Fee), sdk.NewCoins()),
+	}
+	for i, msgAny := range body.Messages {
+		req.MsgTypeURLs[i] = msgAny.TypeUrl
+		req.Amount = collectCoins(reflect.ValueOf(msgAny), req.Amount)
+	}
+
+	return req, nil
+}
+
+var (
+	coinType = reflect.TypeOf(sdk.Coin{})
+	anyType  = reflect.TypeOf(codectypes.Any{})
+)
+
+// collectCoins adds the valid coins found in v, e.g. the amounts of a
+// message, to coins. The messages packed in Any fields, e.g. by authz, are
+// walked through too.
+func collectCoins(v reflect.Value, coins sdk.Coins) sdk.Coins {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return coins
+		}
+		return collectCoins(v.Elem(), coins)
+
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return coins
+		}
+		for i := 0; i < v.Len(); i++ {
+			coins = collectCoins(v.Index(i), coins)
+		}
+
+	case reflect.Struct:
+		switch v.Type() {
+		case coinType:
+			coin := v.Interface().(sdk.Coin)
+			if coin.Validate() == nil && coin.IsPositive() {
+				coins = coins.Add(coin)
+			}
+			return coins
+
+		case anyType:
+			if !v.CanAddr() {
+				return coins
+			}
+			return collectCoins(reflect.ValueOf(v.Addr().Interface().(*codectypes.Any).GetCachedValue()), coins)
+		}
+
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				coins = collectCoins(v.Field(i), coins)
+			}
+		}
+	}
+
+	return coins
+}
@@ -0,0 +1,160 @@
+package keyring
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+func TestKeyPolicyCheck(t *testing.T) {
+	policy := &KeyPolicy{
+		AllowedMsgTypeUrls: []string{"/cosmos.bank.v1beta1.MsgSend"},
+		DailyLimit:         sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		AllowedChainIds:    []string{"test-chain"},
+	}
+	require.NoError(t, policy.Validate())
+
+	req := PolicyRequest{
+		ChainID:     "test-chain",
+		MsgTypeURLs: []string{"/cosmos.bank.v1beta1.MsgSend"},
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("stake", 60), sdk.NewInt64Coin("atom", 1000)),
+	}
+	require.NoError(t, policy.Check(req, nil))
+	require.NoError(t, policy.Check(req, sdk.NewCoins(sdk.NewInt64Coin("stake", 40))))
+	require.ErrorIs(t, policy.Check(req, sdk.NewCoins(sdk.NewInt64Coin("stake", 41))), ErrKeyPolicyViolation)
+
+	other := req
+	other.ChainID = "other-chain"
+	require.ErrorIs(t, policy.Check(other, nil), ErrKeyPolicyViolation)
+
+	other = req
+	other.MsgTypeURLs = []string{"/cosmos.bank.v1beta1.MsgSend", "/cosmos.staking.v1beta1.MsgDelegate"}
+	require.ErrorIs(t, policy.Check(other, nil), ErrKeyPolicyViolation)
+
+	// an empty policy doesn't restrict anything
+	require.True(t, (&KeyPolicy{}).IsEmpty())
+	require.NoError(t, (&KeyPolicy{}).Check(other, nil))
+	var nilPolicy *KeyPolicy
+	require.NoError(t, nilPolicy.Check(other, nil))
+
+	require.ErrorIs(t, (&KeyPolicy{AllowedChainIds: []string{""}}).Validate(), ErrInvalidKeyPolicy)
+	require.ErrorIs(t, (&KeyPolicy{DailyLimit: sdk.Coins{sdk.NewInt64Coin("stake", 0)}}).Validate(), ErrInvalidKeyPolicy)
+}
+
+func TestSetPolicy(t *testing.T) {
+	kr, err := New(t.Name(), BackendTest, t.TempDir(), nil, getCodec())
+	require.NoError(t, err)
+	_, _, err = kr.NewMnemonic("relayer", English, sdk.FullFundraiserPath, DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+
+	policy := &KeyPolicy{
+		AllowedMsgTypeUrls: []string{"/cosmos.bank.v1beta1.MsgSend"},
+		DailyLimit:         sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+	}
+	k, err := kr.SetPolicy("relayer", policy)
+	require.NoError(t, err)
+	require.Equal(t, policy, k.Policy)
+
+	k, err = kr.Key("relayer")
+	require.NoError(t, err)
+	require.Equal(t, policy, k.Policy)
+	addr, err := k.GetAddress()
+	require.NoError(t, err)
+	k, err = kr.KeyByAddress(addr)
+	require.NoError(t, err)
+	require.Equal(t, policy, k.Policy)
+
+	// the policy follows the key when renamed
+	require.NoError(t, kr.Rename("relayer", "relayer-2"))
+	k, err = kr.Key("relayer-2")
+	require.NoError(t, err)
+	require.Equal(t, policy, k.Policy)
+
+	_, err = kr.SetPolicy("relayer-2", &KeyPolicy{AllowedChainIds: []string{""}})
+	require.ErrorIs(t, err, ErrInvalidKeyPolicy)
+	_, err = kr.SetPolicy("unknown", policy)
+	require.Error(t, err)
+
+	_, err = kr.SetPolicy("relayer-2", &KeyPolicy{})
+	require.NoError(t, err)
+	k, err = kr.Key("relayer-2")
+	require.NoError(t, err)
+	require.Nil(t, k.Policy)
+}
+
+// directSignBytes returns the SIGN_MODE_DIRECT sign bytes of a tx without
+// messages paying fee on chainID.
+func directSignBytes(t *testing.T, chainID string, fee sdk.Coins) []byte {
+	t.Helper()
+
+	cdc := getCodec()
+	bodyBytes, err := cdc.Marshal(&tx.TxBody{})
+	require.NoError(t, err)
+	authInfoBytes, err := cdc.Marshal(&tx.AuthInfo{Fee: &tx.Fee{Amount: fee}})
+	require.NoError(t, err)
+	signBytes, err := cdc.Marshal(&tx.SignDoc{BodyBytes: bodyBytes, AuthInfoBytes: authInfoBytes, ChainId: chainID})
+	require.NoError(t, err)
+
+	return signBytes
+}
+
+func TestSignWithPolicy(t *testing.T) {
+	policy := &KeyPolicy{
+		DailyLimit:      sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		AllowedChainIds: []string{"test-chain"},
+	}
+
+	// the policies can't be enforced without audit log
+	kr := NewInMemory(getCodec())
+	_, _, err := kr.NewMnemonic("relayer", English, sdk.FullFundraiserPath, DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	_, err = kr.SetPolicy("relayer", policy)
+	require.NoError(t, err)
+	_, _, err = kr.Sign("relayer", directSignBytes(t, "test-chain", nil), signing.SignMode_SIGN_MODE_DIRECT)
+	require.ErrorIs(t, err, ErrKeyPolicyViolation)
+
+	auditLog := NewAuditLog(filepath.Join(t.TempDir(), AuditLogFileName))
+	kr = NewInMemory(getCodec(), WithAuditLog(auditLog))
+	_, _, err = kr.NewMnemonic("relayer", English, sdk.FullFundraiserPath, DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	_, _, err = kr.NewMnemonic("other", English, sdk.FullFundraiserPath, DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	_, err = kr.SetPolicy("relayer", policy)
+	require.NoError(t, err)
+
+	_, _, err = kr.Sign("relayer", directSignBytes(t, "test-chain", sdk.NewCoins(sdk.NewInt64Coin("stake", 60))), signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+	_, _, err = kr.Sign("relayer", directSignBytes(t, "test-chain", sdk.NewCoins(sdk.NewInt64Coin("stake", 60))), signing.SignMode_SIGN_MODE_DIRECT)
+	require.ErrorIs(t, err, ErrKeyPolicyViolation)
+	_, _, err = kr.Sign("relayer", directSignBytes(t, "other-chain", nil), signing.SignMode_SIGN_MODE_DIRECT)
+	require.ErrorIs(t, err, ErrKeyPolicyViolation)
+
+	// the sign bytes which can't be decoded are rejected
+	_, _, err = kr.Sign("relayer", []byte("sign bytes"), signing.SignMode_SIGN_MODE_DIRECT)
+	require.ErrorIs(t, err, ErrKeyPolicyViolation)
+	_, _, err = kr.Sign("relayer", directSignBytes(t, "test-chain", nil), signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+	require.ErrorIs(t, err, ErrKeyPolicyViolation)
+
+	// unless the key has no policy
+	_, _, err = kr.Sign("other", []byte("sign bytes"), signing.SignMode_SIGN_MODE_TEXTUAL)
+	require.NoError(t, err)
+
+	entries, err := auditLog.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+	require.True(t, entries[0].Allowed)
+	require.Equal(t, "test-chain", entries[0].ChainID)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 60)), entries[0].Amount)
+	for _, entry := range entries[1:5] {
+		require.False(t, entry.Allowed)
+		require.NotEmpty(t, entry.Reason)
+	}
+	require.True(t, entries[5].Allowed)
+	require.Equal(t, "other", entries[5].Key)
+}
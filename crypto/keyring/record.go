@@ -7,6 +7,7 @@ import (
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/frost"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/cosmos/cosmos-sdk/types"
 )
@@ -26,7 +27,7 @@ func newRecord(name string, pk cryptotypes.PubKey, item isRecord_Item) (*Record,
 		return nil, err
 	}
 
-	return &Record{name, any, item}, nil
+	return &Record{Name: name, PubKey: any, Item: item}, nil
 }
 
 // NewLocalRecord creates a new Record with local key item
@@ -53,6 +54,14 @@ func (rl *Record_Ledger) GetPath() *hd.BIP44Params {
 	return rl.Path
 }
 
+// NewRemoteRecord creates a new Record with remote item, referencing the key
+// keyID of the remote signer at endpoint.
+func NewRemoteRecord(name string, pk cryptotypes.PubKey, endpoint, keyID string) (*Record, error) {
+	recordRemote := &Record_Remote{Endpoint: endpoint, KeyId: keyID}
+	recordRemoteItem := &Record_Remote_{recordRemote}
+	return newRecord(name, pk, recordRemoteItem)
+}
+
 // NewOfflineRecord creates a new Record with offline item
 func NewOfflineRecord(name string, pk cryptotypes.PubKey) (*Record, error) {
 	recordOffline := &Record_Offline{}
@@ -97,11 +106,29 @@ func (k Record) GetType() KeyType {
 		return TypeMulti
 	case k.GetOffline() != nil:
 		return TypeOffline
+	case k.GetRemote() != nil:
+		return TypeRemote
 	default:
 		panic("unrecognized record type")
 	}
 }
 
+// GetKeyShare returns the FROST key share of a local record saved with
+// SaveKeyShare.
+func (k *Record) GetKeyShare() (*frost.KeyShare, error) {
+	priv, err := extractPrivKeyFromRecord(k)
+	if err != nil {
+		return nil, err
+	}
+
+	share, ok := priv.(*frost.KeyShare)
+	if !ok {
+		return nil, ErrNotKeyShare
+	}
+
+	return share, nil
+}
+
 // UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
 func (k *Record) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
 	var pk cryptotypes.PubKey
@@ -7,6 +7,8 @@ import (
 	fmt "fmt"
 	types "github.com/cosmos/cosmos-sdk/codec/types"
 	hd "github.com/cosmos/cosmos-sdk/crypto/hd"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types1 "github.com/cosmos/cosmos-sdk/types"
 	_ "github.com/cosmos/gogoproto/gogoproto"
 	proto "github.com/golang/protobuf/proto"
 	io "io"
@@ -38,7 +40,11 @@ type Record struct {
 	//	*Record_Ledger_
 	//	*Record_Multi_
 	//	*Record_Offline_
+	//	*Record_Remote_
 	Item isRecord_Item `protobuf_oneof:"item"`
+	// policy restricts the transactions signed with the key by the keyring, if
+	// set.
+	Policy *KeyPolicy `protobuf:"bytes,8,opt,name=policy,proto3" json:"policy,omitempty"`
 }
 
 func (m *Record) Reset()         { *m = Record{} }
@@ -92,11 +98,15 @@ type Record_Multi_ struct {
 type Record_Offline_ struct {
 	Offline *Record_Offline `protobuf:"bytes,6,opt,name=offline,proto3,oneof" json:"offline,omitempty"`
 }
+type Record_Remote_ struct {
+	Remote *Record_Remote `protobuf:"bytes,7,opt,name=remote,proto3,oneof" json:"remote,omitempty"`
+}
 
 func (*Record_Local_) isRecord_Item()   {}
 func (*Record_Ledger_) isRecord_Item()  {}
 func (*Record_Multi_) isRecord_Item()   {}
 func (*Record_Offline_) isRecord_Item() {}
+func (*Record_Remote_) isRecord_Item()  {}
 
 func (m *Record) GetItem() isRecord_Item {
 	if m != nil {
@@ -133,6 +143,13 @@ func (m *Record) GetOffline() *Record_Offline {
 	return nil
 }
 
+func (m *Record) GetRemote() *Record_Remote {
+	if x, ok := m.GetItem().(*Record_Remote_); ok {
+		return x.Remote
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Record) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -140,6 +157,7 @@ func (*Record) XXX_OneofWrappers() []interface{} {
 		(*Record_Ledger_)(nil),
 		(*Record_Multi_)(nil),
 		(*Record_Offline_)(nil),
+		(*Record_Remote_)(nil),
 	}
 }
 
@@ -294,12 +312,103 @@ func (m *Record_Offline) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Record_Offline proto.InternalMessageInfo
 
+// Remote item
+type Record_Remote struct {
+	// endpoint is the gRPC endpoint of the remote signer, e.g. localhost:9091
+	// or unix:///run/signer.sock.
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// key_id identifies the key in the remote signer.
+	KeyId string `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+}
+
+func (m *Record_Remote) Reset()         { *m = Record_Remote{} }
+func (m *Record_Remote) String() string { return proto.CompactTextString(m) }
+func (*Record_Remote) ProtoMessage()    {}
+func (*Record_Remote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36d640103edea005, []int{0, 4}
+}
+func (m *Record_Remote) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Record_Remote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Record_Remote.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Record_Remote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Record_Remote.Merge(m, src)
+}
+func (m *Record_Remote) XXX_Size() int {
+	return m.Size()
+}
+func (m *Record_Remote) XXX_DiscardUnknown() {
+	xxx_messageInfo_Record_Remote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Record_Remote proto.InternalMessageInfo
+
+// KeyPolicy restricts the transactions signed with a key by the keyring, e.g.
+// to put guardrails on hot keys. An empty field doesn't restrict anything.
+type KeyPolicy struct {
+	// allowed_msg_type_urls are the type URLs of the messages that the
+	// transactions may contain.
+	AllowedMsgTypeUrls []string `protobuf:"bytes,1,rep,name=allowed_msg_type_urls,json=allowedMsgTypeUrls,proto3" json:"allowed_msg_type_urls,omitempty"`
+	// daily_limit is the maximum amount, per denom, of the coins of the messages
+	// and the fees of the transactions signed within 24 hours. The denoms absent
+	// from it are not limited.
+	DailyLimit github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,2,rep,name=daily_limit,json=dailyLimit,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"daily_limit"`
+	// allowed_chain_ids are the chain IDs of the transactions.
+	AllowedChainIds []string `protobuf:"bytes,3,rep,name=allowed_chain_ids,json=allowedChainIds,proto3" json:"allowed_chain_ids,omitempty"`
+}
+
+func (m *KeyPolicy) Reset()         { *m = KeyPolicy{} }
+func (m *KeyPolicy) String() string { return proto.CompactTextString(m) }
+func (*KeyPolicy) ProtoMessage()    {}
+func (*KeyPolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36d640103edea005, []int{1}
+}
+func (m *KeyPolicy) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyPolicy) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeyPolicy.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KeyPolicy) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyPolicy.Merge(m, src)
+}
+func (m *KeyPolicy) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyPolicy) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyPolicy.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyPolicy proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*Record)(nil), "cosmos.crypto.keyring.v1.Record")
 	proto.RegisterType((*Record_Local)(nil), "cosmos.crypto.keyring.v1.Record.Local")
 	proto.RegisterType((*Record_Ledger)(nil), "cosmos.crypto.keyring.v1.Record.Ledger")
 	proto.RegisterType((*Record_Multi)(nil), "cosmos.crypto.keyring.v1.Record.Multi")
 	proto.RegisterType((*Record_Offline)(nil), "cosmos.crypto.keyring.v1.Record.Offline")
+	proto.RegisterType((*Record_Remote)(nil), "cosmos.crypto.keyring.v1.Record.Remote")
+	proto.RegisterType((*KeyPolicy)(nil), "cosmos.crypto.keyring.v1.KeyPolicy")
 }
 
 func init() {
@@ -307,33 +416,47 @@ func init() {
 }
 
 var fileDescriptor_36d640103edea005 = []byte{
-	// 411 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xbd, 0xae, 0xd3, 0x30,
-	0x1c, 0xc5, 0x1d, 0x6e, 0x3e, 0xb8, 0x66, 0xb3, 0xee, 0x10, 0x22, 0x64, 0x55, 0x48, 0x40, 0x25,
-	0x54, 0x5b, 0x85, 0x0e, 0x4c, 0x95, 0x5a, 0x31, 0x14, 0x95, 0x8a, 0x2a, 0x23, 0x0b, 0xca, 0x87,
-	0x9b, 0x44, 0x4d, 0xe2, 0xc8, 0x49, 0x2a, 0xe5, 0x2d, 0x18, 0x79, 0xa4, 0x8e, 0x1d, 0x19, 0xa1,
-	0xd9, 0x78, 0x0a, 0x64, 0x3b, 0x1d, 0xa8, 0x04, 0x65, 0x8a, 0x23, 0xff, 0xce, 0xff, 0x9c, 0x63,
-	0xfd, 0xe1, 0x8b, 0x88, 0xd7, 0x05, 0xaf, 0x69, 0x24, 0xba, 0xaa, 0xe1, 0x74, 0xcf, 0x3a, 0x91,
-	0x95, 0x09, 0x3d, 0x4c, 0xa9, 0x60, 0x11, 0x17, 0x31, 0xa9, 0x04, 0x6f, 0x38, 0x72, 0x35, 0x46,
-	0x34, 0x46, 0x06, 0x8c, 0x1c, 0xa6, 0xde, 0x43, 0xc2, 0x13, 0xae, 0x20, 0x2a, 0x4f, 0x9a, 0xf7,
-	0x9e, 0x26, 0x9c, 0x27, 0x39, 0xa3, 0xea, 0x2f, 0x6c, 0x77, 0x34, 0x28, 0xbb, 0xe1, 0xea, 0xd9,
-	0x9f, 0x8e, 0x69, 0x2c, 0xcd, 0xd2, 0xc1, 0xe8, 0xf9, 0xaf, 0x3b, 0x68, 0xfb, 0xca, 0x19, 0x21,
-	0x68, 0x96, 0x41, 0xc1, 0x5c, 0x63, 0x64, 0x8c, 0xef, 0x7d, 0x75, 0x46, 0x13, 0xe8, 0x54, 0x6d,
-	0xf8, 0x65, 0xcf, 0x3a, 0xf7, 0xd1, 0xc8, 0x18, 0x3f, 0x79, 0xf3, 0x40, 0xb4, 0x13, 0xb9, 0x38,
-	0x91, 0x45, 0xd9, 0xf9, 0x76, 0xd5, 0x86, 0x6b, 0xd6, 0xa1, 0x39, 0xb4, 0x72, 0x1e, 0x05, 0xb9,
-	0x7b, 0xa7, 0xe0, 0x97, 0xe4, 0x6f, 0x35, 0x88, 0xf6, 0x24, 0x1f, 0x25, 0xbd, 0x02, 0xbe, 0x96,
-	0xa1, 0x05, 0xb4, 0x73, 0x16, 0x27, 0x4c, 0xb8, 0xa6, 0x1a, 0xf0, 0xea, 0xf6, 0x00, 0x85, 0xaf,
-	0x80, 0x3f, 0x08, 0x65, 0x84, 0xa2, 0xcd, 0x9b, 0xcc, 0xb5, 0xfe, 0x33, 0xc2, 0x46, 0xd2, 0x32,
-	0x82, 0x92, 0xa1, 0xf7, 0xd0, 0xe1, 0xbb, 0x5d, 0x9e, 0x95, 0xcc, 0xb5, 0xd5, 0x84, 0xf1, 0xcd,
-	0x09, 0x9f, 0x34, 0xbf, 0x02, 0xfe, 0x45, 0xea, 0xbd, 0x83, 0x96, 0xaa, 0x86, 0x28, 0x7c, 0x5c,
-	0x89, 0xec, 0xa0, 0x5e, 0xd0, 0xf8, 0xc7, 0x0b, 0x3a, 0x92, 0x5a, 0xb3, 0xce, 0x9b, 0x43, 0x5b,
-	0x77, 0x42, 0x33, 0x68, 0x56, 0x41, 0x93, 0x0e, 0xb2, 0xd1, 0x55, 0x8c, 0x34, 0x96, 0x09, 0x96,
-	0x1f, 0xb6, 0xb3, 0xd9, 0x36, 0x10, 0x41, 0x51, 0xfb, 0x8a, 0xf6, 0x1c, 0x68, 0xa9, 0x46, 0xde,
-	0x3d, 0x74, 0x86, 0x60, 0x4b, 0x1b, 0x9a, 0x59, 0xc3, 0x8a, 0xe5, 0xe6, 0xf8, 0x13, 0x83, 0xe3,
-	0x19, 0x1b, 0xa7, 0x33, 0x36, 0x7e, 0x9c, 0xb1, 0xf1, 0xb5, 0xc7, 0xe0, 0x5b, 0x8f, 0xc1, 0xa9,
-	0xc7, 0xe0, 0x7b, 0x8f, 0xc1, 0xe7, 0xd7, 0x49, 0xd6, 0xa4, 0x6d, 0x48, 0x22, 0x5e, 0xd0, 0xcb,
-	0xde, 0xa8, 0xcf, 0xa4, 0x8e, 0xf7, 0x57, 0x4b, 0x1b, 0xda, 0xaa, 0xc1, 0xdb, 0xdf, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0x47, 0x24, 0x2f, 0xa9, 0xd4, 0x02, 0x00, 0x00,
+	// 628 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4f, 0x6b, 0xdb, 0x30,
+	0x1c, 0xb5, 0x9b, 0xc4, 0x69, 0xd4, 0xc3, 0x98, 0x68, 0xc1, 0x35, 0xc3, 0x0d, 0x1d, 0xdb, 0xc2,
+	0x46, 0xe5, 0xa5, 0xeb, 0x61, 0x50, 0x28, 0x34, 0xdd, 0xa1, 0xa5, 0x2d, 0x2b, 0x66, 0xbb, 0xec,
+	0x62, 0x6c, 0x4b, 0x75, 0x44, 0x64, 0xcb, 0x58, 0x4e, 0x86, 0xbe, 0xc5, 0x8e, 0xfb, 0x06, 0x83,
+	0x7d, 0x92, 0x1e, 0x7b, 0xec, 0x69, 0x7f, 0x9a, 0x2f, 0x32, 0x24, 0x2b, 0x85, 0x0e, 0xfa, 0xe7,
+	0x14, 0x29, 0xbf, 0xf7, 0xde, 0xef, 0xbd, 0x9f, 0x24, 0x83, 0x17, 0x29, 0x17, 0x39, 0x17, 0x41,
+	0x5a, 0xc9, 0xb2, 0xe6, 0xc1, 0x84, 0xc8, 0x8a, 0x16, 0x59, 0x30, 0x1b, 0x06, 0x15, 0x49, 0x79,
+	0x85, 0x51, 0x59, 0xf1, 0x9a, 0x43, 0xb7, 0x81, 0xa1, 0x06, 0x86, 0x0c, 0x0c, 0xcd, 0x86, 0xde,
+	0x6a, 0xc6, 0x33, 0xae, 0x41, 0x81, 0x5a, 0x35, 0x78, 0x6f, 0x3d, 0xe3, 0x3c, 0x63, 0x24, 0xd0,
+	0xbb, 0x64, 0x7a, 0x1e, 0xc4, 0x85, 0x34, 0xa5, 0x67, 0xb7, 0x3b, 0x8e, 0xb1, 0x6a, 0x36, 0x36,
+	0x8d, 0x3c, 0xdf, 0x54, 0x93, 0x58, 0x90, 0x60, 0x36, 0x4c, 0x48, 0x1d, 0x0f, 0x83, 0x94, 0xd3,
+	0xa2, 0xa9, 0x6f, 0xfe, 0xe8, 0x00, 0x27, 0xd4, 0xce, 0x20, 0x04, 0xed, 0x22, 0xce, 0x89, 0x6b,
+	0xf7, 0xed, 0x41, 0x2f, 0xd4, 0x6b, 0xb8, 0x05, 0xba, 0xe5, 0x34, 0x89, 0x26, 0x44, 0xba, 0x4b,
+	0x7d, 0x7b, 0xb0, 0xb2, 0xbd, 0x8a, 0x1a, 0x27, 0x68, 0xe1, 0x04, 0xed, 0x17, 0x32, 0x74, 0xca,
+	0x69, 0x72, 0x4c, 0x24, 0xdc, 0x03, 0x1d, 0xc6, 0xd3, 0x98, 0xb9, 0x2d, 0x0d, 0x7e, 0x89, 0xee,
+	0x8a, 0x89, 0x9a, 0x9e, 0xe8, 0x44, 0xa1, 0x0f, 0xad, 0xb0, 0xa1, 0xc1, 0x7d, 0xe0, 0x30, 0x82,
+	0x33, 0x52, 0xb9, 0x6d, 0x2d, 0xf0, 0xea, 0x61, 0x01, 0x0d, 0x3f, 0xb4, 0x42, 0x43, 0x54, 0x16,
+	0xf2, 0x29, 0xab, 0xa9, 0xdb, 0x79, 0xa4, 0x85, 0x53, 0x85, 0x56, 0x16, 0x34, 0x0d, 0x7e, 0x00,
+	0x5d, 0x7e, 0x7e, 0xce, 0x68, 0x41, 0x5c, 0x47, 0x2b, 0x0c, 0x1e, 0x54, 0xf8, 0xd8, 0xe0, 0x0f,
+	0xad, 0x70, 0x41, 0x55, 0x41, 0x2a, 0x92, 0xf3, 0x9a, 0xb8, 0xdd, 0x47, 0x06, 0x09, 0x35, 0x5c,
+	0x05, 0x69, 0x88, 0x70, 0x17, 0x38, 0x25, 0x67, 0x34, 0x95, 0xee, 0xb2, 0x96, 0x78, 0x7e, 0xb7,
+	0xc4, 0x31, 0x91, 0x67, 0x1a, 0x1a, 0x1a, 0x8a, 0xf7, 0x1e, 0x74, 0xf4, 0x68, 0x61, 0x00, 0x96,
+	0xcb, 0x8a, 0xce, 0xf4, 0x09, 0xda, 0xf7, 0x9c, 0x60, 0x57, 0xa1, 0x8e, 0x89, 0xf4, 0xf6, 0x80,
+	0xd3, 0xcc, 0x14, 0xee, 0x80, 0x76, 0x19, 0xd7, 0x63, 0x43, 0xeb, 0xff, 0xd7, 0x7e, 0x8c, 0x55,
+	0xe7, 0xd1, 0xd1, 0xd9, 0xce, 0xce, 0x59, 0x5c, 0xc5, 0xb9, 0x08, 0x35, 0xda, 0xeb, 0x82, 0x8e,
+	0x9e, 0xa8, 0xd7, 0x03, 0x5d, 0x33, 0x18, 0x6f, 0x57, 0xdd, 0x31, 0x1d, 0xca, 0x03, 0xcb, 0xa4,
+	0xc0, 0x25, 0xa7, 0x45, 0x6d, 0xee, 0xd9, 0xcd, 0x1e, 0xae, 0x01, 0x67, 0x42, 0x64, 0x44, 0xb1,
+	0xbe, 0x6a, 0xbd, 0xb0, 0x33, 0x21, 0xf2, 0x08, 0x8f, 0x1c, 0xd0, 0xa6, 0x35, 0xc9, 0x37, 0xaf,
+	0x6c, 0xd0, 0xbb, 0x09, 0x0a, 0x87, 0x60, 0x2d, 0x66, 0x8c, 0x7f, 0x25, 0x38, 0xca, 0x45, 0x16,
+	0xd5, 0xb2, 0x24, 0xd1, 0xb4, 0x62, 0xc2, 0xb5, 0xfb, 0xad, 0x41, 0x2f, 0x84, 0xa6, 0x78, 0x2a,
+	0xb2, 0x4f, 0xb2, 0x24, 0x9f, 0x2b, 0x26, 0x20, 0x03, 0x2b, 0x38, 0xa6, 0x4c, 0x46, 0x8c, 0xe6,
+	0xb4, 0x76, 0x97, 0xfa, 0xad, 0xc1, 0xca, 0xf6, 0xfa, 0x22, 0x96, 0x7a, 0x20, 0xc8, 0x3c, 0x10,
+	0x74, 0xc0, 0x69, 0x31, 0x7a, 0x7b, 0xf1, 0x6b, 0xc3, 0xfa, 0xf9, 0x7b, 0x63, 0x90, 0xd1, 0x7a,
+	0x3c, 0x4d, 0x50, 0xca, 0xf3, 0x60, 0xf1, 0xd6, 0xf4, 0xcf, 0x96, 0xc0, 0x93, 0x40, 0x75, 0x16,
+	0x9a, 0x20, 0x42, 0xa0, 0xf5, 0x4f, 0x94, 0x3c, 0x7c, 0x0d, 0x9e, 0x2e, 0x0c, 0xa6, 0xe3, 0x98,
+	0x16, 0x11, 0xc5, 0xc2, 0x6d, 0x69, 0x73, 0x4f, 0x4c, 0xe1, 0x40, 0xfd, 0x7f, 0x84, 0xc5, 0xe8,
+	0xf4, 0xe2, 0xaf, 0x6f, 0x5d, 0x5c, 0xfb, 0xf6, 0xe5, 0xb5, 0x6f, 0xff, 0xb9, 0xf6, 0xed, 0x6f,
+	0x73, 0xdf, 0xfa, 0x3e, 0xf7, 0xad, 0xcb, 0xb9, 0x6f, 0x5d, 0xcd, 0x7d, 0xeb, 0xcb, 0x9b, 0x7b,
+	0x3d, 0xdc, 0xfe, 0xd8, 0x24, 0x8e, 0x3e, 0xd9, 0x77, 0xff, 0x06, 0x00, 0x20, 0xa7, 0x19, 0x3a,
+	0x8c, 0x04, 0x00, 0x00,
 }
 
 func (m *Record) Marshal() (dAtA []byte, err error) {
@@ -356,6 +479,18 @@ func (m *Record) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Policy != nil {
+		{
+			size, err := m.Policy.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
 	if m.Item != nil {
 		{
 			size := m.Item.Size()
@@ -471,6 +606,27 @@ func (m *Record_Offline_) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Record_Remote_) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Record_Remote_) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Remote != nil {
+		{
+			size, err := m.Remote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRecord(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *Record_Local) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -587,6 +743,98 @@ func (m *Record_Offline) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Record_Remote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Record_Remote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Record_Remote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.KeyId) > 0 {
+		i -= len(m.KeyId)
+		copy(dAtA[i:], m.KeyId)
+		i = encodeVarintRecord(dAtA, i, uint64(len(m.KeyId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Endpoint) > 0 {
+		i -= len(m.Endpoint)
+		copy(dAtA[i:], m.Endpoint)
+		i = encodeVarintRecord(dAtA, i, uint64(len(m.Endpoint)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KeyPolicy) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyPolicy) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyPolicy) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AllowedChainIds) > 0 {
+		for iNdEx := len(m.AllowedChainIds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowedChainIds[iNdEx])
+			copy(dAtA[i:], m.AllowedChainIds[iNdEx])
+			i = encodeVarintRecord(dAtA, i, uint64(len(m.AllowedChainIds[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.DailyLimit) > 0 {
+		for iNdEx := len(m.DailyLimit) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DailyLimit[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRecord(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.AllowedMsgTypeUrls) > 0 {
+		for iNdEx := len(m.AllowedMsgTypeUrls) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowedMsgTypeUrls[iNdEx])
+			copy(dAtA[i:], m.AllowedMsgTypeUrls[iNdEx])
+			i = encodeVarintRecord(dAtA, i, uint64(len(m.AllowedMsgTypeUrls[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintRecord(dAtA []byte, offset int, v uint64) int {
 	offset -= sovRecord(v)
 	base := offset
@@ -615,6 +863,10 @@ func (m *Record) Size() (n int) {
 	if m.Item != nil {
 		n += m.Item.Size()
 	}
+	if m.Policy != nil {
+		l = m.Policy.Size()
+		n += 1 + l + sovRecord(uint64(l))
+	}
 	return n
 }
 
@@ -666,6 +918,18 @@ func (m *Record_Offline_) Size() (n int) {
 	}
 	return n
 }
+func (m *Record_Remote_) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Remote != nil {
+		l = m.Remote.Size()
+		n += 1 + l + sovRecord(uint64(l))
+	}
+	return n
+}
 func (m *Record_Local) Size() (n int) {
 	if m == nil {
 		return 0
@@ -710,6 +974,50 @@ func (m *Record_Offline) Size() (n int) {
 	return n
 }
 
+func (m *Record_Remote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Endpoint)
+	if l > 0 {
+		n += 1 + l + sovRecord(uint64(l))
+	}
+	l = len(m.KeyId)
+	if l > 0 {
+		n += 1 + l + sovRecord(uint64(l))
+	}
+	return n
+}
+
+func (m *KeyPolicy) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.AllowedMsgTypeUrls) > 0 {
+		for _, s := range m.AllowedMsgTypeUrls {
+			l = len(s)
+			n += 1 + l + sovRecord(uint64(l))
+		}
+	}
+	if len(m.DailyLimit) > 0 {
+		for _, e := range m.DailyLimit {
+			l = e.Size()
+			n += 1 + l + sovRecord(uint64(l))
+		}
+	}
+	if len(m.AllowedChainIds) > 0 {
+		for _, s := range m.AllowedChainIds {
+			l = len(s)
+			n += 1 + l + sovRecord(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovRecord(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -953,6 +1261,77 @@ func (m *Record) Unmarshal(dAtA []byte) error {
 			}
 			m.Item = &Record_Offline_{v}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Record_Remote{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Item = &Record_Remote_{v}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Policy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Policy == nil {
+				m.Policy = &KeyPolicy{}
+			}
+			if err := m.Policy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipRecord(dAtA[iNdEx:])
@@ -1246,6 +1625,268 @@ func (m *Record_Offline) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *Record_Remote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Remote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Remote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Endpoint", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Endpoint = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KeyPolicy) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRecord
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyPolicy: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyPolicy: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedMsgTypeUrls", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedMsgTypeUrls = append(m.AllowedMsgTypeUrls, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DailyLimit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DailyLimit = append(m.DailyLimit, types1.Coin{})
+			if err := m.DailyLimit[len(m.DailyLimit)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedChainIds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRecord
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRecord
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRecord
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedChainIds = append(m.AllowedChainIds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRecord(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRecord
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipRecord(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,243 @@
+package keyring
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// remoteSignerTimeout bounds the calls to a remote signer, which may wait for
+// an operator or an HSM approval before signing.
+const remoteSignerTimeout = 2 * time.Minute
+
+const (
+	// RemoteSignerTokenEnv is the environment variable holding the token
+	// authenticating the keyring to the remote signers, unless set with
+	// WithRemoteSignerToken.
+	RemoteSignerTokenEnv = "REMOTE_SIGNER_TOKEN"
+
+	// remoteSignerAuthHeader is the gRPC metadata key of the bearer token.
+	remoteSignerAuthHeader = "authorization"
+	bearerPrefix           = "Bearer "
+)
+
+// RemoteSignerDialer connects to the remote signer at endpoint. The keyring
+// closes the connection once its call is done.
+type RemoteSignerDialer func(ctx context.Context, endpoint string) (*grpc.ClientConn, error)
+
+// WithRemoteSignerDialer sets the dialer connecting to the remote signers.
+func WithRemoteSignerDialer(dialer RemoteSignerDialer) Option {
+	return func(options *Options) {
+		options.RemoteSignerDialer = dialer
+	}
+}
+
+// WithRemoteSignerToken sets the token authenticating the keyring to the
+// remote signers.
+func WithRemoteSignerToken(token string) Option {
+	return func(options *Options) {
+		options.RemoteSignerToken = token
+	}
+}
+
+// DialRemoteSigner is the default RemoteSignerDialer. It connects to the unix
+// sockets and loopback endpoints in plaintext, and to any other endpoint over
+// TLS, authenticated by the system root certificates.
+func DialRemoteSigner(ctx context.Context, endpoint string) (*grpc.ClientConn, error) {
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if isLocalEndpoint(endpoint) {
+		creds = insecure.NewCredentials()
+	}
+
+	return grpc.DialContext(ctx, endpoint, grpc.WithTransportCredentials(creds))
+}
+
+func isLocalEndpoint(endpoint string) bool {
+	if strings.HasPrefix(endpoint, "unix:") {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (ks keystore) SaveRemoteKey(uid, endpoint, keyID string) (*Record, error) {
+	var pubKey types.PubKey
+	err := ks.callRemoteSigner(endpoint, func(ctx context.Context, client RemoteSignerClient, opts ...grpc.CallOption) error {
+		res, err := client.PubKey(ctx, &RemoteSignerPubKeyRequest{KeyId: keyID}, opts...)
+		if err != nil {
+			return err
+		}
+		return ks.cdc.UnpackAny(res.PubKey, &pubKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := NewRemoteRecord(uid, pubKey, endpoint, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return k, ks.writeRecord(k)
+}
+
+// signWithRemote delegates the signature of msg to the remote signer of the
+// record, and checks it against the public key of the record.
+func (ks keystore) signWithRemote(k *Record, msg []byte, signMode signing.SignMode) ([]byte, types.PubKey, error) {
+	remote := k.GetRemote()
+	pubKey, err := k.GetPubKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sig []byte
+	err = ks.callRemoteSigner(remote.Endpoint, func(ctx context.Context, client RemoteSignerClient, opts ...grpc.CallOption) error {
+		res, err := client.Sign(ctx, &RemoteSignerSignRequest{KeyId: remote.KeyId, Msg: msg, SignMode: signMode}, opts...)
+		if err != nil {
+			return err
+		}
+		sig = res.Signature
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !pubKey.VerifySignature(msg, sig) {
+		return nil, nil, ErrRemoteInvalidSignature
+	}
+
+	return sig, pubKey, nil
+}
+
+func (ks keystore) callRemoteSigner(endpoint string, call func(context.Context, RemoteSignerClient, ...grpc.CallOption) error) error {
+	dial := ks.options.RemoteSignerDialer
+	if dial == nil {
+		dial = DialRemoteSigner
+	}
+
+	token := ks.options.RemoteSignerToken
+	if token == "" {
+		token = os.Getenv(RemoteSignerTokenEnv)
+	}
+	if token == "" {
+		return errors.Wrapf(ErrRemoteSigner, "no token to authenticate to the signer, set %s", RemoteSignerTokenEnv)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), remoteSignerTimeout)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, remoteSignerAuthHeader, bearerPrefix+token)
+
+	conn, err := dial(ctx, endpoint)
+	if err != nil {
+		return errors.CombineErrors(ErrRemoteSigner, err)
+	}
+	defer conn.Close()
+
+	if err := call(ctx, NewRemoteSignerClient(conn), grpc.ForceCodec(codec.NewProtoCodec(ks.cdc.InterfaceRegistry()).GRPCCodec())); err != nil {
+		return errors.CombineErrors(ErrRemoteSigner, err)
+	}
+
+	return nil
+}
+
+// remoteSignerServer serves the keys of a keyring to remote keyrings.
+type remoteSignerServer struct {
+	kr    Keyring
+	token string
+}
+
+// NewRemoteSignerServer returns the reference RemoteSignerServer, signing with
+// the keys of kr identified by their name for the clients authenticated by
+// token. The signatures go through kr.Sign, which enforces the policies of the
+// keys and records them in the audit log of kr. It must be registered on a
+// gRPC server using the interface registry of kr, e.g.
+//
+//	srv := grpc.NewServer(grpc.ForceServerCodec(codec.NewProtoCodec(registry).GRPCCodec()))
+//	keyring.RegisterRemoteSignerServer(srv, server)
+//
+// The token is sent in plaintext to the local endpoints, so any other endpoint
+// must be served over TLS. An actual signer daemon, e.g. in front of an HSM,
+// implements the same service with its own key store and signing policy.
+func NewRemoteSignerServer(kr Keyring, token string) (RemoteSignerServer, error) {
+	if token == "" {
+		return nil, errors.Wrap(ErrRemoteSigner, "a token is required to serve the keys")
+	}
+
+	return remoteSignerServer{kr: kr, token: token}, nil
+}
+
+// authenticate checks the bearer token of the request in constant time.
+func (s remoteSignerServer) authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(remoteSignerAuthHeader) {
+		token, ok := strings.CutPrefix(value, bearerPrefix)
+		if ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "invalid or missing token")
+}
+
+func (s remoteSignerServer) PubKey(ctx context.Context, req *RemoteSignerPubKeyRequest) (*RemoteSignerPubKeyResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	k, err := s.kr.Key(req.KeyId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	pubKey, err := k.GetPubKey()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	pkAny, err := codectypes.NewAnyWithValue(pubKey)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &RemoteSignerPubKeyResponse{PubKey: pkAny}, nil
+}
+
+func (s remoteSignerServer) Sign(ctx context.Context, req *RemoteSignerSignRequest) (*RemoteSignerSignResponse, error) {
+	if err := s.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.kr.Key(req.KeyId); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	sig, _, err := s.kr.Sign(req.KeyId, req.Msg, req.SignMode)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &RemoteSignerSignResponse{Signature: sig}, nil
+}
@@ -0,0 +1,1083 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/crypto/keyring/v1/remote_signer.proto
+
+package keyring
+
+import (
+	context "context"
+	fmt "fmt"
+	types "github.com/cosmos/cosmos-sdk/codec/types"
+	signing "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// RemoteSignerPubKeyRequest is the request type of the RemoteSigner/PubKey
+// RPC method.
+type RemoteSignerPubKeyRequest struct {
+	// key_id identifies the key in the signer.
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+}
+
+func (m *RemoteSignerPubKeyRequest) Reset()         { *m = RemoteSignerPubKeyRequest{} }
+func (m *RemoteSignerPubKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoteSignerPubKeyRequest) ProtoMessage()    {}
+func (*RemoteSignerPubKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70b1a9e6c78f2fda, []int{0}
+}
+func (m *RemoteSignerPubKeyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RemoteSignerPubKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoteSignerPubKeyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RemoteSignerPubKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoteSignerPubKeyRequest.Merge(m, src)
+}
+func (m *RemoteSignerPubKeyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RemoteSignerPubKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoteSignerPubKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoteSignerPubKeyRequest proto.InternalMessageInfo
+
+func (m *RemoteSignerPubKeyRequest) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+// RemoteSignerPubKeyResponse is the response type of the RemoteSigner/PubKey
+// RPC method.
+type RemoteSignerPubKeyResponse struct {
+	// pub_key is the public key of the key.
+	PubKey *types.Any `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+}
+
+func (m *RemoteSignerPubKeyResponse) Reset()         { *m = RemoteSignerPubKeyResponse{} }
+func (m *RemoteSignerPubKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoteSignerPubKeyResponse) ProtoMessage()    {}
+func (*RemoteSignerPubKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70b1a9e6c78f2fda, []int{1}
+}
+func (m *RemoteSignerPubKeyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RemoteSignerPubKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoteSignerPubKeyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RemoteSignerPubKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoteSignerPubKeyResponse.Merge(m, src)
+}
+func (m *RemoteSignerPubKeyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RemoteSignerPubKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoteSignerPubKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoteSignerPubKeyResponse proto.InternalMessageInfo
+
+func (m *RemoteSignerPubKeyResponse) GetPubKey() *types.Any {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+// RemoteSignerSignRequest is the request type of the RemoteSigner/Sign RPC
+// method.
+type RemoteSignerSignRequest struct {
+	// key_id identifies the key in the signer.
+	KeyId string `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// msg is the message to sign, i.e. the sign bytes of a transaction.
+	Msg []byte `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	// sign_mode is the sign mode of msg, so that the signer can decode and check
+	// it before signing.
+	SignMode signing.SignMode `protobuf:"varint,3,opt,name=sign_mode,json=signMode,proto3,enum=cosmos.tx.signing.v1beta1.SignMode" json:"sign_mode,omitempty"`
+}
+
+func (m *RemoteSignerSignRequest) Reset()         { *m = RemoteSignerSignRequest{} }
+func (m *RemoteSignerSignRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoteSignerSignRequest) ProtoMessage()    {}
+func (*RemoteSignerSignRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70b1a9e6c78f2fda, []int{2}
+}
+func (m *RemoteSignerSignRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RemoteSignerSignRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoteSignerSignRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RemoteSignerSignRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoteSignerSignRequest.Merge(m, src)
+}
+func (m *RemoteSignerSignRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RemoteSignerSignRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoteSignerSignRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoteSignerSignRequest proto.InternalMessageInfo
+
+func (m *RemoteSignerSignRequest) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *RemoteSignerSignRequest) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *RemoteSignerSignRequest) GetSignMode() signing.SignMode {
+	if m != nil {
+		return m.SignMode
+	}
+	return signing.SignMode_SIGN_MODE_UNSPECIFIED
+}
+
+// RemoteSignerSignResponse is the response type of the RemoteSigner/Sign RPC
+// method.
+type RemoteSignerSignResponse struct {
+	// signature is the signature of msg.
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *RemoteSignerSignResponse) Reset()         { *m = RemoteSignerSignResponse{} }
+func (m *RemoteSignerSignResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoteSignerSignResponse) ProtoMessage()    {}
+func (*RemoteSignerSignResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_70b1a9e6c78f2fda, []int{3}
+}
+func (m *RemoteSignerSignResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RemoteSignerSignResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoteSignerSignResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RemoteSignerSignResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoteSignerSignResponse.Merge(m, src)
+}
+func (m *RemoteSignerSignResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RemoteSignerSignResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoteSignerSignResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RemoteSignerSignResponse proto.InternalMessageInfo
+
+func (m *RemoteSignerSignResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*RemoteSignerPubKeyRequest)(nil), "cosmos.crypto.keyring.v1.RemoteSignerPubKeyRequest")
+	proto.RegisterType((*RemoteSignerPubKeyResponse)(nil), "cosmos.crypto.keyring.v1.RemoteSignerPubKeyResponse")
+	proto.RegisterType((*RemoteSignerSignRequest)(nil), "cosmos.crypto.keyring.v1.RemoteSignerSignRequest")
+	proto.RegisterType((*RemoteSignerSignResponse)(nil), "cosmos.crypto.keyring.v1.RemoteSignerSignResponse")
+}
+
+func init() {
+	proto.RegisterFile("cosmos/crypto/keyring/v1/remote_signer.proto", fileDescriptor_70b1a9e6c78f2fda)
+}
+
+var fileDescriptor_70b1a9e6c78f2fda = []byte{
+	// 395 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x52, 0xcd, 0xaa, 0xd3, 0x40,
+	0x14, 0xee, 0x58, 0x8d, 0x76, 0x2c, 0x22, 0x83, 0x62, 0x1a, 0x24, 0x94, 0xb8, 0xb0, 0xa0, 0x9d,
+	0x21, 0xa9, 0x0b, 0x97, 0x2a, 0xb8, 0x90, 0x22, 0x48, 0xdc, 0xb9, 0x09, 0x4d, 0x73, 0x8c, 0x21,
+	0x26, 0x13, 0x33, 0x93, 0xd2, 0xd9, 0xfb, 0x00, 0x3e, 0x96, 0xcb, 0x2e, 0x5d, 0x4a, 0xfb, 0x06,
+	0x3e, 0x81, 0x64, 0x26, 0xc1, 0xe2, 0xbd, 0xbd, 0xdc, 0x6e, 0x92, 0xc9, 0xf0, 0xfd, 0x9d, 0x2f,
+	0x07, 0x3f, 0x5f, 0x73, 0x51, 0x70, 0xc1, 0xd6, 0xb5, 0xaa, 0x24, 0x67, 0x39, 0xa8, 0x3a, 0x2b,
+	0x53, 0xb6, 0xf1, 0x59, 0x0d, 0x05, 0x97, 0x10, 0x89, 0x2c, 0x2d, 0xa1, 0xa6, 0x55, 0xcd, 0x25,
+	0x27, 0xb6, 0x41, 0x53, 0x83, 0xa6, 0x1d, 0x9a, 0x6e, 0x7c, 0x67, 0x92, 0x72, 0x9e, 0x7e, 0x05,
+	0xa6, 0x71, 0x71, 0xf3, 0x99, 0xad, 0x4a, 0x65, 0x48, 0xce, 0xd3, 0xce, 0x42, 0x6e, 0x59, 0x2b,
+	0x66, 0xe4, 0x63, 0x90, 0x2b, 0xbf, 0xff, 0x36, 0x40, 0x2f, 0xc0, 0x93, 0x50, 0x9b, 0x7e, 0xd4,
+	0x9e, 0x1f, 0x9a, 0x78, 0x09, 0x2a, 0x84, 0x6f, 0x0d, 0x08, 0x49, 0x1e, 0x62, 0x2b, 0x07, 0x15,
+	0x65, 0x89, 0x8d, 0xa6, 0x68, 0x36, 0x0a, 0x6f, 0xe5, 0xa0, 0xde, 0x25, 0xde, 0x12, 0x3b, 0x97,
+	0x71, 0x44, 0xc5, 0x4b, 0x01, 0x64, 0x8e, 0x6f, 0x57, 0x4d, 0x1c, 0xe5, 0xa0, 0x34, 0xeb, 0x6e,
+	0xf0, 0x80, 0x9a, 0x9c, 0xb4, 0xcf, 0x49, 0x5f, 0x97, 0x2a, 0xb4, 0x2a, 0x4d, 0xf3, 0xbe, 0x23,
+	0xfc, 0xe8, 0x58, 0xad, 0x7d, 0x5e, 0xed, 0x4f, 0xee, 0xe3, 0x61, 0x21, 0x52, 0xfb, 0xc6, 0x14,
+	0xcd, 0xc6, 0x61, 0x7b, 0x24, 0xaf, 0xf0, 0xa8, 0x1d, 0x2b, 0x2a, 0x78, 0x02, 0xf6, 0x70, 0x8a,
+	0x66, 0xf7, 0x82, 0x27, 0xb4, 0xeb, 0x4d, 0x6e, 0x69, 0x3f, 0x72, 0x57, 0x01, 0x6d, 0x3d, 0xde,
+	0xf3, 0x04, 0xc2, 0x3b, 0xa2, 0x3b, 0x79, 0x2f, 0xb1, 0x7d, 0x31, 0x45, 0x37, 0xd1, 0x63, 0xa3,
+	0xbe, 0x92, 0x4d, 0x0d, 0x3a, 0xc9, 0x38, 0xfc, 0x77, 0x11, 0xfc, 0x41, 0x78, 0x7c, 0x4c, 0x25,
+	0x02, 0x5b, 0xa6, 0x12, 0xb2, 0xa0, 0xa7, 0xfe, 0x1d, 0x3d, 0x59, 0xba, 0xf3, 0xe2, 0x3c, 0x52,
+	0x97, 0xb1, 0xc0, 0x37, 0xdb, 0x7b, 0xe2, 0x5f, 0x8f, 0x7d, 0xd4, 0xb2, 0x13, 0x9c, 0x43, 0x31,
+	0x76, 0x6f, 0xde, 0xfe, 0xdc, 0xbb, 0x68, 0xb7, 0x77, 0xd1, 0xef, 0xbd, 0x8b, 0x7e, 0x1c, 0xdc,
+	0xc1, 0xee, 0xe0, 0x0e, 0x7e, 0x1d, 0xdc, 0xc1, 0xa7, 0x67, 0x69, 0x26, 0xbf, 0x34, 0x31, 0x5d,
+	0xf3, 0x82, 0xf5, 0x7b, 0xae, 0x5f, 0x73, 0x91, 0xe4, 0xff, 0xad, 0x7c, 0x6c, 0xe9, 0x95, 0x58,
+	0xfc, 0x1d, 0x00, 0x5f, 0x1b, 0x76, 0x00, 0x12, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RemoteSignerClient is the client API for RemoteSigner service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RemoteSignerClient interface {
+	// PubKey returns the public key of a key.
+	PubKey(ctx context.Context, in *RemoteSignerPubKeyRequest, opts ...grpc.CallOption) (*RemoteSignerPubKeyResponse, error)
+	// Sign signs a message with a key.
+	Sign(ctx context.Context, in *RemoteSignerSignRequest, opts ...grpc.CallOption) (*RemoteSignerSignResponse, error)
+}
+
+type remoteSignerClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewRemoteSignerClient(cc grpc1.ClientConn) RemoteSignerClient {
+	return &remoteSignerClient{cc}
+}
+
+func (c *remoteSignerClient) PubKey(ctx context.Context, in *RemoteSignerPubKeyRequest, opts ...grpc.CallOption) (*RemoteSignerPubKeyResponse, error) {
+	out := new(RemoteSignerPubKeyResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.crypto.keyring.v1.RemoteSigner/PubKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteSignerClient) Sign(ctx context.Context, in *RemoteSignerSignRequest, opts ...grpc.CallOption) (*RemoteSignerSignResponse, error) {
+	out := new(RemoteSignerSignResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.crypto.keyring.v1.RemoteSigner/Sign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RemoteSignerServer is the server API for RemoteSigner service.
+type RemoteSignerServer interface {
+	// PubKey returns the public key of a key.
+	PubKey(context.Context, *RemoteSignerPubKeyRequest) (*RemoteSignerPubKeyResponse, error)
+	// Sign signs a message with a key.
+	Sign(context.Context, *RemoteSignerSignRequest) (*RemoteSignerSignResponse, error)
+}
+
+// UnimplementedRemoteSignerServer can be embedded to have forward compatible implementations.
+type UnimplementedRemoteSignerServer struct {
+}
+
+func (*UnimplementedRemoteSignerServer) PubKey(ctx context.Context, req *RemoteSignerPubKeyRequest) (*RemoteSignerPubKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PubKey not implemented")
+}
+func (*UnimplementedRemoteSignerServer) Sign(ctx context.Context, req *RemoteSignerSignRequest) (*RemoteSignerSignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
+}
+
+func RegisterRemoteSignerServer(s grpc1.Server, srv RemoteSignerServer) {
+	s.RegisterService(&_RemoteSigner_serviceDesc, srv)
+}
+
+func _RemoteSigner_PubKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoteSignerPubKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).PubKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.crypto.keyring.v1.RemoteSigner/PubKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).PubKey(ctx, req.(*RemoteSignerPubKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteSigner_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoteSignerSignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteSignerServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.crypto.keyring.v1.RemoteSigner/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteSignerServer).Sign(ctx, req.(*RemoteSignerSignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var RemoteSigner_serviceDesc = _RemoteSigner_serviceDesc
+var _RemoteSigner_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cosmos.crypto.keyring.v1.RemoteSigner",
+	HandlerType: (*RemoteSignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PubKey",
+			Handler:    _RemoteSigner_PubKey_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _RemoteSigner_Sign_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cosmos/crypto/keyring/v1/remote_signer.proto",
+}
+
+func (m *RemoteSignerPubKeyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoteSignerPubKeyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoteSignerPubKeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.KeyId) > 0 {
+		i -= len(m.KeyId)
+		copy(dAtA[i:], m.KeyId)
+		i = encodeVarintRemoteSigner(dAtA, i, uint64(len(m.KeyId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoteSignerPubKeyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoteSignerPubKeyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoteSignerPubKeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.PubKey != nil {
+		{
+			size, err := m.PubKey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRemoteSigner(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoteSignerSignRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoteSignerSignRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoteSignerSignRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SignMode != 0 {
+		i = encodeVarintRemoteSigner(dAtA, i, uint64(m.SignMode))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Msg) > 0 {
+		i -= len(m.Msg)
+		copy(dAtA[i:], m.Msg)
+		i = encodeVarintRemoteSigner(dAtA, i, uint64(len(m.Msg)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.KeyId) > 0 {
+		i -= len(m.KeyId)
+		copy(dAtA[i:], m.KeyId)
+		i = encodeVarintRemoteSigner(dAtA, i, uint64(len(m.KeyId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoteSignerSignResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoteSignerSignResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoteSignerSignResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Signature) > 0 {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintRemoteSigner(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintRemoteSigner(dAtA []byte, offset int, v uint64) int {
+	offset -= sovRemoteSigner(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *RemoteSignerPubKeyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.KeyId)
+	if l > 0 {
+		n += 1 + l + sovRemoteSigner(uint64(l))
+	}
+	return n
+}
+
+func (m *RemoteSignerPubKeyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PubKey != nil {
+		l = m.PubKey.Size()
+		n += 1 + l + sovRemoteSigner(uint64(l))
+	}
+	return n
+}
+
+func (m *RemoteSignerSignRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.KeyId)
+	if l > 0 {
+		n += 1 + l + sovRemoteSigner(uint64(l))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovRemoteSigner(uint64(l))
+	}
+	if m.SignMode != 0 {
+		n += 1 + sovRemoteSigner(uint64(m.SignMode))
+	}
+	return n
+}
+
+func (m *RemoteSignerSignResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Signature)
+	if l > 0 {
+		n += 1 + l + sovRemoteSigner(uint64(l))
+	}
+	return n
+}
+
+func sovRemoteSigner(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozRemoteSigner(x uint64) (n int) {
+	return sovRemoteSigner(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RemoteSignerPubKeyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRemoteSigner
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoteSignerPubKeyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoteSignerPubKeyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRemoteSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRemoteSigner(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoteSignerPubKeyResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRemoteSigner
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoteSignerPubKeyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoteSignerPubKeyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRemoteSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PubKey == nil {
+				m.PubKey = &types.Any{}
+			}
+			if err := m.PubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRemoteSigner(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoteSignerSignRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRemoteSigner
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoteSignerSignRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoteSignerSignRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRemoteSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRemoteSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
+			if m.Msg == nil {
+				m.Msg = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignMode", wireType)
+			}
+			m.SignMode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRemoteSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SignMode |= signing.SignMode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRemoteSigner(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoteSignerSignResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRemoteSigner
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoteSignerSignResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoteSignerSignResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRemoteSigner
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRemoteSigner(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRemoteSigner
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipRemoteSigner(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowRemoteSigner
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRemoteSigner
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRemoteSigner
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthRemoteSigner
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupRemoteSigner
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthRemoteSigner
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthRemoteSigner        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowRemoteSigner          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupRemoteSigner = fmt.Errorf("proto: unexpected end of group")
+)
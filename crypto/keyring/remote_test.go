@@ -0,0 +1,215 @@
+package keyring
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// badSignerServer returns signatures of the wrong message.
+type badSignerServer struct {
+	RemoteSignerServer
+}
+
+func (s badSignerServer) Sign(ctx context.Context, req *RemoteSignerSignRequest) (*RemoteSignerSignResponse, error) {
+	req.Msg = append(req.Msg, 0)
+	return s.RemoteSignerServer.Sign(ctx, req)
+}
+
+const testRemoteSignerToken = "secret"
+
+// newTestRemoteSignerServer returns the reference remote signer serving the
+// keys of kr to the clients authenticated by testRemoteSignerToken.
+func newTestRemoteSignerServer(t *testing.T, kr Keyring) RemoteSignerServer {
+	t.Helper()
+
+	server, err := NewRemoteSignerServer(kr, testRemoteSignerToken)
+	require.NoError(t, err)
+	return server
+}
+
+// startRemoteSigner serves the reference remote signer in process and returns
+// a dialer connecting to it whatever the endpoint.
+func startRemoteSigner(t *testing.T, server RemoteSignerServer) RemoteSignerDialer {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(grpc.ForceServerCodec(codec.NewProtoCodec(getCodec().InterfaceRegistry()).GRPCCodec()))
+	RegisterRemoteSignerServer(srv, server)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	return func(ctx context.Context, _ string) (*grpc.ClientConn, error) {
+		return grpc.DialContext(ctx, "bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	cdc := getCodec()
+	signer := NewInMemory(cdc)
+	signerKey, _, err := signer.NewMnemonic("validator-1", English, sdk.FullFundraiserPath, DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	signerPubKey, err := signerKey.GetPubKey()
+	require.NoError(t, err)
+
+	dialer := startRemoteSigner(t, newTestRemoteSignerServer(t, signer))
+	kr, err := New(t.Name(), BackendRemote, t.TempDir(), nil, cdc, WithRemoteSignerDialer(dialer), WithRemoteSignerToken(testRemoteSignerToken))
+	require.NoError(t, err)
+	require.Equal(t, BackendRemote, kr.Backend())
+
+	k, err := kr.SaveRemoteKey("remote", "localhost:9091", "validator-1")
+	require.NoError(t, err)
+	require.Equal(t, TypeRemote, k.GetType())
+	require.Equal(t, "localhost:9091", k.GetRemote().Endpoint)
+	pubKey, err := k.GetPubKey()
+	require.NoError(t, err)
+	require.True(t, signerPubKey.Equals(pubKey))
+
+	records, err := kr.List()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, TypeRemote, records[0].GetType())
+
+	msg := []byte("sign bytes")
+	sig, signPubKey, err := kr.Sign("remote", msg, signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+	require.True(t, pubKey.Equals(signPubKey))
+	require.True(t, pubKey.VerifySignature(msg, sig))
+
+	sig, _, err = kr.SignByAddress(sdk.AccAddress(pubKey.Address()), msg, signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(msg, sig))
+
+	// the remote records are renamed without a private key
+	require.NoError(t, kr.Rename("remote", "renamed"))
+	_, err = kr.Key("remote")
+	require.ErrorIs(t, err, sdkerrors.ErrKeyNotFound)
+	k, err = kr.Key("renamed")
+	require.NoError(t, err)
+	require.Equal(t, "validator-1", k.GetRemote().KeyId)
+	_, _, err = kr.Sign("renamed", msg, signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+
+	// unknown keys of the signer can't be added
+	_, err = kr.SaveRemoteKey("unknown", "localhost:9091", "validator-2")
+	require.ErrorIs(t, err, ErrRemoteSigner)
+
+	// the remote backend doesn't store private keys
+	_, _, err = kr.NewMnemonic("local", English, sdk.FullFundraiserPath, DefaultBIP39Passphrase, hd.Secp256k1)
+	require.ErrorIs(t, err, ErrLocalKeyRemoteBackend)
+
+	// remote keys can be stored in the other backends too
+	kr, err = New(t.Name(), BackendTest, t.TempDir(), nil, cdc, WithRemoteSignerDialer(dialer), WithRemoteSignerToken(testRemoteSignerToken))
+	require.NoError(t, err)
+	_, err = kr.SaveRemoteKey("remote", "localhost:9091", "validator-1")
+	require.NoError(t, err)
+	_, _, err = kr.Sign("remote", msg, signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+}
+
+func TestRemoteSignerInvalidSignature(t *testing.T) {
+	cdc := getCodec()
+	signer := NewInMemory(cdc)
+	_, _, err := signer.NewMnemonic("validator-1", English, sdk.FullFundraiserPath, DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+
+	dialer := startRemoteSigner(t, badSignerServer{newTestRemoteSignerServer(t, signer)})
+	kr := NewInMemory(cdc, WithRemoteSignerDialer(dialer), WithRemoteSignerToken(testRemoteSignerToken))
+	_, err = kr.SaveRemoteKey("remote", "localhost:9091", "validator-1")
+	require.NoError(t, err)
+
+	_, _, err = kr.Sign("remote", []byte("sign bytes"), signing.SignMode_SIGN_MODE_DIRECT)
+	require.ErrorIs(t, err, ErrRemoteInvalidSignature)
+}
+
+func TestRemoteSignerPolicy(t *testing.T) {
+	cdc := getCodec()
+	auditLog := NewAuditLog(filepath.Join(t.TempDir(), AuditLogFileName))
+	signer := NewInMemory(cdc, WithAuditLog(auditLog))
+	_, _, err := signer.NewMnemonic("validator-1", English, sdk.FullFundraiserPath, DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	_, err = signer.SetPolicy("validator-1", &KeyPolicy{AllowedChainIds: []string{"test-chain"}})
+	require.NoError(t, err)
+
+	dialer := startRemoteSigner(t, newTestRemoteSignerServer(t, signer))
+	kr := NewInMemory(cdc, WithRemoteSignerDialer(dialer), WithRemoteSignerToken(testRemoteSignerToken))
+	_, err = kr.SaveRemoteKey("remote", "localhost:9091", "validator-1")
+	require.NoError(t, err)
+
+	// the signer enforces the policies of its keys
+	_, _, err = kr.Sign("remote", directSignBytes(t, "test-chain", nil), signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+	_, _, err = kr.Sign("remote", directSignBytes(t, "other-chain", nil), signing.SignMode_SIGN_MODE_DIRECT)
+	require.ErrorIs(t, err, ErrRemoteSigner)
+
+	entries, err := auditLog.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.True(t, entries[0].Allowed)
+	require.False(t, entries[1].Allowed)
+}
+
+func TestRemoteSignerAuthentication(t *testing.T) {
+	cdc := getCodec()
+	signer := NewInMemory(cdc)
+	_, _, err := signer.NewMnemonic("validator-1", English, sdk.FullFundraiserPath, DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+
+	_, err = NewRemoteSignerServer(signer, "")
+	require.ErrorIs(t, err, ErrRemoteSigner)
+
+	server := newTestRemoteSignerServer(t, signer)
+	_, err = server.PubKey(context.Background(), &RemoteSignerPubKeyRequest{KeyId: "validator-1"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer wrong"))
+	_, err = server.Sign(ctx, &RemoteSignerSignRequest{KeyId: "validator-1", Msg: []byte("sign bytes")})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	dialer := startRemoteSigner(t, server)
+	t.Setenv(RemoteSignerTokenEnv, "")
+
+	// the keyring requires a token
+	kr := NewInMemory(cdc, WithRemoteSignerDialer(dialer))
+	_, err = kr.SaveRemoteKey("remote", "localhost:9091", "validator-1")
+	require.ErrorIs(t, err, ErrRemoteSigner)
+
+	// the signer rejects a wrong token
+	kr = NewInMemory(cdc, WithRemoteSignerDialer(dialer), WithRemoteSignerToken("wrong"))
+	_, err = kr.SaveRemoteKey("remote", "localhost:9091", "validator-1")
+	require.ErrorIs(t, err, ErrRemoteSigner)
+
+	// the token defaults to the environment variable
+	t.Setenv(RemoteSignerTokenEnv, testRemoteSignerToken)
+	kr = NewInMemory(cdc, WithRemoteSignerDialer(dialer))
+	_, err = kr.SaveRemoteKey("remote", "localhost:9091", "validator-1")
+	require.NoError(t, err)
+	_, _, err = kr.Sign("remote", []byte("sign bytes"), signing.SignMode_SIGN_MODE_DIRECT)
+	require.NoError(t, err)
+}
+
+func TestIsLocalEndpoint(t *testing.T) {
+	require.True(t, isLocalEndpoint("unix:///run/signer.sock"))
+	require.True(t, isLocalEndpoint("localhost:9091"))
+	require.True(t, isLocalEndpoint("127.0.0.1:9091"))
+	require.True(t, isLocalEndpoint("[::1]:9091"))
+	require.False(t, isLocalEndpoint("signer.example.com:443"))
+	require.False(t, isLocalEndpoint("10.0.0.1:9091"))
+}
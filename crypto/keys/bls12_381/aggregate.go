@@ -0,0 +1,71 @@
+package bls12_381
+
+import (
+	"errors"
+	"fmt"
+
+	bls12381 "github.com/cloudflare/circl/ecc/bls12381"
+)
+
+// AggregateSignatures aggregates signatures, of the same message or not, into
+// a single signature of the same size.
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+
+	var aggregated bls12381.G2
+	aggregated.SetIdentity()
+	for i, sig := range sigs {
+		s, err := parseSignature(sig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %d: %w", i, err)
+		}
+		aggregated.Add(&aggregated, s)
+	}
+
+	return aggregated.BytesCompressed(), nil
+}
+
+// AggregatePubKeys aggregates public keys into a single public key, which
+// verifies the aggregated signatures of a message by all of them. The proof
+// of possession of every public key must have been verified beforehand.
+func AggregatePubKeys(pubKeys []*PubKey) (*PubKey, error) {
+	if len(pubKeys) == 0 {
+		return nil, errors.New("no pubkeys to aggregate")
+	}
+
+	var aggregated bls12381.G1
+	aggregated.SetIdentity()
+	for i, pubKey := range pubKeys {
+		pk, err := pubKey.point()
+		if err != nil {
+			return nil, fmt.Errorf("invalid pubkey %d: %w", i, err)
+		}
+		aggregated.Add(&aggregated, pk)
+	}
+	if aggregated.IsIdentity() {
+		return nil, errors.New("aggregated pubkey is the identity")
+	}
+
+	return &PubKey{Key: aggregated.BytesCompressed()}, nil
+}
+
+// VerifyAggregateSignature verifies the aggregated signature of msg by all
+// the pubKeys, with a single pairing check. The proof of possession of every
+// public key must have been verified beforehand.
+func VerifyAggregateSignature(pubKeys []*PubKey, msg, sig []byte) bool {
+	pubKey, err := AggregatePubKeys(pubKeys)
+	if err != nil {
+		return false
+	}
+
+	return pubKey.VerifySignature(msg, sig)
+}
+
+// VerifyAggregateSignatureOfMessages verifies the aggregated signature of
+// msgs[i] by pubKeys[i] for every i. Its cost grows with the number of
+// signers, but the messages may differ.
+func VerifyAggregateSignatureOfMessages(pubKeys []*PubKey, msgs [][]byte, sig []byte) bool {
+	return verify(pubKeys, msgs, sig, dstSignature)
+}
@@ -0,0 +1,149 @@
+package bls12_381_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/bls12_381"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	privKey := bls12_381.GenPrivKey()
+	pubKey := privKey.PubKey()
+	require.Len(t, pubKey.Bytes(), bls12_381.PubKeySize)
+	require.Len(t, pubKey.Address(), 20)
+
+	msg := []byte("checkpoint")
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, bls12_381.SignatureSize)
+	require.True(t, pubKey.VerifySignature(msg, sig))
+	require.False(t, pubKey.VerifySignature([]byte("other"), sig))
+	require.False(t, bls12_381.GenPrivKey().PubKey().VerifySignature(msg, sig))
+	require.False(t, pubKey.VerifySignature(msg, sig[1:]))
+
+	require.True(t, privKey.Equals(privKey))
+	require.False(t, privKey.Equals(bls12_381.GenPrivKey()))
+	require.Equal(t, bls12_381.GenPrivKeyFromSecret([]byte("secret")), bls12_381.GenPrivKeyFromSecret([]byte("secret")))
+}
+
+// TestSignVector checks the interoperability of the signatures with a test
+// vector of the Ethereum consensus specs, which use the same ciphersuite.
+func TestSignVector(t *testing.T) {
+	key, err := hex.DecodeString("263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3")
+	require.NoError(t, err)
+	expected, err := hex.DecodeString("b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55")
+	require.NoError(t, err)
+
+	privKey := &bls12_381.PrivKey{Key: key}
+	sig, err := privKey.Sign(make([]byte, 32))
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+}
+
+func TestProofOfPossession(t *testing.T) {
+	privKey := bls12_381.GenPrivKey()
+	pubKey := privKey.PubKey().(*bls12_381.PubKey)
+
+	proof, err := privKey.ProvePossession()
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifyPossession(proof))
+
+	// a proof of possession isn't a signature of the pubkey
+	require.False(t, pubKey.VerifySignature(pubKey.Bytes(), proof))
+	sig, err := privKey.Sign(pubKey.Bytes())
+	require.NoError(t, err)
+	require.False(t, pubKey.VerifyPossession(sig))
+}
+
+func TestAggregateSameMessage(t *testing.T) {
+	msg := []byte("checkpoint")
+	pubKeys := make([]*bls12_381.PubKey, 4)
+	sigs := make([][]byte, len(pubKeys))
+	for i := range pubKeys {
+		privKey := bls12_381.GenPrivKey()
+		pubKeys[i] = privKey.PubKey().(*bls12_381.PubKey)
+		var err error
+		sigs[i], err = privKey.Sign(msg)
+		require.NoError(t, err)
+	}
+
+	aggregated, err := bls12_381.AggregateSignatures(sigs)
+	require.NoError(t, err)
+	require.Len(t, aggregated, bls12_381.SignatureSize)
+	require.True(t, bls12_381.VerifyAggregateSignature(pubKeys, msg, aggregated))
+	require.False(t, bls12_381.VerifyAggregateSignature(pubKeys, []byte("other"), aggregated))
+	require.False(t, bls12_381.VerifyAggregateSignature(pubKeys[1:], msg, aggregated))
+
+	aggregatedPubKey, err := bls12_381.AggregatePubKeys(pubKeys)
+	require.NoError(t, err)
+	require.True(t, aggregatedPubKey.VerifySignature(msg, aggregated))
+
+	// a missing signature makes the aggregate invalid
+	partial, err := bls12_381.AggregateSignatures(sigs[1:])
+	require.NoError(t, err)
+	require.False(t, bls12_381.VerifyAggregateSignature(pubKeys, msg, partial))
+
+	_, err = bls12_381.AggregateSignatures(nil)
+	require.Error(t, err)
+	_, err = bls12_381.AggregateSignatures([][]byte{sigs[0][1:]})
+	require.Error(t, err)
+	_, err = bls12_381.AggregatePubKeys(nil)
+	require.Error(t, err)
+}
+
+func TestAggregateDistinctMessages(t *testing.T) {
+	pubKeys := make([]*bls12_381.PubKey, 3)
+	msgs := make([][]byte, len(pubKeys))
+	sigs := make([][]byte, len(pubKeys))
+	for i := range pubKeys {
+		privKey := bls12_381.GenPrivKey()
+		pubKeys[i] = privKey.PubKey().(*bls12_381.PubKey)
+		msgs[i] = []byte{byte(i)}
+		var err error
+		sigs[i], err = privKey.Sign(msgs[i])
+		require.NoError(t, err)
+	}
+
+	aggregated, err := bls12_381.AggregateSignatures(sigs)
+	require.NoError(t, err)
+	require.True(t, bls12_381.VerifyAggregateSignatureOfMessages(pubKeys, msgs, aggregated))
+	require.False(t, bls12_381.VerifyAggregateSignatureOfMessages(pubKeys, [][]byte{msgs[1], msgs[0], msgs[2]}, aggregated))
+	require.False(t, bls12_381.VerifyAggregateSignatureOfMessages(pubKeys, msgs[1:], aggregated))
+	require.False(t, bls12_381.VerifyAggregateSignature(pubKeys, msgs[0], aggregated))
+}
+
+func TestCodec(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+
+	privKey := bls12_381.GenPrivKey()
+	pubKey := privKey.PubKey()
+
+	bz, err := cdc.MarshalInterface(pubKey)
+	require.NoError(t, err)
+	var decodedPubKey cryptotypes.PubKey
+	require.NoError(t, cdc.UnmarshalInterface(bz, &decodedPubKey))
+	require.True(t, pubKey.Equals(decodedPubKey))
+
+	bz, err = cdc.MarshalInterface(privKey)
+	require.NoError(t, err)
+	var decodedPrivKey cryptotypes.PrivKey
+	require.NoError(t, cdc.UnmarshalInterface(bz, &decodedPrivKey))
+	require.True(t, privKey.Equals(decodedPrivKey))
+
+	amino := codec.NewLegacyAmino()
+	cryptocodec.RegisterCrypto(amino)
+	bz, err = amino.MarshalJSON(pubKey)
+	require.NoError(t, err)
+	var aminoPubKey cryptotypes.PubKey
+	require.NoError(t, amino.UnmarshalJSON(bz, &aminoPubKey))
+	require.True(t, pubKey.Equals(aminoPubKey))
+}
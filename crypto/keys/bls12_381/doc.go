@@ -0,0 +1,18 @@
+/*
+Package bls12_381 implements BLS signatures over the BLS12-381 curve, in the
+minimal public key size variant used by Ethereum and CometBFT: public keys are
+48 bytes compressed points of G1 and signatures 96 bytes compressed points of
+G2.
+
+Messages are hashed to G2 as defined by the BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_
+ciphersuite of the IETF BLS signature draft, so signatures are interoperable
+with the other implementations of the proof of possession scheme.
+
+The signatures of many keys can be aggregated into a single signature, of the
+same size, with AggregateSignatures. An aggregated signature of one message is
+verified with VerifyAggregateSignature, using a single pairing check whatever
+the number of signers. It's only safe if the proof of possession of every
+public key, produced by PrivKey.ProvePossession, was verified beforehand, e.g.
+when the key was registered, as a rogue key could otherwise forge it.
+*/
+package bls12_381
@@ -0,0 +1,196 @@
+package bls12_381
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	bls12381 "github.com/cloudflare/circl/ecc/bls12381"
+	"golang.org/x/crypto/hkdf"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	PrivKeyName = "cosmos/PrivKeyBls12_381"
+	PubKeyName  = "cosmos/PubKeyBls12_381"
+	// PrivKeySize is the size, in bytes, of private keys as used in this package.
+	PrivKeySize = bls12381.ScalarSize
+	// PubKeySize is the size, in bytes, of a compressed point of G1.
+	PubKeySize = bls12381.G1SizeCompressed
+	// SignatureSize is the size, in bytes, of a compressed point of G2.
+	SignatureSize = bls12381.G2SizeCompressed
+
+	keyType = "bls12_381"
+
+	// keyGenSalt is the initial salt of KeyGen.
+	keyGenSalt = "BLS-SIG-KEYGEN-SALT-"
+	// dstSignature is the domain separation tag of the hash of the signed
+	// messages.
+	dstSignature = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+	// dstProofOfPossession is the domain separation tag of the hash of the
+	// public keys signed by the proofs of possession.
+	dstProofOfPossession = "BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+var (
+	_ cryptotypes.PrivKey  = &PrivKey{}
+	_ codec.AminoMarshaler = &PrivKey{}
+)
+
+// GenPrivKey generates a new private key from the OS randomness.
+func GenPrivKey() *PrivKey {
+	return genPrivKey(rand.Reader)
+}
+
+func genPrivKey(rand io.Reader) *PrivKey {
+	ikm := make([]byte, 32)
+	if _, err := io.ReadFull(rand, ikm); err != nil {
+		panic(err)
+	}
+
+	return keyGen(ikm)
+}
+
+// GenPrivKeyFromSecret deterministically generates a private key from the
+// SHA-256 hash of secret. The secret must have a high entropy and be kept
+// secret, as anyone knowing it knows the private key.
+func GenPrivKeyFromSecret(secret []byte) *PrivKey {
+	ikm := sha256.Sum256(secret)
+	return keyGen(ikm[:])
+}
+
+// keyGen derives a private key from the input keying material, as the KeyGen
+// function of the IETF BLS signature draft.
+func keyGen(ikm []byte) *PrivKey {
+	salt := []byte(keyGenSalt)
+	var sk bls12381.Scalar
+	for sk.IsZero() == 1 {
+		hash := sha256.Sum256(salt)
+		salt = hash[:]
+
+		okm := make([]byte, 48)
+		prk := hkdf.Extract(sha256.New, append(append([]byte{}, ikm...), 0), salt)
+		if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, []byte{0, 48}), okm); err != nil {
+			panic(err)
+		}
+		sk.SetBytes(okm)
+	}
+
+	bz, err := sk.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return &PrivKey{Key: bz}
+}
+
+// Bytes returns the privkey byte format.
+func (privKey *PrivKey) Bytes() []byte {
+	return privKey.Key
+}
+
+// Sign produces the signature of msg, a point of G2.
+func (privKey *PrivKey) Sign(msg []byte) ([]byte, error) {
+	return privKey.sign(msg, dstSignature)
+}
+
+// ProvePossession returns the proof of possession of the private key, the
+// signature of its public key under a dedicated domain. It must be verified,
+// with PubKey.VerifyPossession, before the public key is aggregated.
+func (privKey *PrivKey) ProvePossession() ([]byte, error) {
+	return privKey.sign(privKey.PubKey().Bytes(), dstProofOfPossession)
+}
+
+func (privKey *PrivKey) sign(msg []byte, dst string) ([]byte, error) {
+	sk, err := privKey.scalar()
+	if err != nil {
+		return nil, err
+	}
+
+	var sig bls12381.G2
+	sig.ScalarMult(sk, hashToG2(msg, dst))
+	return sig.BytesCompressed(), nil
+}
+
+// PubKey gets the corresponding public key from the private key. It panics if
+// the private key is malformed.
+func (privKey *PrivKey) PubKey() cryptotypes.PubKey {
+	sk, err := privKey.scalar()
+	if err != nil {
+		panic(err)
+	}
+
+	var pk bls12381.G1
+	pk.ScalarMult(sk, bls12381.G1Generator())
+	return &PubKey{Key: pk.BytesCompressed()}
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey *PrivKey) Equals(other cryptotypes.LedgerPrivKey) bool {
+	if privKey.Type() != other.Type() {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(privKey.Bytes(), other.Bytes()) == 1
+}
+
+func (privKey *PrivKey) Type() string {
+	return keyType
+}
+
+// MarshalAmino overrides Amino binary marshaling.
+func (privKey PrivKey) MarshalAmino() ([]byte, error) {
+	return privKey.Key, nil
+}
+
+// UnmarshalAmino overrides Amino binary marshaling.
+func (privKey *PrivKey) UnmarshalAmino(bz []byte) error {
+	if len(bz) != PrivKeySize {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidType, "invalid privkey size")
+	}
+	privKey.Key = bz
+
+	return nil
+}
+
+// MarshalAminoJSON overrides Amino JSON marshaling.
+func (privKey PrivKey) MarshalAminoJSON() ([]byte, error) {
+	// When we marshal to Amino JSON, we don't marshal the "key" field itself,
+	// just its contents (i.e. the key bytes).
+	return privKey.MarshalAmino()
+}
+
+// UnmarshalAminoJSON overrides Amino JSON marshaling.
+func (privKey *PrivKey) UnmarshalAminoJSON(bz []byte) error {
+	return privKey.UnmarshalAmino(bz)
+}
+
+// scalar parses the private key, a non-zero scalar lower than the group order.
+func (privKey *PrivKey) scalar() (*bls12381.Scalar, error) {
+	if len(privKey.Key) != PrivKeySize {
+		return nil, errors.New("invalid private key size")
+	}
+
+	var sk bls12381.Scalar
+	if err := sk.UnmarshalBinary(privKey.Key); err != nil {
+		return nil, err
+	}
+	if sk.IsZero() == 1 {
+		return nil, errors.New("private key is zero")
+	}
+
+	return &sk, nil
+}
+
+func hashToG2(msg []byte, dst string) *bls12381.G2 {
+	var h bls12381.G2
+	h.Hash(msg, []byte(dst))
+	return &h
+}
@@ -0,0 +1,152 @@
+package bls12_381
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	bls12381 "github.com/cloudflare/circl/ecc/bls12381"
+	"github.com/cometbft/cometbft/crypto"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+var (
+	_ cryptotypes.PubKey   = &PubKey{}
+	_ codec.AminoMarshaler = &PubKey{}
+)
+
+// Address is the SHA256-20 of the raw pubkey bytes, as for the ed25519
+// validator keys.
+func (pubKey *PubKey) Address() crypto.Address {
+	if len(pubKey.Key) != PubKeySize {
+		panic("pubkey is incorrect size")
+	}
+	return crypto.AddressHash(pubKey.Key)
+}
+
+// Bytes returns the PubKey byte format.
+func (pubKey *PubKey) Bytes() []byte {
+	return pubKey.Key
+}
+
+// VerifySignature verifies the signature of msg, or the aggregated signature
+// of msg by the keys aggregated into the public key by AggregatePubKeys.
+func (pubKey *PubKey) VerifySignature(msg, sig []byte) bool {
+	return verify([]*PubKey{pubKey}, [][]byte{msg}, sig, dstSignature)
+}
+
+// VerifyPossession verifies the proof of possession of the private key of
+// pubKey, as returned by PrivKey.ProvePossession.
+func (pubKey *PubKey) VerifyPossession(proof []byte) bool {
+	return verify([]*PubKey{pubKey}, [][]byte{pubKey.Key}, proof, dstProofOfPossession)
+}
+
+func (pubKey *PubKey) String() string {
+	return fmt.Sprintf("PubKeyBls12_381{%X}", pubKey.Key)
+}
+
+func (pubKey *PubKey) Type() string {
+	return keyType
+}
+
+func (pubKey *PubKey) Equals(other cryptotypes.PubKey) bool {
+	if pubKey.Type() != other.Type() {
+		return false
+	}
+
+	return bytes.Equal(pubKey.Bytes(), other.Bytes())
+}
+
+// MarshalAmino overrides Amino binary marshaling.
+func (pubKey PubKey) MarshalAmino() ([]byte, error) {
+	return pubKey.Key, nil
+}
+
+// UnmarshalAmino overrides Amino binary marshaling.
+func (pubKey *PubKey) UnmarshalAmino(bz []byte) error {
+	if len(bz) != PubKeySize {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidPubKey, "invalid pubkey size")
+	}
+	pubKey.Key = bz
+
+	return nil
+}
+
+// MarshalAminoJSON overrides Amino JSON marshaling.
+func (pubKey PubKey) MarshalAminoJSON() ([]byte, error) {
+	// When we marshal to Amino JSON, we don't marshal the "key" field itself,
+	// just its contents (i.e. the key bytes).
+	return pubKey.MarshalAmino()
+}
+
+// UnmarshalAminoJSON overrides Amino JSON marshaling.
+func (pubKey *PubKey) UnmarshalAminoJSON(bz []byte) error {
+	return pubKey.UnmarshalAmino(bz)
+}
+
+// point parses the public key, a compressed point of G1 which must not be the
+// identity.
+func (pubKey *PubKey) point() (*bls12381.G1, error) {
+	if len(pubKey.Key) != PubKeySize {
+		return nil, errors.New("invalid pubkey size")
+	}
+
+	var pk bls12381.G1
+	if err := pk.SetBytes(pubKey.Key); err != nil {
+		return nil, err
+	}
+	if pk.IsIdentity() {
+		return nil, errors.New("pubkey is the identity")
+	}
+
+	return &pk, nil
+}
+
+// parseSignature parses a signature, a compressed point of G2.
+func parseSignature(sig []byte) (*bls12381.G2, error) {
+	if len(sig) != SignatureSize {
+		return nil, errors.New("invalid signature size")
+	}
+
+	var p bls12381.G2
+	if err := p.SetBytes(sig); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// verify checks that sig is the aggregated signature of msgs[i] by pubKeys[i]
+// for every i, i.e. that e(-G1, sig) * prod e(pubKeys[i], H(msgs[i])) = 1.
+func verify(pubKeys []*PubKey, msgs [][]byte, sig []byte, dst string) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) {
+		return false
+	}
+	s, err := parseSignature(sig)
+	if err != nil {
+		return false
+	}
+
+	g1s := make([]*bls12381.G1, 0, len(pubKeys)+1)
+	g2s := make([]*bls12381.G2, 0, len(pubKeys)+1)
+	signs := make([]int, 0, len(pubKeys)+1)
+	for i, pubKey := range pubKeys {
+		pk, err := pubKey.point()
+		if err != nil {
+			return false
+		}
+		g1s = append(g1s, pk)
+		g2s = append(g2s, hashToG2(msgs[i], dst))
+		signs = append(signs, 1)
+	}
+	g1s = append(g1s, bls12381.G1Generator())
+	g2s = append(g2s, s)
+	signs = append(signs, -1)
+
+	return bls12381.ProdPairFrac(g1s, g2s, signs).IsIdentity()
+}
@@ -0,0 +1,283 @@
+package frost
+
+import (
+	"fmt"
+
+	secp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// DKGRound1Package is broadcast by a participant to all the others in the
+// first round of the distributed key generation. It commits to the secret
+// polynomial of the participant and proves the knowledge of its constant
+// term.
+type DKGRound1Package struct {
+	Index       uint32   `json:"index"`
+	Commitments [][]byte `json:"commitments"`
+	ProofR      []byte   `json:"proof_r"`
+	ProofZ      []byte   `json:"proof_z"`
+}
+
+// DKGParticipant runs the Pedersen distributed key generation of FROST for one
+// participant, so that no party ever knows the secret key of the group:
+//
+//  1. every participant broadcasts its Round1 package;
+//  2. every participant verifies the packages of the others with Round2 and
+//     sends each of them its secret share privately, e.g. encrypted to them;
+//  3. every participant verifies the shares sent to it with Finalize and gets
+//     its KeyShare.
+type DKGParticipant struct {
+	index        uint32
+	threshold    uint32
+	participants uint32
+	coefficients []secp256k1.ModNScalar
+	round1       DKGRound1Package
+}
+
+// NewDKGParticipant returns the participant of the given index, from 1 to the
+// number of participants, in the generation of a key of the given threshold.
+func NewDKGParticipant(index, threshold, participants uint32) (*DKGParticipant, error) {
+	if err := validateParams(index, threshold, participants); err != nil {
+		return nil, err
+	}
+
+	coefficients, commitments, err := randomPolynomial(threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	// prove the knowledge of the constant term to prevent rogue key attacks
+	k, err := randomScalar()
+	if err != nil {
+		return nil, err
+	}
+	proofR, err := serializePoint(baseMul(&k))
+	if err != nil {
+		return nil, err
+	}
+	c := hashToScalar("dkg", indexBytes(index), commitments[0], proofR)
+	z := *c.Mul(&coefficients[0]).Add(&k)
+
+	return &DKGParticipant{
+		index:        index,
+		threshold:    threshold,
+		participants: participants,
+		coefficients: coefficients,
+		round1: DKGRound1Package{
+			Index:       index,
+			Commitments: commitments,
+			ProofR:      proofR,
+			ProofZ:      scalarBytes(&z),
+		},
+	}, nil
+}
+
+// Round1 returns the package to broadcast to the other participants.
+func (p *DKGParticipant) Round1() DKGRound1Package {
+	return p.round1
+}
+
+// Round2 verifies the round 1 packages of the other participants and returns
+// the secret share to send privately to each of them, by index.
+func (p *DKGParticipant) Round2(packages []DKGRound1Package) (map[uint32][]byte, error) {
+	if _, err := p.verifyPackages(packages); err != nil {
+		return nil, err
+	}
+
+	shares := make(map[uint32][]byte, p.participants-1)
+	for j := uint32(1); j <= p.participants; j++ {
+		if j == p.index {
+			continue
+		}
+		share := evalPolynomial(p.coefficients, j)
+		shares[j] = scalarBytes(&share)
+	}
+
+	return shares, nil
+}
+
+// Finalize verifies the secret shares sent to the participant by the others,
+// by the index of their sender, against their round 1 commitments and returns
+// the key share of the participant.
+func (p *DKGParticipant) Finalize(packages []DKGRound1Package, shares map[uint32][]byte) (*KeyShare, error) {
+	commitments, err := p.verifyPackages(packages)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) != int(p.participants-1) {
+		return nil, fmt.Errorf("expected %d secret shares, got %d", p.participants-1, len(shares))
+	}
+
+	secret := evalPolynomial(p.coefficients, p.index)
+	for sender, bz := range shares {
+		senderCommitments, ok := commitments[sender]
+		if !ok || sender == p.index {
+			return nil, fmt.Errorf("unexpected secret share from participant %d", sender)
+		}
+		share, err := parseScalar(bz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret share from participant %d: %w", sender, err)
+		}
+		if !pointsEqual(baseMul(&share), evalCommitments(senderCommitments, p.index)) {
+			return nil, fmt.Errorf("secret share from participant %d doesn't match its commitments", sender)
+		}
+		secret.Add(&share)
+	}
+
+	return newKeyShare(p.index, p.threshold, p.participants, secret, commitments)
+}
+
+// verifyPackages verifies the round 1 packages of the other participants and
+// returns the commitments of all the participants, by index.
+func (p *DKGParticipant) verifyPackages(packages []DKGRound1Package) (map[uint32][]*secp256k1.JacobianPoint, error) {
+	commitments := make(map[uint32][]*secp256k1.JacobianPoint, p.participants)
+	own, err := parseCommitments(p.round1.Commitments, p.threshold)
+	if err != nil {
+		return nil, err
+	}
+	commitments[p.index] = own
+
+	for _, pkg := range packages {
+		if pkg.Index == p.index {
+			continue
+		}
+		if pkg.Index == 0 || pkg.Index > p.participants {
+			return nil, fmt.Errorf("unknown participant %d", pkg.Index)
+		}
+		if _, ok := commitments[pkg.Index]; ok {
+			return nil, fmt.Errorf("duplicate package of participant %d", pkg.Index)
+		}
+
+		points, err := parseCommitments(pkg.Commitments, p.threshold)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commitments of participant %d: %w", pkg.Index, err)
+		}
+		if err := verifyProofOfKnowledge(pkg, points[0]); err != nil {
+			return nil, fmt.Errorf("invalid proof of knowledge of participant %d: %w", pkg.Index, err)
+		}
+		commitments[pkg.Index] = points
+	}
+
+	if len(commitments) != int(p.participants) {
+		return nil, fmt.Errorf("expected the packages of %d participants, got %d", p.participants, len(commitments))
+	}
+
+	return commitments, nil
+}
+
+func verifyProofOfKnowledge(pkg DKGRound1Package, constant *secp256k1.JacobianPoint) error {
+	r, err := parsePoint(pkg.ProofR)
+	if err != nil {
+		return err
+	}
+	z, err := parseScalar(pkg.ProofZ)
+	if err != nil {
+		return err
+	}
+
+	c := hashToScalar("dkg", indexBytes(pkg.Index), pkg.Commitments[0], pkg.ProofR)
+	if !pointsEqual(baseMul(&z), addPoints(r, mulPoint(&c, constant))) {
+		return fmt.Errorf("proof doesn't verify")
+	}
+
+	return nil
+}
+
+func parseCommitments(commitments [][]byte, threshold uint32) ([]*secp256k1.JacobianPoint, error) {
+	if len(commitments) != int(threshold) {
+		return nil, fmt.Errorf("expected %d commitments, got %d", threshold, len(commitments))
+	}
+
+	points := make([]*secp256k1.JacobianPoint, len(commitments))
+	for i, bz := range commitments {
+		point, err := parsePoint(bz)
+		if err != nil {
+			return nil, err
+		}
+		points[i] = point
+	}
+
+	return points, nil
+}
+
+// GenerateKeyShares generates a threshold key with a trusted dealer and
+// returns the key shares of all the participants, ordered by index. The
+// caller knows the secret key of the group while it runs, the DKGParticipant
+// must be preferred when no party may know it.
+func GenerateKeyShares(threshold, participants uint32) ([]*KeyShare, error) {
+	if err := validateParams(1, threshold, participants); err != nil {
+		return nil, err
+	}
+
+	coefficients, commitments, err := randomPolynomial(threshold)
+	if err != nil {
+		return nil, err
+	}
+	points, err := parseCommitments(commitments, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	keyShares := make([]*KeyShare, participants)
+	for i := uint32(1); i <= participants; i++ {
+		keyShares[i-1], err = newKeyShare(i, threshold, participants, evalPolynomial(coefficients, i), map[uint32][]*secp256k1.JacobianPoint{1: points})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keyShares, nil
+}
+
+// randomPolynomial returns the coefficients of a random polynomial of degree
+// threshold - 1 and their compressed commitments.
+func randomPolynomial(threshold uint32) ([]secp256k1.ModNScalar, [][]byte, error) {
+	coefficients := make([]secp256k1.ModNScalar, threshold)
+	commitments := make([][]byte, threshold)
+	for i := range coefficients {
+		var err error
+		coefficients[i], err = randomScalar()
+		if err != nil {
+			return nil, nil, err
+		}
+		commitments[i], err = serializePoint(baseMul(&coefficients[i]))
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return coefficients, commitments, nil
+}
+
+// newKeyShare returns the key share of the participant with the given secret
+// share, deriving the group key and the verification shares from the
+// commitments to the polynomials summed into the secret shares.
+func newKeyShare(index, threshold, participants uint32, secret secp256k1.ModNScalar, commitments map[uint32][]*secp256k1.JacobianPoint) (*KeyShare, error) {
+	var groupKey secp256k1.JacobianPoint
+	verificationShares := make([]secp256k1.JacobianPoint, participants)
+	for _, points := range commitments {
+		groupKey = *addPoints(&groupKey, points[0])
+		for j := range verificationShares {
+			verificationShares[j] = *addPoints(&verificationShares[j], evalCommitments(points, uint32(j+1)))
+		}
+	}
+
+	keyShare := &KeyShare{
+		Index:              index,
+		Threshold:          threshold,
+		Secret:             scalarBytes(&secret),
+		VerificationShares: make([][]byte, participants),
+	}
+	var err error
+	keyShare.GroupKey, err = serializePoint(&groupKey)
+	if err != nil {
+		return nil, err
+	}
+	for j := range verificationShares {
+		keyShare.VerificationShares[j], err = serializePoint(&verificationShares[j])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return keyShare, keyShare.Validate()
+}
@@ -0,0 +1,204 @@
+package frost
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cosmos/gogoproto/proto"
+	secp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	keyType      = "frost"
+	PubKeyName   = "cosmos/PubKeyFrost"
+	KeyShareName = "cosmos/KeyShareFrost"
+
+	// PubKeySize is the size of a compressed secp256k1 point.
+	PubKeySize = 33
+	// SignatureSize is the size of a signature, the compressed group
+	// commitment R followed by the scalar z.
+	SignatureSize = PubKeySize + 32
+)
+
+// ErrKeyShareSign is returned when a key share is asked to sign alone.
+var ErrKeyShareSign = errors.New("a FROST key share can't sign alone, it must take part in the signing rounds of its group")
+
+var (
+	_ cryptotypes.PubKey   = &PubKey{}
+	_ codec.AminoMarshaler = &PubKey{}
+)
+
+// Address returns the address of the group public key, the hash of the key
+// prefixed by the proto message name, as defined in ADR-028.
+func (pubKey *PubKey) Address() crypto.Address {
+	return address.Hash(proto.MessageName(pubKey), pubKey.Key)
+}
+
+// Bytes returns the pubkey byte format.
+func (pubKey *PubKey) Bytes() []byte {
+	return pubKey.Key
+}
+
+func (pubKey *PubKey) String() string {
+	return fmt.Sprintf("PubKeyFrost{%X}", pubKey.Key)
+}
+
+func (pubKey *PubKey) Type() string {
+	return keyType
+}
+
+func (pubKey *PubKey) Equals(other cryptotypes.PubKey) bool {
+	return pubKey.Type() == other.Type() && bytes.Equal(pubKey.Bytes(), other.Bytes())
+}
+
+// VerifySignature verifies a Schnorr signature of the group, of the form
+// R || z, as produced by KeyShare.Aggregate. It holds if z*G = R + c*Y where Y
+// is the group public key and c the challenge of R, Y and msg.
+func (pubKey *PubKey) VerifySignature(msg, sig []byte) bool {
+	if len(sig) != SignatureSize {
+		return false
+	}
+	groupKey, err := parsePoint(pubKey.Key)
+	if err != nil {
+		return false
+	}
+	commitment, err := parsePoint(sig[:PubKeySize])
+	if err != nil {
+		return false
+	}
+	z, err := parseScalar(sig[PubKeySize:])
+	if err != nil {
+		return false
+	}
+
+	c := challenge(sig[:PubKeySize], pubKey.Key, msg)
+	expected := addPoints(commitment, mulPoint(&c, groupKey))
+
+	return pointsEqual(baseMul(&z), expected)
+}
+
+// MarshalAmino overrides Amino binary marshaling.
+func (pubKey PubKey) MarshalAmino() ([]byte, error) {
+	return pubKey.Key, nil
+}
+
+// UnmarshalAmino overrides Amino binary marshaling.
+func (pubKey *PubKey) UnmarshalAmino(bz []byte) error {
+	if len(bz) != PubKeySize {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidPubKey, "invalid pubkey size")
+	}
+	pubKey.Key = bz
+
+	return nil
+}
+
+// MarshalAminoJSON overrides Amino JSON marshaling.
+func (pubKey PubKey) MarshalAminoJSON() ([]byte, error) {
+	// When we marshal to Amino JSON, we don't marshal the "key" field itself,
+	// just its contents (i.e. the key bytes).
+	return pubKey.MarshalAmino()
+}
+
+// UnmarshalAminoJSON overrides Amino JSON marshaling.
+func (pubKey *PubKey) UnmarshalAminoJSON(bz []byte) error {
+	return pubKey.UnmarshalAmino(bz)
+}
+
+//-------------------------------------
+
+var _ cryptotypes.PrivKey = &KeyShare{}
+
+// Bytes returns the protobuf encoding of the key share.
+func (ks *KeyShare) Bytes() []byte {
+	bz, err := ks.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// Sign always fails, as a key share takes part in the signing rounds of its
+// group instead: Commit, SignShare and Aggregate.
+func (ks *KeyShare) Sign([]byte) ([]byte, error) {
+	return nil, ErrKeyShareSign
+}
+
+// PubKey returns the group public key, so that a key share is stored in the
+// keyring under the address of its group.
+func (ks *KeyShare) PubKey() cryptotypes.PubKey {
+	return &PubKey{Key: ks.GroupKey}
+}
+
+// Equals runs in constant time based on the length of the key shares.
+func (ks *KeyShare) Equals(other cryptotypes.LedgerPrivKey) bool {
+	return ks.Type() == other.Type() && subtle.ConstantTimeCompare(ks.Bytes(), other.Bytes()) == 1
+}
+
+func (ks *KeyShare) Type() string {
+	return keyType
+}
+
+// String doesn't print the secret share.
+func (ks *KeyShare) String() string {
+	return fmt.Sprintf("KeyShareFrost{%d/%d, %X}", ks.Index, ks.Threshold, ks.GroupKey)
+}
+
+// Validate checks that the key share is well formed and that its secret
+// share matches its verification share.
+func (ks *KeyShare) Validate() error {
+	participants := uint32(len(ks.VerificationShares))
+	if err := validateParams(ks.Index, ks.Threshold, participants); err != nil {
+		return err
+	}
+	if _, err := parsePoint(ks.GroupKey); err != nil {
+		return fmt.Errorf("invalid group key: %w", err)
+	}
+	for i, share := range ks.VerificationShares {
+		if _, err := parsePoint(share); err != nil {
+			return fmt.Errorf("invalid verification share %d: %w", i+1, err)
+		}
+	}
+
+	secret, err := parseScalar(ks.Secret)
+	if err != nil {
+		return fmt.Errorf("invalid secret share: %w", err)
+	}
+	public, err := serializePoint(baseMul(&secret))
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(public, ks.VerificationShares[ks.Index-1]) {
+		return errors.New("secret share doesn't match its verification share")
+	}
+
+	return nil
+}
+
+// verificationShare returns the verification share of the participant with the
+// given index.
+func (ks *KeyShare) verificationShare(index uint32) (*secp256k1.JacobianPoint, error) {
+	if index == 0 || int(index) > len(ks.VerificationShares) {
+		return nil, fmt.Errorf("unknown participant %d", index)
+	}
+	return parsePoint(ks.VerificationShares[index-1])
+}
+
+func validateParams(index, threshold, participants uint32) error {
+	if threshold == 0 || threshold > participants {
+		return fmt.Errorf("threshold must be between 1 and the number of participants %d, got %d", participants, threshold)
+	}
+	if index == 0 || index > participants {
+		return fmt.Errorf("participant index must be between 1 and %d, got %d", participants, index)
+	}
+	return nil
+}
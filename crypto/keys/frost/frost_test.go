@@ -0,0 +1,190 @@
+package frost_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/frost"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+)
+
+// runDKG runs the distributed key generation between all the participants.
+func runDKG(t *testing.T, threshold, participants uint32) []*frost.KeyShare {
+	t.Helper()
+
+	dkgParticipants := make([]*frost.DKGParticipant, participants)
+	packages := make([]frost.DKGRound1Package, participants)
+	for i := range dkgParticipants {
+		p, err := frost.NewDKGParticipant(uint32(i+1), threshold, participants)
+		require.NoError(t, err)
+		dkgParticipants[i], packages[i] = p, p.Round1()
+	}
+
+	received := make(map[uint32]map[uint32][]byte)
+	for i, p := range dkgParticipants {
+		shares, err := p.Round2(packages)
+		require.NoError(t, err)
+		for to, share := range shares {
+			if received[to] == nil {
+				received[to] = make(map[uint32][]byte)
+			}
+			received[to][uint32(i+1)] = share
+		}
+	}
+
+	keyShares := make([]*frost.KeyShare, participants)
+	for i, p := range dkgParticipants {
+		keyShare, err := p.Finalize(packages, received[uint32(i+1)])
+		require.NoError(t, err)
+		keyShares[i] = keyShare
+	}
+
+	return keyShares
+}
+
+// sign runs the signing rounds between the given signers.
+func sign(t *testing.T, signers []*frost.KeyShare, msg []byte) ([]byte, error) {
+	t.Helper()
+
+	nonces := make([]*frost.SigningNonces, len(signers))
+	commitments := make([]frost.SigningCommitment, len(signers))
+	for i, ks := range signers {
+		var err error
+		nonces[i], commitments[i], err = ks.Commit()
+		require.NoError(t, err)
+	}
+
+	shares := make(map[uint32][]byte)
+	for i, ks := range signers {
+		share, err := ks.SignShare(nonces[i], msg, commitments)
+		if err != nil {
+			return nil, err
+		}
+		shares[ks.Index] = share
+	}
+
+	return signers[0].Aggregate(msg, commitments, shares)
+}
+
+func TestDKGAndSign(t *testing.T) {
+	keyShares := runDKG(t, 3, 5)
+
+	groupKey := keyShares[0].PubKey()
+	for _, ks := range keyShares {
+		require.True(t, groupKey.Equals(ks.PubKey()))
+		require.Equal(t, keyShares[0].VerificationShares, ks.VerificationShares)
+	}
+
+	msg := []byte("checkpoint")
+	for _, signers := range [][]*frost.KeyShare{
+		{keyShares[0], keyShares[1], keyShares[2]},
+		{keyShares[4], keyShares[1], keyShares[3]},
+		keyShares,
+	} {
+		sig, err := sign(t, signers, msg)
+		require.NoError(t, err)
+		require.Len(t, sig, frost.SignatureSize)
+		require.True(t, groupKey.VerifySignature(msg, sig))
+		require.False(t, groupKey.VerifySignature([]byte("other"), sig))
+	}
+
+	_, err := sign(t, keyShares[:2], msg)
+	require.ErrorContains(t, err, "at least 3 signers")
+}
+
+func TestGenerateKeyShares(t *testing.T) {
+	keyShares, err := frost.GenerateKeyShares(2, 3)
+	require.NoError(t, err)
+	require.Len(t, keyShares, 3)
+
+	msg := []byte("msg")
+	sig, err := sign(t, keyShares[1:], msg)
+	require.NoError(t, err)
+	require.True(t, keyShares[0].PubKey().VerifySignature(msg, sig))
+
+	_, err = frost.GenerateKeyShares(4, 3)
+	require.Error(t, err)
+}
+
+func TestDKGRejectsInvalidShare(t *testing.T) {
+	p1, err := frost.NewDKGParticipant(1, 2, 2)
+	require.NoError(t, err)
+	p2, err := frost.NewDKGParticipant(2, 2, 2)
+	require.NoError(t, err)
+	packages := []frost.DKGRound1Package{p1.Round1(), p2.Round1()}
+
+	shares, err := p1.Round2(packages)
+	require.NoError(t, err)
+	shares[2][31] ^= 1
+	_, err = p2.Finalize(packages, map[uint32][]byte{1: shares[2]})
+	require.ErrorContains(t, err, "doesn't match its commitments")
+
+	forged := p1.Round1()
+	forged.ProofZ = append([]byte{}, forged.ProofZ...)
+	forged.ProofZ[31] ^= 1
+	_, err = p2.Round2([]frost.DKGRound1Package{forged, p2.Round1()})
+	require.ErrorContains(t, err, "invalid proof of knowledge")
+}
+
+func TestAggregateRejectsInvalidShare(t *testing.T) {
+	keyShares, err := frost.GenerateKeyShares(2, 3)
+	require.NoError(t, err)
+	msg := []byte("msg")
+
+	nonces1, commitment1, err := keyShares[0].Commit()
+	require.NoError(t, err)
+	nonces2, commitment2, err := keyShares[1].Commit()
+	require.NoError(t, err)
+	commitments := []frost.SigningCommitment{commitment1, commitment2}
+
+	share1, err := keyShares[0].SignShare(nonces1, msg, commitments)
+	require.NoError(t, err)
+	_, err = keyShares[0].SignShare(nonces1, msg, commitments)
+	require.ErrorContains(t, err, "fresh")
+
+	// participant 2 signs another message
+	share2, err := keyShares[1].SignShare(nonces2, []byte("other"), commitments)
+	require.NoError(t, err)
+
+	_, err = keyShares[2].Aggregate(msg, commitments, map[uint32][]byte{1: share1, 2: share2})
+	require.ErrorContains(t, err, "invalid signature share of participant 2")
+}
+
+func TestKeyShareCodec(t *testing.T) {
+	keyShares, err := frost.GenerateKeyShares(2, 3)
+	require.NoError(t, err)
+	ks := keyShares[0]
+
+	_, err = ks.Sign([]byte("msg"))
+	require.ErrorIs(t, err, frost.ErrKeyShareSign)
+	require.NotContains(t, ks.String(), string(ks.Secret))
+
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+
+	var priv cryptotypes.PrivKey
+	bz, err := cdc.MarshalInterface(cryptotypes.PrivKey(ks))
+	require.NoError(t, err)
+	require.NoError(t, cdc.UnmarshalInterface(bz, &priv))
+	require.True(t, ks.Equals(priv))
+
+	var pub cryptotypes.PubKey
+	bz, err = cdc.MarshalInterface(ks.PubKey())
+	require.NoError(t, err)
+	require.NoError(t, cdc.UnmarshalInterface(bz, &pub))
+	require.True(t, ks.PubKey().Equals(pub))
+	require.Len(t, pub.Address(), 32)
+
+	amino := codec.NewLegacyAmino()
+	cryptocodec.RegisterCrypto(amino)
+	aminoBz, err := amino.MarshalJSON(ks.PubKey())
+	require.NoError(t, err)
+	var aminoPub cryptotypes.PubKey
+	require.NoError(t, amino.UnmarshalJSON(aminoBz, &aminoPub))
+	require.True(t, ks.PubKey().Equals(aminoPub))
+}
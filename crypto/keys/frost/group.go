@@ -0,0 +1,180 @@
+package frost
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	secp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// contextString domain separates the hashes of this FROST instantiation.
+const contextString = "FROST-secp256k1-SHA256-cosmos-v1"
+
+// hashToScalar returns the tagged SHA-256 hash of data, as in BIP-340, reduced
+// modulo the group order.
+func hashToScalar(tag string, data ...[]byte) secp256k1.ModNScalar {
+	tagHash := sha256.Sum256([]byte(contextString + tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, bz := range data {
+		h.Write(bz)
+	}
+
+	var s secp256k1.ModNScalar
+	s.SetByteSlice(h.Sum(nil))
+	return s
+}
+
+// challenge returns the Schnorr challenge of the group commitment, the group
+// public key and the message.
+func challenge(commitment, groupKey, msg []byte) secp256k1.ModNScalar {
+	return hashToScalar("chal", commitment, groupKey, msg)
+}
+
+// randomScalar returns a uniformly random non-zero scalar.
+func randomScalar() (secp256k1.ModNScalar, error) {
+	var (
+		s  secp256k1.ModNScalar
+		bz [32]byte
+	)
+	for {
+		if _, err := rand.Read(bz[:]); err != nil {
+			return s, err
+		}
+		if overflow := s.SetByteSlice(bz[:]); !overflow && !s.IsZero() {
+			return s, nil
+		}
+	}
+}
+
+// parseScalar parses a big-endian scalar lower than the group order.
+func parseScalar(bz []byte) (secp256k1.ModNScalar, error) {
+	var s secp256k1.ModNScalar
+	if len(bz) != 32 {
+		return s, errors.New("scalar must be 32 bytes long")
+	}
+	if overflow := s.SetByteSlice(bz); overflow {
+		return s, errors.New("scalar overflows the group order")
+	}
+	return s, nil
+}
+
+func scalarBytes(s *secp256k1.ModNScalar) []byte {
+	bz := s.Bytes()
+	return bz[:]
+}
+
+func indexScalar(index uint32) secp256k1.ModNScalar {
+	var s secp256k1.ModNScalar
+	s.SetInt(index)
+	return s
+}
+
+func indexBytes(index uint32) []byte {
+	return binary.BigEndian.AppendUint32(nil, index)
+}
+
+// parsePoint parses a compressed point.
+func parsePoint(bz []byte) (*secp256k1.JacobianPoint, error) {
+	if len(bz) != PubKeySize {
+		return nil, errors.New("point must be compressed")
+	}
+	pk, err := secp256k1.ParsePubKey(bz)
+	if err != nil {
+		return nil, err
+	}
+
+	var p secp256k1.JacobianPoint
+	pk.AsJacobian(&p)
+	return &p, nil
+}
+
+// serializePoint returns the compressed form of a point, which must not be the
+// point at infinity.
+func serializePoint(p *secp256k1.JacobianPoint) ([]byte, error) {
+	if isInfinity(p) {
+		return nil, errors.New("point at infinity")
+	}
+
+	affine := *p
+	affine.ToAffine()
+	return secp256k1.NewPublicKey(&affine.X, &affine.Y).SerializeCompressed(), nil
+}
+
+func isInfinity(p *secp256k1.JacobianPoint) bool {
+	return (p.X.IsZero() && p.Y.IsZero()) || p.Z.IsZero()
+}
+
+func pointsEqual(p1, p2 *secp256k1.JacobianPoint) bool {
+	if isInfinity(p1) || isInfinity(p2) {
+		return isInfinity(p1) && isInfinity(p2)
+	}
+
+	a, b := *p1, *p2
+	a.ToAffine()
+	b.ToAffine()
+	return a.X.Equals(&b.X) && a.Y.Equals(&b.Y)
+}
+
+func baseMul(k *secp256k1.ModNScalar) *secp256k1.JacobianPoint {
+	var result secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(k, &result)
+	return &result
+}
+
+func mulPoint(k *secp256k1.ModNScalar, p *secp256k1.JacobianPoint) *secp256k1.JacobianPoint {
+	var result secp256k1.JacobianPoint
+	secp256k1.ScalarMultNonConst(k, p, &result)
+	return &result
+}
+
+func addPoints(p1, p2 *secp256k1.JacobianPoint) *secp256k1.JacobianPoint {
+	var result secp256k1.JacobianPoint
+	secp256k1.AddNonConst(p1, p2, &result)
+	return &result
+}
+
+// evalPolynomial evaluates the polynomial of the given coefficients, the
+// constant term first, at x.
+func evalPolynomial(coefficients []secp256k1.ModNScalar, x uint32) secp256k1.ModNScalar {
+	xs := indexScalar(x)
+	var result secp256k1.ModNScalar
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result.Mul(&xs).Add(&coefficients[i])
+	}
+	return result
+}
+
+// evalCommitments evaluates the commitments to the coefficients of a
+// polynomial at x, i.e. returns f(x)*G.
+func evalCommitments(commitments []*secp256k1.JacobianPoint, x uint32) *secp256k1.JacobianPoint {
+	xs := indexScalar(x)
+	var result secp256k1.JacobianPoint
+	for i := len(commitments) - 1; i >= 0; i-- {
+		result = *addPoints(mulPoint(&xs, &result), commitments[i])
+	}
+	return &result
+}
+
+// lagrangeCoefficient returns the Lagrange coefficient of the participant at
+// x = 0 among the given participants.
+func lagrangeCoefficient(index uint32, participants []uint32) secp256k1.ModNScalar {
+	num, den := indexScalar(1), indexScalar(1)
+	xi := indexScalar(index)
+	for _, j := range participants {
+		if j == index {
+			continue
+		}
+		xj := indexScalar(j)
+		num.Mul(&xj)
+
+		var diff secp256k1.ModNScalar
+		diff.NegateVal(&xi).Add(&xj)
+		den.Mul(&diff)
+	}
+
+	return *num.Mul(den.InverseNonConst())
+}
@@ -0,0 +1,692 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/crypto/frost/keys.proto
+
+package frost
+
+import (
+	fmt "fmt"
+	_ "github.com/cosmos/cosmos-sdk/types/tx/amino"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// PubKey defines the group public key of a FROST threshold Schnorr key over
+// secp256k1. Key is the compressed form of the point. The signatures of the
+// group are produced by a threshold of the holders of the key shares and are
+// verified as a single Schnorr signature.
+type PubKey struct {
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *PubKey) Reset()      { *m = PubKey{} }
+func (*PubKey) ProtoMessage() {}
+func (*PubKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d142dd72d792f980, []int{0}
+}
+func (m *PubKey) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PubKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PubKey.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PubKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PubKey.Merge(m, src)
+}
+func (m *PubKey) XXX_Size() int {
+	return m.Size()
+}
+func (m *PubKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_PubKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PubKey proto.InternalMessageInfo
+
+func (m *PubKey) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// KeyShare defines the share of a participant in a FROST threshold key, as
+// stored in its keyring. It can't sign alone, but takes part in the signing
+// rounds of the group.
+type KeyShare struct {
+	// index is the index of the participant, from 1 to the number of
+	// participants.
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// threshold is the number of participants needed to sign.
+	Threshold uint32 `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// secret is the secret share of the participant, a big-endian scalar.
+	Secret []byte `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	// group_key is the compressed group public key.
+	GroupKey []byte `protobuf:"bytes,4,opt,name=group_key,json=groupKey,proto3" json:"group_key,omitempty"`
+	// verification_shares are the compressed public keys of the secret shares of
+	// all the participants, ordered by index, used to verify their signature
+	// shares.
+	VerificationShares [][]byte `protobuf:"bytes,5,rep,name=verification_shares,json=verificationShares,proto3" json:"verification_shares,omitempty"`
+}
+
+func (m *KeyShare) Reset()      { *m = KeyShare{} }
+func (*KeyShare) ProtoMessage() {}
+func (*KeyShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d142dd72d792f980, []int{1}
+}
+func (m *KeyShare) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *KeyShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_KeyShare.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *KeyShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyShare.Merge(m, src)
+}
+func (m *KeyShare) XXX_Size() int {
+	return m.Size()
+}
+func (m *KeyShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyShare proto.InternalMessageInfo
+
+func (m *KeyShare) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *KeyShare) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *KeyShare) GetSecret() []byte {
+	if m != nil {
+		return m.Secret
+	}
+	return nil
+}
+
+func (m *KeyShare) GetGroupKey() []byte {
+	if m != nil {
+		return m.GroupKey
+	}
+	return nil
+}
+
+func (m *KeyShare) GetVerificationShares() [][]byte {
+	if m != nil {
+		return m.VerificationShares
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*PubKey)(nil), "cosmos.crypto.frost.PubKey")
+	proto.RegisterType((*KeyShare)(nil), "cosmos.crypto.frost.KeyShare")
+}
+
+func init() { proto.RegisterFile("cosmos/crypto/frost/keys.proto", fileDescriptor_d142dd72d792f980) }
+
+var fileDescriptor_d142dd72d792f980 = []byte{
+	// 333 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0x31, 0x4f, 0x02, 0x31,
+	0x14, 0xc7, 0xef, 0x44, 0x08, 0x34, 0x98, 0x68, 0x21, 0xe6, 0x82, 0x5a, 0x09, 0x13, 0x92, 0x78,
+	0x1d, 0xdc, 0x18, 0x4d, 0x34, 0x26, 0x2c, 0x06, 0x37, 0x17, 0x02, 0x77, 0xe5, 0xae, 0x39, 0xe0,
+	0x91, 0xb6, 0x18, 0xfb, 0x15, 0x9c, 0x8c, 0x93, 0xa3, 0x1f, 0x81, 0xef, 0xe0, 0xe2, 0xc8, 0xe8,
+	0x68, 0x60, 0xe0, 0x6b, 0x98, 0xb6, 0x5c, 0x74, 0x69, 0xdf, 0x7b, 0xff, 0x97, 0xbe, 0xdf, 0xeb,
+	0x1f, 0x91, 0x08, 0xe4, 0x14, 0x24, 0x8d, 0x84, 0x9e, 0x2b, 0xa0, 0x63, 0x01, 0x52, 0xd1, 0x8c,
+	0x69, 0x19, 0xce, 0x05, 0x28, 0xc0, 0x35, 0xa7, 0x87, 0x4e, 0x0f, 0xad, 0xde, 0x38, 0x1a, 0x4e,
+	0xf9, 0x0c, 0xa8, 0x3d, 0x5d, 0x5f, 0xa3, 0x9e, 0x40, 0x02, 0x36, 0xa4, 0x26, 0x72, 0xd5, 0xd6,
+	0x0d, 0x2a, 0xdd, 0x2f, 0x46, 0x3d, 0xa6, 0xf1, 0x21, 0x2a, 0x64, 0x4c, 0x07, 0x7e, 0xd3, 0x6f,
+	0x57, 0xfb, 0x26, 0xec, 0x5e, 0xbc, 0x7f, 0x9c, 0x7b, 0x2f, 0xdb, 0x65, 0x07, 0xef, 0x10, 0x5c,
+	0xe7, 0xad, 0x99, 0xf0, 0xb6, 0x5d, 0x76, 0x2a, 0x19, 0xd3, 0x83, 0x31, 0x67, 0x93, 0xb8, 0xf5,
+	0xe9, 0xa3, 0x72, 0x8f, 0xe9, 0x87, 0x74, 0x28, 0x18, 0xae, 0xa3, 0x22, 0x9f, 0xc5, 0xec, 0xd9,
+	0xbe, 0x75, 0xd0, 0x77, 0x09, 0x3e, 0x45, 0x15, 0x95, 0x0a, 0x26, 0x53, 0x98, 0xc4, 0xc1, 0x9e,
+	0x55, 0xfe, 0x0a, 0xf8, 0x18, 0x95, 0x24, 0x8b, 0x04, 0x53, 0x41, 0xc1, 0x02, 0xec, 0x32, 0x7c,
+	0x82, 0x2a, 0x89, 0x80, 0xc5, 0x7c, 0x60, 0xd8, 0xf6, 0xad, 0x54, 0xb6, 0x05, 0x83, 0x4c, 0x51,
+	0xed, 0x89, 0x09, 0x3e, 0xe6, 0xd1, 0x50, 0x71, 0x98, 0x0d, 0xa4, 0x19, 0x2f, 0x83, 0x62, 0xb3,
+	0xd0, 0xae, 0xf6, 0xf1, 0x7f, 0xc9, 0x82, 0xc9, 0xee, 0x59, 0xbe, 0x51, 0x7d, 0xb7, 0x51, 0xce,
+	0x6c, 0x77, 0xba, 0xbe, 0xfb, 0x5a, 0x13, 0x7f, 0xb5, 0x26, 0xfe, 0xcf, 0x9a, 0xf8, 0xaf, 0x1b,
+	0xe2, 0xad, 0x36, 0xc4, 0xfb, 0xde, 0x10, 0xef, 0x31, 0x4c, 0xb8, 0x4a, 0x17, 0xa3, 0x30, 0x82,
+	0x29, 0xcd, 0xfd, 0xb0, 0xd7, 0xa5, 0x8c, 0xb3, 0xdc, 0x1a, 0x63, 0x8a, 0xf3, 0x67, 0x54, 0xb2,
+	0xbf, 0x7b, 0xf5, 0x3b, 0x00, 0xde, 0xe5, 0x81, 0xac, 0xbd, 0x01, 0x00, 0x00,
+}
+
+func (m *PubKey) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PubKey) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PubKey) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintKeys(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *KeyShare) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *KeyShare) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *KeyShare) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.VerificationShares) > 0 {
+		for iNdEx := len(m.VerificationShares) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.VerificationShares[iNdEx])
+			copy(dAtA[i:], m.VerificationShares[iNdEx])
+			i = encodeVarintKeys(dAtA, i, uint64(len(m.VerificationShares[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.GroupKey) > 0 {
+		i -= len(m.GroupKey)
+		copy(dAtA[i:], m.GroupKey)
+		i = encodeVarintKeys(dAtA, i, uint64(len(m.GroupKey)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Secret) > 0 {
+		i -= len(m.Secret)
+		copy(dAtA[i:], m.Secret)
+		i = encodeVarintKeys(dAtA, i, uint64(len(m.Secret)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Threshold != 0 {
+		i = encodeVarintKeys(dAtA, i, uint64(m.Threshold))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Index != 0 {
+		i = encodeVarintKeys(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintKeys(dAtA []byte, offset int, v uint64) int {
+	offset -= sovKeys(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *PubKey) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovKeys(uint64(l))
+	}
+	return n
+}
+
+func (m *KeyShare) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovKeys(uint64(m.Index))
+	}
+	if m.Threshold != 0 {
+		n += 1 + sovKeys(uint64(m.Threshold))
+	}
+	l = len(m.Secret)
+	if l > 0 {
+		n += 1 + l + sovKeys(uint64(l))
+	}
+	l = len(m.GroupKey)
+	if l > 0 {
+		n += 1 + l + sovKeys(uint64(l))
+	}
+	if len(m.VerificationShares) > 0 {
+		for _, b := range m.VerificationShares {
+			l = len(b)
+			n += 1 + l + sovKeys(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovKeys(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozKeys(x uint64) (n int) {
+	return sovKeys(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PubKey) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKeys
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PubKey: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PubKey: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeys
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKeys
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeys
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKeys(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthKeys
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *KeyShare) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowKeys
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: KeyShare: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: KeyShare: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeys
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Threshold", wireType)
+			}
+			m.Threshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeys
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Threshold |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Secret", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeys
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKeys
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeys
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Secret = append(m.Secret[:0], dAtA[iNdEx:postIndex]...)
+			if m.Secret == nil {
+				m.Secret = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeys
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKeys
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeys
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GroupKey = append(m.GroupKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.GroupKey == nil {
+				m.GroupKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VerificationShares", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKeys
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthKeys
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthKeys
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.VerificationShares = append(m.VerificationShares, make([]byte, postIndex-iNdEx))
+			copy(m.VerificationShares[len(m.VerificationShares)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipKeys(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthKeys
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipKeys(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowKeys
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowKeys
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowKeys
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthKeys
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupKeys
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthKeys
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthKeys        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowKeys          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupKeys = fmt.Errorf("proto: unexpected end of group")
+)
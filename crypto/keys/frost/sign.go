@@ -0,0 +1,231 @@
+package frost
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	secp256k1 "github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// SigningNonces are the secret nonces of a participant for one signature,
+// returned by Commit. They are erased once used by SignShare.
+type SigningNonces struct {
+	index   uint32
+	hiding  secp256k1.ModNScalar
+	binding secp256k1.ModNScalar
+	used    bool
+}
+
+// SigningCommitment is the public commitment to the nonces of a participant,
+// sent to the other signers in the first signing round.
+type SigningCommitment struct {
+	Index   uint32 `json:"index"`
+	Hiding  []byte `json:"hiding"`
+	Binding []byte `json:"binding"`
+}
+
+// Commit runs the first signing round: it returns fresh nonces, to keep
+// secret until SignShare, and their commitment to send to the other signers.
+func (ks *KeyShare) Commit() (*SigningNonces, SigningCommitment, error) {
+	hiding, err := randomScalar()
+	if err != nil {
+		return nil, SigningCommitment{}, err
+	}
+	binding, err := randomScalar()
+	if err != nil {
+		return nil, SigningCommitment{}, err
+	}
+
+	commitment := SigningCommitment{Index: ks.Index}
+	if commitment.Hiding, err = serializePoint(baseMul(&hiding)); err != nil {
+		return nil, SigningCommitment{}, err
+	}
+	if commitment.Binding, err = serializePoint(baseMul(&binding)); err != nil {
+		return nil, SigningCommitment{}, err
+	}
+
+	return &SigningNonces{index: ks.Index, hiding: hiding, binding: binding}, commitment, nil
+}
+
+// SignShare runs the second signing round: it returns the signature share of
+// the participant for msg, given its nonces and the commitments of all the
+// signers, including its own. The nonces can't be used again.
+func (ks *KeyShare) SignShare(nonces *SigningNonces, msg []byte, commitments []SigningCommitment) ([]byte, error) {
+	if nonces == nil || nonces.used {
+		return nil, errors.New("signing nonces must be fresh")
+	}
+	if nonces.index != ks.Index {
+		return nil, fmt.Errorf("signing nonces belong to participant %d", nonces.index)
+	}
+	secret, err := parseScalar(ks.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := ks.newSigningPackage(msg, commitments)
+	if err != nil {
+		return nil, err
+	}
+	own, ok := pkg.commitments[ks.Index]
+	if !ok {
+		return nil, fmt.Errorf("the commitments don't include participant %d", ks.Index)
+	}
+	hiding, err := serializePoint(baseMul(&nonces.hiding))
+	if err != nil {
+		return nil, err
+	}
+	binding, err := serializePoint(baseMul(&nonces.binding))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(hiding, own.Hiding) || !bytes.Equal(binding, own.Binding) {
+		return nil, errors.New("the commitment of the participant doesn't match its nonces")
+	}
+
+	// z_i = d_i + e_i * rho_i + lambda_i * s_i * c
+	lambda, rho := pkg.lambdas[ks.Index], pkg.bindingFactors[ks.Index]
+	z := *secret.Mul(&lambda).Mul(&pkg.challenge)
+	var bound secp256k1.ModNScalar
+	bound.Mul2(&nonces.binding, &rho)
+	z.Add(&bound).Add(&nonces.hiding)
+
+	nonces.hiding.Zero()
+	nonces.binding.Zero()
+	nonces.used = true
+
+	return scalarBytes(&z), nil
+}
+
+// Aggregate verifies the signature shares of the signers, by index, and
+// returns the signature of the group for msg, verified by the group PubKey.
+// It can be run by any participant, or by a coordinator holding any of their
+// key shares.
+func (ks *KeyShare) Aggregate(msg []byte, commitments []SigningCommitment, shares map[uint32][]byte) ([]byte, error) {
+	pkg, err := ks.newSigningPackage(msg, commitments)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) != len(pkg.commitments) {
+		return nil, fmt.Errorf("expected %d signature shares, got %d", len(pkg.commitments), len(shares))
+	}
+
+	var z secp256k1.ModNScalar
+	for _, index := range pkg.signers {
+		bz, ok := shares[index]
+		if !ok {
+			return nil, fmt.Errorf("missing the signature share of participant %d", index)
+		}
+		share, err := parseScalar(bz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature share of participant %d: %w", index, err)
+		}
+		if err := ks.verifySignatureShare(pkg, index, &share); err != nil {
+			return nil, err
+		}
+		z.Add(&share)
+	}
+
+	sig := append(append([]byte{}, pkg.groupCommitment...), scalarBytes(&z)...)
+	if !ks.PubKey().VerifySignature(msg, sig) {
+		return nil, errors.New("the aggregated signature doesn't verify")
+	}
+
+	return sig, nil
+}
+
+// verifySignatureShare checks z_i * G = D_i + rho_i * E_i + c * lambda_i * Y_i.
+func (ks *KeyShare) verifySignatureShare(pkg *signingPackage, index uint32, share *secp256k1.ModNScalar) error {
+	verificationShare, err := ks.verificationShare(index)
+	if err != nil {
+		return err
+	}
+
+	lambda := pkg.lambdas[index]
+	var cl secp256k1.ModNScalar
+	cl.Mul2(&pkg.challenge, &lambda)
+	expected := addPoints(pkg.commitmentShares[index], mulPoint(&cl, verificationShare))
+	if !pointsEqual(baseMul(share), expected) {
+		return fmt.Errorf("invalid signature share of participant %d", index)
+	}
+
+	return nil
+}
+
+// signingPackage holds the values derived from the commitments of the signers
+// and the message, shared by all the signers.
+type signingPackage struct {
+	signers          []uint32
+	commitments      map[uint32]SigningCommitment
+	bindingFactors   map[uint32]secp256k1.ModNScalar
+	commitmentShares map[uint32]*secp256k1.JacobianPoint
+	lambdas          map[uint32]secp256k1.ModNScalar
+	groupCommitment  []byte
+	challenge        secp256k1.ModNScalar
+}
+
+func (ks *KeyShare) newSigningPackage(msg []byte, commitments []SigningCommitment) (*signingPackage, error) {
+	if len(commitments) < int(ks.Threshold) {
+		return nil, fmt.Errorf("at least %d signers are needed, got %d", ks.Threshold, len(commitments))
+	}
+
+	sorted := append([]SigningCommitment{}, commitments...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Index < sorted[j].Index })
+
+	pkg := &signingPackage{
+		commitments:      make(map[uint32]SigningCommitment, len(sorted)),
+		bindingFactors:   make(map[uint32]secp256k1.ModNScalar, len(sorted)),
+		commitmentShares: make(map[uint32]*secp256k1.JacobianPoint, len(sorted)),
+		lambdas:          make(map[uint32]secp256k1.ModNScalar, len(sorted)),
+	}
+
+	var encoded []byte
+	hidings := make(map[uint32]*secp256k1.JacobianPoint, len(sorted))
+	bindings := make(map[uint32]*secp256k1.JacobianPoint, len(sorted))
+	for _, commitment := range sorted {
+		if _, err := ks.verificationShare(commitment.Index); err != nil {
+			return nil, err
+		}
+		if _, ok := pkg.commitments[commitment.Index]; ok {
+			return nil, fmt.Errorf("duplicate commitment of participant %d", commitment.Index)
+		}
+		hiding, err := parsePoint(commitment.Hiding)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hiding commitment of participant %d: %w", commitment.Index, err)
+		}
+		binding, err := parsePoint(commitment.Binding)
+		if err != nil {
+			return nil, fmt.Errorf("invalid binding commitment of participant %d: %w", commitment.Index, err)
+		}
+
+		pkg.signers = append(pkg.signers, commitment.Index)
+		pkg.commitments[commitment.Index] = commitment
+		hidings[commitment.Index], bindings[commitment.Index] = hiding, binding
+		encoded = append(encoded, indexBytes(commitment.Index)...)
+		encoded = append(encoded, commitment.Hiding...)
+		encoded = append(encoded, commitment.Binding...)
+	}
+
+	// the binding factors bind the nonces of every signer to the message and
+	// to the commitments of all the signers
+	msgHash := hashToScalar("msg", msg)
+	commitmentsHash := hashToScalar("com", encoded)
+	var groupCommitment secp256k1.JacobianPoint
+	for _, index := range pkg.signers {
+		rho := hashToScalar("rho", ks.GroupKey, scalarBytes(&msgHash), scalarBytes(&commitmentsHash), indexBytes(index))
+		pkg.bindingFactors[index] = rho
+		pkg.commitmentShares[index] = addPoints(hidings[index], mulPoint(&rho, bindings[index]))
+		groupCommitment = *addPoints(&groupCommitment, pkg.commitmentShares[index])
+		pkg.lambdas[index] = lagrangeCoefficient(index, pkg.signers)
+	}
+
+	var err error
+	pkg.groupCommitment, err = serializePoint(&groupCommitment)
+	if err != nil {
+		return nil, err
+	}
+	pkg.challenge = challenge(pkg.groupCommitment, ks.GroupKey, msg)
+
+	return pkg, nil
+}
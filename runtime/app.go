@@ -9,6 +9,7 @@ import (
 
 	runtimev1alpha1 "cosmossdk.io/api/cosmos/app/runtime/v1alpha1"
 	appv1alpha1 "cosmossdk.io/api/cosmos/app/v1alpha1"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
@@ -155,6 +156,12 @@ func (a *App) Load(loadLatest bool) error {
 	return nil
 }
 
+// CollectionsSchemas returns the collections schemas of the app modules, keyed
+// by store name.
+func (a *App) CollectionsSchemas() map[string]collections.Schema {
+	return a.ModuleManager.CollectionsSchemas()
+}
+
 // PreBlocker application updates every pre block
 func (a *App) PreBlocker(ctx sdk.Context, _ *abci.RequestFinalizeBlock) (*sdk.ResponsePreBlock, error) {
 	return a.ModuleManager.PreBlock(ctx)
@@ -208,7 +215,7 @@ func (a *App) RegisterAPIRoutes(apiSvr *api.Server, _ config.APIConfig) {
 
 // RegisterTxService implements the Application.RegisterTxService method.
 func (a *App) RegisterTxService(clientCtx client.Context) {
-	authtx.RegisterTxService(a.GRPCQueryRouter(), clientCtx, a.Simulate, a.interfaceRegistry)
+	authtx.RegisterTxServiceWithOverrides(a.GRPCQueryRouter(), clientCtx, a.Simulate, a.SimulateWithOverrides, a.interfaceRegistry)
 }
 
 // RegisterTendermintService implements the Application.RegisterTendermintService method.
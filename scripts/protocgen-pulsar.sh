@@ -7,6 +7,9 @@ echo "Cleaning API directory"
 echo "Generating API module"
 (cd proto; buf generate --template buf.gen.pulsar.yaml)
 
+echo "Generate the module configs of the modules not yet in the API module"
+(cd proto; buf generate --template buf.gen.modules.yaml --path cosmos/feemarket/module --path cosmos/scheduler/module)
+
 echo "Generate Pulsar Test Data"
 (cd testutil/testdata; buf generate --template buf.gen.pulsar.yaml)
 
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -91,6 +92,31 @@ type BaseConfig struct {
 	// AppDBBackend defines the type of Database to use for the application and snapshots databases.
 	// An empty string indicates that the CometBFT config's DBBackend value should be used.
 	AppDBBackend string `mapstructure:"app-db-backend"`
+
+	// AppHashDumpDir defines the directory the store hashes and change set of
+	// every committed block are written to. An empty string disables the dumps.
+	AppHashDumpDir string `mapstructure:"app-hash-dump-dir"`
+
+	// AppHashDumpKeepRecent defines the number of recent app hash dumps to keep.
+	AppHashDumpKeepRecent uint64 `mapstructure:"app-hash-dump-keep-recent"`
+
+	// CacheKVSpillThreshold defines the size in bytes of the dirty writes of a
+	// block held in memory per store before they are spilled to disk. Zero
+	// disables spilling.
+	CacheKVSpillThreshold uint64 `mapstructure:"cachekv-spill-threshold"`
+
+	// Profiler enables the recording of the gas and time spent by the ante
+	// decorators, the Msg handlers and the modules' block hooks.
+	Profiler bool `mapstructure:"profiler"`
+
+	// OEAbortTimeout aborts the optimistic execution of a block running for
+	// longer than this duration before the block is finalized. Zero disables
+	// the timeout.
+	OEAbortTimeout time.Duration `mapstructure:"oe-abort-timeout"`
+
+	// OEMaxConsecutiveAborts disables the optimistic execution after this many
+	// consecutive aborts. Zero never disables it.
+	OEMaxConsecutiveAborts uint64 `mapstructure:"oe-max-consecutive-aborts"`
 }
 
 // APIConfig defines the API listener configuration.
@@ -166,6 +192,14 @@ type MempoolConfig struct {
 	// unbounded in how many txs it may contain, and a positive value indicates
 	// the maximum amount of txs it may contain.
 	MaxTxs int `mapstructure:"max-txs"`
+
+	// Journal records the txs of the mempool on disk so that they are replayed
+	// through CheckTx when the node restarts.
+	Journal bool `mapstructure:"journal"`
+
+	// JournalMaxAge is the max age of the journaled txs replayed on restart,
+	// all are replayed if zero.
+	JournalMaxAge time.Duration `mapstructure:"journal-max-age"`
 }
 
 // State Streaming configuration
@@ -173,6 +207,8 @@ type (
 	// StreamingConfig defines application configuration for external streaming services
 	StreamingConfig struct {
 		ABCI ABCIListenerConfig `mapstructure:"abci"`
+		// Filters maps a store key name to the key prefix filter applied to its streamed writes
+		Filters map[string]StreamingFilterConfig `mapstructure:"filters"`
 	}
 	// ABCIListenerConfig defines application configuration for ABCIListener streaming service
 	ABCIListenerConfig struct {
@@ -180,6 +216,11 @@ type (
 		Plugin        string   `mapstructure:"plugin"`
 		StopNodeOnErr bool     `mapstructure:"stop-node-on-err"`
 	}
+	// StreamingFilterConfig defines the hex encoded key prefixes allowed and denied for a streamed store
+	StreamingFilterConfig struct {
+		AllowPrefixes []string `mapstructure:"allow-prefixes"`
+		DenyPrefixes  []string `mapstructure:"deny-prefixes"`
+	}
 )
 
 // Config defines the server's top level configuration
@@ -219,17 +260,18 @@ func (c *Config) GetMinGasPrices() sdk.DecCoins {
 func DefaultConfig() *Config {
 	return &Config{
 		BaseConfig: BaseConfig{
-			MinGasPrices:        defaultMinGasPrices,
-			QueryGasLimit:       0,
-			InterBlockCache:     true,
-			Pruning:             pruningtypes.PruningOptionDefault,
-			PruningKeepRecent:   "0",
-			PruningInterval:     "0",
-			MinRetainBlocks:     0,
-			IndexEvents:         make([]string, 0),
-			IAVLCacheSize:       781250,
-			IAVLDisableFastNode: false,
-			AppDBBackend:        "",
+			MinGasPrices:          defaultMinGasPrices,
+			QueryGasLimit:         0,
+			InterBlockCache:       true,
+			Pruning:               pruningtypes.PruningOptionDefault,
+			PruningKeepRecent:     "0",
+			PruningInterval:       "0",
+			MinRetainBlocks:       0,
+			IndexEvents:           make([]string, 0),
+			IAVLCacheSize:         781250,
+			IAVLDisableFastNode:   false,
+			AppDBBackend:          "",
+			AppHashDumpKeepRecent: 100,
 		},
 		Telemetry: telemetry.Config{
 			Enabled:      false,
@@ -263,7 +305,8 @@ func DefaultConfig() *Config {
 			},
 		},
 		Mempool: MempoolConfig{
-			MaxTxs: -1,
+			MaxTxs:        -1,
+			JournalMaxAge: 24 * time.Hour,
 		},
 	}
 }
@@ -86,6 +86,34 @@ func TestStreamingConfig(t *testing.T) {
 	assert.Equal(t, cfg.Streaming, actual.Streaming, "Streaming")
 }
 
+func TestStreamingFiltersConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Streaming.ABCI.Keys = []string{"bank"}
+	cfg.Streaming.ABCI.Plugin = "abci_v1"
+	cfg.Streaming.Filters = map[string]StreamingFilterConfig{
+		"bank": {
+			AllowPrefixes: []string{"02"},
+			DenyPrefixes:  []string{"0200", "0201"},
+		},
+	}
+
+	cfgFile := filepath.Join(t.TempDir(), "app.toml")
+	WriteConfigFile(cfgFile, cfg)
+
+	cfgFileBz, err := os.ReadFile(cfgFile)
+	require.NoError(t, err, "reading %s", cfgFile)
+	require.Contains(t, string(cfgFileBz), "[streaming.filters.bank]\n")
+
+	vpr := viper.New()
+	vpr.SetConfigFile(cfgFile)
+	require.NoError(t, vpr.ReadInConfig(), "reading config file into viper")
+
+	var actual Config
+	require.NoError(t, vpr.Unmarshal(&actual), "vpr.Unmarshal")
+	require.Equal(t, cfg.Streaming.Filters, actual.Streaming.Filters)
+	require.Equal(t, []string{"02"}, vpr.GetStringSlice("streaming.filters.bank.allow-prefixes"))
+}
+
 func TestParseStreaming(t *testing.T) {
 	expectedKeys := `keys = ["*", ]` + "\n"
 	expectedPlugin := `plugin = "abci_v1"` + "\n"
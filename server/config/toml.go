@@ -86,6 +86,39 @@ iavl-disable-fastnode = {{ .BaseConfig.IAVLDisableFastNode }}
 # The fallback is the db_backend value set in CometBFT's config.toml.
 app-db-backend = "{{ .BaseConfig.AppDBBackend }}"
 
+# AppHashDumpDir defines a directory in which the per-store hashes and the change set
+# of every committed block are written. Comparing the dumps of two nodes with
+# '<appd> debug compare-app-hash-dumps' points to the store and keys causing an
+# app hash mismatch. An empty string disables the dumps.
+app-hash-dump-dir = "{{ .BaseConfig.AppHashDumpDir }}"
+
+# AppHashDumpKeepRecent defines the number of recent app hash dumps to keep.
+# 0 keeps all dumps.
+app-hash-dump-keep-recent = {{ .BaseConfig.AppHashDumpKeepRecent }}
+
+# CacheKVSpillThreshold defines the size in bytes of the dirty writes of a block held
+# in memory per store before they are spilled to a temporary sorted run under
+# <home>/data/cachekv-spill. Spilling bounds the memory used by large genesis imports
+# and upgrade migrations and doesn't change the resulting state. 0 disables spilling.
+cachekv-spill-threshold = {{ .BaseConfig.CacheKVSpillThreshold }}
+
+# Profiler enables the recording of the gas consumed and the time spent by each ante
+# decorator, Msg type URL and module BeginBlock/EndBlock in FinalizeBlock. The totals
+# are served by the cosmos.base.profiler.v1alpha1.ProfilerService gRPC service and
+# printed by '<appd> debug profile'.
+profiler = {{ .BaseConfig.Profiler }}
+
+# OEAbortTimeout aborts the optimistic execution of a block, when enabled by the app,
+# running for longer than this duration before the block is finalized; the block is
+# then executed again in FinalizeBlock. 0 disables the timeout.
+oe-abort-timeout = "{{ .BaseConfig.OEAbortTimeout }}"
+
+# OEMaxConsecutiveAborts disables the optimistic execution after this many consecutive
+# aborts, until the node restarts. 0 never disables it. The optimistic execution
+# statistics are served by the cosmos.base.oe.v1alpha1.OptimisticExecutionService gRPC
+# service and printed by '<appd> debug oe-stats'.
+oe-max-consecutive-aborts = {{ .BaseConfig.OEMaxConsecutiveAborts }}
+
 ###############################################################################
 ###                         Telemetry Configuration                         ###
 ###############################################################################
@@ -234,6 +267,23 @@ plugin = "{{ .Streaming.ABCI.Plugin }}"
 # stop-node-on-err specifies whether to stop the node on message delivery error.
 stop-node-on-err = {{ .Streaming.ABCI.StopNodeOnErr }}
 
+# streaming.filters specifies per-store key prefix filters applied by the store
+# listeners before the writes are buffered for streaming, so that they apply to
+# every streaming service. Prefixes are hex encoded. A write is streamed when its
+# key matches one of the allow-prefixes (or allow-prefixes is empty) and none of
+# the deny-prefixes. Filters of stores that are not streamed are ignored.
+#
+# Example:
+# [streaming.filters.bank]
+# allow-prefixes = ["02"]
+# deny-prefixes = []
+{{- range $store, $filter := .Streaming.Filters }}
+
+[streaming.filters.{{ $store }}]
+allow-prefixes = [{{ range $filter.AllowPrefixes }}{{ printf "%q, " . }}{{end}}]
+deny-prefixes = [{{ range $filter.DenyPrefixes }}{{ printf "%q, " . }}{{end}}]
+{{- end }}
+
 ###############################################################################
 ###                         Mempool                                         ###
 ###############################################################################
@@ -246,6 +296,15 @@ stop-node-on-err = {{ .Streaming.ABCI.StopNodeOnErr }}
 # Note, this configuration only applies to SDK built-in app-side mempool
 # implementations.
 max-txs = {{ .Mempool.MaxTxs }}
+
+# Journal records the txs inserted into and removed from the app-side mempool in
+# data/mempool.journal, so that the pending txs are replayed through CheckTx when
+# the node restarts.
+journal = {{ .Mempool.Journal }}
+
+# JournalMaxAge is the max age of the journaled txs replayed on restart, older txs
+# are dropped. Setting it to 0 replays all pending txs.
+journal-max-age = "{{ .Mempool.JournalMaxAge }}"
 `
 
 var configTemplate *template.Template
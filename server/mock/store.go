@@ -54,6 +54,10 @@ func (ms multiStore) AddListeners(keys []storetypes.StoreKey) {
 	panic("not implemented")
 }
 
+func (ms multiStore) SetListenerFilter(storetypes.StoreKey, *storetypes.KeyPrefixFilter) error {
+	panic("not implemented")
+}
+
 func (ms multiStore) SetMetrics(metrics.StoreMetrics) {
 	panic("not implemented")
 }
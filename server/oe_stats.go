@@ -0,0 +1,41 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/baseapp/oe"
+	"github.com/cosmos/cosmos-sdk/version"
+)
+
+// OEStatsCmd returns a command printing the optimistic execution statistics of
+// a running node.
+func OEStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "oe-stats",
+		Short: "Print the optimistic execution statistics of a running node",
+		Long: `Print how many blocks a running node executed optimistically since it started, how many of these executions
+were used by FinalizeBlock or aborted by reason, and the execution time they saved.
+The app must enable the optimistic execution and the gRPC server of the node must be reachable at --grpc-addr.`,
+		Example: fmt.Sprintf("%s debug oe-stats --grpc-addr localhost:9090 --grpc-insecure", version.AppName),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := nodeGRPCClientContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			res, err := oe.NewOptimisticExecutionServiceClient(clientCtx).Stats(cmd.Context(), &oe.StatsRequest{})
+			if err != nil {
+				return fmt.Errorf("failed to query the optimistic execution statistics, make sure the app enables it: %w", err)
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	addNodeGRPCFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/baseapp/profiler"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/version"
+)
+
+const flagReset = "reset"
+
+// ProfileCmd returns a command printing the gas consumed and the time spent by
+// each ante decorator, Msg type URL and module block hook of a running node,
+// as recorded by its profiler.
+func ProfileCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Print the gas and time spent by the ante decorators, Msgs and block hooks of a running node",
+		Long: `Print the gas consumed and the wall time spent by each ante decorator, Msg type URL and module BeginBlock/EndBlock
+in FinalizeBlock since the node started or since the profile was last reset, the slowest first.
+The node must be started with the profiler enabled (--profiler or 'profiler = true' in app.toml) and its gRPC server
+must be reachable at --grpc-addr.`,
+		Example: fmt.Sprintf("%s debug profile --grpc-addr localhost:9090 --grpc-insecure --reset", version.AppName),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := nodeGRPCClientContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := profiler.NewProfilerServiceClient(clientCtx)
+			res, err := queryClient.Profile(cmd.Context(), &profiler.ProfileRequest{})
+			if err != nil {
+				return fmt.Errorf("failed to query the profiler, make sure the node runs with the profiler enabled: %w", err)
+			}
+
+			if reset, _ := cmd.Flags().GetBool(flagReset); reset {
+				if _, err := queryClient.Reset(cmd.Context(), &profiler.ResetRequest{}); err != nil {
+					return fmt.Errorf("failed to reset the profiler: %w", err)
+				}
+			}
+
+			if clientCtx.OutputFormat == flags.OutputFormatJSON {
+				return clientCtx.PrintProto(res)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "CATEGORY\tNAME\tCOUNT\tGAS\tTIME\tAVG TIME")
+			for _, entry := range res.Entries {
+				var avg time.Duration
+				if entry.Count > 0 {
+					avg = entry.Duration / time.Duration(entry.Count)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\n", entry.Category, entry.Name, entry.Count, entry.GasUsed, entry.Duration, avg)
+			}
+
+			return w.Flush()
+		},
+	}
+
+	addNodeGRPCFlags(cmd)
+	cmd.Flags().Bool(flagReset, false, "Reset the profile of the node after printing it")
+
+	return cmd
+}
+
+// addNodeGRPCFlags adds the flags of the commands querying the gRPC server of
+// a running node.
+func addNodeGRPCFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flags.FlagGRPC, "localhost:9090", "the gRPC endpoint of the node")
+	cmd.Flags().Bool(flags.FlagGRPCInsecure, false, "allow gRPC over insecure channels, if not the server must use TLS")
+	cmd.Flags().StringP(flags.FlagOutput, "o", flags.OutputFormatText, "Output format (text|json)")
+}
+
+// nodeGRPCClientContext returns the client context of a command querying the
+// gRPC server of a running node, set with the flags of addNodeGRPCFlags.
+func nodeGRPCClientContext(cmd *cobra.Command) (client.Context, error) {
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return clientCtx, err
+	}
+	if clientCtx.GRPCClient == nil {
+		return clientCtx, errors.New("the gRPC endpoint of the node must be set with --grpc-addr")
+	}
+
+	return clientCtx, nil
+}
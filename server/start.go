@@ -67,14 +67,20 @@ const (
 	FlagTrace              = "trace"
 	FlagInvCheckPeriod     = "inv-check-period"
 
-	FlagPruning             = "pruning"
-	FlagPruningKeepRecent   = "pruning-keep-recent"
-	FlagPruningInterval     = "pruning-interval"
-	FlagIndexEvents         = "index-events"
-	FlagMinRetainBlocks     = "min-retain-blocks"
-	FlagIAVLCacheSize       = "iavl-cache-size"
-	FlagDisableIAVLFastNode = "iavl-disable-fastnode"
-	FlagShutdownGrace       = "shutdown-grace"
+	FlagPruning               = "pruning"
+	FlagPruningKeepRecent     = "pruning-keep-recent"
+	FlagPruningInterval       = "pruning-interval"
+	FlagIndexEvents           = "index-events"
+	FlagMinRetainBlocks       = "min-retain-blocks"
+	FlagIAVLCacheSize         = "iavl-cache-size"
+	FlagDisableIAVLFastNode   = "iavl-disable-fastnode"
+	FlagShutdownGrace         = "shutdown-grace"
+	FlagAppHashDumpDir        = "app-hash-dump-dir"
+	FlagAppHashDumpKeep       = "app-hash-dump-keep-recent"
+	FlagCacheKVSpillThreshold = "cachekv-spill-threshold"
+	FlagProfiler              = "profiler"
+	FlagOEAbortTimeout        = "oe-abort-timeout"
+	FlagOEMaxConsecutiveAbort = "oe-max-consecutive-aborts"
 
 	// state sync-related flags
 	FlagStateSyncSnapshotInterval   = "state-sync.snapshot-interval"
@@ -97,7 +103,9 @@ const (
 	flagGRPCWebEnable = "grpc-web.enable"
 
 	// mempool flags
-	FlagMempoolMaxTxs = "mempool.max-txs"
+	FlagMempoolMaxTxs        = "mempool.max-txs"
+	FlagMempoolJournal       = "mempool.journal"
+	FlagMempoolJournalMaxAge = "mempool.journal-max-age"
 
 	// testnet keys
 	KeyIsTestnet             = "is-testnet"
@@ -228,6 +236,14 @@ func start(svrCtx *Context, clientCtx client.Context, appCreator types.AppCreato
 	}
 	defer appCleanupFn()
 
+	// the journaled mempool txs are replayed in every start mode, before
+	// CometBFT, in-process or not, starts delivering blocks
+	if journaledApp, ok := app.(interface{ ReplayMempoolJournal() error }); ok {
+		if err := journaledApp.ReplayMempoolJournal(); err != nil {
+			return fmt.Errorf("failed to replay mempool journal: %w", err)
+		}
+	}
+
 	metrics, err := startTelemetry(svrCfg)
 	if err != nil {
 		return err
@@ -321,6 +337,7 @@ func startInProcess(svrCtx *Context, svrCfg serverconfig.Config, clientCtx clien
 		svrCfg.GRPC.Enable = true
 	} else {
 		svrCtx.Logger.Info("starting node with ABCI CometBFT in-process")
+
 		tmNode, cleanupFn, err := startCmtNode(ctx, cmtCfg, app, svrCtx)
 		if err != nil {
 			return err
@@ -995,7 +1012,15 @@ func addStartNodeFlags(cmd *cobra.Command, opts StartCmdOptions) {
 	cmd.Flags().Uint64(FlagStateSyncSnapshotInterval, 0, "State sync snapshot interval")
 	cmd.Flags().Uint32(FlagStateSyncSnapshotKeepRecent, 2, "State sync snapshot to keep")
 	cmd.Flags().Bool(FlagDisableIAVLFastNode, false, "Disable fast node for IAVL tree")
+	cmd.Flags().String(FlagAppHashDumpDir, "", "Write the store hashes and change set of every committed block to this directory to diagnose app hash mismatches")
+	cmd.Flags().Uint64(FlagAppHashDumpKeep, 100, "Number of recent app hash dumps to keep (0 keeps all)")
+	cmd.Flags().Uint64(FlagCacheKVSpillThreshold, 0, "Size in bytes of the dirty writes of a block held in memory per store before they are spilled to disk (0 disables spilling)")
+	cmd.Flags().Bool(FlagProfiler, false, "Record the gas and time spent by the ante decorators, Msg handlers and block hooks, served by the ProfilerService gRPC service")
+	cmd.Flags().Duration(FlagOEAbortTimeout, 0, "Abort the optimistic execution of a block running for longer than this duration before the block is finalized (0 disables the timeout)")
+	cmd.Flags().Uint64(FlagOEMaxConsecutiveAbort, 0, "Disable the optimistic execution after this many consecutive aborts (0 never disables it)")
 	cmd.Flags().Int(FlagMempoolMaxTxs, mempool.DefaultMaxTx, "Sets MaxTx value for the app-side mempool")
+	cmd.Flags().Bool(FlagMempoolJournal, false, "Record the app-side mempool txs on disk and replay them through CheckTx on restart")
+	cmd.Flags().Duration(FlagMempoolJournalMaxAge, 24*time.Hour, "Max age of the journaled mempool txs replayed on restart (0 replays all)")
 	cmd.Flags().Duration(FlagShutdownGrace, 0*time.Second, "On Shutdown, duration to wait for resource clean up")
 
 	// support old flags name for backwards compatibility
@@ -0,0 +1,356 @@
+package server
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	iavltree "github.com/cosmos/iavl"
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/store/iavl"
+	"cosmossdk.io/store/rootmulti"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/types/simulation"
+	"github.com/cosmos/cosmos-sdk/version"
+)
+
+const (
+	flagFromHeight = "from"
+	flagToHeight   = "to"
+	flagStore      = "store"
+
+	// StateDiffOpAdded marks a key that does not exist at the start height.
+	StateDiffOpAdded = "added"
+	// StateDiffOpUpdated marks a key whose value changed between the heights.
+	StateDiffOpUpdated = "updated"
+	// StateDiffOpDeleted marks a key that does not exist at the end height.
+	StateDiffOpDeleted = "deleted"
+)
+
+// StateDiffEntry is a single key changed between two heights. Keys and raw
+// values are hex encoded. The values of the keys belonging to a collection of
+// the module owning the store are decoded in OldDecoded and NewDecoded, while
+// Decoded holds the old and new values of the other updated keys rendered by
+// the simulation store decoder of the module, if any.
+type StateDiffEntry struct {
+	Store      string `json:"store"`
+	Operation  string `json:"operation"`
+	Key        string `json:"key"`
+	Collection string `json:"collection,omitempty"`
+	OldValue   string `json:"old_value,omitempty"`
+	NewValue   string `json:"new_value,omitempty"`
+	OldDecoded string `json:"old_decoded,omitempty"`
+	NewDecoded string `json:"new_decoded,omitempty"`
+	Decoded    string `json:"decoded,omitempty"`
+}
+
+// StateDiffCmd returns a command printing the keys changed in the application
+// state between two heights. Values are decoded with the collections schemas
+// of the modules when the application exposes them, and with the modules'
+// store decoders when the application exposes a simulation manager.
+func StateDiffCmd(appCreator types.AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "state-diff",
+		Short: "Print the state changes between two heights",
+		Long: `Print the keys changed in the application state between two heights, together with their old and new values.
+Values are decoded using the collections schemas of the modules, or the store decoders registered by the modules in the simulation manager of the application, and printed as hex otherwise.
+This command is useful to investigate unexpected app hash changes. Daemon should not be running when calling this command.`,
+		Example: fmt.Sprintf("%s debug state-diff --from 100 --to 101 --store bank --output json", version.AppName),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			serverCtx := GetServerContextFromCmd(cmd)
+
+			from, err := cmd.Flags().GetInt64(flagFromHeight)
+			if err != nil {
+				return err
+			}
+			to, err := cmd.Flags().GetInt64(flagToHeight)
+			if err != nil {
+				return err
+			}
+			if from <= 0 || to <= from {
+				return fmt.Errorf("invalid height range [%d, %d]: heights must be positive and --to must be greater than --from", from, to)
+			}
+			storeNames, err := cmd.Flags().GetStringSlice(flagStore)
+			if err != nil {
+				return err
+			}
+
+			db, err := openDB(serverCtx.Config.RootDir, GetAppDBBackend(serverCtx.Viper))
+			if err != nil {
+				return fmt.Errorf("error opening DB, make sure daemon is not running when calling this command: %w", err)
+			}
+			defer db.Close()
+
+			app := appCreator(serverCtx.Logger, db, nil, serverCtx.Viper)
+			rms, ok := app.CommitMultiStore().(*rootmulti.Store)
+			if !ok {
+				return fmt.Errorf("expected rootmulti.Store, got %T", app.CommitMultiStore())
+			}
+
+			var schemas map[string]collections.Schema
+			if schemaApp, ok := app.(interface {
+				CollectionsSchemas() map[string]collections.Schema
+			}); ok {
+				schemas = schemaApp.CollectionsSchemas()
+			}
+
+			var decoders simulation.StoreDecoderRegistry
+			if simApp, ok := app.(interface {
+				SimulationManager() *module.SimulationManager
+			}); ok && simApp.SimulationManager() != nil {
+				decoders = simApp.SimulationManager().StoreDecoders
+			}
+
+			entries, err := StateDiff(rms, from, to, storeNames, schemas, decoders)
+			if err != nil {
+				return err
+			}
+
+			output, _ := cmd.Flags().GetString(flags.FlagOutput)
+			if output == flags.OutputFormatJSON {
+				bz, err := json.MarshalIndent(entries, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(bz))
+				return nil
+			}
+
+			for _, entry := range entries {
+				cmd.Printf("%s %s %s\n", entry.Store, entry.Operation, entry.Key)
+				if entry.Collection != "" {
+					cmd.Printf("  collection: %s\n", entry.Collection)
+				}
+				if entry.OldDecoded != "" || entry.NewDecoded != "" {
+					cmd.Printf("  old: %s\n  new: %s\n", entry.OldDecoded, entry.NewDecoded)
+					continue
+				}
+				if entry.Decoded != "" {
+					cmd.Printf("  %s\n", strings.ReplaceAll(entry.Decoded, "\n", "\n  "))
+					continue
+				}
+				cmd.Printf("  old: %s\n  new: %s\n", entry.OldValue, entry.NewValue)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(flagFromHeight, 0, "Height of the state to compare from")
+	cmd.Flags().Int64(flagToHeight, 0, "Height of the state to compare to")
+	cmd.Flags().StringSlice(flagStore, nil, "Restrict the diff to the given stores (defaults to all stores)")
+	cmd.Flags().StringP(flags.FlagOutput, "o", flags.OutputFormatText, "Output format (text|json)")
+	_ = cmd.MarkFlagRequired(flagFromHeight)
+	_ = cmd.MarkFlagRequired(flagToHeight)
+
+	return cmd
+}
+
+// StateDiff returns the keys of the IAVL stores changed between the from and
+// to heights, sorted by store name and key. The changed keys are collected by
+// walking the IAVL change sets of the heights in between, and their values are
+// read from branches of the multistore at both heights. An empty storeNames
+// selects all stores. The values are decoded with the collections schemas
+// and store decoders of the stores, keyed by store name.
+func StateDiff(
+	rms *rootmulti.Store,
+	from, to int64,
+	storeNames []string,
+	schemas map[string]collections.Schema,
+	decoders simulation.StoreDecoderRegistry,
+) ([]StateDiffEntry, error) {
+	fromInfo, err := rms.GetCommitInfo(from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load commit info at height %d: %w", from, err)
+	}
+	existedAtFrom := make(map[string]bool, len(fromInfo.StoreInfos))
+	for _, storeInfo := range fromInfo.StoreInfos {
+		existedAtFrom[storeInfo.Name] = true
+	}
+
+	fromStore, err := rms.CacheMultiStoreWithVersion(from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state at height %d: %w", from, err)
+	}
+	toStore, err := rms.CacheMultiStoreWithVersion(to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state at height %d: %w", to, err)
+	}
+
+	keysByName := rms.StoreKeysByName()
+	// sort a copy, the slice of the caller is left untouched
+	names := append([]string{}, storeNames...)
+	if len(names) == 0 {
+		for name := range keysByName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var entries []StateDiffEntry
+	for _, name := range names {
+		key, ok := keysByName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown store %s", name)
+		}
+
+		iavlStore, ok := rms.GetCommitKVStore(key).(*iavl.Store)
+		if !ok {
+			// only IAVL stores are versioned
+			continue
+		}
+
+		changed := make(map[string]struct{})
+		err := iavlStore.TraverseStateChanges(from+1, to, func(_ int64, changeSet *iavltree.ChangeSet) error {
+			for _, pair := range changeSet.Pairs {
+				changed[string(pair.Key)] = struct{}{}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to traverse state changes of store %s: %w", name, err)
+		}
+
+		var schema *collections.Schema
+		if s, ok := schemas[name]; ok {
+			schema = &s
+		}
+		decoder := newStateDecoder(schema, decoders[name])
+
+		changedKeys := make([]string, 0, len(changed))
+		for k := range changed {
+			changedKeys = append(changedKeys, k)
+		}
+		sort.Strings(changedKeys)
+
+		for _, k := range changedKeys {
+			var oldValue []byte
+			if existedAtFrom[name] {
+				oldValue = fromStore.GetKVStore(key).Get([]byte(k))
+			}
+			newValue := toStore.GetKVStore(key).Get([]byte(k))
+
+			entry, ok := newStateDiffEntry(name, []byte(k), oldValue, newValue, decoder)
+			if ok {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// stateDecoder decodes the values of a store with the collections schema of
+// the module owning it, falling back to the simulation store decoder of the
+// module for the keys outside of its collections.
+type stateDecoder struct {
+	collections []collections.Collection
+	decoder     func(kvA, kvB kv.Pair) string
+}
+
+func newStateDecoder(schema *collections.Schema, decoder func(kvA, kvB kv.Pair) string) stateDecoder {
+	d := stateDecoder{decoder: decoder}
+	if schema != nil {
+		d.collections = schema.ListCollections()
+	}
+
+	return d
+}
+
+// collection returns the collection owning the key, if any.
+func (d stateDecoder) collection(key []byte) collections.Collection {
+	var owner collections.Collection
+	for _, coll := range d.collections {
+		// the prefixes of a schema don't overlap, keep the longest one anyway
+		if bytes.HasPrefix(key, coll.GetPrefix()) && (owner == nil || len(coll.GetPrefix()) > len(owner.GetPrefix())) {
+			owner = coll
+		}
+	}
+
+	return owner
+}
+
+// newStateDiffEntry builds the entry of a changed key. It returns false if the
+// value is identical at both heights, i.e. the key was changed and restored.
+func newStateDiffEntry(store string, key, oldValue, newValue []byte, decoder stateDecoder) (StateDiffEntry, bool) {
+	entry := StateDiffEntry{
+		Store:    store,
+		Key:      hex.EncodeToString(key),
+		OldValue: hex.EncodeToString(oldValue),
+		NewValue: hex.EncodeToString(newValue),
+	}
+
+	switch {
+	case oldValue == nil && newValue == nil:
+		return entry, false
+	case oldValue == nil:
+		entry.Operation = StateDiffOpAdded
+	case newValue == nil:
+		entry.Operation = StateDiffOpDeleted
+	case bytes.Equal(oldValue, newValue):
+		return entry, false
+	default:
+		entry.Operation = StateDiffOpUpdated
+	}
+
+	if coll := decoder.collection(key); coll != nil {
+		entry.Collection = coll.GetName()
+		entry.OldDecoded = decodeCollectionValue(coll, oldValue)
+		entry.NewDecoded = decodeCollectionValue(coll, newValue)
+	} else if entry.Operation == StateDiffOpUpdated {
+		// store decoders render a pair of values, only updated keys have one
+		entry.Decoded = decodeStateValues(decoder.decoder, key, oldValue, newValue)
+	}
+
+	return entry, true
+}
+
+// decodeCollectionValue renders a value with the value codec of its
+// collection. An empty string is returned if the value can't be decoded, in
+// which case the raw value is used instead.
+func decodeCollectionValue(coll collections.Collection, value []byte) string {
+	if value == nil {
+		return ""
+	}
+
+	codec := coll.ValueCodec()
+	v, err := codec.Decode(value)
+	if err != nil {
+		return ""
+	}
+
+	decoded, err := codec.Stringify(v)
+	if err != nil {
+		return ""
+	}
+
+	return decoded
+}
+
+// decodeStateValues renders the old and new values of a key with the given
+// store decoder. Store decoders panic on keys and values they don't know
+// about, in which case an empty string is returned and the raw values are
+// used instead.
+func decodeStateValues(decoder func(kvA, kvB kv.Pair) string, key, oldValue, newValue []byte) (decoded string) {
+	if decoder == nil {
+		return ""
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			decoded = ""
+		}
+	}()
+
+	return decoder(kv.Pair{Key: key, Value: oldValue}, kv.Pair{Key: key, Value: newValue})
+}
@@ -0,0 +1,153 @@
+package server_test
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/core/store"
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	"github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+// balanceKey returns the key of the balance of addr in the balances
+// collection of the test bank store.
+func balanceKey(addr string) []byte {
+	return append([]byte{0}, addr...)
+}
+
+func balanceValue(t *testing.T, amount uint64) []byte {
+	t.Helper()
+
+	bz, err := collections.Uint64Value.Encode(amount)
+	require.NoError(t, err)
+	return bz
+}
+
+func TestStateDiff(t *testing.T) {
+	bankKey := storetypes.NewKVStoreKey("bank")
+	accKey := storetypes.NewKVStoreKey("acc")
+
+	rms := rootmulti.NewStore(dbm.NewMemDB(), log.NewNopLogger(), metrics.NewNoOpMetrics())
+	rms.MountStoreWithDB(bankKey, storetypes.StoreTypeIAVL, nil)
+	rms.MountStoreWithDB(accKey, storetypes.StoreTypeIAVL, nil)
+	require.NoError(t, rms.LoadLatestVersion())
+
+	// height 1
+	rms.GetKVStore(bankKey).Set(balanceKey("alice"), balanceValue(t, 100))
+	rms.GetKVStore(bankKey).Set(balanceKey("bob"), balanceValue(t, 50))
+	rms.GetKVStore(bankKey).Set([]byte("legacy"), []byte("old"))
+	rms.GetKVStore(accKey).Set([]byte("alice"), []byte("account"))
+	rms.Commit()
+
+	// height 2
+	rms.GetKVStore(bankKey).Set(balanceKey("alice"), balanceValue(t, 90))
+	rms.GetKVStore(bankKey).Set(balanceKey("carol"), balanceValue(t, 10))
+	rms.GetKVStore(bankKey).Set([]byte("legacy"), []byte("new"))
+	rms.GetKVStore(accKey).Set([]byte("alice"), []byte("changed"))
+	rms.Commit()
+
+	// height 3
+	rms.GetKVStore(bankKey).Delete(balanceKey("bob"))
+	rms.GetKVStore(accKey).Set([]byte("alice"), []byte("account"))
+	rms.Commit()
+
+	sb := collections.NewSchemaBuilderFromAccessor(func(context.Context) store.KVStore { return nil })
+	collections.NewMap(sb, collections.NewPrefix(0), "balances", collections.StringKey, collections.Uint64Value)
+	schema, err := sb.Build()
+	require.NoError(t, err)
+	schemas := map[string]collections.Schema{"bank": schema}
+
+	decoders := simulation.StoreDecoderRegistry{
+		"bank": func(kvA, kvB kv.Pair) string {
+			return fmt.Sprintf("%s\n%s", kvA.Value, kvB.Value)
+		},
+	}
+
+	entries, err := server.StateDiff(rms, 1, 3, nil, schemas, decoders)
+	require.NoError(t, err)
+
+	// the acc store change was reverted and is not reported, the keys of the
+	// balances collection are decoded with its value codec and the others
+	// with the store decoder
+	expected := []server.StateDiffEntry{
+		{
+			Store:      "bank",
+			Operation:  server.StateDiffOpUpdated,
+			Key:        hex.EncodeToString(balanceKey("alice")),
+			Collection: "balances",
+			OldValue:   hex.EncodeToString(balanceValue(t, 100)),
+			NewValue:   hex.EncodeToString(balanceValue(t, 90)),
+			OldDecoded: "100",
+			NewDecoded: "90",
+		},
+		{
+			Store:      "bank",
+			Operation:  server.StateDiffOpDeleted,
+			Key:        hex.EncodeToString(balanceKey("bob")),
+			Collection: "balances",
+			OldValue:   hex.EncodeToString(balanceValue(t, 50)),
+			OldDecoded: "50",
+		},
+		{
+			Store:      "bank",
+			Operation:  server.StateDiffOpAdded,
+			Key:        hex.EncodeToString(balanceKey("carol")),
+			Collection: "balances",
+			NewValue:   hex.EncodeToString(balanceValue(t, 10)),
+			NewDecoded: "10",
+		},
+		{
+			Store:     "bank",
+			Operation: server.StateDiffOpUpdated,
+			Key:       hex.EncodeToString([]byte("legacy")),
+			OldValue:  hex.EncodeToString([]byte("old")),
+			NewValue:  hex.EncodeToString([]byte("new")),
+			Decoded:   "old\nnew",
+		},
+	}
+	require.Equal(t, expected, entries)
+
+	// without schemas, the store decoder only renders the updated keys
+	entries, err = server.StateDiff(rms, 1, 3, []string{"bank"}, nil, decoders)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	require.Equal(t, fmt.Sprintf("%s\n%s", balanceValue(t, 100), balanceValue(t, 90)), entries[0].Decoded)
+	require.Empty(t, entries[1].Decoded)
+	require.Empty(t, entries[2].Decoded)
+	require.Empty(t, entries[0].Collection)
+
+	entries, err = server.StateDiff(rms, 1, 2, []string{"acc"}, schemas, decoders)
+	require.NoError(t, err)
+	require.Equal(t, []server.StateDiffEntry{
+		{
+			Store:     "acc",
+			Operation: server.StateDiffOpUpdated,
+			Key:       hex.EncodeToString([]byte("alice")),
+			OldValue:  hex.EncodeToString([]byte("account")),
+			NewValue:  hex.EncodeToString([]byte("changed")),
+		},
+	}, entries)
+
+	// the store names of the caller are not reordered
+	storeNames := []string{"bank", "acc"}
+	entries, err = server.StateDiff(rms, 1, 2, storeNames, schemas, decoders)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	require.Equal(t, "acc", entries[0].Store)
+	require.Equal(t, []string{"bank", "acc"}, storeNames)
+
+	_, err = server.StateDiff(rms, 1, 2, []string{"unknown"}, schemas, decoders)
+	require.Error(t, err)
+}
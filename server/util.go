@@ -31,6 +31,7 @@ import (
 	storetypes "cosmossdk.io/store/types"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/baseapp/oe"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/server/config"
 	"github.com/cosmos/cosmos-sdk/server/types"
@@ -549,6 +550,16 @@ func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
 		)
 	}
 
+	var mempoolJournalPath string
+	if cast.ToBool(appOpts.Get(FlagMempoolJournal)) {
+		mempoolJournalPath = filepath.Join(homeDir, "data", "mempool.journal")
+	}
+
+	var profiler *baseapp.Profiler
+	if cast.ToBool(appOpts.Get(FlagProfiler)) {
+		profiler = baseapp.NewProfiler()
+	}
+
 	return []func(*baseapp.BaseApp){
 		baseapp.SetPruning(pruningOpts),
 		baseapp.SetMinGasPrices(cast.ToString(appOpts.Get(FlagMinGasPrices))),
@@ -561,7 +572,15 @@ func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
 		baseapp.SetSnapshot(snapshotStore, snapshotOptions),
 		baseapp.SetIAVLCacheSize(cast.ToInt(appOpts.Get(FlagIAVLCacheSize))),
 		baseapp.SetIAVLDisableFastNode(cast.ToBool(appOpts.Get(FlagDisableIAVLFastNode))),
+		baseapp.SetAppHashDump(cast.ToString(appOpts.Get(FlagAppHashDumpDir)), cast.ToUint64(appOpts.Get(FlagAppHashDumpKeep))),
+		baseapp.SetCacheKVSpill(cast.ToUint64(appOpts.Get(FlagCacheKVSpillThreshold)), filepath.Join(homeDir, "data", "cachekv-spill")),
+		baseapp.SetProfiler(profiler),
+		baseapp.SetOptimisticExecutionAbortPolicy(oe.AbortPolicy{
+			Timeout:              cast.ToDuration(appOpts.Get(FlagOEAbortTimeout)),
+			MaxConsecutiveAborts: cast.ToUint64(appOpts.Get(FlagOEMaxConsecutiveAbort)),
+		}),
 		defaultMempool,
+		baseapp.SetMempoolJournal(mempoolJournalPath, cast.ToDuration(appOpts.Get(FlagMempoolJournalMaxAge))),
 		baseapp.SetChainID(chainID),
 		baseapp.SetQueryGasLimit(cast.ToUint64(appOpts.Get(FlagQueryGasLimit))),
 	}
@@ -7,12 +7,16 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	feemarketante "github.com/cosmos/cosmos-sdk/x/feemarket/ante"
 )
 
 // HandlerOptions are the options required for constructing a default SDK AnteHandler.
 type HandlerOptions struct {
 	ante.HandlerOptions
 	CircuitKeeper circuitante.CircuitBreaker
+	// FeeMarketKeeper enables the x/feemarket base fee, when set and the fee
+	// market is enabled in its params. It replaces the TxFeeChecker.
+	FeeMarketKeeper feemarketante.FeeMarketKeeper
 }
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
@@ -31,20 +35,46 @@ func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
 		return nil, errors.New("sign mode handler is required for ante builder")
 	}
 
+	txFeeChecker := options.TxFeeChecker
+	if options.FeeMarketKeeper != nil {
+		txFeeChecker = feemarketante.NewTxFeeChecker(options.FeeMarketKeeper)
+	}
+
+	extensionOptionChecker := options.ExtensionOptionChecker
+	if options.UnorderedTxKeeper != nil {
+		extensionOptionChecker = ante.AcceptUnorderedExtensionOption(extensionOptionChecker)
+	}
+
 	anteDecorators := []sdk.AnteDecorator{
 		ante.NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
 		circuitante.NewCircuitBreakerDecorator(options.CircuitKeeper),
-		ante.NewExtensionOptionsDecorator(options.ExtensionOptionChecker),
+		ante.NewExtensionOptionsDecorator(extensionOptionChecker),
 		ante.NewValidateBasicDecorator(),
 		ante.NewTxTimeoutHeightDecorator(),
+	}
+
+	if options.UnorderedTxKeeper != nil {
+		maxTimeout := options.MaxUnorderedTxTimeout
+		if maxTimeout == 0 {
+			maxTimeout = ante.DefaultMaxUnorderedTxTimeout
+		}
+		anteDecorators = append(anteDecorators, ante.NewUnorderedTxDecorator(maxTimeout, options.UnorderedTxKeeper))
+	}
+
+	anteDecorators = append(anteDecorators,
 		ante.NewValidateMemoDecorator(options.AccountKeeper),
 		ante.NewConsumeGasForTxSizeDecorator(options.AccountKeeper),
-		ante.NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.FeegrantKeeper, options.TxFeeChecker),
+		ante.NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.FeegrantKeeper, txFeeChecker),
 		ante.NewSetPubKeyDecorator(options.AccountKeeper), // SetPubKeyDecorator must be called before all signature verification decorators
 		ante.NewValidateSigCountDecorator(options.AccountKeeper),
 		ante.NewSigGasConsumeDecorator(options.AccountKeeper, options.SigGasConsumer),
 		ante.NewSigVerificationDecorator(options.AccountKeeper, options.SignModeHandler),
 		ante.NewIncrementSequenceDecorator(options.AccountKeeper),
+	)
+
+	if options.FeeMarketKeeper != nil {
+		// the base fee is burnt once the fees are deducted and the tx is authenticated
+		anteDecorators = append(anteDecorators, feemarketante.NewBurnBaseFeeDecorator(options.FeeMarketKeeper))
 	}
 
 	return sdk.ChainAnteDecorators(anteDecorators...), nil
@@ -56,6 +56,9 @@ import (
 	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
 	_ "github.com/cosmos/cosmos-sdk/x/distribution" // import for side-effects
 	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	_ "github.com/cosmos/cosmos-sdk/x/feemarket" // import for side-effects
+	feemarketmodulev1 "github.com/cosmos/cosmos-sdk/x/feemarket/module/v1"
+	feemarkettypes "github.com/cosmos/cosmos-sdk/x/feemarket/types"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
@@ -68,6 +71,9 @@ import (
 	_ "github.com/cosmos/cosmos-sdk/x/params" // import for side-effects
 	paramsclient "github.com/cosmos/cosmos-sdk/x/params/client"
 	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	_ "github.com/cosmos/cosmos-sdk/x/scheduler" // import for side-effects
+	schedulermodulev1 "github.com/cosmos/cosmos-sdk/x/scheduler/module/v1"
+	schedulertypes "github.com/cosmos/cosmos-sdk/x/scheduler/types"
 	_ "github.com/cosmos/cosmos-sdk/x/slashing" // import for side-effects
 	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	_ "github.com/cosmos/cosmos-sdk/x/staking" // import for side-effects
@@ -84,6 +90,8 @@ var (
 		{Account: stakingtypes.NotBondedPoolName, Permissions: []string{authtypes.Burner, stakingtypes.ModuleName}},
 		{Account: govtypes.ModuleName, Permissions: []string{authtypes.Burner}},
 		{Account: nft.ModuleName},
+		{Account: feemarkettypes.ModuleName, Permissions: []string{authtypes.Burner}},
+		{Account: schedulertypes.ModuleName},
 	}
 
 	// blocked account addresses
@@ -94,6 +102,8 @@ var (
 		stakingtypes.BondedPoolName,
 		stakingtypes.NotBondedPoolName,
 		nft.ModuleName,
+		feemarkettypes.ModuleName,
+		schedulertypes.ModuleName,
 		// We allow the following module accounts to receive funds:
 		// govtypes.ModuleName
 	}
@@ -108,6 +118,7 @@ var (
 					// NOTE: upgrade module is required to be prioritized
 					PreBlockers: []string{
 						upgradetypes.ModuleName,
+						authtypes.ModuleName,
 					},
 					// During begin block slashing happens after distr.BeginBlocker so that
 					// there is nothing left over in the validator fee pool, so as to keep the
@@ -121,12 +132,17 @@ var (
 						stakingtypes.ModuleName,
 						authz.ModuleName,
 					},
+					// NOTE: The scheduler module must occur first so that the scheduled txs are
+					// executed before the invariants are checked and the validator updates are
+					// computed.
 					EndBlockers: []string{
+						schedulertypes.ModuleName,
 						crisistypes.ModuleName,
 						govtypes.ModuleName,
 						stakingtypes.ModuleName,
 						feegrant.ModuleName,
 						group.ModuleName,
+						feemarkettypes.ModuleName,
 					},
 					OverrideStoreKeys: []*runtimev1alpha1.StoreKeyConfig{
 						{
@@ -137,6 +153,7 @@ var (
 					// NOTE: The genutils module must occur after staking so that pools are
 					// properly initialized with tokens from genesis accounts.
 					// NOTE: The genutils module must also occur after auth so that it can access the params from auth.
+					// NOTE: The feemarket module must occur before genutils so that the ante handler can access its params.
 					InitGenesis: []string{
 						authtypes.ModuleName,
 						banktypes.ModuleName,
@@ -146,6 +163,7 @@ var (
 						govtypes.ModuleName,
 						minttypes.ModuleName,
 						crisistypes.ModuleName,
+						feemarkettypes.ModuleName,
 						genutiltypes.ModuleName,
 						evidencetypes.ModuleName,
 						authz.ModuleName,
@@ -156,6 +174,7 @@ var (
 						upgradetypes.ModuleName,
 						vestingtypes.ModuleName,
 						circuittypes.ModuleName,
+						schedulertypes.ModuleName,
 					},
 					// When ExportGenesis is not specified, the export genesis module order
 					// is equal to the init genesis order
@@ -262,6 +281,14 @@ var (
 				Name:   circuittypes.ModuleName,
 				Config: appconfig.WrapAny(&circuitmodulev1.Module{}),
 			},
+			{
+				Name:   feemarkettypes.ModuleName,
+				Config: appconfig.WrapAny(&feemarketmodulev1.Module{}),
+			},
+			{
+				Name:   schedulertypes.ModuleName,
+				Config: appconfig.WrapAny(&schedulermodulev1.Module{}),
+			},
 		},
 	}),
 		depinject.Supply(
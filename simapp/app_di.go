@@ -38,11 +38,13 @@ import (
 	consensuskeeper "github.com/cosmos/cosmos-sdk/x/consensus/keeper"
 	crisiskeeper "github.com/cosmos/cosmos-sdk/x/crisis/keeper"
 	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	feemarketkeeper "github.com/cosmos/cosmos-sdk/x/feemarket/keeper"
 	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
 	groupkeeper "github.com/cosmos/cosmos-sdk/x/group/keeper"
 	mintkeeper "github.com/cosmos/cosmos-sdk/x/mint/keeper"
 	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
 	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	schedulerkeeper "github.com/cosmos/cosmos-sdk/x/scheduler/keeper"
 	slashingkeeper "github.com/cosmos/cosmos-sdk/x/slashing/keeper"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 )
@@ -79,6 +81,8 @@ type SimApp struct {
 	AuthzKeeper           authzkeeper.Keeper
 	EvidenceKeeper        evidencekeeper.Keeper
 	FeeGrantKeeper        feegrantkeeper.Keeper
+	FeeMarketKeeper       feemarketkeeper.Keeper
+	SchedulerKeeper       schedulerkeeper.Keeper
 	GroupKeeper           groupkeeper.Keeper
 	NFTKeeper             nftkeeper.Keeper
 	ConsensusParamsKeeper consensuskeeper.Keeper
@@ -109,6 +113,10 @@ func NewSimApp(
 		app        = &SimApp{}
 		appBuilder *runtime.AppBuilder
 
+		// overriders of the balances and sequences of simulated txs
+		balanceOverrider  bankkeeper.BalanceOverrider
+		sequenceOverrider authkeeper.SequenceOverrider
+
 		// merge the AppConfig and other configuration in one config
 		appConfig = depinject.Configs(
 			AppConfig,
@@ -117,6 +125,9 @@ func NewSimApp(
 				appOpts,
 				// supply the logger
 				logger,
+				// supply the options of the x/auth/tx post handler, which read the
+				// keepers once injected
+				app.postHandlerOptions,
 
 				// ADVANCED CONFIGURATION
 
@@ -180,10 +191,14 @@ func NewSimApp(
 		&app.AuthzKeeper,
 		&app.EvidenceKeeper,
 		&app.FeeGrantKeeper,
+		&app.FeeMarketKeeper,
+		&app.SchedulerKeeper,
 		&app.GroupKeeper,
 		&app.NFTKeeper,
 		&app.ConsensusParamsKeeper,
 		&app.CircuitKeeper,
+		&balanceOverrider,
+		&sequenceOverrider,
 	); err != nil {
 		panic(err)
 	}
@@ -252,6 +267,9 @@ func NewSimApp(
 	// set custom ante handler
 	app.setAnteHandler(app.txConfig)
 
+	// allow simulating txs with balance and sequence overrides
+	app.SetStateOverriders(balanceOverrider, sequenceOverrider)
+
 	// A custom InitChainer can be set if extra pre-init-genesis logic is required.
 	// By default, when using app wiring enabled module, this is not required.
 	// For instance, the upgrade module will set automatically the module version map in its init genesis thanks to app wiring.
@@ -281,8 +299,11 @@ func (app *SimApp) setAnteHandler(txConfig client.TxConfig) {
 				SignModeHandler: txConfig.SignModeHandler(),
 				FeegrantKeeper:  app.FeeGrantKeeper,
 				SigGasConsumer:  ante.DefaultSigVerificationGasConsumer,
+				// opt in to the unordered txs, pruned by the x/auth PreBlock
+				UnorderedTxKeeper: app.AccountKeeper,
 			},
 			&app.CircuitKeeper,
+			app.FeeMarketKeeper,
 		},
 	)
 	if err != nil {
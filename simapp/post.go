@@ -0,0 +1,20 @@
+package simapp
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/posthandler"
+	feemarketante "github.com/cosmos/cosmos-sdk/x/feemarket/ante"
+)
+
+// postHandlerOptions returns the options of the SimApp post handler, which
+// refunds the fee paid for the unused gas, minus the burned base fee, and
+// restores it to the fee grants.
+func (app *SimApp) postHandlerOptions() posthandler.HandlerOptions {
+	return posthandler.HandlerOptions{
+		BankKeeper:     app.BankKeeper,
+		FeegrantKeeper: app.FeeGrantKeeper,
+		BurnedFee: func(ctx sdk.Context, tx sdk.FeeTx) (sdk.Coins, error) {
+			return feemarketante.BurnedFee(ctx, app.FeeMarketKeeper, tx)
+		},
+	}
+}
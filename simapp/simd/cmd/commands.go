@@ -107,10 +107,15 @@ func initRootCmd(
 	cfg := sdk.GetConfig()
 	cfg.Seal()
 
+	debugCmd := debug.Cmd()
+	debugCmd.AddCommand(server.StateDiffCmd(newApp))
+	debugCmd.AddCommand(server.ProfileCmd())
+	debugCmd.AddCommand(server.OEStatsCmd())
+
 	rootCmd.AddCommand(
 		genutilcli.InitCmd(basicManager, simapp.DefaultNodeHome),
 		NewTestnetCmd(basicManager, banktypes.GenesisBalancesIterator{}),
-		debug.Cmd(),
+		debugCmd,
 		confixcmd.ConfigCommand(),
 		pruning.Cmd(newApp, simapp.DefaultNodeHome),
 		snapshot.Cmd(newApp),
@@ -178,6 +183,7 @@ func txCommand() *cobra.Command {
 		authcmd.GetSignBatchCommand(),
 		authcmd.GetMultiSignCommand(),
 		authcmd.GetMultiSignBatchCmd(),
+		authcmd.GetMultisigCommand(),
 		authcmd.GetValidateSignaturesCommand(),
 		authcmd.GetBroadcastCommand(),
 		authcmd.GetEncodeCommand(),
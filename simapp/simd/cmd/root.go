@@ -19,6 +19,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtxconfig "github.com/cosmos/cosmos-sdk/x/auth/tx/config"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
+	feemarketclient "github.com/cosmos/cosmos-sdk/x/feemarket/client"
 )
 
 // NewRootCmd creates a new root command for simd. It is called once in the
@@ -42,6 +43,7 @@ func NewRootCmd() *cobra.Command {
 		WithInput(os.Stdin).
 		WithAccountRetriever(types.AccountRetriever{}).
 		WithHomeDir(simapp.DefaultNodeHome).
+		WithGasPricesHook(feemarketclient.GasPrices).
 		WithViper("") // In simapp, we don't use any prefix for env variables.
 
 	rootCmd := &cobra.Command{
@@ -64,6 +66,9 @@ func NewRootCmd() *cobra.Command {
 				return err
 			}
 
+			// estimate the gas of the offline txs against the local state
+			initClientCtx = initClientCtx.WithSimulator(localSimulator(initClientCtx.HomeDir))
+
 			// This needs to go after ReadFromClientConfig, as that function
 			// sets the RPC client needed for SIGN_MODE_TEXTUAL. This sign mode
 			// is only available if the client is online.
@@ -21,6 +21,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtxconfig "github.com/cosmos/cosmos-sdk/x/auth/tx/config"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
+	feemarketclient "github.com/cosmos/cosmos-sdk/x/feemarket/client"
 )
 
 // NewRootCmd creates a new root command for simd. It is called once in the main function.
@@ -67,6 +68,9 @@ func NewRootCmd() *cobra.Command {
 				return err
 			}
 
+			// estimate the gas of the offline txs against the local state
+			clientCtx = clientCtx.WithSimulator(localSimulator(clientCtx.HomeDir))
+
 			if err := client.SetCmdClientContextHandler(clientCtx, cmd); err != nil {
 				return err
 			}
@@ -100,6 +104,7 @@ func ProvideClientContext(
 		WithInput(os.Stdin).
 		WithAccountRetriever(types.AccountRetriever{}).
 		WithHomeDir(simapp.DefaultNodeHome).
+		WithGasPricesHook(feemarketclient.GasPrices).
 		WithViper("") // In simapp, we don't use any prefix for env variables.
 
 	clientCtx, _ = config.ReadFromClientConfig(clientCtx)
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/simapp"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// localSimulator returns a client.SimulateFn simulating the txs against the
+// latest state of the node in homeDir, e.g. to estimate the gas of the txs
+// generated offline. The node must be stopped, as it locks its database.
+func localSimulator(homeDir string) client.SimulateFn {
+	return func(txBytes []byte) (gasInfo sdk.GasInfo, result *sdk.Result, err error) {
+		dataDir := filepath.Join(homeDir, "data")
+		if _, err := os.Stat(filepath.Join(dataDir, "application.db")); err != nil {
+			return gasInfo, nil, fmt.Errorf("no local state to simulate against in %s: %w", homeDir, err)
+		}
+
+		appOpts := simtestutil.AppOptionsMap{flags.FlagHome: homeDir}
+		db, err := dbm.NewDB("application", server.GetAppDBBackend(appOpts), dataDir)
+		if err != nil {
+			return gasInfo, nil, err
+		}
+		defer db.Close()
+
+		// the app panics when its state can't be loaded
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("failed to load the local state: %v", r)
+			}
+		}()
+
+		app := simapp.NewSimApp(log.NewNopLogger(), db, nil, true, appOpts)
+		return app.Simulate(txBytes)
+	}
+}
@@ -0,0 +1,221 @@
+package simapp
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/testutil/mock"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+const txExecutorChainID = "tx-executor"
+
+// txExecutorApp is a SimApp whose senders send coins to their own recipient,
+// with the given tx executor.
+type txExecutorApp struct {
+	*SimApp
+
+	valSet     *cmttypes.ValidatorSet
+	keys       []*secp256k1.PrivKey
+	recipients []sdk.AccAddress
+	accNums    []uint64
+	seqs       []uint64
+}
+
+// parallelTxExecutor returns a ParallelTxExecutor accumulating the fees
+// credited to the fee collector, counting the executions of txs.
+func parallelTxExecutor(workers int, executions *atomic.Int64) baseapp.TxExecutor {
+	executor := baseapp.ParallelTxExecutor(workers, bankkeeper.NewBalancesAccumulator(authtypes.NewModuleAddress(authtypes.FeeCollectorName)))
+	return func(ctx context.Context, txs [][]byte, ms storetypes.MultiStore, blockGasMeter storetypes.GasMeter, deliverTx baseapp.DeliverTxFunc) ([]*abci.ExecTxResult, error) {
+		return executor(ctx, txs, ms, blockGasMeter, func(tx []byte, ms storetypes.MultiStore, blockGasMeter storetypes.GasMeter) *abci.ExecTxResult {
+			executions.Add(1)
+			return deliverTx(tx, ms, blockGasMeter)
+		})
+	}
+}
+
+func newTxExecutorApp(tb testing.TB, senders int, executor baseapp.TxExecutor) *txExecutorApp {
+	tb.Helper()
+
+	// the apps of the same accounts have the same genesis
+	privVal := mock.PV{PrivKey: ed25519.GenPrivKeyFromSecret([]byte("validator"))}
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(tb, err)
+	valSet := cmttypes.NewValidatorSet([]*cmttypes.Validator{cmttypes.NewValidator(pubKey, 1)})
+
+	app := &txExecutorApp{valSet: valSet}
+	var (
+		genAccs  []authtypes.GenesisAccount
+		balances []banktypes.Balance
+	)
+	for i := 0; i < senders; i++ {
+		key := secp256k1.GenPrivKeyFromSecret([]byte(fmt.Sprintf("sender%d", i)))
+		addr := sdk.AccAddress(key.PubKey().Address())
+		app.keys = append(app.keys, key)
+
+		// the recipients exist, so that sending to them doesn't create an
+		// account with the next account number
+		recipient := sdk.AccAddress(secp256k1.GenPrivKeyFromSecret([]byte(fmt.Sprintf("recipient%d", i))).PubKey().Address())
+		app.recipients = append(app.recipients, recipient)
+
+		for _, addr := range []sdk.AccAddress{addr, recipient} {
+			genAccs = append(genAccs, authtypes.NewBaseAccount(addr, nil, 0, 0))
+			balances = append(balances, banktypes.Balance{
+				Address: addr.String(),
+				Coins:   sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdkmath.NewInt(100000000000000))),
+			})
+		}
+	}
+
+	appOptions := simtestutil.AppOptionsMap{
+		flags.FlagHome:            tb.TempDir(),
+		server.FlagInvCheckPeriod: 0,
+	}
+	opts := []func(*baseapp.BaseApp){baseapp.SetChainID(txExecutorChainID)}
+	if executor != nil {
+		opts = append(opts, baseapp.SetTxExecutor(executor))
+	}
+	app.SimApp = NewSimApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, appOptions, opts...)
+
+	genesisState, err := simtestutil.GenesisStateWithValSet(app.AppCodec(), app.DefaultGenesis(), valSet, genAccs, balances...)
+	require.NoError(tb, err)
+	stateBytes, err := cmtjson.MarshalIndent(genesisState, "", " ")
+	require.NoError(tb, err)
+
+	_, err = app.InitChain(&abci.RequestInitChain{
+		ChainId:         txExecutorChainID,
+		ConsensusParams: simtestutil.DefaultConsensusParams,
+		AppStateBytes:   stateBytes,
+	})
+	require.NoError(tb, err)
+	app.finalizeBlock(tb, nil)
+
+	ctx := app.NewContext(true)
+	for _, key := range app.keys {
+		acc := app.AccountKeeper.GetAccount(ctx, sdk.AccAddress(key.PubKey().Address()))
+		app.accNums = append(app.accNums, acc.GetAccountNumber())
+		app.seqs = append(app.seqs, acc.GetSequence())
+	}
+
+	return app
+}
+
+// sendTxs returns a block of txs of every sender sending coins to its
+// recipient and paying a fee, which are independent but for the fees.
+func (app *txExecutorApp) sendTxs(tb testing.TB) [][]byte {
+	tb.Helper()
+
+	r := rand.New(rand.NewSource(int64(app.seqs[0])))
+	txs := make([][]byte, len(app.keys))
+	for i, key := range app.keys {
+		msg := banktypes.NewMsgSend(sdk.AccAddress(key.PubKey().Address()), app.recipients[i], sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1)))
+
+		tx, err := simtestutil.GenSignedMockTx(r, app.TxConfig(), []sdk.Msg{msg}, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 2000)),
+			simtestutil.DefaultGenTxGas, txExecutorChainID, []uint64{app.accNums[i]}, []uint64{app.seqs[i]}, key)
+		require.NoError(tb, err)
+		app.seqs[i]++
+
+		txs[i], err = app.TxConfig().TxEncoder()(tx)
+		require.NoError(tb, err)
+	}
+
+	return txs
+}
+
+func (app *txExecutorApp) finalizeBlock(tb testing.TB, txs [][]byte) *abci.ResponseFinalizeBlock {
+	tb.Helper()
+
+	res, err := app.FinalizeBlock(&abci.RequestFinalizeBlock{
+		Height:             app.LastBlockHeight() + 1,
+		Txs:                txs,
+		NextValidatorsHash: app.valSet.Hash(),
+	})
+	require.NoError(tb, err)
+	_, err = app.Commit()
+	require.NoError(tb, err)
+
+	return res
+}
+
+func TestParallelTxExecutor(t *testing.T) {
+	var executions atomic.Int64
+	sequential := newTxExecutorApp(t, 20, nil)
+	parallel := newTxExecutorApp(t, 20, parallelTxExecutor(4, &executions))
+
+	for i := 0; i < 3; i++ {
+		txs := sequential.sendTxs(t)
+		require.Equal(t, txs, parallel.sendTxs(t))
+
+		executions.Store(0)
+		expRes := sequential.finalizeBlock(t, txs)
+		res := parallel.finalizeBlock(t, txs)
+		for _, txResult := range expRes.TxResults {
+			require.Equal(t, uint32(0), txResult.Code, txResult.Log)
+		}
+		require.Equal(t, expRes.TxResults, res.TxResults)
+		require.Equal(t, expRes.AppHash, res.AppHash)
+
+		// the fees credited to the fee collector are accumulated: only the
+		// sends after its balance gains a digit, changing the gas of reading
+		// it, are executed again, not every send after the first
+		require.Less(t, executions.Load(), int64(2*len(txs)-1))
+	}
+}
+
+// BenchmarkTxExecutor measures the execution of blocks of independent sends,
+// sequentially and with a ParallelTxExecutor on every CPU, reporting the
+// executions per tx of the latter.
+func BenchmarkTxExecutor(b *testing.B) {
+	const accounts = 200
+
+	var executions atomic.Int64
+	for name, executor := range map[string]baseapp.TxExecutor{
+		"sequential": nil,
+		"parallel":   parallelTxExecutor(0, &executions),
+	} {
+		b.Run(name, func(b *testing.B) {
+			app := newTxExecutorApp(b, accounts, executor)
+			executions.Store(0)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				txs := app.sendTxs(b)
+				b.StartTimer()
+
+				res := app.finalizeBlock(b, txs)
+				for _, txResult := range res.TxResults {
+					if txResult.Code != 0 {
+						b.Fatal(txResult.Log)
+					}
+				}
+			}
+
+			if executor != nil {
+				b.ReportMetric(float64(executions.Load())/float64(b.N*accounts), "executions/tx")
+			}
+		})
+	}
+}
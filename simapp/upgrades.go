@@ -8,6 +8,8 @@ import (
 	upgradetypes "cosmossdk.io/x/upgrade/types"
 
 	"github.com/cosmos/cosmos-sdk/types/module"
+	feemarkettypes "github.com/cosmos/cosmos-sdk/x/feemarket/types"
+	schedulertypes "github.com/cosmos/cosmos-sdk/x/scheduler/types"
 )
 
 // UpgradeName defines the on-chain upgrade name for the sample SimApp upgrade
@@ -35,6 +37,8 @@ func (app SimApp) RegisterUpgradeHandlers() {
 		storeUpgrades := storetypes.StoreUpgrades{
 			Added: []string{
 				circuittypes.ModuleName,
+				feemarkettypes.StoreKey,
+				schedulertypes.StoreKey,
 			},
 		}
 
@@ -0,0 +1,166 @@
+package cachekv
+
+import (
+	"os"
+	"runtime"
+	"sync"
+
+	dbm "github.com/cosmos/cosmos-db"
+
+	"cosmossdk.io/store/types"
+)
+
+// SpillConfig configures a Store that bounds the memory used by its dirty
+// entries. Once their size exceeds Threshold, the dirty entries are written in
+// sorted order to a temporary sorted run on disk and dropped from memory.
+// Spilling doesn't change the content, the iteration order or the writes of
+// the Store, only where the dirty entries are held until Write.
+type SpillConfig struct {
+	// Threshold is the approximate size in bytes of the keys and values of the
+	// dirty entries kept in memory. Zero disables spilling.
+	Threshold uint64
+	// Dir is the directory in which the temporary sorted runs are created.
+	// os.TempDir() is used if empty.
+	Dir string
+}
+
+// Enabled returns true if the config spills dirty entries to disk.
+func (c SpillConfig) Enabled() bool {
+	return c.Threshold > 0
+}
+
+const (
+	spillDeleted byte = iota
+	spillSet
+)
+
+// spillRun is a temporary on-disk sorted run of the dirty entries evicted from
+// memory. Later spills overwrite the entries of earlier ones, so the run
+// always holds the most recent spilled write of each key. Deletions are
+// stored as tombstones so that they keep shadowing the parent.
+type spillRun struct {
+	dir       string
+	db        dbm.DB
+	closeOnce sync.Once
+}
+
+func newSpillRun(baseDir string) (*spillRun, error) {
+	if baseDir != "" {
+		if err := os.MkdirAll(baseDir, 0o750); err != nil {
+			return nil, err
+		}
+	}
+
+	dir, err := os.MkdirTemp(baseDir, "cachekv-spill-")
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := dbm.NewGoLevelDB("spill", dir, nil)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	run := &spillRun{dir: dir, db: db}
+	// branches may be discarded without being written, remove their run once
+	// they are garbage collected.
+	runtime.SetFinalizer(run, (*spillRun).close)
+
+	return run, nil
+}
+
+// write stores the given entries, which must be sorted by key. A nil value
+// marks a deletion.
+func (r *spillRun) write(entries []spillEntry) error {
+	batch := r.db.NewBatch()
+	defer batch.Close()
+
+	for _, entry := range entries {
+		if err := batch.Set([]byte(entry.key), encodeSpillValue(entry.value)); err != nil {
+			return err
+		}
+	}
+
+	return batch.Write()
+}
+
+// get returns the spilled value of the key. found is false if the key was
+// never spilled, value is nil if the spilled write is a deletion.
+func (r *spillRun) get(key []byte) (value []byte, found bool) {
+	bz, err := r.db.Get(key)
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return nil, false
+	}
+
+	return decodeSpillValue(bz), true
+}
+
+// iterator returns an iterator over the spilled entries of the domain. As
+// for the in-memory cache, deleted entries have a nil value. The iterator
+// reads from a snapshot and is not affected by subsequent spills.
+func (r *spillRun) iterator(start, end []byte, ascending bool) types.Iterator {
+	var (
+		iter dbm.Iterator
+		err  error
+	)
+	if ascending {
+		iter, err = r.db.Iterator(start, end)
+	} else {
+		iter, err = r.db.ReverseIterator(start, end)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	return &spillIterator{Iterator: iter}
+}
+
+// close closes and removes the run from disk. It is called on Write and by
+// the finalizer, only the first call has an effect.
+func (r *spillRun) close() {
+	r.closeOnce.Do(func() {
+		runtime.SetFinalizer(r, nil)
+		_ = r.db.Close()
+		_ = os.RemoveAll(r.dir)
+	})
+}
+
+type spillEntry struct {
+	key   string
+	value []byte
+}
+
+func encodeSpillValue(value []byte) []byte {
+	if value == nil {
+		return []byte{spillDeleted}
+	}
+
+	bz := make([]byte, 1+len(value))
+	bz[0] = spillSet
+	copy(bz[1:], value)
+	return bz
+}
+
+func decodeSpillValue(bz []byte) []byte {
+	if bz[0] == spillDeleted {
+		return nil
+	}
+
+	return bz[1:]
+}
+
+// spillIterator decodes the values of a spill run.
+type spillIterator struct {
+	dbm.Iterator
+}
+
+var _ types.Iterator = (*spillIterator)(nil)
+
+// Value implements types.Iterator.
+func (iter *spillIterator) Value() []byte {
+	return decodeSpillValue(iter.Iterator.Value())
+}
@@ -0,0 +1,21 @@
+package cachekv
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpillRunCloseTwice(t *testing.T) {
+	run, err := newSpillRun(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, run.write([]spillEntry{{key: "a", value: []byte("1")}}))
+
+	run.close()
+	_, err = os.Stat(run.dir)
+	require.True(t, os.IsNotExist(err))
+
+	// e.g. the finalizer running after Write
+	require.NotPanics(t, run.close)
+}
@@ -2,6 +2,7 @@ package cachekv
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"sort"
 	"sync"
@@ -30,6 +31,10 @@ type Store struct {
 	unsortedCache map[string]struct{}
 	sortedCache   internal.BTree // always ascending sorted
 	parent        types.KVStore
+
+	spillConfig SpillConfig
+	dirtySize   uint64    // approximate size of the dirty entries in memory
+	spill       *spillRun // dirty entries evicted from memory, if any
 }
 
 var _ types.CacheKVStore = (*Store)(nil)
@@ -44,6 +49,15 @@ func NewStore(parent types.KVStore) *Store {
 	}
 }
 
+// NewStoreWithSpill creates a new Store object which spills its dirty entries
+// to a temporary sorted run on disk once their size exceeds the configured
+// threshold. Spilled entries are removed from disk on Write.
+func NewStoreWithSpill(parent types.KVStore, config SpillConfig) *Store {
+	store := NewStore(parent)
+	store.spillConfig = config
+	return store
+}
+
 // GetStoreType implements Store.
 func (store *Store) GetStoreType() types.StoreType {
 	return store.parent.GetStoreType()
@@ -58,7 +72,14 @@ func (store *Store) Get(key []byte) (value []byte) {
 
 	cacheValue, ok := store.cache[conv.UnsafeBytesToStr(key)]
 	if !ok {
-		value = store.parent.Get(key)
+		var spilled bool
+		if store.spill != nil {
+			value, spilled = store.spill.get(key)
+		}
+		if !spilled {
+			value = store.parent.Get(key)
+		}
+		// a spilled value is cached as clean as it is written from the spill run.
 		store.setCacheValue(key, value, false)
 	} else {
 		value = cacheValue.value
@@ -120,6 +141,11 @@ func (store *Store) Write() {
 	store.mtx.Lock()
 	defer store.mtx.Unlock()
 
+	if store.spill != nil {
+		store.writeSpilled()
+		return
+	}
+
 	if len(store.cache) == 0 && len(store.unsortedCache) == 0 {
 		store.sortedCache = internal.NewBTree()
 		return
@@ -141,6 +167,7 @@ func (store *Store) Write() {
 		}
 	}
 	store.resetCaches()
+	store.dirtySize = 0
 	sort.Slice(sortedCache, func(i, j int) bool {
 		return sortedCache[i].key < sortedCache[j].key
 	})
@@ -207,6 +234,12 @@ func (store *Store) iterator(start, end []byte, ascending bool) types.Iterator {
 		panic(err)
 	}
 
+	if store.spill != nil {
+		// the spilled entries are older than the ones in memory, hence
+		// shadow the parent and are shadowed by the cache.
+		parent = internal.NewCacheMergeIterator(parent, store.spill.iterator(start, end, ascending), ascending)
+	}
+
 	return internal.NewCacheMergeIterator(parent, cache, ascending)
 }
 
@@ -398,11 +431,83 @@ func (store *Store) clearUnsortedCacheSubset(unsorted []*kv.Pair, sortState sort
 // A `nil` value means a deletion.
 func (store *Store) setCacheValue(key, value []byte, dirty bool) {
 	keyStr := conv.UnsafeBytesToStr(key)
+	if dirty && store.spillConfig.Enabled() {
+		// the overwritten dirty value is no longer held in memory
+		if prev, ok := store.cache[keyStr]; ok && prev.dirty {
+			store.dirtySize -= uint64(len(key) + len(prev.value))
+		}
+	}
 	store.cache[keyStr] = &cValue{
 		value: value,
 		dirty: dirty,
 	}
 	if dirty {
 		store.unsortedCache[keyStr] = struct{}{}
+
+		if store.spillConfig.Enabled() {
+			store.dirtySize += uint64(len(key) + len(value))
+			if store.dirtySize > store.spillConfig.Threshold {
+				store.spillDirty()
+			}
+		}
+	}
+}
+
+// spillDirty moves all dirty entries from memory to the spill run, which is
+// created on the first spill. Clean entries stay cached.
+func (store *Store) spillDirty() {
+	if store.spill == nil {
+		spill, err := newSpillRun(store.spillConfig.Dir)
+		if err != nil {
+			panic(fmt.Errorf("failed to create cachekv spill run: %w", err))
+		}
+		store.spill = spill
+	}
+
+	entries := make([]spillEntry, 0, len(store.cache))
+	for key, cacheValue := range store.cache {
+		if cacheValue.dirty {
+			entries = append(entries, spillEntry{key: key, value: cacheValue.value})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	if err := store.spill.write(entries); err != nil {
+		panic(fmt.Errorf("failed to write cachekv spill run: %w", err))
+	}
+
+	for _, entry := range entries {
+		delete(store.cache, entry.key)
+	}
+	for key := range store.unsortedCache {
+		delete(store.unsortedCache, key)
+	}
+	// the sorted cache only holds dirty entries, all of them are spilled.
+	store.sortedCache = internal.NewBTree()
+	store.dirtySize = 0
+}
+
+// writeSpilled writes the spilled and in-memory dirty entries to the parent
+// in key order, then removes the spill run. Entries still in memory are
+// spilled first so that a single sorted pass over the run covers all writes.
+func (store *Store) writeSpilled() {
+	store.spillDirty()
+	store.resetCaches()
+
+	spill := store.spill
+	store.spill = nil
+	defer spill.close()
+
+	iter := spill.iterator(nil, nil, true)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if value := iter.Value(); value != nil {
+			store.parent.Set(iter.Key(), value)
+		} else {
+			store.parent.Delete(iter.Key())
+		}
 	}
 }
@@ -2,6 +2,7 @@ package cachekv_test
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
 	dbm "github.com/cosmos/cosmos-db"
@@ -431,6 +432,97 @@ func TestNilEndIterator(t *testing.T) {
 	}
 }
 
+func TestCacheKVSpill(t *testing.T) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	mem.Set(keyFmt(0), valFmt(0))
+	mem.Set(keyFmt(1), valFmt(1))
+
+	// spill after every couple of writes
+	st := cachekv.NewStoreWithSpill(mem, cachekv.SpillConfig{Threshold: 64, Dir: t.TempDir()})
+	st.Delete(keyFmt(0))
+	for i := 2; i < 100; i++ {
+		st.Set(keyFmt(i), valFmt(i))
+	}
+	st.Set(keyFmt(50), valFmt(500))
+	st.Delete(keyFmt(60))
+
+	// spilled writes shadow the parent and are shadowed by later writes
+	require.Nil(t, st.Get(keyFmt(0)))
+	require.Equal(t, valFmt(1), st.Get(keyFmt(1)))
+	require.Equal(t, valFmt(2), st.Get(keyFmt(2)))
+	require.Equal(t, valFmt(500), st.Get(keyFmt(50)))
+	require.Nil(t, st.Get(keyFmt(60)))
+	require.Nil(t, mem.Get(keyFmt(2)))
+
+	st.Write()
+	require.Nil(t, mem.Get(keyFmt(0)))
+	require.Equal(t, valFmt(1), mem.Get(keyFmt(1)))
+	require.Equal(t, valFmt(99), mem.Get(keyFmt(99)))
+	require.Equal(t, valFmt(500), mem.Get(keyFmt(50)))
+	require.Nil(t, mem.Get(keyFmt(60)))
+
+	// the store is usable after a write
+	st.Set(keyFmt(60), valFmt(60))
+	st.Write()
+	require.Equal(t, valFmt(60), mem.Get(keyFmt(60)))
+}
+
+func TestCacheKVSpillOverwrite(t *testing.T) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	dir := t.TempDir()
+	st := cachekv.NewStoreWithSpill(mem, cachekv.SpillConfig{Threshold: 64, Dir: dir})
+
+	// overwriting a key doesn't grow the dirty entries beyond the threshold
+	for i := 0; i < 100; i++ {
+		st.Set(keyFmt(0), valFmt(i))
+	}
+	runs, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, runs)
+
+	st.Write()
+	require.Equal(t, valFmt(99), mem.Get(keyFmt(0)))
+}
+
+func TestCacheKVSpillMergeIteratorRandom(t *testing.T) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	st := cachekv.NewStoreWithSpill(mem, cachekv.SpillConfig{Threshold: 512, Dir: t.TempDir()})
+	truth := dbm.NewMemDB()
+
+	setRange(t, mem, truth, 0, 500)
+	setRange(t, st, truth, 250, 750)
+
+	for i := 0; i < 2000; i++ {
+		doRandomOp(t, st, truth, 1000)
+		assertIterateDomainCompare(t, st, truth)
+	}
+
+	st.Write()
+	assertIterateDomainCompare(t, mem, truth)
+}
+
+func TestCacheKVSpillIteratorIsolation(t *testing.T) {
+	mem := dbadapter.Store{DB: dbm.NewMemDB()}
+	st := cachekv.NewStoreWithSpill(mem, cachekv.SpillConfig{Threshold: 64, Dir: t.TempDir()})
+	for i := 0; i < 10; i++ {
+		st.Set(keyFmt(i), valFmt(i))
+	}
+
+	itr := st.Iterator(nil, nil)
+	// spill further writes while iterating
+	for i := 0; i < 10; i++ {
+		st.Set(keyFmt(i), valFmt(i+100))
+	}
+	for i := 0; i < 10; i++ {
+		require.True(t, itr.Valid())
+		require.Equal(t, keyFmt(i), itr.Key())
+		require.Equal(t, valFmt(i), itr.Value())
+		itr.Next()
+	}
+	require.False(t, itr.Valid())
+	require.NoError(t, itr.Close())
+}
+
 // TestIteratorDeadlock demonstrate the deadlock issue in cache store.
 func TestIteratorDeadlock(t *testing.T) {
 	mem := dbadapter.Store{DB: dbm.NewMemDB()}
@@ -40,6 +40,14 @@ var _ types.CacheMultiStore = Store{}
 func NewFromKVStore(
 	store types.KVStore, stores map[types.StoreKey]types.CacheWrapper,
 	keys map[string]types.StoreKey, traceWriter io.Writer, traceContext types.TraceContext,
+) Store {
+	return newFromKVStore(store, stores, keys, traceWriter, traceContext, cachekv.SpillConfig{})
+}
+
+func newFromKVStore(
+	store types.KVStore, stores map[types.StoreKey]types.CacheWrapper,
+	keys map[string]types.StoreKey, traceWriter io.Writer, traceContext types.TraceContext,
+	spill cachekv.SpillConfig,
 ) Store {
 	cms := Store{
 		db:           cachekv.NewStore(store),
@@ -57,7 +65,7 @@ func NewFromKVStore(
 
 			store = tracekv.NewStore(store.(types.KVStore), cms.traceWriter, tctx)
 		}
-		cms.stores[key] = cachekv.NewStore(store.(types.KVStore))
+		cms.stores[key] = cachekv.NewStoreWithSpill(store.(types.KVStore), spill)
 	}
 
 	return cms
@@ -72,6 +80,15 @@ func NewStore(
 	return NewFromKVStore(dbadapter.Store{DB: db}, stores, keys, traceWriter, traceContext)
 }
 
+// NewStoreWithSpill is analogous to NewStore except that the branched stores
+// spill their dirty entries to disk as configured by spill.
+func NewStoreWithSpill(
+	db dbm.DB, stores map[types.StoreKey]types.CacheWrapper, keys map[string]types.StoreKey,
+	traceWriter io.Writer, traceContext types.TraceContext, spill cachekv.SpillConfig,
+) Store {
+	return newFromKVStore(dbadapter.Store{DB: db}, stores, keys, traceWriter, traceContext, spill)
+}
+
 func newCacheMultiStoreFromCMS(cms Store) Store {
 	stores := make(map[types.StoreKey]types.CacheWrapper)
 	for k, v := range cms.stores {
@@ -352,6 +352,57 @@ func (st *Store) Query(req *types.RequestQuery) (res *types.ResponseQuery, err e
 		// get proof from tree and convert to merkle.Proof before adding to result
 		res.ProofOps = getProofFromTree(mtree, req.Data, res.Value != nil)
 
+	case "/keys": // get many keys at once
+		var query kv.Pairs
+		if err := query.Unmarshal(req.Data); err != nil {
+			return &types.ResponseQuery{}, errorsmod.Wrap(types.ErrTxDecode, err.Error())
+		}
+		if len(query.Pairs) == 0 {
+			return &types.ResponseQuery{}, errorsmod.Wrap(types.ErrInvalidRequest, "batch query must contain at least one key")
+		}
+
+		if !st.VersionExists(res.Height) {
+			res.Log = iavl.ErrVersionDoesNotExist.Error()
+			break
+		}
+
+		keys := make([][]byte, len(query.Pairs))
+		// only existing keys are returned, absent keys are omitted from the result
+		pairs := kv.Pairs{
+			Pairs: make([]kv.Pair, 0, len(query.Pairs)),
+		}
+		for i, pair := range query.Pairs {
+			keys[i] = pair.Key
+			value, err := tree.GetVersioned(pair.Key, res.Height)
+			if err != nil {
+				panic(err)
+			}
+			if value != nil {
+				pairs.Pairs = append(pairs.Pairs, kv.Pair{Key: pair.Key, Value: value})
+			}
+		}
+
+		bz, err := pairs.Marshal()
+		if err != nil {
+			panic(fmt.Errorf("failed to marshal KV pairs: %w", err))
+		}
+		res.Value = bz
+
+		if !req.Prove {
+			break
+		}
+
+		iTree, err := tree.GetImmutable(res.Height)
+		if err != nil {
+			// sanity check: If values for given version were retrieved, immutable tree must also be retrievable
+			panic(fmt.Sprintf("version exists in store but could not retrieve corresponding versioned tree in store, %s", err.Error()))
+		}
+
+		res.ProofOps, err = getBatchProofFromTree(iTree, keys)
+		if err != nil {
+			return &types.ResponseQuery{}, errorsmod.Wrap(types.ErrInvalidRequest, err.Error())
+		}
+
 	case "/subspace":
 		pairs := kv.Pairs{
 			Pairs: make([]kv.Pair, 0),
@@ -415,3 +466,26 @@ func getProofFromTree(tree *iavl.MutableTree, key []byte, exists bool) *cmtproto
 	op := types.NewIavlCommitmentOp(key, commitmentProof)
 	return &cmtprotocrypto.ProofOps{Ops: []cmtprotocrypto.ProofOp{op.ProofOp()}}
 }
+
+// getBatchProofFromTree creates a compressed ICS23 batch proof holding an
+// existence or non-existence proof for each of the given keys.
+func getBatchProofFromTree(tree *iavl.ImmutableTree, keys [][]byte) (*cmtprotocrypto.ProofOps, error) {
+	proofs := make([]*ics23.CommitmentProof, 0, len(keys))
+	for _, key := range keys {
+		// GetProof returns a membership proof if the key exists and a
+		// non-membership proof otherwise.
+		proof, err := tree.GetProof(key)
+		if err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, proof)
+	}
+
+	batch, err := ics23.CombineProofs(proofs)
+	if err != nil {
+		return nil, err
+	}
+
+	op := types.NewIavlCommitmentOp(nil, ics23.Compress(batch))
+	return &cmtprotocrypto.ProofOps{Ops: []cmtprotocrypto.ProofOp{op.ProofOp()}}, nil
+}
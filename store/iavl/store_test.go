@@ -10,6 +10,7 @@ import (
 
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/cosmos/iavl"
+	ics23 "github.com/cosmos/ics23/go"
 	"github.com/stretchr/testify/require"
 
 	"cosmossdk.io/log"
@@ -712,3 +713,39 @@ func TestChangeSets(t *testing.T) {
 	restoreCommitID := iavlStore.LastCommitID()
 	require.Equal(t, commitID, restoreCommitID)
 }
+
+func TestIAVLStoreBatchQuery(t *testing.T) {
+	db := wrapper.NewDBWrapper(dbm.NewMemDB())
+	tree := iavl.NewMutableTree(db, cacheSize, false, log.NewNopLogger())
+
+	iavlStore := UnsafeNewStore(tree)
+
+	k1, v1 := []byte("key1"), []byte("val1")
+	k2 := []byte("key2")
+	iavlStore.Set(k1, v1)
+	cid := iavlStore.Commit()
+
+	query := kv.Pairs{Pairs: []kv.Pair{{Key: k1}, {Key: k2}}}
+	data, err := query.Marshal()
+	require.NoError(t, err)
+
+	// only existing keys are returned
+	result := kv.Pairs{Pairs: []kv.Pair{{Key: k1, Value: v1}}}
+	valExp, err := result.Marshal()
+	require.NoError(t, err)
+
+	qres, err := iavlStore.Query(&types.RequestQuery{Path: "/keys", Data: data, Height: cid.Version, Prove: true})
+	require.NoError(t, err)
+	require.Equal(t, valExp, qres.Value)
+	require.Len(t, qres.ProofOps.Ops, 1)
+
+	proof := &ics23.CommitmentProof{}
+	require.NoError(t, proof.Unmarshal(qres.ProofOps.Ops[0].Data))
+	require.True(t, ics23.IsCompressed(proof))
+	require.True(t, ics23.VerifyMembership(ics23.IavlSpec, cid.Hash, proof, k1, v1))
+	require.True(t, ics23.VerifyNonMembership(ics23.IavlSpec, cid.Hash, proof, k2))
+
+	// an empty batch is rejected
+	_, err = iavlStore.Query(&types.RequestQuery{Path: "/keys", Data: []byte{}, Height: cid.Version})
+	require.Error(t, err)
+}
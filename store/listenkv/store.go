@@ -31,18 +31,23 @@ func (s *Store) Get(key []byte) []byte {
 }
 
 // Set implements the KVStore interface. It traces a write operation and
-// delegates the Set call to the parent KVStore.
+// delegates the Set call to the parent KVStore. Writes to keys rejected by the
+// listener's filter are not recorded.
 func (s *Store) Set(key, value []byte) {
 	types.AssertValidKey(key)
 	s.parent.Set(key, value)
-	s.listener.OnWrite(s.parentStoreKey, key, value, false)
+	if s.listener.Listening(key) {
+		s.listener.OnWrite(s.parentStoreKey, key, value, false)
+	}
 }
 
 // Delete implements the KVStore interface. It traces a write operation and
 // delegates the Delete call to the parent KVStore.
 func (s *Store) Delete(key []byte) {
 	s.parent.Delete(key)
-	s.listener.OnWrite(s.parentStoreKey, key, nil, true)
+	if s.listener.Listening(key) {
+		s.listener.OnWrite(s.parentStoreKey, key, nil, true)
+	}
 }
 
 // Has implements the KVStore interface. It delegates the Has call to the
@@ -151,6 +151,26 @@ func TestListenKVStoreDelete(t *testing.T) {
 	}
 }
 
+func TestListenKVStoreFilter(t *testing.T) {
+	listener := types.NewMemoryListener()
+	listener.SetFilter(&types.KeyPrefixFilter{
+		Allow: [][]byte{bz("key")},
+		Deny:  [][]byte{keyFmt(2)},
+	})
+
+	store := newListenKVStore(listener)
+	store.Set(bz("other"), bz("value"))
+	store.Delete(keyFmt(2))
+	store.Delete(keyFmt(3))
+
+	cache := listener.PopStateCache()
+	require.Len(t, cache, 3)
+	require.Equal(t, keyFmt(1), cache[0].Key)
+	require.Equal(t, keyFmt(3), cache[1].Key)
+	require.Equal(t, keyFmt(3), cache[2].Key)
+	require.True(t, cache[2].Delete)
+}
+
 func TestListenKVStoreHas(t *testing.T) {
 	testCases := []struct {
 		key      []byte
@@ -1,18 +1,29 @@
 package rootmulti
 
 import (
+	"bytes"
+	"fmt"
+
 	"github.com/cometbft/cometbft/crypto/merkle"
+	cmtprotocrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	ics23 "github.com/cosmos/ics23/go"
 
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/store/internal/kv"
 	storetypes "cosmossdk.io/store/types"
 )
 
+// BatchQueryPath is the store query subpath returning the values of several
+// keys, and with Prove set a single compressed ICS23 batch proof for all of them.
+const BatchQueryPath = "/keys"
+
 // RequireProof returns whether proof is required for the subpath.
 func RequireProof(subpath string) bool {
 	// XXX: create a better convention.
-	// Currently, only when query subpath is "/key", will proof be included in
+	// Currently, only when query subpath is "/key" or "/keys", will proof be included in
 	// response. If there are some changes about proof building in iavlstore.go,
 	// we must change code here to keep consistency with iavlStore#Query.
-	return subpath == "/key"
+	return subpath == "/key" || subpath == BatchQueryPath
 }
 
 //-----------------------------------------------------------------------------
@@ -25,3 +36,117 @@ func DefaultProofRuntime() (prt *merkle.ProofRuntime) {
 	prt.RegisterOpDecoder(storetypes.ProofOpSimpleMerkleCommitment, storetypes.CommitmentOpDecoder)
 	return
 }
+
+//-----------------------------------------------------------------------------
+
+// EncodeBatchQuery encodes the keys of a batch query into the data of a
+// RequestQuery sent to the BatchQueryPath of a store.
+func EncodeBatchQuery(keys [][]byte) ([]byte, error) {
+	pairs := kv.Pairs{Pairs: make([]kv.Pair, len(keys))}
+	for i, key := range keys {
+		pairs.Pairs[i] = kv.Pair{Key: key}
+	}
+
+	return pairs.Marshal()
+}
+
+// DecodeBatchQueryResult decodes the value of a batch query response into a
+// map of key to value. Keys that do not exist in the store are not part of the
+// result.
+func DecodeBatchQueryResult(bz []byte) (map[string][]byte, error) {
+	var pairs kv.Pairs
+	if err := pairs.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string][]byte, len(pairs.Pairs))
+	for _, pair := range pairs.Pairs {
+		values[string(pair.Key)] = pair.Value
+	}
+
+	return values, nil
+}
+
+// VerifyBatchProof verifies the proof returned by a batch query against the
+// given app hash. Every key in keys must be proven: keys present in values are
+// verified for membership with their value, all other keys for absence. Values
+// of keys which were not requested are rejected, as they are not verified.
+func VerifyBatchProof(
+	appHash []byte,
+	storeName string,
+	keys [][]byte,
+	values map[string][]byte,
+	proofOps *cmtprotocrypto.ProofOps,
+) error {
+	if proofOps == nil || len(proofOps.Ops) != 2 {
+		return errorsmod.Wrap(storetypes.ErrInvalidProof, "batch proof must contain a store proof and a commit info proof")
+	}
+
+	requested := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		requested[string(key)] = struct{}{}
+	}
+	for key := range values {
+		if _, ok := requested[key]; !ok {
+			return errorsmod.Wrapf(storetypes.ErrInvalidProof, "value of key %X was not requested", key)
+		}
+	}
+
+	storeOp, err := decodeCommitmentOp(proofOps.Ops[0])
+	if err != nil {
+		return err
+	}
+	if storeOp.Type != storetypes.ProofOpIAVLCommitment {
+		return errorsmod.Wrapf(storetypes.ErrInvalidProof, "unexpected store proof type %s", storeOp.Type)
+	}
+
+	storeRoot, err := storeOp.Proof.Calculate()
+	if err != nil {
+		return errorsmod.Wrapf(storetypes.ErrInvalidProof, "could not calculate root for proof: %v", err)
+	}
+
+	for _, key := range keys {
+		if value, ok := values[string(key)]; ok {
+			if !ics23.VerifyMembership(storeOp.Spec, storeRoot, storeOp.Proof, key, value) {
+				return errorsmod.Wrapf(storetypes.ErrInvalidProof, "proof did not verify existence of key %X", key)
+			}
+			continue
+		}
+
+		if !ics23.VerifyNonMembership(storeOp.Spec, storeRoot, storeOp.Proof, key) {
+			return errorsmod.Wrapf(storetypes.ErrInvalidProof, "proof did not verify absence of key %X", key)
+		}
+	}
+
+	commitOp, err := decodeCommitmentOp(proofOps.Ops[1])
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(commitOp.GetKey(), []byte(storeName)) {
+		return errorsmod.Wrapf(storetypes.ErrInvalidProof, "commit info proof is for store %s, expected %s", commitOp.GetKey(), storeName)
+	}
+
+	root, err := commitOp.Run([][]byte{storeRoot})
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root[0], appHash) {
+		return errorsmod.Wrapf(storetypes.ErrInvalidProof, "calculated app hash %X does not match %X", root[0], appHash)
+	}
+
+	return nil
+}
+
+func decodeCommitmentOp(pop cmtprotocrypto.ProofOp) (storetypes.CommitmentOp, error) {
+	op, err := storetypes.CommitmentOpDecoder(pop)
+	if err != nil {
+		return storetypes.CommitmentOp{}, err
+	}
+
+	commitmentOp, ok := op.(storetypes.CommitmentOp)
+	if !ok {
+		return storetypes.CommitmentOp{}, fmt.Errorf("unexpected proof operator %T", op)
+	}
+
+	return commitmentOp, nil
+}
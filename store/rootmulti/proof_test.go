@@ -150,3 +150,58 @@ func TestVerifyMultiStoreQueryProofAbsence(t *testing.T) {
 	err = prt.VerifyValue(res.ProofOps, cid.Hash, "/iavlStoreKey/MYABSENTKEY", []byte(""))
 	require.NotNil(t, err)
 }
+
+func TestVerifyMultiStoreBatchQueryProof(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := NewStore(db, log.NewNopLogger(), metrics.NewNoOpMetrics())
+	iavlStoreKey := types.NewKVStoreKey("iavlStoreKey")
+
+	store.MountStoreWithDB(iavlStoreKey, types.StoreTypeIAVL, nil)
+	require.NoError(t, store.LoadVersion(0))
+
+	iavlStore := store.GetCommitStore(iavlStoreKey).(*iavl.Store)
+	iavlStore.Set([]byte("KEY1"), []byte("VALUE1"))
+	iavlStore.Set([]byte("KEY2"), []byte("VALUE2"))
+	iavlStore.Set([]byte("KEY4"), []byte("VALUE4"))
+	cid := store.Commit()
+
+	keys := [][]byte{[]byte("KEY1"), []byte("KEY3"), []byte("KEY4"), []byte("KEY5")}
+	data, err := EncodeBatchQuery(keys)
+	require.NoError(t, err)
+
+	res, err := store.Query(&types.RequestQuery{
+		Path:  "/iavlStoreKey" + BatchQueryPath,
+		Data:  data,
+		Prove: true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res.ProofOps)
+	require.Len(t, res.ProofOps.Ops, 2)
+
+	values, err := DecodeBatchQueryResult(res.Value)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{"KEY1": []byte("VALUE1"), "KEY4": []byte("VALUE4")}, values)
+
+	// Verify proof.
+	require.NoError(t, VerifyBatchProof(cid.Hash, "iavlStoreKey", keys, values, res.ProofOps))
+
+	// Verify (bad) proof: wrong app hash.
+	require.Error(t, VerifyBatchProof([]byte("bad hash"), "iavlStoreKey", keys, values, res.ProofOps))
+
+	// Verify (bad) proof: wrong store name.
+	require.Error(t, VerifyBatchProof(cid.Hash, "otherStore", keys, values, res.ProofOps))
+
+	// Verify (bad) proof: tampered value.
+	tampered := map[string][]byte{"KEY1": []byte("VALUE_NOT"), "KEY4": []byte("VALUE4")}
+	require.Error(t, VerifyBatchProof(cid.Hash, "iavlStoreKey", keys, tampered, res.ProofOps))
+
+	// Verify (bad) proof: existing key claimed absent.
+	require.Error(t, VerifyBatchProof(cid.Hash, "iavlStoreKey", keys, map[string][]byte{"KEY4": []byte("VALUE4")}, res.ProofOps))
+
+	// Verify (bad) proof: key not covered by the proof.
+	require.Error(t, VerifyBatchProof(cid.Hash, "iavlStoreKey", append(keys, []byte("KEY2")), values, res.ProofOps))
+
+	// Verify (bad) proof: injected value of a key which was not requested.
+	injected := map[string][]byte{"KEY1": []byte("VALUE1"), "KEY4": []byte("VALUE4"), "KEY6": []byte("VALUE6")}
+	require.Error(t, VerifyBatchProof(cid.Hash, "iavlStoreKey", keys, injected, res.ProofOps))
+}
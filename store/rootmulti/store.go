@@ -18,6 +18,7 @@ import (
 
 	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
+	"cosmossdk.io/store/cachekv"
 	"cosmossdk.io/store/cachemulti"
 	"cosmossdk.io/store/dbadapter"
 	"cosmossdk.io/store/iavl"
@@ -62,6 +63,7 @@ type Store struct {
 	pruningManager      *pruning.Manager
 	iavlCacheSize       int
 	iavlDisableFastNode bool
+	cacheSpill          cachekv.SpillConfig
 	storesParams        map[types.StoreKey]storeParams
 	stores              map[types.StoreKey]types.CommitKVStore
 	keysByName          map[string]types.StoreKey
@@ -132,6 +134,14 @@ func (rs *Store) SetIAVLDisableFastNode(disableFastNode bool) {
 	rs.iavlDisableFastNode = disableFastNode
 }
 
+// SetCacheKVSpill makes the stores branched by CacheMultiStoreWithSpill spill
+// their dirty entries to a temporary sorted run in dir once their size exceeds
+// threshold bytes, bounding the memory used by large blocks such as genesis
+// imports and upgrade migrations. A zero threshold disables spilling.
+func (rs *Store) SetCacheKVSpill(threshold uint64, dir string) {
+	rs.cacheSpill = cachekv.SpillConfig{Threshold: threshold, Dir: dir}
+}
+
 // GetStoreType implements Store.
 func (rs *Store) GetStoreType() types.StoreType {
 	return types.StoreTypeMulti
@@ -405,6 +415,19 @@ func (rs *Store) AddListeners(keys []types.StoreKey) {
 	}
 }
 
+// SetListenerFilter restricts the writes recorded by the listener of the
+// KVStore belonging to the provided StoreKey to the keys accepted by filter.
+// AddListeners must be called for the key beforehand.
+func (rs *Store) SetListenerFilter(key types.StoreKey, filter *types.KeyPrefixFilter) error {
+	listener := rs.listeners[key]
+	if listener == nil {
+		return fmt.Errorf("listening is not enabled for store %s", key.Name())
+	}
+
+	listener.SetFilter(filter)
+	return nil
+}
+
 // ListeningEnabled returns if listening is enabled for a specific KVStore
 func (rs *Store) ListeningEnabled(key types.StoreKey) bool {
 	if ls, ok := rs.listeners[key]; ok {
@@ -552,6 +575,19 @@ func (rs *Store) CacheWrapWithTrace(_ io.Writer, _ types.TraceContext) types.Cac
 // CacheMultiStore creates ephemeral branch of the multi-store and returns a CacheMultiStore.
 // It implements the MultiStore interface.
 func (rs *Store) CacheMultiStore() types.CacheMultiStore {
+	return cachemulti.NewStore(rs.db, rs.cacheWrappers(), rs.keysByName, rs.traceWriter, rs.getTracingContext())
+}
+
+// CacheMultiStoreWithSpill is analogous to CacheMultiStore except that the
+// branched stores spill their dirty entries to disk as configured by
+// SetCacheKVSpill. It is meant for the branch of the block being executed,
+// the branches of CacheMultiStore and CacheMultiStoreWithVersion never spill.
+func (rs *Store) CacheMultiStoreWithSpill() types.CacheMultiStore {
+	return cachemulti.NewStoreWithSpill(rs.db, rs.cacheWrappers(), rs.keysByName, rs.traceWriter, rs.getTracingContext(), rs.cacheSpill)
+}
+
+// cacheWrappers returns the stores to branch in a CacheMultiStore.
+func (rs *Store) cacheWrappers() map[types.StoreKey]types.CacheWrapper {
 	stores := make(map[types.StoreKey]types.CacheWrapper)
 	for k, v := range rs.stores {
 		store := types.KVStore(v)
@@ -562,7 +598,7 @@ func (rs *Store) CacheMultiStore() types.CacheMultiStore {
 		}
 		stores[k] = store
 	}
-	return cachemulti.NewStore(rs.db, stores, rs.keysByName, rs.traceWriter, rs.getTracingContext())
+	return stores
 }
 
 // CacheMultiStoreWithVersion is analogous to CacheMultiStore except that it
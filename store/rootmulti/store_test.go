@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -67,6 +68,36 @@ func TestCacheMultiStore(t *testing.T) {
 	require.IsType(t, cachemulti.Store{}, cacheMulti)
 }
 
+func TestCacheMultiStoreSpill(t *testing.T) {
+	var db dbm.DB = dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+	dir := t.TempDir()
+	ms.SetCacheKVSpill(32, dir)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	// plain branches never spill
+	plain := ms.CacheMultiStore().GetKVStore(testStoreKey1)
+	for i := byte(0); i < 100; i++ {
+		plain.Set([]byte{i}, []byte{i})
+	}
+	runs, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, runs)
+
+	cacheMulti := ms.CacheMultiStoreWithSpill()
+	store := cacheMulti.GetKVStore(testStoreKey1)
+	for i := byte(0); i < 100; i++ {
+		store.Set([]byte{i}, []byte{i})
+	}
+	require.Equal(t, []byte{42}, store.Get([]byte{42}))
+	require.Nil(t, ms.GetKVStore(testStoreKey1).Get([]byte{42}))
+
+	cacheMulti.Write()
+	for i := byte(0); i < 100; i++ {
+		require.Equal(t, []byte{i}, ms.GetKVStore(testStoreKey1).Get([]byte{i}))
+	}
+}
+
 func TestCacheMultiStoreWithVersion(t *testing.T) {
 	var db dbm.DB = dbm.NewMemDB()
 	ms := newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
@@ -915,6 +946,28 @@ func TestStateListeners(t *testing.T) {
 	require.Empty(t, ms.PopStateCache())
 }
 
+func TestStateListenersFilter(t *testing.T) {
+	var db dbm.DB = dbm.NewMemDB()
+	ms := newMultiStoreWithMounts(db, pruningtypes.NewPruningOptions(pruningtypes.PruningNothing))
+
+	filter := &types.KeyPrefixFilter{Deny: [][]byte{{2}}}
+	require.Error(t, ms.SetListenerFilter(testStoreKey1, filter))
+
+	ms.AddListeners([]types.StoreKey{testStoreKey1})
+	require.NoError(t, ms.SetListenerFilter(testStoreKey1, filter))
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cacheMulti := ms.CacheMultiStore()
+	store := cacheMulti.GetKVStore(testStoreKey1)
+	store.Set([]byte{1}, []byte{1})
+	store.Set([]byte{2}, []byte{2})
+	cacheMulti.Write()
+
+	cache := ms.PopStateCache()
+	require.Len(t, cache, 1)
+	require.Equal(t, []byte{1}, cache[0].Key)
+}
+
 type commitKVStoreStub struct {
 	types.CommitKVStore
 	Committed int
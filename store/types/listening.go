@@ -1,8 +1,41 @@
 package types
 
+import "bytes"
+
+// KeyPrefixFilter restricts the keys a MemoryListener records. A key is
+// recorded when it matches at least one allowed prefix (or no allowed prefixes
+// are configured) and matches none of the denied prefixes. Deny takes
+// precedence over allow.
+type KeyPrefixFilter struct {
+	Allow [][]byte
+	Deny  [][]byte
+}
+
+// Allowed returns true if the given key passes the filter.
+func (f KeyPrefixFilter) Allowed(key []byte) bool {
+	for _, prefix := range f.Deny {
+		if bytes.HasPrefix(key, prefix) {
+			return false
+		}
+	}
+
+	if len(f.Allow) == 0 {
+		return true
+	}
+
+	for _, prefix := range f.Allow {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MemoryListener listens to the state writes and accumulate the records in memory.
 type MemoryListener struct {
 	stateCache []*StoreKVPair
+	filter     *KeyPrefixFilter
 }
 
 // NewMemoryListener creates a listener that accumulate the state writes in memory.
@@ -10,6 +43,17 @@ func NewMemoryListener() *MemoryListener {
 	return &MemoryListener{}
 }
 
+// SetFilter sets the key prefix filter applied to the writes observed by the
+// listener. Passing nil removes the filter.
+func (fl *MemoryListener) SetFilter(filter *KeyPrefixFilter) {
+	fl.filter = filter
+}
+
+// Listening returns true if writes to the given key should be recorded.
+func (fl *MemoryListener) Listening(key []byte) bool {
+	return fl.filter == nil || fl.filter.Allowed(key)
+}
+
 // OnWrite implements MemoryListener interface
 func (fl *MemoryListener) OnWrite(storeKey StoreKey, key, value []byte, delete bool) {
 	fl.stateCache = append(fl.stateCache, &StoreKVPair{
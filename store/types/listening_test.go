@@ -40,3 +40,37 @@ func TestOnWrite(t *testing.T) {
 	}
 	require.EqualValues(t, expectedOutputKVPair, outputKVPair)
 }
+
+func TestKeyPrefixFilter(t *testing.T) {
+	testCases := []struct {
+		name    string
+		filter  KeyPrefixFilter
+		key     []byte
+		allowed bool
+	}{
+		{"empty filter", KeyPrefixFilter{}, []byte{0x01, 0x02}, true},
+		{"allowed prefix", KeyPrefixFilter{Allow: [][]byte{{0x01}}}, []byte{0x01, 0x02}, true},
+		{"not in allow list", KeyPrefixFilter{Allow: [][]byte{{0x02}}}, []byte{0x01, 0x02}, false},
+		{"denied prefix", KeyPrefixFilter{Deny: [][]byte{{0x01}}}, []byte{0x01, 0x02}, false},
+		{"deny takes precedence", KeyPrefixFilter{Allow: [][]byte{{0x01}}, Deny: [][]byte{{0x01, 0x02}}}, []byte{0x01, 0x02, 0x03}, false},
+		{"allowed but not denied", KeyPrefixFilter{Allow: [][]byte{{0x01}}, Deny: [][]byte{{0x01, 0x03}}}, []byte{0x01, 0x02}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.allowed, tc.filter.Allowed(tc.key))
+		})
+	}
+}
+
+func TestMemoryListenerFilter(t *testing.T) {
+	listener := NewMemoryListener()
+	require.True(t, listener.Listening([]byte("anything")))
+
+	listener.SetFilter(&KeyPrefixFilter{Allow: [][]byte{[]byte("balances/")}})
+	require.True(t, listener.Listening([]byte("balances/foo")))
+	require.False(t, listener.Listening([]byte("supply/foo")))
+
+	listener.SetFilter(nil)
+	require.True(t, listener.Listening([]byte("supply/foo")))
+}
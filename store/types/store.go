@@ -219,6 +219,9 @@ type CommitMultiStore interface {
 	// AddListeners adds a listener for the KVStore belonging to the provided StoreKey
 	AddListeners(keys []StoreKey)
 
+	// SetListenerFilter sets the key prefix filter of the listener belonging to the provided StoreKey
+	SetListenerFilter(key StoreKey, filter *KeyPrefixFilter) error
+
 	// PopStateCache returns the accumulated state change messages from the CommitMultiStore
 	PopStateCache() []*StoreKVPair
 
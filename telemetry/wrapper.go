@@ -100,6 +100,16 @@ func MeasureSince(start time.Time, keys ...string) {
 	metrics.MeasureSinceWithLabels(keys, start.UTC(), globalLabels)
 }
 
+// AddSample provides a wrapper functionality for emitting a sample metric with
+// global labels (if any).
+func AddSample(val float32, keys ...string) {
+	if !IsTelemetryEnabled() {
+		return
+	}
+
+	metrics.AddSampleWithLabels(keys, val, globalLabels)
+}
+
 // Now return the current time if telemetry is enabled or a zero time if it's not
 func Now() time.Time {
 	if !IsTelemetryEnabled() {
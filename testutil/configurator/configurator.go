@@ -40,6 +40,7 @@ func defaultConfig() *Config {
 		ModuleConfigs: make(map[string]*appv1alpha1.ModuleConfig),
 		PreBlockersOrder: []string{
 			"upgrade",
+			"auth",
 		},
 		BeginBlockersOrder: []string{
 			"mint",
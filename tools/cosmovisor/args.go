@@ -28,6 +28,9 @@ const (
 	EnvInterval             = "DAEMON_POLL_INTERVAL"
 	EnvPreupgradeMaxRetries = "DAEMON_PREUPGRADE_MAX_RETRIES"
 	EnvDisableLogs          = "COSMOVISOR_DISABLE_LOGS"
+	EnvDownloadChecksum     = "DAEMON_DOWNLOAD_MUST_HAVE_CHECKSUM"
+	EnvRollbackCrashes      = "DAEMON_ROLLBACK_CRASHES"
+	EnvRollbackWindow       = "DAEMON_ROLLBACK_WINDOW"
 )
 
 const (
@@ -40,6 +43,10 @@ const (
 // must be the same as x/upgrade/types.UpgradeInfoFilename
 const defaultFilename = "upgrade-info.json"
 
+// defaultRollbackWindow is the window after an upgrade in which the crashes of
+// its binary are counted to roll it back.
+const defaultRollbackWindow = 10 * time.Minute
+
 // Config is the information passed in to control the daemon
 type Config struct {
 	Home                  string
@@ -52,6 +59,14 @@ type Config struct {
 	DataBackupPath        string
 	PreupgradeMaxRetries  int
 	DisableLogs           bool
+	// DownloadMustHaveChecksum rejects the upgrade binaries whose download URL
+	// doesn't have a checksum, before downloading them.
+	DownloadMustHaveChecksum bool
+	// RollbackCrashes is the number of crashes of an upgrade binary within
+	// RollbackWindow after the upgrade which roll the upgrade back, 0 to
+	// disable.
+	RollbackCrashes int
+	RollbackWindow  time.Duration
 
 	// currently running upgrade
 	currentUpgrade upgradetypes.Plan
@@ -162,6 +177,9 @@ func GetConfigFromEnv() (*Config, error) {
 	if cfg.DisableLogs, err = booleanOption(EnvDisableLogs, false); err != nil {
 		errs = append(errs, err)
 	}
+	if cfg.DownloadMustHaveChecksum, err = booleanOption(EnvDownloadChecksum, false); err != nil {
+		errs = append(errs, err)
+	}
 
 	interval := os.Getenv(EnvInterval)
 	if interval != "" {
@@ -191,6 +209,21 @@ func GetConfigFromEnv() (*Config, error) {
 		errs = append(errs, fmt.Errorf("%s could not be parsed to int: %w", EnvPreupgradeMaxRetries, err))
 	}
 
+	envRollbackCrashesVal := os.Getenv(EnvRollbackCrashes)
+	if cfg.RollbackCrashes, err = strconv.Atoi(envRollbackCrashesVal); err != nil && envRollbackCrashesVal != "" {
+		errs = append(errs, fmt.Errorf("%s could not be parsed to int: %w", EnvRollbackCrashes, err))
+	}
+
+	cfg.RollbackWindow = defaultRollbackWindow
+	if rollbackWindow := os.Getenv(EnvRollbackWindow); rollbackWindow != "" {
+		val, err := parseEnvDuration(rollbackWindow)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid: %s: %w", EnvRollbackWindow, err))
+		} else {
+			cfg.RollbackWindow = val
+		}
+	}
+
 	errs = append(errs, cfg.validate()...)
 
 	if len(errs) > 0 {
@@ -252,8 +285,16 @@ func (cfg *Config) validate() []error {
 		}
 	}
 
+	if cfg.RollbackCrashes < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative", EnvRollbackCrashes))
+	}
+
 	// check the DataBackupPath
 	if cfg.UnsafeSkipBackup {
+		// the rollback restores the data backup
+		if cfg.RollbackCrashes > 0 {
+			errs = append(errs, fmt.Errorf("%s requires the data backup, %s must not be set", EnvRollbackCrashes, EnvSkipBackup))
+		}
 		return errs
 	}
 
@@ -285,20 +326,10 @@ func (cfg *Config) SetCurrentUpgrade(u upgradetypes.Plan) (rerr error) {
 	}
 
 	// set a symbolic link
-	link := filepath.Join(cfg.Root(), currentLink)
 	safeName := url.PathEscape(u.Name)
 	upgrade := filepath.Join(cfg.Root(), upgradesDir, safeName)
-
-	// remove link if it exists
-	if _, err := os.Stat(link); err == nil {
-		if err := os.Remove(link); err != nil {
-			return fmt.Errorf("failed to remove existing link: %w", err)
-		}
-	}
-
-	// point to the new directory
-	if err := os.Symlink(upgrade, link); err != nil {
-		return fmt.Errorf("creating current symlink: %w", err)
+	if err := cfg.setCurrentDir(upgrade); err != nil {
+		return err
 	}
 
 	cfg.currentUpgrade = u
@@ -321,6 +352,25 @@ func (cfg *Config) SetCurrentUpgrade(u upgradetypes.Plan) (rerr error) {
 	return err
 }
 
+// setCurrentDir points the current link to the given binary directory.
+func (cfg *Config) setCurrentDir(dir string) error {
+	link := filepath.Join(cfg.Root(), currentLink)
+
+	// remove link if it exists
+	if _, err := os.Lstat(link); err == nil {
+		if err := os.Remove(link); err != nil {
+			return fmt.Errorf("failed to remove existing link: %w", err)
+		}
+	}
+
+	// point to the new directory
+	if err := os.Symlink(dir, link); err != nil {
+		return fmt.Errorf("creating current symlink: %w", err)
+	}
+
+	return nil
+}
+
 func (cfg *Config) UpgradeInfo() (upgradetypes.Plan, error) {
 	if cfg.currentUpgrade.Name != "" {
 		return cfg.currentUpgrade, nil
@@ -374,6 +424,9 @@ func (cfg Config) DetailString() string {
 		{EnvDataBackupPath, cfg.DataBackupPath},
 		{EnvPreupgradeMaxRetries, fmt.Sprintf("%d", cfg.PreupgradeMaxRetries)},
 		{EnvDisableLogs, fmt.Sprintf("%t", cfg.DisableLogs)},
+		{EnvDownloadChecksum, fmt.Sprintf("%t", cfg.DownloadMustHaveChecksum)},
+		{EnvRollbackCrashes, fmt.Sprintf("%d", cfg.RollbackCrashes)},
+		{EnvRollbackWindow, cfg.RollbackWindow.String()},
 	}
 
 	derivedEntries := []struct{ name, value string }{
@@ -382,6 +435,7 @@ func (cfg Config) DetailString() string {
 		{"Genesis Bin", cfg.GenesisBin()},
 		{"Monitored File", cfg.UpgradeInfoFilePath()},
 		{"Data Backup Dir", cfg.DataBackupPath},
+		{"Health File", cfg.HealthFilePath()},
 	}
 
 	var sb strings.Builder
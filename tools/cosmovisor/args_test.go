@@ -204,6 +204,18 @@ func (s *argsTestSuite) TestValidate() {
 			cfg:   Config{Home: absPath, Name: "bind", UnsafeSkipBackup: true, DataBackupPath: relPath},
 			valid: true,
 		},
+		"happy with rollback": {
+			cfg:   Config{Home: absPath, Name: "bind", DataBackupPath: absPath, RollbackCrashes: 3},
+			valid: true,
+		},
+		"rollback with skip data backup": {
+			cfg:   Config{Home: absPath, Name: "bind", UnsafeSkipBackup: true, RollbackCrashes: 3},
+			valid: false,
+		},
+		"negative rollback crashes": {
+			cfg:   Config{Home: absPath, Name: "bind", DataBackupPath: absPath, RollbackCrashes: -1},
+			valid: false,
+		},
 		"missing home": {
 			cfg:   Config{Name: "bind"},
 			valid: false,
@@ -327,6 +339,8 @@ func (s *argsTestSuite) TestDetailString() {
 	unsafeSkipBackup := false
 	dataBackupPath := "/home"
 	preupgradeMaxRetries := 8
+	rollbackCrashes := 3
+	rollbackWindow := 5 * time.Minute
 	cfg := &Config{
 		Home:                  home,
 		Name:                  name,
@@ -336,6 +350,8 @@ func (s *argsTestSuite) TestDetailString() {
 		UnsafeSkipBackup:      unsafeSkipBackup,
 		DataBackupPath:        dataBackupPath,
 		PreupgradeMaxRetries:  preupgradeMaxRetries,
+		RollbackCrashes:       rollbackCrashes,
+		RollbackWindow:        rollbackWindow,
 	}
 
 	expectedPieces := []string{
@@ -348,12 +364,15 @@ func (s *argsTestSuite) TestDetailString() {
 		fmt.Sprintf("%s: %t", EnvSkipBackup, unsafeSkipBackup),
 		fmt.Sprintf("%s: %s", EnvDataBackupPath, home),
 		fmt.Sprintf("%s: %d", EnvPreupgradeMaxRetries, preupgradeMaxRetries),
+		fmt.Sprintf("%s: %d", EnvRollbackCrashes, rollbackCrashes),
+		fmt.Sprintf("%s: %s", EnvRollbackWindow, rollbackWindow),
 		"Derived Values:",
 		fmt.Sprintf("Root Dir: %s", home),
 		fmt.Sprintf("Upgrade Dir: %s", home),
 		fmt.Sprintf("Genesis Bin: %s", home),
 		fmt.Sprintf("Monitored File: %s", home),
 		fmt.Sprintf("Data Backup Dir: %s", home),
+		fmt.Sprintf("Health File: %s", home),
 	}
 
 	actual := cfg.DetailString()
@@ -383,6 +402,7 @@ func (s *argsTestSuite) TestGetConfigFromEnv() {
 			DataBackupPath:        dataBackupPath,
 			PreupgradeMaxRetries:  preupgradeMaxRetries,
 			DisableLogs:           disableLogs,
+			RollbackWindow:        defaultRollbackWindow,
 		}
 	}
 
@@ -621,6 +641,70 @@ func (s *argsTestSuite) TestGetConfigFromEnv() {
 	}
 }
 
+func (s *argsTestSuite) TestGetConfigFromEnvRollback() {
+	initialEnv := s.clearEnv()
+	defer s.setEnv(nil, initialEnv)
+
+	absPath, err := filepath.Abs(filepath.Join("testdata", "validate"))
+	s.Require().NoError(err)
+	s.setEnv(s.T(), &cosmovisorEnv{Home: absPath, Name: "testname", DataBackupPath: absPath})
+
+	tests := []struct {
+		name             string
+		env              map[string]string
+		expectedCrashes  int
+		expectedWindow   time.Duration
+		expectedChecksum bool
+		expectedErr      string
+	}{
+		{
+			name:           "not set",
+			expectedWindow: defaultRollbackWindow,
+		},
+		{
+			name:             "all set",
+			env:              map[string]string{EnvRollbackCrashes: "3", EnvRollbackWindow: "30m", EnvDownloadChecksum: "true"},
+			expectedCrashes:  3,
+			expectedWindow:   30 * time.Minute,
+			expectedChecksum: true,
+		},
+		{
+			name:        "rollback crashes bad",
+			env:         map[string]string{EnvRollbackCrashes: "bad"},
+			expectedErr: EnvRollbackCrashes,
+		},
+		{
+			name:        "rollback window bad",
+			env:         map[string]string{EnvRollbackWindow: "-1m"},
+			expectedErr: EnvRollbackWindow,
+		},
+		{
+			name:        "rollback without backup",
+			env:         map[string]string{EnvRollbackCrashes: "3", EnvSkipBackup: "true"},
+			expectedErr: EnvSkipBackup,
+		},
+	}
+
+	for _, tc := range tests {
+		s.T().Run(tc.name, func(t *testing.T) {
+			for envVar, envVal := range tc.env {
+				t.Setenv(envVar, envVal)
+			}
+
+			cfg, err := GetConfigFromEnv()
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCrashes, cfg.RollbackCrashes)
+			require.Equal(t, tc.expectedWindow, cfg.RollbackWindow)
+			require.Equal(t, tc.expectedChecksum, cfg.DownloadMustHaveChecksum)
+		})
+	}
+}
+
 func (s *argsTestSuite) TestLogConfigOrError() {
 	cfg := &Config{
 		Home:                  "/no/place/like/it",
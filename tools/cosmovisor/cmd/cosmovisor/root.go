@@ -17,6 +17,7 @@ func NewRootCmd() *cobra.Command {
 		configCmd,
 		NewVersionCmd(),
 		NewAddUpgradeCmd(),
+		NewStatusCmd(),
 	)
 
 	return rootCmd
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/tools/cosmovisor"
+)
+
+func NewStatusCmd() *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:          "status",
+		Short:        "Display the health history of the APP, and whether it runs in safe mode after a rollback.",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE:         Status,
+	}
+
+	statusCmd.Flags().StringP(cosmovisor.FlagOutput, "o", "text", "Output format (text|json)")
+	statusCmd.Flags().Bool(cosmovisor.FlagReset, false, "leave the safe mode, so that the rolled back upgrade is applied again")
+
+	return statusCmd
+}
+
+// Status prints the health history of the app.
+func Status(cmd *cobra.Command, args []string) error {
+	cfg, err := cosmovisor.GetConfigFromEnv()
+	if err != nil {
+		return err
+	}
+
+	health, err := cfg.ReadHealth()
+	if err != nil {
+		return err
+	}
+
+	if reset, _ := cmd.Flags().GetBool(cosmovisor.FlagReset); reset {
+		if health.SafeMode == nil {
+			return fmt.Errorf("not in safe mode")
+		}
+
+		health.Record(cosmovisor.HealthEvent{
+			Time:    time.Now(),
+			Type:    cosmovisor.HealthEventReset,
+			Upgrade: health.SafeMode.Upgrade,
+			Height:  health.SafeMode.Height,
+		})
+		health.SafeMode = nil
+		if err := cfg.WriteHealth(health); err != nil {
+			return err
+		}
+
+		cmd.Println("Safe mode left, the upgrade will be applied again on the next run.")
+		return nil
+	}
+
+	if val, _ := cmd.Flags().GetString(cosmovisor.FlagOutput); val == "json" {
+		out, err := json.MarshalIndent(health, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		cmd.Println(string(out))
+		return nil
+	}
+
+	currentBin, err := cfg.CurrentBin()
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Current binary: %s\n", currentBin)
+	if health.SafeMode != nil {
+		cmd.Printf("Safe mode: upgrade %q rolled back at %s, halting at height %d.\n", health.SafeMode.Upgrade, health.SafeMode.Since.Format(time.RFC3339), health.SafeMode.Height)
+		cmd.Println("Fix the upgrade binary (see add-upgrade --force), then run `cosmovisor status --reset` to apply the upgrade again.")
+	}
+
+	if upgrade, crashes := health.UpgradeCrashes(cfg.RollbackWindow); cfg.RollbackCrashes > 0 && upgrade != nil {
+		cmd.Printf("Crashes within %s of upgrade %q: %d (rollback after %d)\n", cfg.RollbackWindow, upgrade.Upgrade, crashes, cfg.RollbackCrashes)
+	}
+
+	if len(health.Events) == 0 {
+		cmd.Println("No health history.")
+		return nil
+	}
+
+	cmd.Println("Health history:")
+	for _, event := range health.Events {
+		cmd.Printf("  %s %-8s %s\n", event.Time.Format(time.RFC3339), event.Type, healthEventDetails(event))
+	}
+
+	return nil
+}
+
+func healthEventDetails(event cosmovisor.HealthEvent) string {
+	upgrade := event.Upgrade
+	if upgrade == "" {
+		upgrade = "genesis"
+	}
+
+	switch event.Type {
+	case cosmovisor.HealthEventUpgrade:
+		if event.Backup == "" {
+			return fmt.Sprintf("%s at height %d, no backup", upgrade, event.Height)
+		}
+		return fmt.Sprintf("%s at height %d, backup %s", upgrade, event.Height, event.Backup)
+	case cosmovisor.HealthEventCrash:
+		return fmt.Sprintf("%s: %s", upgrade, event.Error)
+	case cosmovisor.HealthEventRollback:
+		return fmt.Sprintf("%s at height %d after %s, restored %s", upgrade, event.Height, event.Error, event.Backup)
+	default:
+		return upgrade
+	}
+}
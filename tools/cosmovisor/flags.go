@@ -5,4 +5,6 @@ const (
 	FlagSkipUpgradeHeight = "unsafe-skip-upgrades"
 	FlagNoAppVersion      = "no-app-version"
 	FlagForce             = "force"
+	FlagHaltHeight        = "halt-height"
+	FlagReset             = "reset"
 )
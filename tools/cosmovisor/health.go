@@ -0,0 +1,177 @@
+package cosmovisor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	healthFilename = "health.json"
+
+	// maxHealthEvents is the number of events kept in the health history.
+	maxHealthEvents = 100
+)
+
+// HealthEventType is the type of an event of the health history.
+type HealthEventType string
+
+const (
+	// HealthEventUpgrade is recorded when switching to the binary of an upgrade.
+	HealthEventUpgrade HealthEventType = "upgrade"
+	// HealthEventCrash is recorded when the app exits with an error.
+	HealthEventCrash HealthEventType = "crash"
+	// HealthEventRollback is recorded when rolling back a failed upgrade.
+	HealthEventRollback HealthEventType = "rollback"
+	// HealthEventReset is recorded when the safe mode is left by the operator.
+	HealthEventReset HealthEventType = "reset"
+)
+
+// HealthEvent is an event of the health history.
+type HealthEvent struct {
+	Time time.Time       `json:"time"`
+	Type HealthEventType `json:"type"`
+	// Upgrade is the name of the upgrade of the binary, empty for genesis.
+	Upgrade string `json:"upgrade,omitempty"`
+	Height  int64  `json:"height,omitempty"`
+	// Error is the reason for a crash or a rollback.
+	Error string `json:"error,omitempty"`
+	// PreviousDir is the directory of the binary used before an upgrade.
+	PreviousDir string `json:"previous_dir,omitempty"`
+	// Backup is the data backup taken before an upgrade, or restored by a
+	// rollback.
+	Backup string `json:"backup,omitempty"`
+}
+
+// SafeMode is the state of a node whose upgrade was rolled back. The previous
+// binary is run until the upgrade height, and upgrades are not applied until
+// the operator leaves the safe mode.
+type SafeMode struct {
+	Since   time.Time `json:"since"`
+	Upgrade string    `json:"upgrade"`
+	Height  int64     `json:"height"`
+}
+
+// Health is the health history of the app, persisted across cosmovisor runs.
+type Health struct {
+	Events   []HealthEvent `json:"events"`
+	SafeMode *SafeMode     `json:"safe_mode,omitempty"`
+}
+
+// HealthFilePath is the path to the health history of the app.
+func (cfg *Config) HealthFilePath() string {
+	return filepath.Join(cfg.Root(), healthFilename)
+}
+
+// ReadHealth reads the health history of the app, empty if none was recorded.
+func (cfg *Config) ReadHealth() (*Health, error) {
+	bz, err := os.ReadFile(cfg.HealthFilePath())
+	if os.IsNotExist(err) {
+		return &Health{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var health Health
+	if err := json.Unmarshal(bz, &health); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", cfg.HealthFilePath(), err)
+	}
+
+	return &health, nil
+}
+
+// WriteHealth writes the health history of the app.
+func (cfg *Config) WriteHealth(health *Health) error {
+	bz, err := json.MarshalIndent(health, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so that a crash doesn't leave a corrupted file
+	tmp := cfg.HealthFilePath() + ".tmp"
+	if err := os.WriteFile(tmp, bz, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, cfg.HealthFilePath())
+}
+
+// Record appends an event to the health history, dropping the oldest events
+// beyond the kept ones.
+func (h *Health) Record(event HealthEvent) {
+	h.Events = append(h.Events, event)
+	if len(h.Events) > maxHealthEvents {
+		h.Events = h.Events[len(h.Events)-maxHealthEvents:]
+	}
+}
+
+// LastUpgrade returns the last upgrade of the health history, and the events
+// which happened since then. It returns nil if no upgrade was recorded.
+func (h *Health) LastUpgrade() (*HealthEvent, []HealthEvent) {
+	for i := len(h.Events) - 1; i >= 0; i-- {
+		if h.Events[i].Type == HealthEventUpgrade {
+			return &h.Events[i], h.Events[i+1:]
+		}
+	}
+
+	return nil, nil
+}
+
+// Crashes returns the number of crashes since the given time.
+func (h *Health) Crashes(since time.Time) int {
+	n := 0
+	for _, event := range h.Events {
+		if event.Type == HealthEventCrash && !event.Time.Before(since) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// UpgradeCrashes returns the last upgrade of the health history, and the
+// number of crashes of its binary within the given window after the upgrade.
+// The crashes after the window, once the binary ran past the upgrade, are
+// not counted. It returns nil if no upgrade was recorded.
+func (h *Health) UpgradeCrashes(window time.Duration) (*HealthEvent, int) {
+	upgrade, since := h.LastUpgrade()
+	if upgrade == nil {
+		return nil, 0
+	}
+
+	crashes := 0
+	for _, event := range since {
+		if event.Type == HealthEventCrash && event.Upgrade == upgrade.Upgrade && event.Time.Sub(upgrade.Time) <= window {
+			crashes++
+		}
+	}
+
+	return upgrade, crashes
+}
+
+// needsRollback returns the last upgrade if its binary crashed at least the
+// configured number of times within the rollback window after the upgrade,
+// and it wasn't rolled back yet. An upgrade binary which crashes once the
+// window is over made progress past the upgrade, and restoring the backup
+// taken before the upgrade would discard that progress.
+func (h *Health) needsRollback(cfg *Config) *HealthEvent {
+	if cfg.RollbackCrashes <= 0 || h.SafeMode != nil {
+		return nil
+	}
+
+	upgrade, crashes := h.UpgradeCrashes(cfg.RollbackWindow)
+	if upgrade == nil || upgrade.Backup == "" || crashes < cfg.RollbackCrashes {
+		return nil
+	}
+
+	_, since := h.LastUpgrade()
+	for _, event := range since {
+		if event.Type == HealthEventRollback {
+			return nil
+		}
+	}
+
+	return upgrade
+}
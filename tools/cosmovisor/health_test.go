@@ -0,0 +1,127 @@
+package cosmovisor
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthNeedsRollback(t *testing.T) {
+	now := time.Now()
+	cfg := &Config{RollbackCrashes: 2, RollbackWindow: 10 * time.Minute}
+	upgradeAt := func(ago time.Duration) HealthEvent {
+		return HealthEvent{Time: now.Add(-ago), Type: HealthEventUpgrade, Upgrade: "v2", Height: 10, Backup: "/backup"}
+	}
+	upgrade := upgradeAt(5 * time.Minute)
+	crash := func(ago time.Duration, upgrade string) HealthEvent {
+		return HealthEvent{Time: now.Add(-ago), Type: HealthEventCrash, Upgrade: upgrade}
+	}
+
+	testCases := []struct {
+		name     string
+		cfg      *Config
+		health   Health
+		rollback bool
+	}{
+		{
+			name:     "crashes within the window after the upgrade",
+			cfg:      cfg,
+			health:   Health{Events: []HealthEvent{upgrade, crash(4*time.Minute, "v2"), crash(0, "v2")}},
+			rollback: true,
+		},
+		{
+			name:   "crashes once the binary ran past the window",
+			cfg:    cfg,
+			health: Health{Events: []HealthEvent{upgradeAt(time.Hour), crash(10*time.Second, "v2"), crash(0, "v2")}},
+		},
+		{
+			name:   "a single crash within the window",
+			cfg:    cfg,
+			health: Health{Events: []HealthEvent{upgradeAt(15 * time.Minute), crash(14*time.Minute, "v2"), crash(0, "v2")}},
+		},
+		{
+			name:   "crashes before the upgrade",
+			cfg:    cfg,
+			health: Health{Events: []HealthEvent{crash(6*time.Minute, ""), upgrade, crash(0, "v2")}},
+		},
+		{
+			name:   "crashes of another binary",
+			cfg:    cfg,
+			health: Health{Events: []HealthEvent{upgrade, crash(10*time.Second, ""), crash(0, "")}},
+		},
+		{
+			name: "already rolled back",
+			cfg:  cfg,
+			health: Health{Events: []HealthEvent{
+				upgrade, crash(3*time.Minute, "v2"), crash(2*time.Minute, "v2"),
+				{Time: now.Add(-2 * time.Minute), Type: HealthEventRollback, Upgrade: "v2"},
+				crash(10*time.Second, "v2"), crash(0, "v2"),
+			}},
+		},
+		{
+			name:   "no backup",
+			cfg:    cfg,
+			health: Health{Events: []HealthEvent{{Time: now.Add(-time.Minute), Type: HealthEventUpgrade, Upgrade: "v2"}, crash(10*time.Second, "v2"), crash(0, "v2")}},
+		},
+		{
+			name:   "disabled",
+			cfg:    &Config{RollbackWindow: 10 * time.Minute},
+			health: Health{Events: []HealthEvent{upgrade, crash(10*time.Second, "v2"), crash(0, "v2")}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := tc.health.needsRollback(tc.cfg)
+			if !tc.rollback {
+				require.Nil(t, res)
+				return
+			}
+
+			require.Equal(t, &upgrade, res)
+		})
+	}
+}
+
+func TestHealthUpgradeCrashes(t *testing.T) {
+	upgradeTime := time.Unix(1000, 0)
+	health := &Health{}
+
+	upgrade, crashes := health.UpgradeCrashes(time.Minute)
+	require.Nil(t, upgrade)
+	require.Zero(t, crashes)
+
+	health.Record(HealthEvent{Time: upgradeTime.Add(-time.Second), Type: HealthEventCrash})
+	health.Record(HealthEvent{Time: upgradeTime, Type: HealthEventUpgrade, Upgrade: "v2"})
+	health.Record(HealthEvent{Time: upgradeTime.Add(30 * time.Second), Type: HealthEventCrash, Upgrade: "v2"})
+	health.Record(HealthEvent{Time: upgradeTime.Add(time.Minute), Type: HealthEventCrash, Upgrade: "v2"})
+	health.Record(HealthEvent{Time: upgradeTime.Add(2 * time.Minute), Type: HealthEventCrash, Upgrade: "v2"})
+
+	upgrade, crashes = health.UpgradeCrashes(time.Minute)
+	require.Equal(t, "v2", upgrade.Upgrade)
+	require.Equal(t, 2, crashes)
+}
+
+func TestHealthFile(t *testing.T) {
+	cfg := &Config{Home: t.TempDir()}
+	require.NoError(t, os.MkdirAll(cfg.Root(), 0o755))
+
+	health, err := cfg.ReadHealth()
+	require.NoError(t, err)
+	require.Empty(t, health.Events)
+
+	for i := 0; i < maxHealthEvents+10; i++ {
+		health.Record(HealthEvent{Time: time.Unix(int64(i), 0).UTC(), Type: HealthEventCrash})
+	}
+	health.SafeMode = &SafeMode{Since: time.Unix(1, 0).UTC(), Upgrade: "v2", Height: 10}
+	require.NoError(t, cfg.WriteHealth(health))
+
+	read, err := cfg.ReadHealth()
+	require.NoError(t, err)
+	require.Equal(t, health, read)
+	require.Len(t, read.Events, maxHealthEvents)
+	require.Equal(t, time.Unix(10, 0).UTC(), read.Events[0].Time)
+	require.Equal(t, maxHealthEvents-10, read.Crashes(time.Unix(20, 0)))
+}
@@ -2,14 +2,17 @@ package cosmovisor
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -21,6 +24,10 @@ import (
 	upgradetypes "cosmossdk.io/x/upgrade/types"
 )
 
+// privValidatorStateFilename is the last signed state of the validator, in the
+// data directory.
+const privValidatorStateFilename = "priv_validator_state.json"
+
 type Launcher struct {
 	logger *zerolog.Logger
 	cfg    *Config
@@ -39,7 +46,8 @@ func NewLauncher(logger log.Logger, cfg *Config) (Launcher, error) {
 
 // Run launches the app in a subprocess and returns when the subprocess (app)
 // exits (either when it dies, or *after* a successful upgrade.) and upgrade finished.
-// Returns true if the upgrade request was detected and the upgrade process started.
+// Returns true if the upgrade request was detected and the upgrade process started,
+// or if a failed upgrade was rolled back, so that the app should be launched again.
 func (l Launcher) Run(args []string, stdout, stderr io.Writer) (bool, error) {
 	bin, err := l.cfg.CurrentBin()
 	if err != nil {
@@ -50,6 +58,17 @@ func (l Launcher) Run(args []string, stdout, stderr io.Writer) (bool, error) {
 		return false, fmt.Errorf("current binary is invalid: %w", err)
 	}
 
+	health, err := l.cfg.ReadHealth()
+	if err != nil {
+		return false, err
+	}
+
+	if health.SafeMode != nil {
+		args = SafeModeArgs(args, health.SafeMode)
+		l.logger.Warn().Str("upgrade", health.SafeMode.Upgrade).Int64("height", health.SafeMode.Height).
+			Msg("running in safe mode after the rollback of a failed upgrade, upgrades are disabled")
+	}
+
 	l.logger.Info().Str("path", bin).Strs("args", args).Msg("running app")
 	cmd := exec.Command(bin, args...)
 	cmd.Stdout = stdout
@@ -58,30 +77,61 @@ func (l Launcher) Run(args []string, stdout, stderr io.Writer) (bool, error) {
 		return false, fmt.Errorf("launching process %s %s failed: %w", bin, strings.Join(args, " "), err)
 	}
 
+	// set when the process is stopped by a signal forwarded to it, which isn't a crash
+	stopped := &atomic.Bool{}
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGQUIT, syscall.SIGTERM)
 	go func() {
 		sig := <-sigs
+		stopped.Store(true)
 		if err := cmd.Process.Signal(sig); err != nil {
 			l.logger.Fatal().Err(err).Str("bin", bin).Msg("terminated")
 		}
 	}()
 
-	if needsUpdate, err := l.WaitForUpgradeOrExit(cmd); err != nil || !needsUpdate {
+	if health.SafeMode != nil {
+		// the upgrade was rolled back, don't apply it again
+		if err := cmd.Wait(); err != nil {
+			return false, err
+		}
+
+		l.logger.Info().Msg("app halted in safe mode. Fix the upgrade binary and run `cosmovisor status --reset` to apply the upgrade again.")
+		return false, nil
+	}
+
+	needsUpdate, err := l.WaitForUpgradeOrExit(cmd)
+	if err != nil && !stopped.Load() {
+		return l.handleCrash(health, err)
+	}
+	if err != nil || !needsUpdate {
 		return false, err
 	}
 
 	if !IsSkipUpgradeHeight(args, l.fw.currentInfo) {
 		l.cfg.WaitRestartDelay()
 
-		if err := l.doBackup(); err != nil {
+		backup, err := l.doBackup()
+		if err != nil {
 			return false, err
 		}
 
+		previousDir := filepath.Dir(filepath.Dir(bin))
 		if err := UpgradeBinary(log.NewCustomLogger(*l.logger), l.cfg, l.fw.currentInfo); err != nil {
 			return false, err
 		}
 
+		health.Record(HealthEvent{
+			Time:        time.Now(),
+			Type:        HealthEventUpgrade,
+			Upgrade:     l.fw.currentInfo.Name,
+			Height:      l.fw.currentInfo.Height,
+			PreviousDir: previousDir,
+			Backup:      backup,
+		})
+		if err := l.cfg.WriteHealth(health); err != nil {
+			return false, err
+		}
+
 		if err = l.doPreUpgrade(); err != nil {
 			return false, err
 		}
@@ -92,6 +142,96 @@ func (l Launcher) Run(args []string, stdout, stderr io.Writer) (bool, error) {
 	return false, nil
 }
 
+// handleCrash records a crash of the app, and rolls back the last upgrade if
+// its binary crashed too many times. It returns true if the upgrade was rolled
+// back, and the previous binary should be launched in safe mode.
+func (l Launcher) handleCrash(health *Health, runErr error) (bool, error) {
+	var upgradeName string
+	// no upgrade info is read for the genesis binary
+	if upgrade, err := l.cfg.UpgradeInfo(); err == nil && upgrade.Name != "_" {
+		upgradeName = upgrade.Name
+	}
+
+	health.Record(HealthEvent{
+		Time:    time.Now(),
+		Type:    HealthEventCrash,
+		Upgrade: upgradeName,
+		Error:   runErr.Error(),
+	})
+
+	upgrade := health.needsRollback(l.cfg)
+	if upgrade != nil {
+		l.logger.Error().Err(runErr).Str("upgrade", upgrade.Upgrade).Int("crashes", l.cfg.RollbackCrashes).
+			Dur("window", l.cfg.RollbackWindow).Msg("upgrade binary keeps crashing after the upgrade, rolling back the upgrade")
+
+		if err := l.rollback(*upgrade); err != nil {
+			return false, errors.Join(runErr, fmt.Errorf("rollback of upgrade %s failed: %w", upgrade.Upgrade, err))
+		}
+
+		health.Record(HealthEvent{
+			Time:    time.Now(),
+			Type:    HealthEventRollback,
+			Upgrade: upgrade.Upgrade,
+			Height:  upgrade.Height,
+			Error:   fmt.Sprintf("%d crashes within %s of the upgrade", l.cfg.RollbackCrashes, l.cfg.RollbackWindow),
+			Backup:  upgrade.Backup,
+		})
+		health.SafeMode = &SafeMode{Since: time.Now(), Upgrade: upgrade.Upgrade, Height: upgrade.Height}
+	}
+
+	if err := l.cfg.WriteHealth(health); err != nil {
+		return false, errors.Join(runErr, err)
+	}
+
+	if upgrade == nil {
+		return false, runErr
+	}
+
+	l.logger.Info().Str("backup", upgrade.Backup).Str("binary", upgrade.PreviousDir).Msg("rollback completed")
+	return true, nil
+}
+
+// rollback restores the data backup taken before the given upgrade, and the
+// binary used before it. The data of the failed upgrade is kept aside, and its
+// validator state is kept, so that the validator doesn't double sign.
+func (l Launcher) rollback(upgrade HealthEvent) error {
+	dataDir := filepath.Join(l.cfg.Home, "data")
+	failedDir := filepath.Join(l.cfg.Home, fmt.Sprintf("data-failed-%s-%s", url.PathEscape(upgrade.Upgrade), time.Now().Format("20060102-150405")))
+	if err := os.Rename(dataDir, failedDir); err != nil {
+		return fmt.Errorf("error while moving the data directory aside: %w", err)
+	}
+	l.logger.Info().Str("path", failedDir).Msg("data directory of the failed upgrade moved aside")
+
+	if err := copy.Copy(upgrade.Backup, dataDir); err != nil {
+		return fmt.Errorf("error while restoring data backup: %w", err)
+	}
+
+	validatorState := filepath.Join(failedDir, privValidatorStateFilename)
+	if _, err := os.Stat(validatorState); err == nil {
+		if err := copy.Copy(validatorState, filepath.Join(dataDir, privValidatorStateFilename)); err != nil {
+			return fmt.Errorf("error while restoring the validator state: %w", err)
+		}
+	}
+
+	if err := l.cfg.setCurrentDir(upgrade.PreviousDir); err != nil {
+		return err
+	}
+	// the current upgrade is read again from the previous binary directory
+	l.cfg.currentUpgrade = upgradetypes.Plan{}
+
+	return nil
+}
+
+// SafeModeArgs returns the args of the app in safe mode, halting the node at
+// the height of the upgrade which was rolled back.
+func SafeModeArgs(args []string, safeMode *SafeMode) []string {
+	if len(args) == 0 || args[0] != "start" {
+		return args
+	}
+
+	return append(args[:len(args):len(args)], fmt.Sprintf("--%s", FlagHaltHeight), strconv.FormatInt(safeMode.Height, 10))
+}
+
 // WaitForUpgradeOrExit checks upgrade plan file created by the app.
 // When it returns, the process (app) is finished.
 //
@@ -130,22 +270,24 @@ func (l Launcher) WaitForUpgradeOrExit(cmd *exec.Cmd) (bool, error) {
 	return true, nil
 }
 
-func (l Launcher) doBackup() error {
+// doBackup takes a backup of the data directory, and returns its path, empty
+// if the backup is skipped.
+func (l Launcher) doBackup() (string, error) {
 	// take backup if `UNSAFE_SKIP_BACKUP` is not set.
 	if !l.cfg.UnsafeSkipBackup {
 		// check if upgrade-info.json is not empty.
 		var uInfo upgradetypes.Plan
 		upgradeInfoFile, err := os.ReadFile(filepath.Join(l.cfg.Home, "data", "upgrade-info.json"))
 		if err != nil {
-			return fmt.Errorf("error while reading upgrade-info.json: %w", err)
+			return "", fmt.Errorf("error while reading upgrade-info.json: %w", err)
 		}
 
 		if err = json.Unmarshal(upgradeInfoFile, &uInfo); err != nil {
-			return err
+			return "", err
 		}
 
 		if uInfo.Name == "" {
-			return fmt.Errorf("upgrade-info.json is empty")
+			return "", fmt.Errorf("upgrade-info.json is empty")
 		}
 
 		// a destination directory, Format YYYY-MM-DD
@@ -157,15 +299,16 @@ func (l Launcher) doBackup() error {
 
 		// copy the $DAEMON_HOME/data to a backup dir
 		if err = copy.Copy(filepath.Join(l.cfg.Home, "data"), dst); err != nil {
-			return fmt.Errorf("error while taking data backup: %w", err)
+			return "", fmt.Errorf("error while taking data backup: %w", err)
 		}
 
 		// backup is done, lets check endtime to calculate total time taken for backup process
 		et := time.Now()
 		l.logger.Info().Str("backup saved at", dst).Time("backup completion time", et).TimeDiff("time taken to complete backup", et, st).Msg("backup completed")
+		return dst, nil
 	}
 
-	return nil
+	return "", nil
 }
 
 // doPreUpgrade runs the pre-upgrade command defined by the application and handles respective error codes.
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -168,6 +169,81 @@ func (s *processTestSuite) TestLaunchProcessWithDownloads() {
 	require.Equal(cfg.UpgradeBin("chain3"), currentBin)
 }
 
+// TestLaunchProcessWithRollback checks that an upgrade whose binary keeps crashing is rolled back,
+// and that the previous binary is then run in safe mode
+func (s *processTestSuite) TestLaunchProcessWithRollback() {
+	// binaries from testdata/rollback directory:
+	// genesis -> chain2, which crashes
+	require := s.Require()
+	home := copyTestData(s.T(), "rollback")
+	cfg := &cosmovisor.Config{Home: home, Name: "dummyd", PollInterval: 20, DataBackupPath: home, RollbackCrashes: 2, RollbackWindow: time.Minute}
+	logger := log.NewTestLogger(s.T()).With(log.ModuleKey, "cosmovisor")
+	upgradeFile := cfg.UpgradeInfoFilePath()
+	dataDir := filepath.Dir(upgradeFile)
+
+	launcher, err := cosmovisor.NewLauncher(logger, cfg)
+	require.NoError(err)
+
+	stdout, stderr := newBuffer(), newBuffer()
+	args := []string{"start", upgradeFile}
+	doUpgrade, err := launcher.Run(args, stdout, stderr)
+	require.NoError(err)
+	require.True(doUpgrade)
+	currentBin, err := cfg.CurrentBin()
+	require.NoError(err)
+	require.Equal(cfg.UpgradeBin("chain2"), currentBin)
+
+	// the first crash is only recorded
+	doUpgrade, err = launcher.Run(args, stdout, stderr)
+	require.Error(err)
+	require.False(doUpgrade)
+	currentBin, err = cfg.CurrentBin()
+	require.NoError(err)
+	require.Equal(cfg.UpgradeBin("chain2"), currentBin)
+
+	// the second crash rolls back the upgrade
+	doUpgrade, err = launcher.Run(args, stdout, stderr)
+	require.NoError(err)
+	require.True(doUpgrade)
+	currentBin, err = cfg.CurrentBin()
+	require.NoError(err)
+	require.Equal(cfg.GenesisBin(), currentBin)
+
+	// the backup is restored, with the last validator state
+	require.NoFileExists(filepath.Join(dataDir, "application.db"))
+	bz, err := os.ReadFile(filepath.Join(dataDir, "priv_validator_state.json"))
+	require.NoError(err)
+	require.Equal(`{"height":"50"}`+"\n", string(bz))
+	failedDirs, err := filepath.Glob(filepath.Join(home, "data-failed-chain2-*"))
+	require.NoError(err)
+	require.Len(failedDirs, 1)
+	require.FileExists(filepath.Join(failedDirs[0], "application.db"))
+
+	health, err := cfg.ReadHealth()
+	require.NoError(err)
+	require.Equal(&cosmovisor.SafeMode{Since: health.SafeMode.Since, Upgrade: "chain2", Height: 49}, health.SafeMode)
+	var events []cosmovisor.HealthEventType
+	for _, event := range health.Events {
+		events = append(events, event.Type)
+	}
+	require.Equal([]cosmovisor.HealthEventType{
+		cosmovisor.HealthEventUpgrade,
+		cosmovisor.HealthEventCrash,
+		cosmovisor.HealthEventCrash,
+		cosmovisor.HealthEventRollback,
+	}, events)
+
+	// the previous binary halts at the upgrade height, without upgrading
+	stdout.Reset()
+	doUpgrade, err = launcher.Run(args, stdout, stderr)
+	require.NoError(err)
+	require.False(doUpgrade)
+	require.Equal(fmt.Sprintf("Genesis start %s --halt-height 49\nHalted\n", upgradeFile), stdout.String())
+	currentBin, err = cfg.CurrentBin()
+	require.NoError(err)
+	require.Equal(cfg.GenesisBin(), currentBin)
+}
+
 // TestSkipUpgrade tests heights that are identified to be skipped and return if upgrade height matches the skip heights
 func TestSkipUpgrade(t *testing.T) {
 	cases := []struct {
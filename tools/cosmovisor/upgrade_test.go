@@ -129,9 +129,10 @@ func (s *upgradeTestSuite) TestUpgradeBinary() {
 	logger := log.NewLogger(os.Stdout).With(log.ModuleKey, "cosmovisor")
 
 	cases := map[string]struct {
-		url         string
-		canDownload bool
-		validBinary bool
+		url          string
+		mustChecksum bool
+		canDownload  bool
+		validBinary  bool
 	}{
 		"get raw binary with checksum": {
 			// sha256sum ./testdata/repo/raw_binary/autod
@@ -139,6 +140,22 @@ func (s *upgradeTestSuite) TestUpgradeBinary() {
 			canDownload: true,
 			validBinary: true,
 		},
+		"get raw binary without checksum": {
+			url:         "./testdata/repo/raw_binary/autod",
+			canDownload: true,
+			validBinary: true,
+		},
+		"get raw binary without required checksum": {
+			url:          "./testdata/repo/raw_binary/autod",
+			mustChecksum: true,
+			canDownload:  false,
+		},
+		"get raw binary with required checksum": {
+			url:          "./testdata/repo/raw_binary/autod?checksum=sha256:e6bc7851600a2a9917f7bf88eb7bdee1ec162c671101485690b4deb089077b0d",
+			mustChecksum: true,
+			canDownload:  true,
+			validBinary:  true,
+		},
 		"get raw binary with invalid checksum": {
 			url:         "./testdata/repo/raw_binary/autod?checksum=sha256:73e2bd6cbb99261733caf137015d5cc58e3f96248d8b01da68be8564989dd906",
 			canDownload: false,
@@ -171,9 +188,10 @@ func (s *upgradeTestSuite) TestUpgradeBinary() {
 			home := copyTestData(s.T(), "download")
 
 			cfg := &cosmovisor.Config{
-				Home:                  home,
-				Name:                  "autod",
-				AllowDownloadBinaries: true,
+				Home:                     home,
+				Name:                     "autod",
+				AllowDownloadBinaries:    true,
+				DownloadMustHaveChecksum: tc.mustChecksum,
 			}
 
 			url := tc.url
@@ -15,8 +15,12 @@ type Config struct {
 }
 
 type ChainConfig struct {
+	// GRPCEndpoints are tried in order, falling back to the next one when an
+	// endpoint is unreachable.
 	GRPCEndpoints []GRPCEndpoint `toml:"trusted-grpc-endpoints"`
 	Bech32Prefix  string         `toml:"bech32-prefix"`
+	// AppVersion is the app version of the cached chain data in use.
+	AppVersion string `toml:"app-version,omitempty"`
 }
 
 type GRPCEndpoint struct {
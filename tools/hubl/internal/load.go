@@ -7,11 +7,17 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
 
 	cockroachdberrors "github.com/cockroachdb/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoregistry"
@@ -19,13 +25,28 @@ import (
 
 	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
 	reflectionv2alpha1 "cosmossdk.io/api/cosmos/base/reflection/v2alpha1"
+	cmtservicev1beta1 "cosmossdk.io/api/cosmos/base/tendermint/v1beta1"
 	reflectionv1 "cosmossdk.io/api/cosmos/reflection/v1"
 )
 
-const DefaultConfigDirName = ".hubl"
+const (
+	DefaultConfigDirName = ".hubl"
+
+	descriptorsCacheFilename = "descriptors.fds"
+	autocliCacheFilename     = "autocli.pb"
+
+	// unknownAppVersion is the cache version of the chains which don't report
+	// their app version.
+	unknownAppVersion = "unknown"
+
+	// endpointTimeout is the time given to a gRPC endpoint to answer before
+	// falling back to the next one.
+	endpointTimeout = 10 * time.Second
+)
 
 type ChainInfo struct {
-	client *grpc.ClientConn
+	client     *grpc.ClientConn
+	appVersion string
 
 	Context   context.Context
 	ConfigDir string
@@ -45,68 +66,68 @@ func NewChainInfo(configDir, chain string, config *ChainConfig) *ChainInfo {
 	}
 }
 
-func (c *ChainInfo) getCacheDir() (string, error) {
-	cacheDir := path.Join(c.ConfigDir, "cache")
-	return cacheDir, os.MkdirAll(cacheDir, 0o755)
+func (c *ChainInfo) chainCacheDir() string {
+	return path.Join(c.ConfigDir, "cache", c.Chain)
 }
 
-func (c *ChainInfo) fdsCacheFilename() (string, error) {
-	cacheDir, err := c.getCacheDir()
-	if err != nil {
-		return "", err
+// cacheFilenames returns the descriptors and autocli options cache files of
+// an app version of the chain. The cache of the chains configured before it
+// was versioned has no version.
+func (c *ChainInfo) cacheFilenames(appVersion string) (string, string) {
+	if appVersion == "" {
+		cacheDir := path.Join(c.ConfigDir, "cache")
+		return path.Join(cacheDir, fmt.Sprintf("%s.fds", c.Chain)), path.Join(cacheDir, fmt.Sprintf("%s.autocli", c.Chain))
 	}
-	return path.Join(cacheDir, fmt.Sprintf("%s.fds", c.Chain)), nil
+
+	cacheDir := path.Join(c.chainCacheDir(), appVersion)
+	return path.Join(cacheDir, descriptorsCacheFilename), path.Join(cacheDir, autocliCacheFilename)
 }
 
-func (c *ChainInfo) appOptsCacheFilename() (string, error) {
-	cacheDir, err := c.getCacheDir()
-	if err != nil {
-		return "", err
+// CachedVersions returns the app versions of the chain with cached data.
+func (c *ChainInfo) CachedVersions() ([]string, error) {
+	entries, err := os.ReadDir(c.chainCacheDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			versions = append(versions, entry.Name())
+		}
 	}
-	return path.Join(cacheDir, fmt.Sprintf("%s.autocli", c.Chain)), nil
+	sort.Strings(versions)
+
+	return versions, nil
 }
 
+// Load loads the file descriptors and autocli options of the chain from the
+// cache of its configured app version. They are only fetched from the chain
+// when reload is set, or nothing is cached yet.
 func (c *ChainInfo) Load(reload bool) error {
-	fdSet := &descriptorpb.FileDescriptorSet{}
-	fdsFilename, err := c.fdsCacheFilename()
-	if err != nil {
-		return err
+	if reload {
+		return c.Refresh()
 	}
 
-	if _, err := os.Stat(fdsFilename); os.IsNotExist(err) || reload {
-		client, err := c.OpenClient()
-		if err != nil {
-			return err
-		}
-
-		reflectionClient := reflectionv1.NewReflectionServiceClient(client)
-		fdRes, err := reflectionClient.FileDescriptors(c.Context, &reflectionv1.FileDescriptorsRequest{})
-		if err != nil {
-			fdSet, err = loadFileDescriptorsGRPCReflection(c.Context, client)
-			if err != nil {
-				return err
-			}
-		} else {
-			fdSet = &descriptorpb.FileDescriptorSet{File: fdRes.Files}
+	fdsFilename, appOptsFilename := c.cacheFilenames(c.Config.AppVersion)
+	if _, err := os.Stat(fdsFilename); os.IsNotExist(err) {
+		if c.Config.AppVersion != "" {
+			return fmt.Errorf("no cached data for %s version %s, use --%s to fetch it from the chain", c.Chain, c.Config.AppVersion, flagUpdate)
 		}
 
-		bz, err := proto.Marshal(fdSet)
-		if err != nil {
-			return err
-		}
+		return c.Refresh()
+	}
 
-		if err = os.WriteFile(fdsFilename, bz, 0o600); err != nil {
-			return err
-		}
-	} else {
-		bz, err := os.ReadFile(fdsFilename)
-		if err != nil {
-			return err
-		}
+	bz, err := os.ReadFile(fdsFilename)
+	if err != nil {
+		return err
+	}
 
-		if err = proto.Unmarshal(bz, fdSet); err != nil {
-			return err
-		}
+	fdSet := &descriptorpb.FileDescriptorSet{}
+	if err = proto.Unmarshal(bz, fdSet); err != nil {
+		return err
 	}
 
 	c.ProtoFiles, err = protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(fdSet)
@@ -114,50 +135,81 @@ func (c *ChainInfo) Load(reload bool) error {
 		return fmt.Errorf("error building protoregistry.Files: %w", err)
 	}
 
-	appOptsFilename, err := c.appOptsCacheFilename()
+	bz, err = os.ReadFile(appOptsFilename)
 	if err != nil {
 		return err
 	}
 
-	if _, err := os.Stat(appOptsFilename); os.IsNotExist(err) || reload {
-		client, err := c.OpenClient()
-		if err != nil {
-			return err
-		}
+	var appOptsRes autocliv1.AppOptionsResponse
+	if err := proto.Unmarshal(bz, &appOptsRes); err != nil {
+		return err
+	}
 
-		autocliQueryClient := autocliv1.NewQueryClient(client)
-		appOptsRes, err := autocliQueryClient.AppOptions(c.Context, &autocliv1.AppOptionsRequest{})
-		if err != nil {
-			appOptsRes = guessAutocli(c.ProtoFiles)
-		}
+	c.ModuleOptions = appOptsRes.ModuleOptions
+	return nil
+}
 
-		bz, err := proto.Marshal(appOptsRes)
+// Refresh fetches the file descriptors and autocli options of the chain and
+// caches them for its current app version, which becomes the configured one.
+func (c *ChainInfo) Refresh() error {
+	client, err := c.OpenClient()
+	if err != nil {
+		return err
+	}
+
+	fdSet := &descriptorpb.FileDescriptorSet{}
+	reflectionClient := reflectionv1.NewReflectionServiceClient(client)
+	fdRes, err := reflectionClient.FileDescriptors(c.Context, &reflectionv1.FileDescriptorsRequest{})
+	if err != nil {
+		fdSet, err = loadFileDescriptorsGRPCReflection(c.Context, client)
 		if err != nil {
 			return err
 		}
+	} else {
+		fdSet = &descriptorpb.FileDescriptorSet{File: fdRes.Files}
+	}
 
-		if err := os.WriteFile(appOptsFilename, bz, 0o600); err != nil {
-			return err
-		}
+	c.ProtoFiles, err = protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(fdSet)
+	if err != nil {
+		return fmt.Errorf("error building protoregistry.Files: %w", err)
+	}
 
-		c.ModuleOptions = appOptsRes.ModuleOptions
-	} else {
-		bz, err := os.ReadFile(appOptsFilename)
-		if err != nil {
-			return err
-		}
+	autocliQueryClient := autocliv1.NewQueryClient(client)
+	appOptsRes, err := autocliQueryClient.AppOptions(c.Context, &autocliv1.AppOptionsRequest{})
+	if err != nil {
+		appOptsRes = guessAutocli(c.ProtoFiles)
+	}
+	c.ModuleOptions = appOptsRes.ModuleOptions
 
-		var appOptsRes autocliv1.AppOptionsResponse
-		if err := proto.Unmarshal(bz, &appOptsRes); err != nil {
-			return err
-		}
+	fdsFilename, appOptsFilename := c.cacheFilenames(c.appVersion)
+	if err := os.MkdirAll(path.Dir(fdsFilename), 0o755); err != nil {
+		return err
+	}
+
+	bz, err := proto.Marshal(fdSet)
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(fdsFilename, bz, 0o600); err != nil {
+		return err
+	}
 
-		c.ModuleOptions = appOptsRes.ModuleOptions
+	bz, err = proto.Marshal(appOptsRes)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(appOptsFilename, bz, 0o600); err != nil {
+		return err
 	}
 
+	c.Config.AppVersion = c.appVersion
 	return nil
 }
 
+// OpenClient connects to the first gRPC endpoint of the chain answering in
+// time, falling back to the next endpoints in order.
 func (c *ChainInfo) OpenClient() (*grpc.ClientConn, error) {
 	if c.client != nil {
 		return c.client, nil
@@ -174,19 +226,53 @@ func (c *ChainInfo) OpenClient() (*grpc.ClientConn, error) {
 			})
 		}
 
-		var err error
-		c.client, err = grpc.Dial(endpoint.Endpoint, grpc.WithTransportCredentials(creds))
+		client, err := grpc.Dial(endpoint.Endpoint, grpc.WithTransportCredentials(creds))
 		if err != nil {
 			res = errors.Join(res, err)
 			continue
 		}
 
+		appVersion, err := getAppVersion(c.Context, client)
+		if err != nil {
+			_ = client.Close()
+			res = errors.Join(res, fmt.Errorf("%s: %w", endpoint.Endpoint, err))
+			continue
+		}
+
+		c.client, c.appVersion = client, appVersion
 		return c.client, nil
 	}
 
 	return nil, cockroachdberrors.Wrapf(res, "error loading gRPC client")
 }
 
+// getAppVersion returns the app version of the chain, which is also used to
+// check that the endpoint is reachable.
+func getAppVersion(ctx context.Context, conn grpc.ClientConnInterface) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, endpointTimeout)
+	defer cancel()
+
+	res, err := cmtservicev1beta1.NewServiceClient(conn).GetNodeInfo(ctx, &cmtservicev1beta1.GetNodeInfoRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		return unknownAppVersion, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	appVersion := res.GetApplicationVersion().GetVersion()
+	if appVersion == "" {
+		return unknownAppVersion, nil
+	}
+
+	// the version names a cache directory
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(".-_+", r) {
+			return r
+		}
+		return '_'
+	}, appVersion), nil
+}
+
 // getAddressPrefix returns the address prefix of the chain.
 func getAddressPrefix(ctx context.Context, conn grpc.ClientConnInterface) (string, error) {
 	reflectionClient := reflectionv2alpha1.NewReflectionServiceClient(conn)
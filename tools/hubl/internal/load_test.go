@@ -0,0 +1,179 @@
+package internal
+
+import (
+	"context"
+	"net"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
+	bankv1beta1 "cosmossdk.io/api/cosmos/bank/v1beta1"
+	cmtservicev1beta1 "cosmossdk.io/api/cosmos/base/tendermint/v1beta1"
+)
+
+// writeCache writes the cached data of an app version of the chain, with the
+// given service as the tx service of the bank module.
+func writeCache(t *testing.T, chainInfo *ChainInfo, appVersion, service string) {
+	t.Helper()
+
+	fdsFilename, appOptsFilename := chainInfo.cacheFilenames(appVersion)
+	require.NoError(t, os.MkdirAll(path.Dir(fdsFilename), 0o755))
+
+	bz, err := proto.Marshal(&descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(bankv1beta1.File_cosmos_bank_v1beta1_tx_proto)},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(fdsFilename, bz, 0o600))
+
+	bz, err = proto.Marshal(&autocliv1.AppOptionsResponse{
+		ModuleOptions: map[string]*autocliv1.ModuleOptions{
+			"bank": {Tx: &autocliv1.ServiceCommandDescriptor{Service: service}},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(appOptsFilename, bz, 0o600))
+}
+
+func TestLoadCachedVersion(t *testing.T) {
+	chainInfo := NewChainInfo(t.TempDir(), "foochain", &ChainConfig{AppVersion: "v2.0.0"})
+
+	versions, err := chainInfo.CachedVersions()
+	require.NoError(t, err)
+	require.Empty(t, versions)
+
+	// nothing is fetched from the chain, which has no endpoint
+	err = chainInfo.Load(false)
+	require.ErrorContains(t, err, "no cached data for foochain version v2.0.0")
+
+	writeCache(t, chainInfo, "v1.0.0", "cosmos.bank.v1beta1.Msg")
+	writeCache(t, chainInfo, "v2.0.0", "cosmos.bank.v1beta1.MsgV2")
+
+	versions, err = chainInfo.CachedVersions()
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0", "v2.0.0"}, versions)
+
+	require.NoError(t, chainInfo.Load(false))
+	require.Equal(t, "cosmos.bank.v1beta1.MsgV2", chainInfo.ModuleOptions["bank"].Tx.Service)
+	_, err = chainInfo.ProtoFiles.FindDescriptorByName("cosmos.bank.v1beta1.MsgSend")
+	require.NoError(t, err)
+
+	chainInfo.Config.AppVersion = "v1.0.0"
+	require.NoError(t, chainInfo.Load(false))
+	require.Equal(t, "cosmos.bank.v1beta1.Msg", chainInfo.ModuleOptions["bank"].Tx.Service)
+}
+
+func TestLoadUnversionedCache(t *testing.T) {
+	// chains configured before the cache was versioned have no app version
+	chainInfo := NewChainInfo(t.TempDir(), "foochain", &ChainConfig{})
+	writeCache(t, chainInfo, "", "cosmos.bank.v1beta1.Msg")
+
+	require.NoError(t, chainInfo.Load(false))
+	require.Equal(t, "cosmos.bank.v1beta1.Msg", chainInfo.ModuleOptions["bank"].Tx.Service)
+
+	versions, err := chainInfo.CachedVersions()
+	require.NoError(t, err)
+	require.Empty(t, versions)
+}
+
+type nodeInfoServer struct {
+	cmtservicev1beta1.UnimplementedServiceServer
+
+	appVersion string
+}
+
+func (s nodeInfoServer) GetNodeInfo(context.Context, *cmtservicev1beta1.GetNodeInfoRequest) (*cmtservicev1beta1.GetNodeInfoResponse, error) {
+	return &cmtservicev1beta1.GetNodeInfoResponse{
+		ApplicationVersion: &cmtservicev1beta1.VersionInfo{Version: s.appVersion},
+	}, nil
+}
+
+// startServer starts a gRPC server on a local port, with the node info
+// service if appVersion is set, and returns its address.
+func startServer(t *testing.T, appVersion string) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	if appVersion != "" {
+		cmtservicev1beta1.RegisterServiceServer(server, nodeInfoServer{appVersion: appVersion})
+	}
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	return lis.Addr().String()
+}
+
+// closedEndpoint returns the address of a local port nothing listens on.
+func closedEndpoint(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, lis.Close())
+
+	return lis.Addr().String()
+}
+
+func TestOpenClientFallback(t *testing.T) {
+	testCases := []struct {
+		name       string
+		endpoints  []string
+		appVersion string
+		expErr     string
+	}{
+		{
+			name:       "first endpoint",
+			endpoints:  []string{startServer(t, "v1.0.0"), closedEndpoint(t)},
+			appVersion: "v1.0.0",
+		},
+		{
+			name:       "fallback to the next endpoint",
+			endpoints:  []string{closedEndpoint(t), closedEndpoint(t), startServer(t, "v1.0.0/rc 1")},
+			appVersion: "v1.0.0_rc_1",
+		},
+		{
+			name:       "node info not implemented",
+			endpoints:  []string{closedEndpoint(t), startServer(t, "")},
+			appVersion: unknownAppVersion,
+		},
+		{
+			name:      "no reachable endpoint",
+			endpoints: []string{closedEndpoint(t), closedEndpoint(t)},
+			expErr:    "error loading gRPC client",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &ChainConfig{}
+			for _, endpoint := range tc.endpoints {
+				config.GRPCEndpoints = append(config.GRPCEndpoints, GRPCEndpoint{Endpoint: endpoint, Insecure: true})
+			}
+
+			chainInfo := NewChainInfo(t.TempDir(), "foochain", config)
+			client, err := chainInfo.OpenClient()
+			if tc.expErr != "" {
+				require.ErrorContains(t, err, tc.expErr)
+				return
+			}
+
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = client.Close() })
+			require.Equal(t, tc.appVersion, chainInfo.appVersion)
+
+			// the client is reused
+			again, err := chainInfo.OpenClient()
+			require.NoError(t, err)
+			require.Same(t, client, again)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/iancoleman/strcase"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
+	"cosmossdk.io/client/v2/autocli/flag"
+)
+
+// messageBuilder builds the message of a command from the flags and
+// positional arguments bound by the flag builder.
+type messageBuilder struct {
+	ctx         context.Context
+	builder     *flag.Builder
+	messageType protoreflect.MessageType
+	options     *autocliv1.RpcCommandOptions
+	binder      *flag.MessageBinder
+}
+
+// newMessageBuilder adds the flags of the message fields to the command.
+func newMessageBuilder(ctx context.Context, cmd *cobra.Command, builder *flag.Builder, messageType protoreflect.MessageType, options *autocliv1.RpcCommandOptions) (*messageBuilder, error) {
+	binder, err := builder.AddMessageFlags(ctx, cmd.Flags(), messageType, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &messageBuilder{
+		ctx:         ctx,
+		builder:     builder,
+		messageType: messageType,
+		options:     options,
+		binder:      binder,
+	}, nil
+}
+
+// BuildMessage builds the message with the flag binder. The binder only sets
+// the scalar fields, so the list and message fields are set here from the
+// values bound to their flag or positional arguments.
+func (b *messageBuilder) BuildMessage(cmd *cobra.Command, args []string) (protoreflect.Message, error) {
+	msg, err := b.binder.BuildMessage(args)
+	if err != nil {
+		return nil, err
+	}
+
+	positionalArgs := map[protoreflect.Name][]string{}
+	for i, arg := range b.options.PositionalArgs {
+		if i >= len(args) {
+			break
+		}
+
+		if arg.Varargs {
+			positionalArgs[protoreflect.Name(arg.ProtoField)] = args[i:]
+		} else {
+			positionalArgs[protoreflect.Name(arg.ProtoField)] = args[i : i+1]
+		}
+	}
+
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.IsMap() || (!field.IsList() && field.Kind() != protoreflect.MessageKind) {
+			continue
+		}
+
+		flagValue := cmd.Flags().Lookup(b.flagName(field))
+		if values, ok := positionalArgs[field.Name()]; ok {
+			if flagValue, err = b.positionalFlag(field, values); err != nil {
+				return nil, err
+			}
+		}
+		if flagValue == nil {
+			continue
+		}
+
+		if err := setField(msg, field, flagValue.Value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", field.Name(), err)
+		}
+	}
+
+	return msg, nil
+}
+
+// flagName returns the name of the flag of a message field, as named by the
+// flag builder.
+func (b *messageBuilder) flagName(field protoreflect.FieldDescriptor) string {
+	if name := b.options.GetFlagOptions()[string(field.Name())].GetName(); name != "" {
+		return name
+	}
+
+	return strcase.ToKebab(string(field.Name()))
+}
+
+// positionalFlag returns a flag of the field set to the values of its
+// positional arguments, parsed as the flag builder parses them.
+func (b *messageBuilder) positionalFlag(field protoreflect.FieldDescriptor, values []string) (*pflag.Flag, error) {
+	flagSet := pflag.NewFlagSet("positional", pflag.ContinueOnError)
+	if _, err := b.builder.AddMessageFlags(b.ctx, flagSet, b.messageType, &autocliv1.RpcCommandOptions{}); err != nil {
+		return nil, err
+	}
+
+	flagValue := flagSet.Lookup(strcase.ToKebab(string(field.Name())))
+	if flagValue == nil {
+		return nil, fmt.Errorf("can't find the flag of %s", field.Name())
+	}
+
+	for _, value := range values {
+		if err := flagValue.Value.Set(value); err != nil {
+			return nil, fmt.Errorf("invalid argument %q for %s: %w", value, field.Name(), err)
+		}
+	}
+
+	return flagValue, nil
+}
+
+// setField sets a list or message field to the value of its flag.
+func setField(msg protoreflect.Message, field protoreflect.FieldDescriptor, value pflag.Value) error {
+	if !field.IsList() {
+		hasValue, ok := value.(flag.HasValue)
+		if !ok {
+			return nil
+		}
+
+		val, err := hasValue.Get(msg.NewField(field))
+		if err != nil || !val.IsValid() {
+			return err
+		}
+
+		m, err := convertMessage(field.Message(), val.Message())
+		if err != nil {
+			return err
+		}
+
+		msg.Set(field, protoreflect.ValueOfMessage(m))
+		return nil
+	}
+
+	list := &valueList{}
+	switch value := value.(type) {
+	case flag.HasValue:
+		if _, err := value.Get(protoreflect.ValueOfList(list)); err != nil {
+			return err
+		}
+	case pflag.SliceValue:
+		// the flags of the lists of scalars are plain pflag slices
+		for _, s := range value.GetSlice() {
+			val, err := scalarValue(field.Kind(), s)
+			if err != nil {
+				return err
+			}
+			list.Append(val)
+		}
+	}
+
+	if list.Len() == 0 {
+		return nil
+	}
+
+	fieldList := msg.Mutable(field).List()
+	for i := 0; i < list.Len(); i++ {
+		val := list.Get(i)
+		if field.Kind() == protoreflect.MessageKind {
+			m, err := convertMessage(field.Message(), val.Message())
+			if err != nil {
+				return err
+			}
+			val = protoreflect.ValueOfMessage(m)
+		}
+		fieldList.Append(val)
+	}
+
+	return nil
+}
+
+// convertMessage returns the message as a message of the given descriptor,
+// e.g. a coin parsed by the flag builder as a coin of the chain descriptors.
+func convertMessage(desc protoreflect.MessageDescriptor, msg protoreflect.Message) (protoreflect.Message, error) {
+	if msg.Descriptor() == desc {
+		return msg, nil
+	}
+
+	bz, err := proto.Marshal(msg.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	m := dynamicpb.NewMessage(desc)
+	if err := proto.Unmarshal(bz, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// scalarValue parses a value of a list of scalars.
+func scalarValue(kind protoreflect.Kind, s string) (protoreflect.Value, error) {
+	switch kind {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported list of %s", kind)
+	}
+}
+
+// valueList is a protoreflect.List collecting the values of a list flag.
+type valueList struct {
+	values []protoreflect.Value
+}
+
+var _ protoreflect.List = &valueList{}
+
+func (l *valueList) Len() int                        { return len(l.values) }
+func (l *valueList) Get(i int) protoreflect.Value    { return l.values[i] }
+func (l *valueList) Set(i int, v protoreflect.Value) { l.values[i] = v }
+func (l *valueList) Append(v protoreflect.Value)     { l.values = append(l.values, v) }
+func (l *valueList) AppendMutable() protoreflect.Value {
+	panic("valueList: AppendMutable is not supported")
+}
+func (l *valueList) Truncate(n int)                 { l.values = l.values[:n] }
+func (l *valueList) NewElement() protoreflect.Value { panic("valueList: NewElement is not supported") }
+func (l *valueList) IsValid() bool                  { return true }
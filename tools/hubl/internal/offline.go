@@ -0,0 +1,215 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
+	basev1beta1 "cosmossdk.io/api/cosmos/base/v1beta1"
+	txv1beta1 "cosmossdk.io/api/cosmos/tx/v1beta1"
+	"cosmossdk.io/client/v2/autocli/flag"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagUnsignedTx    = "unsigned-tx"
+	flagFees          = "fees"
+	flagGas           = "gas"
+	flagNote          = "note"
+	flagTimeoutHeight = "timeout-height"
+
+	defaultGasLimit = 200000
+)
+
+// addOfflineTxCommands rebinds the tx commands of the chain built by autocli,
+// so that they build their message from the flags bound by the flag builder
+// and can print it in an unsigned transaction, without connecting to the
+// chain. The generated transaction can be signed on an air-gapped machine,
+// e.g. with the offline mode of the tx sign command of the chain binary.
+func addOfflineTxCommands(chainCmd *cobra.Command, chainInfo *ChainInfo, builder *flag.Builder) error {
+	txCmd := findCommand(chainCmd, "tx")
+	if txCmd == nil {
+		return nil
+	}
+
+	for moduleName, moduleOptions := range chainInfo.ModuleOptions {
+		moduleCmd := findCommand(txCmd, moduleName)
+		if moduleCmd == nil || moduleOptions.Tx == nil {
+			continue
+		}
+
+		if err := addOfflineTxServiceCommands(moduleCmd, moduleOptions.Tx, chainInfo, builder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addOfflineTxServiceCommands rebinds the commands of the methods of a
+// service, and of its sub-services.
+func addOfflineTxServiceCommands(cmd *cobra.Command, desc *autocliv1.ServiceCommandDescriptor, chainInfo *ChainInfo, builder *flag.Builder) error {
+	for name, subDesc := range desc.SubCommands {
+		subCmd := findCommand(cmd, name)
+		if subCmd == nil {
+			continue
+		}
+
+		if err := addOfflineTxServiceCommands(subCmd, subDesc, chainInfo, builder); err != nil {
+			return err
+		}
+	}
+
+	if desc.Service == "" {
+		return nil
+	}
+
+	descriptor, err := chainInfo.ProtoFiles.FindDescriptorByName(protoreflect.FullName(desc.Service))
+	if err != nil {
+		return fmt.Errorf("can't find service %s: %w", desc.Service, err)
+	}
+
+	options := map[string]*autocliv1.RpcCommandOptions{}
+	for _, option := range desc.RpcCommandOptions {
+		options[option.RpcMethod] = option
+	}
+
+	methods := descriptor.(protoreflect.ServiceDescriptor).Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		methodOptions, ok := options[string(method.Name())]
+		if !ok {
+			methodOptions = &autocliv1.RpcCommandOptions{}
+		}
+
+		// same as the command use of autocli
+		use := methodOptions.Use
+		if use == "" {
+			use = strcase.ToKebab(string(method.Name()))
+		}
+
+		name, _, _ := strings.Cut(use, " ")
+		methodCmd := findCommand(cmd, name)
+		if methodCmd == nil {
+			continue
+		}
+
+		if err := addOfflineTxFlags(methodCmd, method, methodOptions, chainInfo, builder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addOfflineTxFlags binds the message flags of a tx command with the flag
+// builder and adds the flags of the unsigned transaction. The command prints
+// its message, or the unsigned transaction with it if --unsigned-tx is set.
+func addOfflineTxFlags(cmd *cobra.Command, method protoreflect.MethodDescriptor, options *autocliv1.RpcCommandOptions, chainInfo *ChainInfo, builder *flag.Builder) error {
+	// the flags registered by autocli are bound to the message of its own
+	// run function, bind them again to the message built here
+	cmd.ResetFlags()
+	msgBuilder, err := newMessageBuilder(chainInfo.Context, cmd, builder, dynamicpb.NewMessageType(method.Input()), options)
+	if err != nil {
+		return err
+	}
+	cmd.Args = msgBuilder.binder.CobraArgs
+
+	cmd.Flags().Bool(flagUnsignedTx, false, "print an unsigned transaction with the message instead of the message only, for offline signing")
+	cmd.Flags().String(flagFees, "", "fees of the unsigned transaction, e.g. 10uatom")
+	cmd.Flags().Uint64(flagGas, defaultGasLimit, "gas limit of the unsigned transaction")
+	cmd.Flags().String(flagNote, "", "note (memo) of the unsigned transaction")
+	cmd.Flags().Uint64(flagTimeoutHeight, 0, "block height after which the unsigned transaction is no longer valid")
+
+	marshalOptions := protojson.MarshalOptions{
+		Indent:          "  ",
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+		Resolver:        &dynamicTypeResolver{chainInfo},
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		msg, err := msgBuilder.BuildMessage(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		var out proto.Message = msg.Interface()
+		if unsignedTx, _ := cmd.Flags().GetBool(flagUnsignedTx); unsignedTx {
+			if out, err = buildUnsignedTx(cmd, msg.Interface()); err != nil {
+				return err
+			}
+		}
+
+		bz, err := marshalOptions.Marshal(out)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+		return err
+	}
+
+	return nil
+}
+
+// findCommand returns the sub-command of cmd with the given name, or nil.
+func findCommand(cmd *cobra.Command, name string) *cobra.Command {
+	for _, subCmd := range cmd.Commands() {
+		if subCmd.Name() == name {
+			return subCmd
+		}
+	}
+
+	return nil
+}
+
+// buildUnsignedTx builds an unsigned transaction with the given message and
+// the fee, gas limit, note and timeout height of the command flags.
+func buildUnsignedTx(cmd *cobra.Command, msg proto.Message) (*txv1beta1.Tx, error) {
+	fees, _ := cmd.Flags().GetString(flagFees)
+	gas, _ := cmd.Flags().GetUint64(flagGas)
+	note, _ := cmd.Flags().GetString(flagNote)
+	timeoutHeight, _ := cmd.Flags().GetUint64(flagTimeoutHeight)
+
+	coins, err := sdk.ParseCoinsNormalized(fees)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fees %q: %w", fees, err)
+	}
+
+	amount := make([]*basev1beta1.Coin, len(coins))
+	for i, coin := range coins {
+		amount[i] = &basev1beta1.Coin{Denom: coin.Denom, Amount: coin.Amount.String()}
+	}
+
+	bz, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &txv1beta1.Tx{
+		Body: &txv1beta1.TxBody{
+			Messages: []*anypb.Any{{
+				TypeUrl: "/" + string(msg.ProtoReflect().Descriptor().FullName()),
+				Value:   bz,
+			}},
+			Memo:          note,
+			TimeoutHeight: timeoutHeight,
+		},
+		AuthInfo: &txv1beta1.AuthInfo{
+			Fee: &txv1beta1.Fee{
+				Amount:   amount,
+				GasLimit: gas,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,205 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	autocliv1 "cosmossdk.io/api/cosmos/autocli/v1"
+	bankv1beta1 "cosmossdk.io/api/cosmos/bank/v1beta1"
+	basev1beta1 "cosmossdk.io/api/cosmos/base/v1beta1"
+	txv1beta1 "cosmossdk.io/api/cosmos/tx/v1beta1"
+	"cosmossdk.io/client/v2/autocli"
+	"cosmossdk.io/client/v2/autocli/flag"
+
+	addresscodec "github.com/cosmos/cosmos-sdk/codec/address"
+)
+
+const (
+	testFromAddress = "cosmos1y74p8wyy4enfhfn342njve6cjmj5c8dtl6emdk"
+	testToAddress   = "cosmos1ghekyjucln7y67ntx7cf27m9dpuxxemn4c8g4r"
+)
+
+// testChainCommand returns the command of a chain with the bank tx service,
+// built as hubl builds it from the cached chain data. It never connects to
+// the chain.
+func testChainCommand(t *testing.T) *cobra.Command {
+	t.Helper()
+
+	chainInfo := NewChainInfo(t.TempDir(), "foochain", &ChainConfig{})
+	chainInfo.ProtoFiles = chainFiles(t, bankv1beta1.File_cosmos_bank_v1beta1_tx_proto)
+	chainInfo.ModuleOptions = map[string]*autocliv1.ModuleOptions{
+		"bank": {
+			Tx: &autocliv1.ServiceCommandDescriptor{
+				Service: bankv1beta1.Msg_ServiceDesc.ServiceName,
+				RpcCommandOptions: []*autocliv1.RpcCommandOptions{
+					{
+						RpcMethod: "Send",
+						Use:       "send [from_key_or_address] [to_address] [amount]",
+						PositionalArgs: []*autocliv1.PositionalArgDescriptor{
+							{ProtoField: "from_address"},
+							{ProtoField: "to_address"},
+							{ProtoField: "amount", Varargs: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	builder := &autocli.Builder{
+		Builder: flag.Builder{
+			AddressCodec:          addresscodec.NewBech32Codec("cosmos"),
+			ValidatorAddressCodec: addresscodec.NewBech32Codec("cosmosvaloper"),
+			ConsensusAddressCodec: addresscodec.NewBech32Codec("cosmosvalcons"),
+			TypeResolver:          &dynamicTypeResolver{chainInfo},
+			FileResolver:          chainInfo.ProtoFiles,
+		},
+		AddQueryConnFlags: func(*cobra.Command) {},
+		AddTxConnFlags:    func(*cobra.Command) {},
+	}
+
+	chainCmd := &cobra.Command{Use: "foochain"}
+	appOpts := autocli.AppOptions{ModuleOptions: chainInfo.ModuleOptions}
+	require.NoError(t, appOpts.EnhanceRootCommandWithBuilder(chainCmd, builder))
+	require.NoError(t, addOfflineTxCommands(chainCmd, chainInfo, &builder.Builder))
+
+	return chainCmd
+}
+
+// chainFiles returns the descriptors of the given file and its imports, built
+// from their protos as when they are fetched from a chain.
+func chainFiles(t *testing.T, file protoreflect.FileDescriptor) *protoregistry.Files {
+	t.Helper()
+
+	fdSet := &descriptorpb.FileDescriptorSet{}
+	seen := map[string]bool{}
+	var addFile func(file protoreflect.FileDescriptor)
+	addFile = func(file protoreflect.FileDescriptor) {
+		if seen[file.Path()] {
+			return
+		}
+		seen[file.Path()] = true
+
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			addFile(imports.Get(i).FileDescriptor)
+		}
+		fdSet.File = append(fdSet.File, protodesc.ToFileDescriptorProto(file))
+	}
+	addFile(file)
+
+	files, err := protodesc.NewFiles(fdSet)
+	require.NoError(t, err)
+
+	return files
+}
+
+func runChainCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	cmd := testChainCommand(t)
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	cmd.SetArgs(args)
+
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestTxCommandMessage(t *testing.T) {
+	out, err := runChainCommand(t, "tx", "bank", "send", testFromAddress, testToAddress, "10foo", "5bar")
+	require.NoError(t, err)
+
+	msg := &bankv1beta1.MsgSend{}
+	require.NoError(t, protojson.Unmarshal([]byte(out), msg))
+	require.True(t, proto.Equal(&bankv1beta1.MsgSend{
+		FromAddress: testFromAddress,
+		ToAddress:   testToAddress,
+		Amount: []*basev1beta1.Coin{
+			{Denom: "foo", Amount: "10"},
+			{Denom: "bar", Amount: "5"},
+		},
+	}, msg), out)
+}
+
+func TestTxCommandUnsignedTx(t *testing.T) {
+	out, err := runChainCommand(t, "tx", "bank", "send", testFromAddress, testToAddress, "10foo",
+		"--"+flagUnsignedTx, "--"+flagFees, "5foo", "--"+flagGas, "100000", "--"+flagNote, "offline", "--"+flagTimeoutHeight, "42")
+	require.NoError(t, err)
+
+	tx := &txv1beta1.Tx{}
+	require.NoError(t, protojson.Unmarshal([]byte(out), tx))
+	require.Equal(t, "offline", tx.Body.Memo)
+	require.Equal(t, uint64(42), tx.Body.TimeoutHeight)
+	require.Equal(t, uint64(100000), tx.AuthInfo.Fee.GasLimit)
+	require.Len(t, tx.AuthInfo.Fee.Amount, 1)
+	require.Equal(t, "5", tx.AuthInfo.Fee.Amount[0].Amount)
+	require.Empty(t, tx.Signatures)
+	require.Empty(t, tx.AuthInfo.SignerInfos)
+
+	require.Len(t, tx.Body.Messages, 1)
+	msg := &bankv1beta1.MsgSend{}
+	require.NoError(t, tx.Body.Messages[0].UnmarshalTo(msg))
+	require.Equal(t, testFromAddress, msg.FromAddress)
+	require.Equal(t, testToAddress, msg.ToAddress)
+	require.Equal(t, "10", msg.Amount[0].Amount)
+
+	// the unset flags default to the gas limit and no fees
+	out, err = runChainCommand(t, "tx", "bank", "send", testFromAddress, testToAddress, "10foo", "--"+flagUnsignedTx)
+	require.NoError(t, err)
+
+	var res map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &res))
+	fee := res["auth_info"].(map[string]any)["fee"].(map[string]any)
+	require.Equal(t, "200000", fee["gas_limit"])
+	require.Empty(t, fee["amount"])
+}
+
+func TestTxCommandErrors(t *testing.T) {
+	_, err := runChainCommand(t, "tx", "bank", "send", testFromAddress, testToAddress, "10foo", "--"+flagUnsignedTx, "--"+flagFees, "foo")
+	require.ErrorContains(t, err, "invalid fees")
+
+	_, err = runChainCommand(t, "tx", "bank", "send", testFromAddress)
+	require.ErrorContains(t, err, "requires at least 2 arg(s)")
+}
+
+func TestTxCommandListAndMessageFlags(t *testing.T) {
+	out, err := runChainCommand(t, "tx", "bank", "set-send-enabled",
+		"--authority", testFromAddress,
+		"--send-enabled", `{"denom":"foo","enabled":true}`,
+		"--send-enabled", `{"denom":"bar"}`,
+		"--use-default-for", "baz,qux")
+	require.NoError(t, err)
+
+	msg := &bankv1beta1.MsgSetSendEnabled{}
+	require.NoError(t, protojson.Unmarshal([]byte(out), msg))
+	require.True(t, proto.Equal(&bankv1beta1.MsgSetSendEnabled{
+		Authority: testFromAddress,
+		SendEnabled: []*bankv1beta1.SendEnabled{
+			{Denom: "foo", Enabled: true},
+			{Denom: "bar"},
+		},
+		UseDefaultFor: []string{"baz", "qux"},
+	}, msg), out)
+
+	out, err = runChainCommand(t, "tx", "bank", "update-params",
+		"--authority", testFromAddress,
+		"--params", `{"default_send_enabled":true}`)
+	require.NoError(t, err)
+
+	updateParams := &bankv1beta1.MsgUpdateParams{}
+	require.NoError(t, protojson.Unmarshal([]byte(out), updateParams))
+	require.True(t, updateParams.Params.DefaultSendEnabled, out)
+}
@@ -65,14 +65,22 @@ func GetChainRegistryEntry(chain string) (*ChainRegistryEntry, error) {
 	return data, nil
 }
 
-func SelectGRPCEndpoints(chain string) (string, error) {
+// SelectGRPCEndpoints prompts for the gRPC endpoint of the chain. When it is
+// selected from the chain registry, it is followed by the other endpoints of
+// the registry, used as fallbacks.
+func SelectGRPCEndpoints(chain string) ([]string, error) {
 	entry, err := GetChainRegistryEntry(chain)
 	if err != nil {
 		fmt.Printf("Unable to load data for %s in the chain registry. Specify a custom gRPC endpoint manually.\n", chain)
 		prompt := &promptui.Prompt{
 			Label: "Enter a gRPC endpoint that you trust",
 		}
-		return prompt.Run()
+		endpoint, err := prompt.Run()
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{endpoint}, nil
 	}
 
 	var items []string
@@ -89,13 +97,20 @@ func SelectGRPCEndpoints(chain string) (string, error) {
 
 	i, ep, err := prompt.Run()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// user selected a custom endpoint
 	if i == -1 {
-		return ep, nil
+		return []string{ep}, nil
+	}
+
+	endpoints := []string{entry.APIs.GRPC[i].Address}
+	for j, apiEntry := range entry.APIs.GRPC {
+		if j != i {
+			endpoints = append(endpoints, apiEntry.Address)
+		}
 	}
 
-	return entry.APIs.GRPC[i].Address, nil
+	return endpoints, nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -20,9 +21,12 @@ import (
 )
 
 var (
-	flagInsecure = "insecure"
-	flagUpdate   = "update"
-	flagConfig   = "config"
+	flagInsecure     = "insecure"
+	flagUpdate       = "update"
+	flagConfig       = "config"
+	flagEndpoints    = "endpoints"
+	flagUseVersion   = "use-version"
+	flagListVersions = "list-versions"
 )
 
 func RootCommand() (*cobra.Command, error) {
@@ -71,6 +75,7 @@ If the chain is not listed in the chain registry, you can use any unique name.`,
 	}
 
 	cmd.Flags().BoolVar(&insecure, flagInsecure, false, "allow setting up insecure gRPC connection")
+	cmd.Flags().StringSlice(flagEndpoints, nil, "gRPC endpoints of the chain, tried in order, instead of selecting one from the chain registry")
 
 	return cmd
 }
@@ -104,12 +109,15 @@ func RemoteCommand(config *Config, configDir string) ([]*cobra.Command, error) {
 				return chainInfo.OpenClient()
 			},
 			AddQueryConnFlags: func(command *cobra.Command) {},
+			AddTxConnFlags:    func(command *cobra.Command) {},
 		}
 
 		var (
-			update   bool
-			reconfig bool
-			insecure bool
+			update       bool
+			reconfig     bool
+			insecure     bool
+			useVersion   string
+			listVersions bool
 		)
 
 		chainCmd := &cobra.Command{
@@ -121,7 +129,16 @@ func RemoteCommand(config *Config, configDir string) ([]*cobra.Command, error) {
 					return reconfigure(cmd, config, configDir, chain)
 				case update:
 					cmd.Printf("Updating autocli data for %s\n", chain)
-					return chainInfo.Load(true)
+					if err := chainInfo.Load(true); err != nil {
+						return err
+					}
+
+					cmd.Printf("Cached autocli data for %s version %s\n", chain, chainConfig.AppVersion)
+					return SaveConfig(configDir, config)
+				case useVersion != "":
+					return switchVersion(cmd, config, configDir, chainInfo, useVersion)
+				case listVersions:
+					return printVersions(cmd, chainInfo)
 				default:
 					return cmd.Help()
 				}
@@ -130,11 +147,18 @@ func RemoteCommand(config *Config, configDir string) ([]*cobra.Command, error) {
 		chainCmd.Flags().BoolVar(&update, flagUpdate, false, "update the CLI commands for the selected chain (should be used after every chain upgrade)")
 		chainCmd.Flags().BoolVar(&reconfig, flagConfig, false, "re-configure the selected chain (allows choosing a new gRPC endpoint and refreshes data")
 		chainCmd.Flags().BoolVar(&insecure, flagInsecure, false, "allow re-configuring the selected chain using an insecure gRPC connection")
+		chainCmd.Flags().StringSlice(flagEndpoints, nil, "gRPC endpoints used when re-configuring the selected chain, tried in order")
+		chainCmd.Flags().StringVar(&useVersion, flagUseVersion, "", "use the cached autocli data of another version of the selected chain, without connecting to it")
+		chainCmd.Flags().BoolVar(&listVersions, flagListVersions, false, "list the versions of the selected chain with cached autocli data")
 
 		if err := appOpts.EnhanceRootCommandWithBuilder(chainCmd, builder); err != nil {
 			return nil, err
 		}
 
+		if err := addOfflineTxCommands(chainCmd, chainInfo, &builder.Builder); err != nil {
+			return nil, err
+		}
+
 		commands = append(commands, chainCmd)
 	}
 
@@ -154,31 +178,37 @@ func RemoteErrorCommand(config *Config, configDir, chain string, chainConfig *Ch
 	}
 
 	cmd.Flags().Bool(flagInsecure, chainConfig.GRPCEndpoints[0].Insecure, "allow setting up insecure gRPC connection")
+	cmd.Flags().StringSlice(flagEndpoints, nil, "gRPC endpoints of the chain, tried in order, instead of selecting one from the chain registry")
 
 	return cmd
 }
 
 func reconfigure(cmd *cobra.Command, config *Config, configDir, chain string) error {
 	insecure, _ := cmd.Flags().GetBool(flagInsecure)
+	endpoints, _ := cmd.Flags().GetStringSlice(flagEndpoints)
 
 	cmd.Printf("Configuring %s\n", chain)
-	endpoint, err := SelectGRPCEndpoints(chain)
-	if err != nil {
-		return err
+	if len(endpoints) == 0 {
+		var err error
+		if endpoints, err = SelectGRPCEndpoints(chain); err != nil {
+			return err
+		}
 	}
 
-	cmd.Printf("%s endpoint selected\n", endpoint)
-	chainConfig := &ChainConfig{
-		GRPCEndpoints: []GRPCEndpoint{
-			{
-				Endpoint: endpoint,
-				Insecure: insecure,
-			},
-		},
+	cmd.Printf("%s endpoint selected\n", endpoints[0])
+	if len(endpoints) > 1 {
+		cmd.Printf("Fallback endpoints: %s\n", strings.Join(endpoints[1:], ", "))
+	}
+	chainConfig := &ChainConfig{}
+	for _, endpoint := range endpoints {
+		chainConfig.GRPCEndpoints = append(chainConfig.GRPCEndpoints, GRPCEndpoint{
+			Endpoint: endpoint,
+			Insecure: insecure,
+		})
 	}
 
 	chainInfo := NewChainInfo(configDir, chain, chainConfig)
-	if err = chainInfo.Load(true); err != nil {
+	if err := chainInfo.Load(true); err != nil {
 		return err
 	}
 
@@ -203,6 +233,44 @@ func reconfigure(cmd *cobra.Command, config *Config, configDir, chain string) er
 	return nil
 }
 
+// switchVersion makes the cached autocli data of the given version the ones
+// used for the chain.
+func switchVersion(cmd *cobra.Command, config *Config, configDir string, chainInfo *ChainInfo, version string) error {
+	versions, err := chainInfo.CachedVersions()
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(versions, version) {
+		return fmt.Errorf("no cached data for %s version %s, cached versions: %v", chainInfo.Chain, version, versions)
+	}
+
+	chainInfo.Config.AppVersion = version
+	if err := SaveConfig(configDir, config); err != nil {
+		return err
+	}
+
+	cmd.Printf("Using autocli data for %s version %s\n", chainInfo.Chain, version)
+	return nil
+}
+
+func printVersions(cmd *cobra.Command, chainInfo *ChainInfo) error {
+	versions, err := chainInfo.CachedVersions()
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		if version == chainInfo.Config.AppVersion {
+			cmd.Printf("* %s\n", version)
+		} else {
+			cmd.Printf("  %s\n", version)
+		}
+	}
+
+	return nil
+}
+
 type dynamicTypeResolver struct {
 	*ChainInfo
 }
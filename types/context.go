@@ -65,6 +65,7 @@ type Context struct {
 	streamingManager     storetypes.StreamingManager
 	cometInfo            comet.BlockInfo
 	headerInfo           header.Info
+	profiler             Profiler
 }
 
 // Proposed rename, not done to avoid API breakage
@@ -93,6 +94,7 @@ func (c Context) TransientKVGasConfig() storetypes.GasConfig    { return c.trans
 func (c Context) StreamingManager() storetypes.StreamingManager { return c.streamingManager }
 func (c Context) CometInfo() comet.BlockInfo                    { return c.cometInfo }
 func (c Context) HeaderInfo() header.Info                       { return c.headerInfo }
+func (c Context) Profiler() Profiler                            { return c.profiler }
 
 // clone the header before returning
 func (c Context) BlockHeader() cmtproto.Header {
@@ -319,6 +321,12 @@ func (c Context) WithHeaderInfo(headerInfo header.Info) Context {
 	return c
 }
 
+// WithProfiler returns a Context with an updated profiler
+func (c Context) WithProfiler(p Profiler) Context {
+	c.profiler = p
+	return c
+}
+
 // TODO: remove???
 func (c Context) IsZero() bool {
 	return c.ms == nil
@@ -140,6 +140,10 @@ var (
 	// supplied.
 	ErrInvalidGasLimit = errorsmod.Register(RootCodespace, 41, "invalid gas limit")
 
+	// ErrTxTimeout defines an error for when an unordered tx is rejected due to
+	// its timeout timestamp.
+	ErrTxTimeout = errorsmod.Register(RootCodespace, 42, "tx timeout")
+
 	// ErrPanic should only be set when we recovering from a panic
 	ErrPanic = errorsmod.ErrPanic
 )
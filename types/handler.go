@@ -1,5 +1,10 @@
 package types
 
+import (
+	"fmt"
+	"time"
+)
+
 // AnteHandler authenticates transactions, before their internal messages are handled.
 // If newCtx.IsZero(), ctx is used instead.
 type AnteHandler func(ctx Context, tx Tx, simulate bool) (newCtx Context, err error)
@@ -45,6 +50,9 @@ func ChainAnteDecorators(chain ...AnteDecorator) AnteHandler {
 	for i := 0; i < len(chain); i++ {
 		ii := i
 		handlerChain[ii] = func(ctx Context, tx Tx, simulate bool) (Context, error) {
+			if ctx.Profiler() != nil {
+				return profileAnteDecorator(ctx, tx, simulate, chain[ii], handlerChain[ii+1])
+			}
 			return chain[ii].AnteHandle(ctx, tx, simulate, handlerChain[ii+1])
 		}
 	}
@@ -52,6 +60,35 @@ func ChainAnteDecorators(chain ...AnteDecorator) AnteHandler {
 	return handlerChain[0]
 }
 
+// profileAnteDecorator runs the decorator and records the gas it consumed and
+// the time it took in the profiler of the context, excluding the decorators
+// further along the chain.
+func profileAnteDecorator(ctx Context, tx Tx, simulate bool, decorator AnteDecorator, next AnteHandler) (Context, error) {
+	var (
+		nestedGas  uint64
+		nestedTime time.Duration
+	)
+	profiledNext := func(ctx Context, tx Tx, simulate bool) (Context, error) {
+		start, gas := time.Now(), gasConsumed(ctx.GasMeter())
+		newCtx, err := next(ctx, tx, simulate)
+		nestedTime, nestedGas = time.Since(start), gasConsumedSince(ctx.GasMeter(), gas, newCtx)
+		return newCtx, err
+	}
+
+	start, gas := time.Now(), gasConsumed(ctx.GasMeter())
+	newCtx, err := decorator.AnteHandle(ctx, tx, simulate, profiledNext)
+
+	consumed := gasConsumedSince(ctx.GasMeter(), gas, newCtx)
+	if consumed > nestedGas {
+		consumed -= nestedGas
+	} else {
+		consumed = 0
+	}
+	ctx.Profiler().Record(ProfileCategoryAnte, fmt.Sprintf("%T", decorator), consumed, time.Since(start)-nestedTime)
+
+	return newCtx, err
+}
+
 // ChainPostDecorators chains PostDecorators together with each PostDecorator
 // wrapping over the decorators further along chain and returns a single PostHandler.
 //
@@ -2,10 +2,13 @@ package types_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	storetypes "cosmossdk.io/store/types"
+
 	"github.com/cosmos/cosmos-sdk/testutil/mock"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -34,6 +37,55 @@ func TestChainAnteDecorators(t *testing.T) {
 	require.NoError(t, err)
 }
 
+type gasAnteDecorator uint64
+
+func (d gasAnteDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	ctx.GasMeter().ConsumeGas(uint64(d), "test")
+	return next(ctx, tx, simulate)
+}
+
+type setUpGasAnteDecorator uint64
+
+func (d setUpGasAnteDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	ctx = ctx.WithGasMeter(storetypes.NewGasMeter(1000))
+	ctx.GasMeter().ConsumeGas(uint64(d), "test")
+	return next(ctx, tx, simulate)
+}
+
+type profileRecord struct {
+	category, name string
+	gas            uint64
+}
+
+type testProfiler struct {
+	records []profileRecord
+}
+
+func (p *testProfiler) Record(category, name string, gas uint64, _ time.Duration) {
+	p.records = append(p.records, profileRecord{category: category, name: name, gas: gas})
+}
+
+func TestChainAnteDecoratorsProfiler(t *testing.T) {
+	profiler := &testProfiler{}
+	ctx := sdk.Context{}.WithGasMeter(storetypes.NewInfiniteGasMeter()).WithProfiler(profiler)
+
+	newCtx, err := sdk.ChainAnteDecorators(
+		gasAnteDecorator(3),
+		setUpGasAnteDecorator(5),
+		gasAnteDecorator(10),
+	)(ctx, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15), newCtx.GasMeter().GasConsumed())
+
+	// the records exclude the gas of the decorators further along the chain,
+	// innermost decorators are recorded first
+	require.Equal(t, []profileRecord{
+		{category: sdk.ProfileCategoryAnte, name: "types_test.gasAnteDecorator", gas: 10},
+		{category: sdk.ProfileCategoryAnte, name: "types_test.setUpGasAnteDecorator", gas: 5},
+		{category: sdk.ProfileCategoryAnte, name: "types_test.gasAnteDecorator", gas: 3},
+	}, profiler.records)
+}
+
 func TestChainPostDecorators(t *testing.T) {
 	// test panic when passing an empty sclice of PostDecorators
 	require.Nil(t, sdk.ChainPostDecorators([]sdk.PostDecorator{}...))
@@ -0,0 +1,408 @@
+package mempool
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	journalOpInsert byte = iota + 1
+	journalOpRemove
+
+	// journalCompactThreshold is the number of records above which the journal
+	// is compacted when it holds more than twice as many records as pending txs.
+	journalCompactThreshold = 1024
+)
+
+// JournalEntry is a tx recorded as inserted and not removed by a Journal.
+type JournalEntry struct {
+	Tx         []byte
+	InsertedAt time.Time
+
+	seq uint64
+}
+
+// Journal is a write-ahead log of the txs inserted into and removed from a
+// mempool, so that the pending txs survive a restart. Records are appended to
+// a single file, which is compacted to the pending txs once most of its
+// records are obsolete. Appended records reach the disk once Sync is called,
+// e.g. on every commit. A record truncated by a crash is ignored when opening
+// the journal.
+type Journal struct {
+	mtx     sync.Mutex
+	path    string
+	file    *os.File
+	pending map[[sha256.Size]byte]JournalEntry
+	seq     uint64
+	records int
+}
+
+// OpenJournal opens the journal at the given path, creating it if needed, and
+// loads the pending txs it records.
+func OpenJournal(path string) (*Journal, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, err
+	}
+
+	j := &Journal{
+		path:    path,
+		pending: make(map[[sha256.Size]byte]JournalEntry),
+	}
+
+	valid, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	// drop a partially written last record
+	if err := file.Truncate(valid); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(valid, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	j.file = file
+
+	return j, nil
+}
+
+// load reads the records of the journal file and returns the size of its
+// valid prefix.
+func (j *Journal) load() (int64, error) {
+	file, err := os.Open(j.path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var (
+		reader = bufio.NewReader(file)
+		valid  int64
+	)
+	for {
+		op, at, payload, n, err := readJournalRecord(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return valid, nil
+			}
+			return 0, fmt.Errorf("failed to read mempool journal %s: %w", j.path, err)
+		}
+		valid += n
+
+		switch op {
+		case journalOpInsert:
+			j.addPending(payload, at)
+		case journalOpRemove:
+			var hash [sha256.Size]byte
+			copy(hash[:], payload)
+			delete(j.pending, hash)
+		default:
+			return 0, fmt.Errorf("invalid mempool journal %s: unknown record type %d", j.path, op)
+		}
+		j.records++
+	}
+}
+
+func (j *Journal) addPending(txBz []byte, at time.Time) {
+	j.seq++
+	j.pending[sha256.Sum256(txBz)] = JournalEntry{Tx: txBz, InsertedAt: at, seq: j.seq}
+}
+
+// Insert records the insertion of the tx at the given time.
+func (j *Journal) Insert(txBz []byte, at time.Time) error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	if _, ok := j.pending[sha256.Sum256(txBz)]; ok {
+		return nil
+	}
+
+	if err := j.append(journalOpInsert, at, txBz); err != nil {
+		return err
+	}
+	j.addPending(txBz, at)
+
+	return nil
+}
+
+// Remove records the removal of the tx. Txs that are not pending are ignored.
+func (j *Journal) Remove(txBz []byte) error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	hash := sha256.Sum256(txBz)
+	if _, ok := j.pending[hash]; !ok {
+		return nil
+	}
+
+	if err := j.append(journalOpRemove, time.Now(), hash[:]); err != nil {
+		return err
+	}
+	delete(j.pending, hash)
+
+	if j.records > journalCompactThreshold && j.records > 2*len(j.pending) {
+		return j.compact()
+	}
+
+	return nil
+}
+
+// Pending returns the pending txs in insertion order.
+func (j *Journal) Pending() []JournalEntry {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	entries := make([]JournalEntry, 0, len(j.pending))
+	for _, entry := range j.pending {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].seq < entries[b].seq })
+
+	return entries
+}
+
+// Compact rewrites the journal with the insertion records of the pending txs
+// only.
+func (j *Journal) Compact() error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	return j.compact()
+}
+
+func (j *Journal) compact() error {
+	entries := make([]JournalEntry, 0, len(j.pending))
+	for _, entry := range j.pending {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(a, b int) bool { return entries[a].seq < entries[b].seq })
+
+	tmpPath := j.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(tmp)
+	for _, entry := range entries {
+		if _, err := writer.Write(encodeJournalRecord(journalOpInsert, entry.InsertedAt, entry.Tx)); err != nil {
+			_ = tmp.Close()
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := j.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, j.path); err != nil {
+		return err
+	}
+
+	j.file, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	j.records = len(entries)
+
+	return nil
+}
+
+// Sync commits the records appended to the journal to stable storage.
+func (j *Journal) Sync() error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	return j.file.Sync()
+}
+
+// Close closes the journal file.
+func (j *Journal) Close() error {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+
+	return j.file.Close()
+}
+
+func (j *Journal) append(op byte, at time.Time, payload []byte) error {
+	if _, err := j.file.Write(encodeJournalRecord(op, at, payload)); err != nil {
+		return err
+	}
+	j.records++
+
+	return nil
+}
+
+// encodeJournalRecord encodes a record as its type, its unix time in
+// nanoseconds, the uvarint length of the payload and the payload.
+func encodeJournalRecord(op byte, at time.Time, payload []byte) []byte {
+	bz := make([]byte, 1+8+binary.MaxVarintLen64+len(payload))
+	bz[0] = op
+	binary.BigEndian.PutUint64(bz[1:9], uint64(at.UnixNano()))
+	n := binary.PutUvarint(bz[9:], uint64(len(payload)))
+	copy(bz[9+n:], payload)
+
+	return bz[:9+n+len(payload)]
+}
+
+func readJournalRecord(reader *bufio.Reader) (op byte, at time.Time, payload []byte, n int64, err error) {
+	var header [9]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return 0, time.Time{}, nil, 0, err
+	}
+
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, time.Time{}, nil, 0, err
+	}
+
+	payload = make([]byte, size)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, time.Time{}, nil, 0, err
+	}
+
+	var varint [binary.MaxVarintLen64]byte
+	n = int64(len(header) + binary.PutUvarint(varint[:], size) + len(payload))
+
+	return header[0], time.Unix(0, int64(binary.BigEndian.Uint64(header[1:]))), payload, n, nil
+}
+
+var _ ExtMempool = (*JournaledMempool)(nil)
+
+// JournaledMempool wraps a mempool and records the txs inserted into and
+// removed from it in a Journal. On startup, Replay inserts the pending txs of
+// the journal back into the mempool.
+type JournaledMempool struct {
+	Mempool
+
+	journal   *Journal
+	txEncoder sdk.TxEncoder
+	replaying atomic.Bool
+}
+
+// NewJournaledMempool returns a mempool journaling the txs of the given
+// mempool. The encoder must return the bytes the txs were decoded from.
+func NewJournaledMempool(mp Mempool, journal *Journal, txEncoder sdk.TxEncoder) *JournaledMempool {
+	return &JournaledMempool{
+		Mempool:   mp,
+		journal:   journal,
+		txEncoder: txEncoder,
+	}
+}
+
+// Unwrap returns the journaled mempool.
+func (mp *JournaledMempool) Unwrap() Mempool {
+	return mp.Mempool
+}
+
+// Journal returns the journal of the mempool.
+func (mp *JournaledMempool) Journal() *Journal {
+	return mp.journal
+}
+
+// Insert inserts the tx into the mempool and records it in the journal.
+func (mp *JournaledMempool) Insert(ctx context.Context, tx sdk.Tx) error {
+	if err := mp.Mempool.Insert(ctx, tx); err != nil {
+		return err
+	}
+
+	// replayed txs are already pending in the journal
+	if mp.replaying.Load() {
+		return nil
+	}
+
+	txBz, err := mp.txEncoder(tx)
+	if err != nil {
+		return err
+	}
+
+	return mp.journal.Insert(txBz, time.Now())
+}
+
+// Remove removes the tx from the mempool and records its removal in the
+// journal.
+func (mp *JournaledMempool) Remove(tx sdk.Tx) error {
+	if err := mp.Mempool.Remove(tx); err != nil {
+		return err
+	}
+
+	txBz, err := mp.txEncoder(tx)
+	if err != nil {
+		return err
+	}
+
+	return mp.journal.Remove(txBz)
+}
+
+// SelectBy implements ExtMempool.
+func (mp *JournaledMempool) SelectBy(ctx context.Context, txs [][]byte, callback func(sdk.Tx) bool) {
+	SelectBy(ctx, mp.Mempool, txs, callback)
+}
+
+// Replay passes the pending txs of the journal inserted less than maxAge
+// before now to checkTx, in insertion order, which is expected to insert them
+// back into the mempool. Older txs and txs rejected by checkTx are removed
+// from the journal. A zero maxAge replays all pending txs. It returns the
+// number of replayed txs.
+func (mp *JournaledMempool) Replay(maxAge time.Duration, now time.Time, checkTx func(txBz []byte) error) (int, error) {
+	mp.replaying.Store(true)
+	defer mp.replaying.Store(false)
+
+	replayed := 0
+	for _, entry := range mp.journal.Pending() {
+		if maxAge > 0 && now.Sub(entry.InsertedAt) > maxAge {
+			if err := mp.journal.Remove(entry.Tx); err != nil {
+				return replayed, err
+			}
+			continue
+		}
+
+		if err := checkTx(entry.Tx); err != nil {
+			if err := mp.journal.Remove(entry.Tx); err != nil {
+				return replayed, err
+			}
+			continue
+		}
+		replayed++
+	}
+
+	return replayed, mp.journal.Compact()
+}
@@ -0,0 +1,146 @@
+package mempool_test
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+func journalPayloads(entries []mempool.JournalEntry) []string {
+	payloads := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		payloads = append(payloads, string(entry.Tx))
+	}
+	return payloads
+}
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "mempool.journal")
+	now := time.Unix(1_700_000_000, 0)
+
+	journal, err := mempool.OpenJournal(path)
+	require.NoError(t, err)
+	require.Empty(t, journal.Pending())
+
+	require.NoError(t, journal.Insert([]byte("tx1"), now))
+	require.NoError(t, journal.Insert([]byte("tx2"), now.Add(time.Second)))
+	require.NoError(t, journal.Insert([]byte("tx3"), now.Add(2*time.Second)))
+	require.NoError(t, journal.Remove([]byte("tx2")))
+	// unknown txs are ignored
+	require.NoError(t, journal.Remove([]byte("tx4")))
+	require.NoError(t, journal.Sync())
+	require.NoError(t, journal.Close())
+
+	// simulate a crash while appending a record
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = file.Write([]byte{1, 0, 0})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	journal, err = mempool.OpenJournal(path)
+	require.NoError(t, err)
+	pending := journal.Pending()
+	require.Equal(t, []string{"tx1", "tx3"}, journalPayloads(pending))
+	require.True(t, now.Equal(pending[0].InsertedAt))
+
+	require.NoError(t, journal.Insert([]byte("tx5"), now))
+	require.NoError(t, journal.Compact())
+	require.NoError(t, journal.Close())
+
+	journal, err = mempool.OpenJournal(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"tx1", "tx3", "tx5"}, journalPayloads(journal.Pending()))
+	require.NoError(t, journal.Close())
+}
+
+func TestJournalCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mempool.journal")
+
+	journal, err := mempool.OpenJournal(path)
+	require.NoError(t, err)
+
+	for i := 0; i < 2000; i++ {
+		tx := []byte{byte(i >> 8), byte(i)}
+		require.NoError(t, journal.Insert(tx, time.Now()))
+		if i%100 != 0 {
+			require.NoError(t, journal.Remove(tx))
+		}
+	}
+	require.Len(t, journal.Pending(), 20)
+	require.NoError(t, journal.Close())
+
+	// the removed txs have been compacted away, a removal record is 42 bytes
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Less(t, info.Size(), int64(1025*42))
+
+	journal, err = mempool.OpenJournal(path)
+	require.NoError(t, err)
+	require.Len(t, journal.Pending(), 20)
+	require.NoError(t, journal.Close())
+}
+
+func TestJournaledMempool(t *testing.T) {
+	ctx := sdk.NewContext(nil, cmtproto.Header{}, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 3)
+	path := filepath.Join(t.TempDir(), "mempool.journal")
+
+	// txs are encoded as their id
+	txs := make(map[string]testTx)
+	encoder := func(tx sdk.Tx) ([]byte, error) {
+		bz := []byte{byte(tx.(testTx).id)}
+		txs[string(bz)] = tx.(testTx)
+		return bz, nil
+	}
+
+	journal, err := mempool.OpenJournal(path)
+	require.NoError(t, err)
+	mp := mempool.NewJournaledMempool(mempool.DefaultPriorityMempool(), journal, encoder)
+
+	for i, account := range accounts {
+		require.NoError(t, mp.Insert(ctx, testTx{id: i, address: account.Address}))
+	}
+	require.NoError(t, mp.Remove(testTx{id: 1, address: accounts[1].Address}))
+	require.ErrorIs(t, mp.Remove(testTx{id: 1, address: accounts[1].Address}), mempool.ErrTxNotFound)
+	require.Equal(t, []string{"\x00", "\x02"}, journalPayloads(journal.Pending()))
+	require.NoError(t, journal.Close())
+
+	// restart with an empty mempool, tx 2 is rejected by CheckTx
+	journal, err = mempool.OpenJournal(path)
+	require.NoError(t, err)
+	mp = mempool.NewJournaledMempool(mempool.DefaultPriorityMempool(), journal, encoder)
+
+	replayed, err := mp.Replay(time.Hour, time.Now(), func(txBz []byte) error {
+		if txBz[0] == 2 {
+			return errors.New("invalid sequence")
+		}
+		return mp.Insert(ctx, txs[string(txBz)])
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, replayed)
+	require.Equal(t, 1, mp.CountTx())
+	require.Equal(t, []string{"\x00"}, journalPayloads(journal.Pending()))
+
+	// txs older than the max age are dropped
+	replayed, err = mp.Replay(time.Hour, time.Now().Add(2*time.Hour), func([]byte) error {
+		t.Fatal("expired tx replayed")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Zero(t, replayed)
+	require.Empty(t, journal.Pending())
+	require.NoError(t, journal.Close())
+}
@@ -0,0 +1,171 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ Mempool = (*LanedMempool)(nil)
+
+// ErrNoLane is returned when inserting a tx matched by none of the lanes.
+var ErrNoLane = errors.New("tx does not match any mempool lane")
+
+// Lane is a mempool holding the txs matched by Match, e.g. oracle or
+// governance txs, which are given at most a share of each block.
+type Lane struct {
+	// Name identifies the lane.
+	Name string
+
+	// Mempool holds the txs of the lane, in the lane's order.
+	Mempool Mempool
+
+	// Match returns true if the tx belongs to the lane. A nil Match matches
+	// all txs, which is the behavior expected of the last, default, lane.
+	Match func(ctx context.Context, tx sdk.Tx) bool
+
+	// MaxBlockSpace is the share of the block max tx bytes and max gas the
+	// txs of the lane may use. A nil or zero share gives the lane the whole
+	// block.
+	MaxBlockSpace math.LegacyDec
+}
+
+// Matches returns true if the tx belongs to the lane.
+func (l Lane) Matches(ctx context.Context, tx sdk.Tx) bool {
+	return l.Match == nil || l.Match(ctx, tx)
+}
+
+// Space returns the part of the given block limit available to the lane.
+func (l Lane) Space(limit uint64) uint64 {
+	if l.MaxBlockSpace.IsNil() || l.MaxBlockSpace.IsZero() {
+		return limit
+	}
+
+	return l.MaxBlockSpace.MulInt(math.NewIntFromUint64(limit)).TruncateInt().Uint64()
+}
+
+// LanedMempool is a mempool made of lanes in priority order. A tx is inserted
+// into the first lane matching it, and blocks are filled lane after lane, each
+// lane within its share of the block.
+type LanedMempool struct {
+	lanes []Lane
+}
+
+// NewLanedMempool returns a mempool made of the given lanes, in priority
+// order. It panics if the lanes are invalid.
+func NewLanedMempool(lanes ...Lane) *LanedMempool {
+	if len(lanes) == 0 {
+		panic("laned mempool requires at least one lane")
+	}
+
+	names := make(map[string]struct{}, len(lanes))
+	for _, lane := range lanes {
+		if lane.Mempool == nil {
+			panic(fmt.Sprintf("lane %s has no mempool", lane.Name))
+		}
+		if _, ok := names[lane.Name]; ok {
+			panic(fmt.Sprintf("duplicate lane %s", lane.Name))
+		}
+		names[lane.Name] = struct{}{}
+
+		if !lane.MaxBlockSpace.IsNil() && (lane.MaxBlockSpace.IsNegative() || lane.MaxBlockSpace.GT(math.LegacyOneDec())) {
+			panic(fmt.Sprintf("lane %s max block space must be between 0 and 1, got %s", lane.Name, lane.MaxBlockSpace))
+		}
+	}
+
+	return &LanedMempool{lanes: lanes}
+}
+
+// Lanes returns the lanes of the mempool in priority order.
+func (m *LanedMempool) Lanes() []Lane {
+	return m.lanes
+}
+
+// LaneIndex returns the index of the first lane matching the tx, or -1 if no
+// lane matches it.
+func (m *LanedMempool) LaneIndex(ctx context.Context, tx sdk.Tx) int {
+	for i, lane := range m.lanes {
+		if lane.Matches(ctx, tx) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Insert inserts the tx into the first lane matching it.
+func (m *LanedMempool) Insert(ctx context.Context, tx sdk.Tx) error {
+	i := m.LaneIndex(ctx, tx)
+	if i < 0 {
+		return ErrNoLane
+	}
+
+	return m.lanes[i].Mempool.Insert(ctx, tx)
+}
+
+// Select returns an iterator over the txs of all lanes, in lane order.
+func (m *LanedMempool) Select(ctx context.Context, txs [][]byte) Iterator {
+	return newLanesIterator(ctx, m.lanes, txs)
+}
+
+// CountTx returns the number of txs in all lanes.
+func (m *LanedMempool) CountTx() int {
+	count := 0
+	for _, lane := range m.lanes {
+		count += lane.Mempool.CountTx()
+	}
+
+	return count
+}
+
+// Remove removes the tx from the lane holding it. As the lane of a tx may
+// depend on the context it was inserted with, all lanes are tried.
+func (m *LanedMempool) Remove(tx sdk.Tx) error {
+	for _, lane := range m.lanes {
+		err := lane.Mempool.Remove(tx)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrTxNotFound) {
+			return err
+		}
+	}
+
+	return ErrTxNotFound
+}
+
+// lanesIterator chains the iterators of the lanes.
+type lanesIterator struct {
+	ctx   context.Context
+	lanes []Lane
+	txs   [][]byte
+	iter  Iterator
+}
+
+func newLanesIterator(ctx context.Context, lanes []Lane, txs [][]byte) Iterator {
+	for i, lane := range lanes {
+		if iter := lane.Mempool.Select(ctx, txs); iter != nil {
+			return &lanesIterator{ctx: ctx, lanes: lanes[i+1:], txs: txs, iter: iter}
+		}
+	}
+
+	return nil
+}
+
+// Next implements Iterator.
+func (i *lanesIterator) Next() Iterator {
+	if i.iter = i.iter.Next(); i.iter != nil {
+		return i
+	}
+
+	return newLanesIterator(i.ctx, i.lanes, i.txs)
+}
+
+// Tx implements Iterator.
+func (i *lanesIterator) Tx() sdk.Tx {
+	return i.iter.Tx()
+}
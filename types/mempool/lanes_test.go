@@ -0,0 +1,88 @@
+package mempool_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+func TestLanedMempool(t *testing.T) {
+	ctx := sdk.NewContext(nil, cmtproto.Header{}, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 2)
+	oracle := accounts[0].Address
+
+	mp := mempool.NewLanedMempool(
+		mempool.Lane{
+			Name:    "oracle",
+			Mempool: mempool.DefaultPriorityMempool(),
+			Match: func(_ context.Context, tx sdk.Tx) bool {
+				return tx.(testTx).address.Equals(oracle)
+			},
+			MaxBlockSpace: math.LegacyNewDecWithPrec(1, 1),
+		},
+		mempool.Lane{Name: "default", Mempool: mempool.DefaultPriorityMempool()},
+	)
+
+	txs := []testTx{
+		{id: 0, priority: 10, nonce: 0, address: accounts[1].Address},
+		{id: 1, priority: 1, nonce: 0, address: oracle},
+		{id: 2, priority: 20, nonce: 1, address: accounts[1].Address},
+		{id: 3, priority: 2, nonce: 1, address: oracle},
+	}
+	for _, tx := range txs {
+		require.NoError(t, mp.Insert(ctx.WithPriority(tx.priority), tx))
+	}
+	require.Equal(t, 4, mp.CountTx())
+	require.Equal(t, 2, mp.Lanes()[0].Mempool.CountTx())
+	require.Equal(t, 0, mp.LaneIndex(ctx, txs[1]))
+	require.Equal(t, 1, mp.LaneIndex(ctx, txs[0]))
+
+	// the oracle lane comes first, whatever the priorities of the other lanes
+	var ids []int
+	for iter := mp.Select(ctx, nil); iter != nil; iter = iter.Next() {
+		ids = append(ids, iter.Tx().(testTx).id)
+	}
+	require.Equal(t, []int{1, 3, 0, 2}, ids)
+
+	require.NoError(t, mp.Remove(txs[3]))
+	require.NoError(t, mp.Remove(txs[0]))
+	require.ErrorIs(t, mp.Remove(txs[0]), mempool.ErrTxNotFound)
+	require.Equal(t, 2, mp.CountTx())
+
+	require.Equal(t, uint64(100), mp.Lanes()[0].Space(1000))
+	require.Equal(t, uint64(1000), mp.Lanes()[1].Space(1000))
+}
+
+func TestLanedMempoolNoLane(t *testing.T) {
+	ctx := sdk.NewContext(nil, cmtproto.Header{}, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 1)
+
+	mp := mempool.NewLanedMempool(mempool.Lane{
+		Name:    "none",
+		Mempool: mempool.DefaultPriorityMempool(),
+		Match:   func(context.Context, sdk.Tx) bool { return false },
+	})
+	require.ErrorIs(t, mp.Insert(ctx, testTx{address: accounts[0].Address}), mempool.ErrNoLane)
+	require.Nil(t, mp.Select(ctx, nil))
+
+	require.Panics(t, func() { mempool.NewLanedMempool() })
+	require.Panics(t, func() {
+		mempool.NewLanedMempool(mempool.Lane{Name: "a", Mempool: mempool.NoOpMempool{}, MaxBlockSpace: math.LegacyNewDec(2)})
+	})
+	require.Panics(t, func() {
+		mempool.NewLanedMempool(
+			mempool.Lane{Name: "a", Mempool: mempool.NoOpMempool{}},
+			mempool.Lane{Name: "a", Mempool: mempool.NoOpMempool{}},
+		)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/stretchr/testify/require"
@@ -12,11 +13,13 @@ import (
 
 	"cosmossdk.io/log"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/mempool"
 	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
 	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	txsigning "github.com/cosmos/cosmos-sdk/types/tx/signing"
 	"github.com/cosmos/cosmos-sdk/x/auth/signing"
 	"github.com/cosmos/cosmos-sdk/x/distribution"
@@ -102,6 +105,21 @@ func (sigErrTx) GetPubKeys() ([]cryptotypes.PubKey, error) { return nil, nil }
 
 func (t sigErrTx) GetSignaturesV2() ([]txsigning.SignatureV2, error) { return t.getSigs() }
 
+// unorderedTestTx is a testTx carrying an ExtensionOptionUnordered.
+type unorderedTestTx struct {
+	testTx
+	timeout time.Time
+}
+
+func (tx unorderedTestTx) GetExtensionOptions() []*codectypes.Any {
+	opt, err := txtypes.NewUnorderedExtensionOption(tx.timeout)
+	if err != nil {
+		panic(err)
+	}
+
+	return []*codectypes.Any{opt}
+}
+
 type txSpec struct {
 	i int
 	p int
@@ -200,6 +218,57 @@ func (s *MempoolTestSuite) TestDefaultMempool() {
 	require.Equal(t, 1, s.mempool.CountTx())
 }
 
+func TestUnorderedTxs(t *testing.T) {
+	ctx := sdk.NewContext(nil, cmtproto.Header{}, false, log.NewNopLogger())
+	sender := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 1)[0].Address
+	now := time.Now()
+
+	mempools := map[string]func() mempool.Mempool{
+		"priority nonce": func() mempool.Mempool { return mempool.DefaultPriorityMempool() },
+		"sender nonce":   func() mempool.Mempool { return mempool.NewSenderNonceMempool(mempool.SenderNonceMaxTxOpt(5000)) },
+	}
+	for name, newMempool := range mempools {
+		t.Run(name, func(t *testing.T) {
+			mp := newMempool()
+
+			// the unordered txs of a sender all carry its current sequence,
+			// they are keyed by their timeout instead
+			var txs []unorderedTestTx
+			for i := 0; i < 3; i++ {
+				tx := unorderedTestTx{
+					testTx:  testTx{id: i, address: sender, nonce: 0, priority: 1},
+					timeout: now.Add(time.Duration(i+1) * time.Second),
+				}
+				txs = append(txs, tx)
+				require.NoError(t, mp.Insert(ctx, tx))
+			}
+			require.Equal(t, 3, mp.CountTx())
+
+			// an ordered tx with the same sequence doesn't replace them
+			require.NoError(t, mp.Insert(ctx, testTx{address: sender, nonce: 0, priority: 1}))
+			require.Equal(t, 4, mp.CountTx())
+
+			// an unordered tx with the same timeout replaces the pending one
+			require.NoError(t, mp.Insert(ctx, unorderedTestTx{
+				testTx:  testTx{id: 3, address: sender, nonce: 1, priority: 1},
+				timeout: txs[0].timeout,
+			}))
+			require.Equal(t, 4, mp.CountTx())
+
+			require.NoError(t, mp.Remove(txs[1]))
+			require.Equal(t, 3, mp.CountTx())
+			require.ErrorIs(t, mp.Remove(txs[1]), mempool.ErrTxNotFound)
+
+			// the ordered tx is selected first, then the unordered txs by timeout
+			sel := fetchTxs(mp.Select(ctx, nil), 10)
+			require.Len(t, sel, 3)
+			require.IsType(t, testTx{}, sel[0])
+			require.Equal(t, 3, sel[1].(unorderedTestTx).id)
+			require.Equal(t, 2, sel[2].(unorderedTestTx).id)
+		})
+	}
+}
+
 type MempoolTestSuite struct {
 	suite.Suite
 	numTxs      int
@@ -137,7 +137,13 @@ func (snm *SenderNonceMempool) Insert(_ context.Context, tx sdk.Tx) error {
 
 	sig := sigs[0]
 	sender := sdk.AccAddress(sig.PubKey.Address()).String()
-	nonce := sig.Sequence
+	nonce, unordered, err := unorderedNonce(tx)
+	if err != nil {
+		return err
+	}
+	if !unordered {
+		nonce = sig.Sequence
+	}
 
 	senderTxs, found := snm.senders[sender]
 	if !found {
@@ -225,7 +231,13 @@ func (snm *SenderNonceMempool) Remove(tx sdk.Tx) error {
 
 	sig := sigs[0]
 	sender := sdk.AccAddress(sig.PubKey.Address()).String()
-	nonce := sig.Sequence
+	nonce, unordered, err := unorderedNonce(tx)
+	if err != nil {
+		return err
+	}
+	if !unordered {
+		nonce = sig.Sequence
+	}
 
 	senderTxs, found := snm.senders[sender]
 	if !found {
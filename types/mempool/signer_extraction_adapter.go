@@ -43,7 +43,8 @@ func NewDefaultSignerExtractionAdapter() DefaultSignerExtractionAdapter {
 	return DefaultSignerExtractionAdapter{}
 }
 
-// GetSigners implements the Adapter interface
+// GetSigners implements the Adapter interface. The sequence of the signers of
+// an unordered tx is its nonce derived from its timeout.
 func (DefaultSignerExtractionAdapter) GetSigners(tx sdk.Tx) ([]SignerData, error) {
 	sigTx, ok := tx.(signing.SigVerifiableTx)
 	if !ok {
@@ -55,11 +56,20 @@ func (DefaultSignerExtractionAdapter) GetSigners(tx sdk.Tx) ([]SignerData, error
 		return nil, err
 	}
 
+	nonce, unordered, err := unorderedNonce(tx)
+	if err != nil {
+		return nil, err
+	}
+
 	signers := make([]SignerData, len(sigs))
 	for i, sig := range sigs {
+		sequence := sig.Sequence
+		if unordered {
+			sequence = nonce
+		}
 		signers[i] = NewSignerData(
 			sig.PubKey.Address().Bytes(),
-			sig.Sequence,
+			sequence,
 		)
 	}
 
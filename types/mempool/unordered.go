@@ -0,0 +1,34 @@
+package mempool
+
+import (
+	"fmt"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// unorderedNonce returns the nonce of an unordered tx in the mempools. As it
+// isn't bound to the sequence of its signers, it is the Unix time in
+// nanoseconds of its timeout, so that the unordered txs of a sender don't
+// replace each other. ok is false if the tx is ordered.
+func unorderedNonce(tx sdk.Tx) (nonce uint64, ok bool, err error) {
+	extTx, isExtTx := tx.(interface {
+		GetExtensionOptions() []*codectypes.Any
+	})
+	if !isExtTx {
+		return 0, false, nil
+	}
+
+	timeout, unordered, err := txtypes.GetUnorderedTimeout(extTx.GetExtensionOptions())
+	if err != nil || !unordered {
+		return 0, false, err
+	}
+
+	ns := timeout.UnixNano()
+	if ns < 0 {
+		return 0, false, fmt.Errorf("invalid unordered tx timeout timestamp %s", timeout)
+	}
+
+	return uint64(ns), true, nil
+}
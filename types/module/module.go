@@ -39,6 +39,7 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/maps"
 
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/core/genesis"
 	errorsmod "cosmossdk.io/errors"
@@ -225,6 +226,13 @@ type HasConsensusVersion interface {
 	ConsensusVersion() uint64
 }
 
+// HasCollectionsSchema is the interface for modules storing their state with
+// collections. It exposes the schemas of the module stores, keyed by store
+// name, so that tooling can decode the keys and values of the stores.
+type HasCollectionsSchema interface {
+	CollectionsSchemas() map[string]collections.Schema
+}
+
 // HasABCIEndblock is a released typo of HasABCIEndBlock.
 // Deprecated: use HasABCIEndBlock instead.
 type HasABCIEndblock HasABCIEndBlock
@@ -775,7 +783,10 @@ func (m *Manager) BeginBlock(ctx sdk.Context) (sdk.BeginBlock, error) {
 	ctx = ctx.WithEventManager(sdk.NewEventManager())
 	for _, moduleName := range m.OrderBeginBlockers {
 		if module, ok := m.Modules[moduleName].(appmodule.HasBeginBlocker); ok {
-			if err := module.BeginBlock(ctx); err != nil {
+			err := sdk.ProfileFunc(ctx, sdk.ProfileCategoryBeginBlock, moduleName, func() error {
+				return module.BeginBlock(ctx)
+			})
+			if err != nil {
 				return sdk.BeginBlock{}, err
 			}
 		}
@@ -795,12 +806,18 @@ func (m *Manager) EndBlock(ctx sdk.Context) (sdk.EndBlock, error) {
 
 	for _, moduleName := range m.OrderEndBlockers {
 		if module, ok := m.Modules[moduleName].(appmodule.HasEndBlocker); ok {
-			err := module.EndBlock(ctx)
+			err := sdk.ProfileFunc(ctx, sdk.ProfileCategoryEndBlock, moduleName, func() error {
+				return module.EndBlock(ctx)
+			})
 			if err != nil {
 				return sdk.EndBlock{}, err
 			}
 		} else if module, ok := m.Modules[moduleName].(HasABCIEndBlock); ok {
-			moduleValUpdates, err := module.EndBlock(ctx)
+			var moduleValUpdates []abci.ValidatorUpdate
+			err := sdk.ProfileFunc(ctx, sdk.ProfileCategoryEndBlock, moduleName, func() (err error) {
+				moduleValUpdates, err = module.EndBlock(ctx)
+				return err
+			})
 			if err != nil {
 				return sdk.EndBlock{}, err
 			}
@@ -869,6 +886,21 @@ func (m *Manager) GetVersionMap() VersionMap {
 	return vermap
 }
 
+// CollectionsSchemas returns the collections schemas of the modules
+// implementing HasCollectionsSchema, keyed by store name.
+func (m *Manager) CollectionsSchemas() map[string]collections.Schema {
+	schemas := make(map[string]collections.Schema)
+	for _, mod := range m.Modules {
+		if mod, ok := mod.(HasCollectionsSchema); ok {
+			for storeName, schema := range mod.CollectionsSchemas() {
+				schemas[storeName] = schema
+			}
+		}
+	}
+
+	return schemas
+}
+
 // ModuleNames returns list of all module names, without any particular order.
 func (m *Manager) ModuleNames() []string {
 	return maps.Keys(m.Modules)
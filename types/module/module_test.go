@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -548,6 +549,43 @@ func TestCoreAPIManager_EndBlock(t *testing.T) {
 	require.EqualError(t, err, "some error")
 }
 
+type blockHookProfiler struct {
+	names []string
+}
+
+func (p *blockHookProfiler) Record(category, name string, _ uint64, _ time.Duration) {
+	p.names = append(p.names, category+"/"+name)
+}
+
+func TestCoreAPIManager_BlockHooksProfiler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	t.Cleanup(mockCtrl.Finish)
+
+	mockAppModule1 := mock.NewMockCoreAppModule(mockCtrl)
+	mockAppModule2 := mock.NewMockCoreAppModule(mockCtrl)
+	mm := module.NewManagerFromMap(map[string]appmodule.AppModule{
+		"module1": mockAppModule1,
+		"module2": mockAppModule2,
+	})
+
+	profiler := &blockHookProfiler{}
+	ctx := sdk.Context{}.WithProfiler(profiler)
+
+	mockAppModule1.EXPECT().BeginBlock(gomock.Any()).Times(1).Return(nil)
+	mockAppModule2.EXPECT().BeginBlock(gomock.Any()).Times(1).Return(nil)
+	mockAppModule1.EXPECT().EndBlock(gomock.Any()).Times(1).Return(nil)
+	mockAppModule2.EXPECT().EndBlock(gomock.Any()).Times(1).Return(errors.New("some error"))
+	_, err := mm.BeginBlock(ctx)
+	require.NoError(t, err)
+	_, err = mm.EndBlock(ctx)
+	require.EqualError(t, err, "some error")
+
+	require.Equal(t, []string{
+		"begin_block/module1", "begin_block/module2",
+		"end_block/module1", "end_block/module2",
+	}, profiler.names)
+}
+
 func TestManager_PrepareCheckState(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	t.Cleanup(mockCtrl.Finish)
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"time"
+
+	storetypes "cosmossdk.io/store/types"
+)
+
+// Profiler categories recorded by the SDK.
+const (
+	ProfileCategoryAnte       = "ante"
+	ProfileCategoryMsg        = "msg"
+	ProfileCategoryBeginBlock = "begin_block"
+	ProfileCategoryEndBlock   = "end_block"
+)
+
+// Profiler records the gas consumed and the wall time spent by a unit of work,
+// such as an ante decorator, a Msg handler or a module's block hook, identified
+// by a category and a name. A Profiler must be safe for concurrent use.
+type Profiler interface {
+	Record(category, name string, gas uint64, elapsed time.Duration)
+}
+
+// ProfileFunc runs fn and records the gas it consumed on the gas meter of the
+// context and the time it took in the profiler of the context, if any.
+func ProfileFunc(ctx Context, category, name string, fn func() error) error {
+	profiler := ctx.Profiler()
+	if profiler == nil {
+		return fn()
+	}
+
+	start, gas := time.Now(), gasConsumed(ctx.GasMeter())
+	err := fn()
+	profiler.Record(category, name, gasConsumedSince(ctx.GasMeter(), gas, ctx), time.Since(start))
+
+	return err
+}
+
+func gasConsumed(meter storetypes.GasMeter) uint64 {
+	if meter == nil {
+		return 0
+	}
+
+	return meter.GasConsumed()
+}
+
+// gasConsumedSince returns the gas consumed since the given meter consumed gas.
+// When the context returned by the profiled work holds another gas meter, the
+// work has set it up and all of the gas consumed on it is attributed to the
+// work as well.
+func gasConsumedSince(meter storetypes.GasMeter, gas uint64, after Context) uint64 {
+	var consumed uint64
+	if current := gasConsumed(meter); current > gas {
+		consumed = current - gas
+	}
+
+	if afterMeter := after.GasMeter(); afterMeter != nil && afterMeter != meter {
+		consumed += afterMeter.GasConsumed()
+	}
+
+	return consumed
+}
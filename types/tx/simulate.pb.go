@@ -0,0 +1,2304 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/tx/v1beta1/simulate.proto
+
+package tx
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/cosmos/cosmos-proto"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/cosmos/cosmos-sdk/types/tx/amino"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// BalanceOverride replaces the balances of an account with the given coins.
+// The supply is not adjusted.
+type BalanceOverride struct {
+	// address is the account address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// coins are the balances of the account.
+	Coins github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,2,rep,name=coins,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"coins"`
+}
+
+func (m *BalanceOverride) Reset()         { *m = BalanceOverride{} }
+func (m *BalanceOverride) String() string { return proto.CompactTextString(m) }
+func (*BalanceOverride) ProtoMessage()    {}
+func (*BalanceOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d9a795bced3ae0, []int{0}
+}
+func (m *BalanceOverride) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BalanceOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BalanceOverride.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BalanceOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BalanceOverride.Merge(m, src)
+}
+func (m *BalanceOverride) XXX_Size() int {
+	return m.Size()
+}
+func (m *BalanceOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_BalanceOverride.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BalanceOverride proto.InternalMessageInfo
+
+func (m *BalanceOverride) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *BalanceOverride) GetCoins() github_com_cosmos_cosmos_sdk_types.Coins {
+	if m != nil {
+		return m.Coins
+	}
+	return nil
+}
+
+// SequenceOverride sets the sequence of an account, creating the account if it
+// doesn't exist.
+type SequenceOverride struct {
+	// address is the account address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// sequence is the sequence of the account.
+	Sequence uint64 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (m *SequenceOverride) Reset()         { *m = SequenceOverride{} }
+func (m *SequenceOverride) String() string { return proto.CompactTextString(m) }
+func (*SequenceOverride) ProtoMessage()    {}
+func (*SequenceOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d9a795bced3ae0, []int{1}
+}
+func (m *SequenceOverride) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SequenceOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SequenceOverride.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SequenceOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SequenceOverride.Merge(m, src)
+}
+func (m *SequenceOverride) XXX_Size() int {
+	return m.Size()
+}
+func (m *SequenceOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_SequenceOverride.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SequenceOverride proto.InternalMessageInfo
+
+func (m *SequenceOverride) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SequenceOverride) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+// StoreOverride sets the value of a key in the store of a module, or deletes
+// the key.
+type StoreOverride struct {
+	// store is the name of the module store.
+	Store string `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	// key is the store key.
+	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// value is the value of the key, ignored if delete is set.
+	Value []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// delete deletes the key.
+	Delete bool `protobuf:"varint,4,opt,name=delete,proto3" json:"delete,omitempty"`
+}
+
+func (m *StoreOverride) Reset()         { *m = StoreOverride{} }
+func (m *StoreOverride) String() string { return proto.CompactTextString(m) }
+func (*StoreOverride) ProtoMessage()    {}
+func (*StoreOverride) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d9a795bced3ae0, []int{2}
+}
+func (m *StoreOverride) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StoreOverride) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StoreOverride.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StoreOverride) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreOverride.Merge(m, src)
+}
+func (m *StoreOverride) XXX_Size() int {
+	return m.Size()
+}
+func (m *StoreOverride) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreOverride.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreOverride proto.InternalMessageInfo
+
+func (m *StoreOverride) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
+func (m *StoreOverride) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StoreOverride) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *StoreOverride) GetDelete() bool {
+	if m != nil {
+		return m.Delete
+	}
+	return false
+}
+
+// StateOverrides are the hypothetical changes to the state a transaction is
+// simulated against. Balances and sequences are applied first, then the store
+// overrides.
+type StateOverrides struct {
+	Balances  []BalanceOverride  `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances"`
+	Sequences []SequenceOverride `protobuf:"bytes,2,rep,name=sequences,proto3" json:"sequences"`
+	Stores    []StoreOverride    `protobuf:"bytes,3,rep,name=stores,proto3" json:"stores"`
+}
+
+func (m *StateOverrides) Reset()         { *m = StateOverrides{} }
+func (m *StateOverrides) String() string { return proto.CompactTextString(m) }
+func (*StateOverrides) ProtoMessage()    {}
+func (*StateOverrides) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d9a795bced3ae0, []int{3}
+}
+func (m *StateOverrides) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StateOverrides) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StateOverrides.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StateOverrides) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateOverrides.Merge(m, src)
+}
+func (m *StateOverrides) XXX_Size() int {
+	return m.Size()
+}
+func (m *StateOverrides) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateOverrides.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateOverrides proto.InternalMessageInfo
+
+func (m *StateOverrides) GetBalances() []BalanceOverride {
+	if m != nil {
+		return m.Balances
+	}
+	return nil
+}
+
+func (m *StateOverrides) GetSequences() []SequenceOverride {
+	if m != nil {
+		return m.Sequences
+	}
+	return nil
+}
+
+func (m *StateOverrides) GetStores() []StoreOverride {
+	if m != nil {
+		return m.Stores
+	}
+	return nil
+}
+
+// StoreChange is a key of a module store changed by a simulated transaction.
+type StoreChange struct {
+	// store is the name of the module store.
+	Store string `protobuf:"bytes,1,opt,name=store,proto3" json:"store,omitempty"`
+	// key is the store key.
+	Key []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// old_value is the value of the key after the overrides were applied.
+	OldValue []byte `protobuf:"bytes,3,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	// new_value is the value of the key after the transaction.
+	NewValue []byte `protobuf:"bytes,4,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	// deleted is set if the transaction deleted the key.
+	Deleted bool `protobuf:"varint,5,opt,name=deleted,proto3" json:"deleted,omitempty"`
+}
+
+func (m *StoreChange) Reset()         { *m = StoreChange{} }
+func (m *StoreChange) String() string { return proto.CompactTextString(m) }
+func (*StoreChange) ProtoMessage()    {}
+func (*StoreChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d9a795bced3ae0, []int{4}
+}
+func (m *StoreChange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StoreChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StoreChange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StoreChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StoreChange.Merge(m, src)
+}
+func (m *StoreChange) XXX_Size() int {
+	return m.Size()
+}
+func (m *StoreChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_StoreChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StoreChange proto.InternalMessageInfo
+
+func (m *StoreChange) GetStore() string {
+	if m != nil {
+		return m.Store
+	}
+	return ""
+}
+
+func (m *StoreChange) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (m *StoreChange) GetOldValue() []byte {
+	if m != nil {
+		return m.OldValue
+	}
+	return nil
+}
+
+func (m *StoreChange) GetNewValue() []byte {
+	if m != nil {
+		return m.NewValue
+	}
+	return nil
+}
+
+func (m *StoreChange) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
+// SimulateWithOverridesRequest is the request type for the
+// SimulateService.SimulateWithOverrides RPC method.
+type SimulateWithOverridesRequest struct {
+	// tx_bytes is the raw transaction.
+	TxBytes []byte `protobuf:"bytes,1,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	// height is the height of the state to simulate the transaction against,
+	// or zero for the latest state.
+	Height int64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// overrides are the state overrides applied before the simulation.
+	Overrides StateOverrides `protobuf:"bytes,3,opt,name=overrides,proto3" json:"overrides"`
+}
+
+func (m *SimulateWithOverridesRequest) Reset()         { *m = SimulateWithOverridesRequest{} }
+func (m *SimulateWithOverridesRequest) String() string { return proto.CompactTextString(m) }
+func (*SimulateWithOverridesRequest) ProtoMessage()    {}
+func (*SimulateWithOverridesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d9a795bced3ae0, []int{5}
+}
+func (m *SimulateWithOverridesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SimulateWithOverridesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SimulateWithOverridesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SimulateWithOverridesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateWithOverridesRequest.Merge(m, src)
+}
+func (m *SimulateWithOverridesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SimulateWithOverridesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateWithOverridesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulateWithOverridesRequest proto.InternalMessageInfo
+
+func (m *SimulateWithOverridesRequest) GetTxBytes() []byte {
+	if m != nil {
+		return m.TxBytes
+	}
+	return nil
+}
+
+func (m *SimulateWithOverridesRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SimulateWithOverridesRequest) GetOverrides() StateOverrides {
+	if m != nil {
+		return m.Overrides
+	}
+	return StateOverrides{}
+}
+
+// SimulateWithOverridesResponse is the response type for the
+// SimulateService.SimulateWithOverrides RPC method.
+type SimulateWithOverridesResponse struct {
+	// gas_info is the information about gas used in the simulation.
+	GasInfo *types.GasInfo `protobuf:"bytes,1,opt,name=gas_info,json=gasInfo,proto3" json:"gas_info,omitempty"`
+	// result is the result of the simulation.
+	Result *types.Result `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	// state_diff are the store keys changed by the transaction, sorted by store
+	// name and key.
+	StateDiff []StoreChange `protobuf:"bytes,3,rep,name=state_diff,json=stateDiff,proto3" json:"state_diff"`
+}
+
+func (m *SimulateWithOverridesResponse) Reset()         { *m = SimulateWithOverridesResponse{} }
+func (m *SimulateWithOverridesResponse) String() string { return proto.CompactTextString(m) }
+func (*SimulateWithOverridesResponse) ProtoMessage()    {}
+func (*SimulateWithOverridesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c6d9a795bced3ae0, []int{6}
+}
+func (m *SimulateWithOverridesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SimulateWithOverridesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SimulateWithOverridesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SimulateWithOverridesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimulateWithOverridesResponse.Merge(m, src)
+}
+func (m *SimulateWithOverridesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SimulateWithOverridesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimulateWithOverridesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimulateWithOverridesResponse proto.InternalMessageInfo
+
+func (m *SimulateWithOverridesResponse) GetGasInfo() *types.GasInfo {
+	if m != nil {
+		return m.GasInfo
+	}
+	return nil
+}
+
+func (m *SimulateWithOverridesResponse) GetResult() *types.Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *SimulateWithOverridesResponse) GetStateDiff() []StoreChange {
+	if m != nil {
+		return m.StateDiff
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*BalanceOverride)(nil), "cosmos.tx.v1beta1.BalanceOverride")
+	proto.RegisterType((*SequenceOverride)(nil), "cosmos.tx.v1beta1.SequenceOverride")
+	proto.RegisterType((*StoreOverride)(nil), "cosmos.tx.v1beta1.StoreOverride")
+	proto.RegisterType((*StateOverrides)(nil), "cosmos.tx.v1beta1.StateOverrides")
+	proto.RegisterType((*StoreChange)(nil), "cosmos.tx.v1beta1.StoreChange")
+	proto.RegisterType((*SimulateWithOverridesRequest)(nil), "cosmos.tx.v1beta1.SimulateWithOverridesRequest")
+	proto.RegisterType((*SimulateWithOverridesResponse)(nil), "cosmos.tx.v1beta1.SimulateWithOverridesResponse")
+}
+
+func init() { proto.RegisterFile("cosmos/tx/v1beta1/simulate.proto", fileDescriptor_c6d9a795bced3ae0) }
+
+var fileDescriptor_c6d9a795bced3ae0 = []byte{
+	// 745 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xcf, 0x6b, 0x13, 0x4f,
+	0x14, 0xcf, 0x34, 0x69, 0x7e, 0x4c, 0xfb, 0xfd, 0xb6, 0x5d, 0xaa, 0x6c, 0xd3, 0xba, 0x8d, 0x5b,
+	0x84, 0x50, 0xe8, 0xae, 0x8d, 0x14, 0x44, 0x44, 0x31, 0xad, 0x14, 0x4f, 0xc2, 0x06, 0x14, 0xbc,
+	0x84, 0xd9, 0xec, 0x64, 0x33, 0x74, 0xb3, 0x13, 0x77, 0x26, 0x69, 0x7a, 0xf5, 0xe6, 0x4d, 0xf0,
+	0xe2, 0x9f, 0x20, 0x82, 0xa0, 0xe0, 0xbf, 0x20, 0xf4, 0x58, 0xf4, 0xe2, 0x41, 0xac, 0xb4, 0x82,
+	0xff, 0x86, 0xec, 0xcc, 0xec, 0x36, 0xb6, 0xa9, 0x16, 0xbc, 0x24, 0xf3, 0xe6, 0xbd, 0xcf, 0xe7,
+	0xbd, 0xf7, 0x79, 0x6f, 0x07, 0x56, 0x5a, 0x94, 0x75, 0x29, 0xb3, 0xf9, 0xd0, 0x1e, 0xac, 0xbb,
+	0x98, 0xa3, 0x75, 0x9b, 0x91, 0x6e, 0x3f, 0x40, 0x1c, 0x5b, 0xbd, 0x88, 0x72, 0xaa, 0xcd, 0xc9,
+	0x08, 0x8b, 0x0f, 0x2d, 0x15, 0x51, 0x5e, 0xf2, 0x29, 0xf5, 0x03, 0x6c, 0xa3, 0x1e, 0xb1, 0x51,
+	0x18, 0x52, 0x8e, 0x38, 0xa1, 0x21, 0x93, 0x80, 0xf2, 0xbc, 0x4f, 0x7d, 0x2a, 0x8e, 0x76, 0x7c,
+	0x52, 0xb7, 0x73, 0xa8, 0x4b, 0x42, 0x6a, 0x8b, 0x5f, 0x75, 0xb5, 0x20, 0x99, 0x9b, 0x32, 0x56,
+	0xa5, 0x91, 0xae, 0x15, 0x55, 0x96, 0x8b, 0x18, 0xb6, 0x91, 0xdb, 0x22, 0x69, 0x75, 0xb1, 0xa1,
+	0x82, 0x8c, 0xd1, 0xa0, 0xc4, 0xdf, 0xa2, 0x24, 0x94, 0x7e, 0xf3, 0x2d, 0x80, 0x33, 0x75, 0x14,
+	0xa0, 0xb0, 0x85, 0x1f, 0x0e, 0x70, 0x14, 0x11, 0x0f, 0x6b, 0x35, 0x58, 0x40, 0x9e, 0x17, 0x61,
+	0xc6, 0x74, 0x50, 0x01, 0xd5, 0x52, 0x5d, 0xff, 0xf4, 0x61, 0x6d, 0x5e, 0xe5, 0xbe, 0x27, 0x3d,
+	0x0d, 0x1e, 0x91, 0xd0, 0x77, 0x92, 0x40, 0xad, 0x0d, 0x27, 0x63, 0x56, 0xa6, 0x4f, 0x54, 0xb2,
+	0xd5, 0xa9, 0xda, 0x82, 0xa5, 0xc2, 0xe3, 0xbc, 0x89, 0x26, 0xd6, 0x26, 0x25, 0x61, 0x7d, 0x63,
+	0xff, 0xdb, 0x72, 0xe6, 0xcd, 0xe1, 0x72, 0xd5, 0x27, 0xbc, 0xd3, 0x77, 0xad, 0x16, 0xed, 0xaa,
+	0xbe, 0xd4, 0xdf, 0x1a, 0xf3, 0x76, 0x6c, 0xbe, 0xd7, 0xc3, 0x4c, 0x00, 0xd8, 0xeb, 0x9f, 0xef,
+	0x56, 0x81, 0x23, 0xe9, 0x4d, 0x17, 0xce, 0x36, 0xf0, 0xd3, 0x3e, 0xfe, 0xd7, 0x7a, 0xcb, 0xb0,
+	0xc8, 0x14, 0x8f, 0x3e, 0x51, 0x01, 0xd5, 0x9c, 0x93, 0xda, 0x26, 0x86, 0xff, 0x35, 0x38, 0x8d,
+	0x4e, 0x12, 0xcc, 0xc3, 0x49, 0x16, 0x5f, 0x48, 0x7a, 0x47, 0x1a, 0xda, 0x2c, 0xcc, 0xee, 0xe0,
+	0x3d, 0x81, 0x9e, 0x76, 0xe2, 0x63, 0x1c, 0x37, 0x40, 0x41, 0x1f, 0xeb, 0x59, 0x71, 0x27, 0x0d,
+	0xed, 0x32, 0xcc, 0x7b, 0x38, 0xc0, 0x1c, 0xeb, 0xb9, 0x0a, 0xa8, 0x16, 0x1d, 0x65, 0x99, 0x87,
+	0x00, 0xfe, 0xdf, 0xe0, 0x88, 0xa7, 0x79, 0x98, 0xb6, 0x05, 0x8b, 0xae, 0x1c, 0x46, 0xdc, 0x4a,
+	0x2c, 0xa4, 0x69, 0x9d, 0x59, 0x2d, 0xeb, 0xd4, 0xbc, 0xea, 0xb9, 0x58, 0x51, 0x27, 0x45, 0x6a,
+	0xdb, 0xb0, 0x94, 0xf4, 0x92, 0xcc, 0x63, 0x65, 0x0c, 0xcd, 0x69, 0x1d, 0x15, 0xcf, 0x09, 0x56,
+	0xbb, 0x03, 0xf3, 0xa2, 0x55, 0xa6, 0x67, 0x05, 0x4b, 0x65, 0x1c, 0xcb, 0xa8, 0x52, 0x8a, 0x42,
+	0xa1, 0xcc, 0xe7, 0x00, 0x4e, 0x09, 0xff, 0x66, 0x07, 0x85, 0xfe, 0xc5, 0x75, 0x5c, 0x84, 0x25,
+	0x1a, 0x78, 0xcd, 0x51, 0x2d, 0x8b, 0x34, 0xf0, 0x1e, 0x09, 0x39, 0x17, 0x61, 0x29, 0xc4, 0xbb,
+	0xca, 0x99, 0x93, 0xce, 0x10, 0xef, 0x4a, 0xa7, 0x0e, 0x0b, 0x52, 0x5d, 0x4f, 0x9f, 0x14, 0x62,
+	0x27, 0xa6, 0xf9, 0x0a, 0xc0, 0xa5, 0x86, 0xfa, 0x6a, 0x1f, 0x13, 0xde, 0x49, 0x45, 0x77, 0xe2,
+	0x76, 0x19, 0xd7, 0x16, 0x60, 0x91, 0x0f, 0x9b, 0xee, 0x1e, 0xc7, 0x72, 0x8d, 0xa6, 0x9d, 0x02,
+	0x1f, 0xd6, 0x63, 0x33, 0x9e, 0x60, 0x07, 0x13, 0xbf, 0xc3, 0x45, 0x91, 0x59, 0x47, 0x59, 0xda,
+	0x7d, 0x58, 0xa2, 0x09, 0x8d, 0xa8, 0x73, 0xaa, 0x76, 0x75, 0xac, 0x44, 0xa3, 0x43, 0x4e, 0x64,
+	0x4e, 0x91, 0xe6, 0x57, 0x00, 0xaf, 0x9c, 0x53, 0x1a, 0xeb, 0xd1, 0x90, 0x61, 0xed, 0x36, 0x2c,
+	0xfa, 0x88, 0x35, 0x49, 0xd8, 0xa6, 0x3a, 0xf8, 0x3d, 0x8f, 0xf8, 0xc0, 0xc4, 0x07, 0x9f, 0xa4,
+	0xdb, 0x46, 0xec, 0x41, 0xd8, 0xa6, 0x4e, 0xc1, 0x97, 0x07, 0xed, 0x26, 0xcc, 0x47, 0x98, 0xf5,
+	0x03, 0x59, 0xfe, 0xc8, 0x18, 0xcf, 0x62, 0x1d, 0x11, 0xe7, 0xa8, 0x78, 0x6d, 0x13, 0x42, 0x16,
+	0x17, 0xdf, 0xf4, 0x48, 0xbb, 0xad, 0x96, 0xc0, 0x38, 0x6f, 0x09, 0xe4, 0x90, 0xd3, 0x2d, 0x8a,
+	0x71, 0x5b, 0xa4, 0xdd, 0xae, 0x7d, 0x04, 0x70, 0x26, 0x69, 0xaf, 0x81, 0xa3, 0x01, 0x69, 0x61,
+	0xed, 0x3d, 0x80, 0x97, 0xc6, 0xb6, 0xac, 0xd9, 0xe3, 0xe8, 0xff, 0x30, 0xb7, 0xf2, 0xf5, 0x8b,
+	0x03, 0xa4, 0x9a, 0xe6, 0xc6, 0xb3, 0xcf, 0x3f, 0x5e, 0x4e, 0xd8, 0xe6, 0xaa, 0x7d, 0xfe, 0xc3,
+	0xde, 0xdc, 0x25, 0xbc, 0xd3, 0x4c, 0x67, 0x74, 0x0b, 0xac, 0xd6, 0xef, 0xee, 0x1f, 0x19, 0xe0,
+	0xe0, 0xc8, 0x00, 0xdf, 0x8f, 0x0c, 0xf0, 0xe2, 0xd8, 0xc8, 0x1c, 0x1c, 0x1b, 0x99, 0x2f, 0xc7,
+	0x46, 0xe6, 0xc9, 0xb5, 0xbf, 0x3f, 0x65, 0x36, 0x1f, 0xba, 0x79, 0xf1, 0xe4, 0xde, 0xf8, 0x35,
+	0x00, 0x36, 0x69, 0x2d, 0x45, 0x50, 0x06, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SimulateServiceClient is the client API for SimulateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SimulateServiceClient interface {
+	// SimulateWithOverrides simulates executing a transaction against the state
+	// at a given height after applying hypothetical state overrides, and returns
+	// the state changes of the transaction.
+	SimulateWithOverrides(ctx context.Context, in *SimulateWithOverridesRequest, opts ...grpc.CallOption) (*SimulateWithOverridesResponse, error)
+}
+
+type simulateServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewSimulateServiceClient(cc grpc1.ClientConn) SimulateServiceClient {
+	return &simulateServiceClient{cc}
+}
+
+func (c *simulateServiceClient) SimulateWithOverrides(ctx context.Context, in *SimulateWithOverridesRequest, opts ...grpc.CallOption) (*SimulateWithOverridesResponse, error) {
+	out := new(SimulateWithOverridesResponse)
+	err := c.cc.Invoke(ctx, "/cosmos.tx.v1beta1.SimulateService/SimulateWithOverrides", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SimulateServiceServer is the server API for SimulateService service.
+type SimulateServiceServer interface {
+	// SimulateWithOverrides simulates executing a transaction against the state
+	// at a given height after applying hypothetical state overrides, and returns
+	// the state changes of the transaction.
+	SimulateWithOverrides(context.Context, *SimulateWithOverridesRequest) (*SimulateWithOverridesResponse, error)
+}
+
+// UnimplementedSimulateServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedSimulateServiceServer struct {
+}
+
+func (*UnimplementedSimulateServiceServer) SimulateWithOverrides(ctx context.Context, req *SimulateWithOverridesRequest) (*SimulateWithOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulateWithOverrides not implemented")
+}
+
+func RegisterSimulateServiceServer(s grpc1.Server, srv SimulateServiceServer) {
+	s.RegisterService(&_SimulateService_serviceDesc, srv)
+}
+
+func _SimulateService_SimulateWithOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulateWithOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulateServiceServer).SimulateWithOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmos.tx.v1beta1.SimulateService/SimulateWithOverrides",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulateServiceServer).SimulateWithOverrides(ctx, req.(*SimulateWithOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var SimulateService_serviceDesc = _SimulateService_serviceDesc
+var _SimulateService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cosmos.tx.v1beta1.SimulateService",
+	HandlerType: (*SimulateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SimulateWithOverrides",
+			Handler:    _SimulateService_SimulateWithOverrides_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cosmos/tx/v1beta1/simulate.proto",
+}
+
+func (m *BalanceOverride) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BalanceOverride) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BalanceOverride) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Coins) > 0 {
+		for iNdEx := len(m.Coins) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Coins[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSimulate(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintSimulate(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SequenceOverride) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SequenceOverride) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SequenceOverride) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Sequence != 0 {
+		i = encodeVarintSimulate(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintSimulate(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StoreOverride) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreOverride) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StoreOverride) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Delete {
+		i--
+		if m.Delete {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintSimulate(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintSimulate(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Store) > 0 {
+		i -= len(m.Store)
+		copy(dAtA[i:], m.Store)
+		i = encodeVarintSimulate(dAtA, i, uint64(len(m.Store)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StateOverrides) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StateOverrides) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StateOverrides) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Stores) > 0 {
+		for iNdEx := len(m.Stores) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Stores[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSimulate(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Sequences) > 0 {
+		for iNdEx := len(m.Sequences) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Sequences[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSimulate(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Balances) > 0 {
+		for iNdEx := len(m.Balances) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Balances[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSimulate(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StoreChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StoreChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StoreChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Deleted {
+		i--
+		if m.Deleted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.NewValue) > 0 {
+		i -= len(m.NewValue)
+		copy(dAtA[i:], m.NewValue)
+		i = encodeVarintSimulate(dAtA, i, uint64(len(m.NewValue)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.OldValue) > 0 {
+		i -= len(m.OldValue)
+		copy(dAtA[i:], m.OldValue)
+		i = encodeVarintSimulate(dAtA, i, uint64(len(m.OldValue)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintSimulate(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Store) > 0 {
+		i -= len(m.Store)
+		copy(dAtA[i:], m.Store)
+		i = encodeVarintSimulate(dAtA, i, uint64(len(m.Store)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SimulateWithOverridesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SimulateWithOverridesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SimulateWithOverridesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Overrides.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintSimulate(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Height != 0 {
+		i = encodeVarintSimulate(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TxBytes) > 0 {
+		i -= len(m.TxBytes)
+		copy(dAtA[i:], m.TxBytes)
+		i = encodeVarintSimulate(dAtA, i, uint64(len(m.TxBytes)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SimulateWithOverridesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SimulateWithOverridesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SimulateWithOverridesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.StateDiff) > 0 {
+		for iNdEx := len(m.StateDiff) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.StateDiff[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSimulate(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Result != nil {
+		{
+			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSimulate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.GasInfo != nil {
+		{
+			size, err := m.GasInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSimulate(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSimulate(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSimulate(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *BalanceOverride) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	if len(m.Coins) > 0 {
+		for _, e := range m.Coins {
+			l = e.Size()
+			n += 1 + l + sovSimulate(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SequenceOverride) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	if m.Sequence != 0 {
+		n += 1 + sovSimulate(uint64(m.Sequence))
+	}
+	return n
+}
+
+func (m *StoreOverride) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Store)
+	if l > 0 {
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	if m.Delete {
+		n += 2
+	}
+	return n
+}
+
+func (m *StateOverrides) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Balances) > 0 {
+		for _, e := range m.Balances {
+			l = e.Size()
+			n += 1 + l + sovSimulate(uint64(l))
+		}
+	}
+	if len(m.Sequences) > 0 {
+		for _, e := range m.Sequences {
+			l = e.Size()
+			n += 1 + l + sovSimulate(uint64(l))
+		}
+	}
+	if len(m.Stores) > 0 {
+		for _, e := range m.Stores {
+			l = e.Size()
+			n += 1 + l + sovSimulate(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StoreChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Store)
+	if l > 0 {
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	l = len(m.OldValue)
+	if l > 0 {
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	l = len(m.NewValue)
+	if l > 0 {
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	if m.Deleted {
+		n += 2
+	}
+	return n
+}
+
+func (m *SimulateWithOverridesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxBytes)
+	if l > 0 {
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovSimulate(uint64(m.Height))
+	}
+	l = m.Overrides.Size()
+	n += 1 + l + sovSimulate(uint64(l))
+	return n
+}
+
+func (m *SimulateWithOverridesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GasInfo != nil {
+		l = m.GasInfo.Size()
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	if m.Result != nil {
+		l = m.Result.Size()
+		n += 1 + l + sovSimulate(uint64(l))
+	}
+	if len(m.StateDiff) > 0 {
+		for _, e := range m.StateDiff {
+			l = e.Size()
+			n += 1 + l + sovSimulate(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovSimulate(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSimulate(x uint64) (n int) {
+	return sovSimulate(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *BalanceOverride) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSimulate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BalanceOverride: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BalanceOverride: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Coins", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Coins = append(m.Coins, types.Coin{})
+			if err := m.Coins[len(m.Coins)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSimulate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SequenceOverride) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSimulate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SequenceOverride: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SequenceOverride: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSimulate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreOverride) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSimulate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreOverride: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreOverride: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Store", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Store = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Delete = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSimulate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StateOverrides) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSimulate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StateOverrides: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StateOverrides: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Balances", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Balances = append(m.Balances, BalanceOverride{})
+			if err := m.Balances[len(m.Balances)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequences", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sequences = append(m.Sequences, SequenceOverride{})
+			if err := m.Sequences[len(m.Sequences)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stores", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Stores = append(m.Stores, StoreOverride{})
+			if err := m.Stores[len(m.Stores)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSimulate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StoreChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSimulate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StoreChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StoreChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Store", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Store = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldValue", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldValue = append(m.OldValue[:0], dAtA[iNdEx:postIndex]...)
+			if m.OldValue == nil {
+				m.OldValue = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewValue", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewValue = append(m.NewValue[:0], dAtA[iNdEx:postIndex]...)
+			if m.NewValue == nil {
+				m.NewValue = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deleted = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSimulate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SimulateWithOverridesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSimulate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SimulateWithOverridesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SimulateWithOverridesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxBytes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxBytes = append(m.TxBytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.TxBytes == nil {
+				m.TxBytes = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Overrides", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Overrides.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSimulate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SimulateWithOverridesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSimulate
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SimulateWithOverridesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SimulateWithOverridesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.GasInfo == nil {
+				m.GasInfo = &types.GasInfo{}
+			}
+			if err := m.GasInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Result == nil {
+				m.Result = &types.Result{}
+			}
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StateDiff", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.StateDiff = append(m.StateDiff, StoreChange{})
+			if err := m.StateDiff[len(m.StateDiff)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSimulate(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSimulate
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipSimulate(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowSimulate
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSimulate
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthSimulate
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSimulate
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSimulate
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthSimulate        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSimulate          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSimulate = fmt.Errorf("proto: unexpected end of group")
+)
@@ -233,4 +233,5 @@ func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
 	registry.RegisterImplementations((*sdk.HasMsgs)(nil), &Tx{})
 
 	registry.RegisterInterface("cosmos.tx.v1beta1.TxExtensionOptionI", (*TxExtensionOptionI)(nil))
+	registry.RegisterImplementations((*TxExtensionOptionI)(nil), &ExtensionOptionUnordered{})
 }
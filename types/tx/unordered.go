@@ -0,0 +1,47 @@
+package tx
+
+import (
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// UnorderedTypeURL is the type URL of the ExtensionOptionUnordered extension
+// option.
+const UnorderedTypeURL = "/cosmos.tx.v1beta1.ExtensionOptionUnordered"
+
+// NewUnorderedExtensionOption returns the extension option marking a tx as
+// unordered until the given timeout.
+func NewUnorderedExtensionOption(timeout time.Time) (*types.Any, error) {
+	return types.NewAnyWithValue(&ExtensionOptionUnordered{TimeoutTimestamp: timeout.UTC()})
+}
+
+// GetUnorderedTimeout returns the timeout of an unordered tx given its
+// extension options. ok is false if the tx is ordered, i.e. it doesn't carry
+// an ExtensionOptionUnordered.
+func GetUnorderedTimeout(extOpts []*types.Any) (timeout time.Time, ok bool, err error) {
+	for _, opt := range extOpts {
+		if opt.TypeUrl != UnorderedTypeURL {
+			continue
+		}
+		if ok {
+			return time.Time{}, false, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "multiple unordered extension options")
+		}
+
+		unordered, isUnordered := opt.GetCachedValue().(*ExtensionOptionUnordered)
+		if !isUnordered {
+			unordered = &ExtensionOptionUnordered{}
+			if err := proto.Unmarshal(opt.Value, unordered); err != nil {
+				return time.Time{}, false, errorsmod.Wrap(sdkerrors.ErrTxDecode, err.Error())
+			}
+		}
+		timeout, ok = unordered.TimeoutTimestamp, true
+	}
+
+	return timeout, ok, nil
+}
@@ -0,0 +1,332 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/tx/v1beta1/unordered.proto
+
+package tx
+
+import (
+	fmt "fmt"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// ExtensionOptionUnordered is a TxBody extension option marking the tx as
+// unordered. An unordered tx is not bound to the sequence of its signers:
+// it neither has to carry their current sequence nor increments it. It is
+// instead protected against replays by rejecting the txs already executed
+// until its timeout.
+type ExtensionOptionUnordered struct {
+	// timeout_timestamp is the block time from which the tx is no longer
+	// valid. It must be after the block time, within a maximum duration set by
+	// the chain.
+	TimeoutTimestamp time.Time `protobuf:"bytes,1,opt,name=timeout_timestamp,json=timeoutTimestamp,proto3,stdtime" json:"timeout_timestamp"`
+}
+
+func (m *ExtensionOptionUnordered) Reset()         { *m = ExtensionOptionUnordered{} }
+func (m *ExtensionOptionUnordered) String() string { return proto.CompactTextString(m) }
+func (*ExtensionOptionUnordered) ProtoMessage()    {}
+func (*ExtensionOptionUnordered) Descriptor() ([]byte, []int) {
+	return fileDescriptor_29d147d236189c5a, []int{0}
+}
+func (m *ExtensionOptionUnordered) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExtensionOptionUnordered) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExtensionOptionUnordered.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExtensionOptionUnordered) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExtensionOptionUnordered.Merge(m, src)
+}
+func (m *ExtensionOptionUnordered) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExtensionOptionUnordered) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExtensionOptionUnordered.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExtensionOptionUnordered proto.InternalMessageInfo
+
+func (m *ExtensionOptionUnordered) GetTimeoutTimestamp() time.Time {
+	if m != nil {
+		return m.TimeoutTimestamp
+	}
+	return time.Time{}
+}
+
+func init() {
+	proto.RegisterType((*ExtensionOptionUnordered)(nil), "cosmos.tx.v1beta1.ExtensionOptionUnordered")
+}
+
+func init() { proto.RegisterFile("cosmos/tx/v1beta1/unordered.proto", fileDescriptor_29d147d236189c5a) }
+
+var fileDescriptor_29d147d236189c5a = []byte{
+	// 233 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x4c, 0xce, 0x2f, 0xce,
+	0xcd, 0x2f, 0xd6, 0x2f, 0xa9, 0xd0, 0x2f, 0x33, 0x4c, 0x4a, 0x2d, 0x49, 0x34, 0xd4, 0x2f, 0xcd,
+	0xcb, 0x2f, 0x4a, 0x49, 0x2d, 0x4a, 0x4d, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x84,
+	0x28, 0xd1, 0x2b, 0xa9, 0xd0, 0x83, 0x2a, 0x91, 0x12, 0x49, 0xcf, 0x4f, 0xcf, 0x07, 0xcb, 0xea,
+	0x83, 0x58, 0x10, 0x85, 0x52, 0xf2, 0xe9, 0xf9, 0xf9, 0xe9, 0x39, 0xa9, 0xfa, 0x60, 0x5e, 0x52,
+	0x69, 0x9a, 0x7e, 0x49, 0x66, 0x6e, 0x6a, 0x71, 0x49, 0x62, 0x6e, 0x01, 0x44, 0x81, 0x52, 0x2e,
+	0x97, 0x84, 0x6b, 0x45, 0x49, 0x6a, 0x5e, 0x71, 0x66, 0x7e, 0x9e, 0x7f, 0x41, 0x49, 0x66, 0x7e,
+	0x5e, 0x28, 0xcc, 0x2e, 0xa1, 0x40, 0x2e, 0x41, 0x90, 0xf2, 0xfc, 0xd2, 0x92, 0x78, 0xb8, 0x36,
+	0x09, 0x46, 0x05, 0x46, 0x0d, 0x6e, 0x23, 0x29, 0x3d, 0x88, 0xc1, 0x7a, 0x30, 0x83, 0xf5, 0x42,
+	0x60, 0x2a, 0x9c, 0x38, 0x4e, 0xdc, 0x93, 0x67, 0x98, 0x70, 0x5f, 0x9e, 0x31, 0x48, 0x00, 0xaa,
+	0x1d, 0x21, 0x67, 0x7f, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31,
+	0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1, 0x1c, 0xc3, 0x8d, 0xc7, 0x72, 0x0c, 0x51, 0xaa, 0xe9,
+	0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0xd0, 0x00, 0x80, 0x50, 0xba, 0xc5,
+	0x29, 0xd9, 0xfa, 0x25, 0x95, 0x05, 0xa9, 0xa0, 0x10, 0x49, 0x62, 0x03, 0x5b, 0x68, 0x0c, 0x18,
+	0x00, 0x1b, 0x73, 0x6a, 0xcf, 0x25, 0x01, 0x00, 0x00,
+}
+
+func (m *ExtensionOptionUnordered) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExtensionOptionUnordered) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExtensionOptionUnordered) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n1, err1 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.TimeoutTimestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.TimeoutTimestamp):])
+	if err1 != nil {
+		return 0, err1
+	}
+	i -= n1
+	i = encodeVarintUnordered(dAtA, i, uint64(n1))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintUnordered(dAtA []byte, offset int, v uint64) int {
+	offset -= sovUnordered(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ExtensionOptionUnordered) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.TimeoutTimestamp)
+	n += 1 + l + sovUnordered(uint64(l))
+	return n
+}
+
+func sovUnordered(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozUnordered(x uint64) (n int) {
+	return sovUnordered(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ExtensionOptionUnordered) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowUnordered
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExtensionOptionUnordered: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExtensionOptionUnordered: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutTimestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowUnordered
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthUnordered
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthUnordered
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.TimeoutTimestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipUnordered(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthUnordered
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipUnordered(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowUnordered
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowUnordered
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowUnordered
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthUnordered
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupUnordered
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthUnordered
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthUnordered        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowUnordered          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupUnordered = fmt.Errorf("proto: unexpected end of group")
+)
@@ -1,6 +1,8 @@
 package ante
 
 import (
+	"time"
+
 	errorsmod "cosmossdk.io/errors"
 	storetypes "cosmossdk.io/store/types"
 	txsigning "cosmossdk.io/x/tx/signing"
@@ -20,6 +22,13 @@ type HandlerOptions struct {
 	SignModeHandler        *txsigning.HandlerMap
 	SigGasConsumer         func(meter storetypes.GasMeter, sig signing.SignatureV2, params types.Params) error
 	TxFeeChecker           TxFeeChecker
+	// UnorderedTxKeeper opts in to the unordered txs when set, typically to
+	// the x/auth keeper, whose PreBlock prunes them. They are rejected
+	// otherwise.
+	UnorderedTxKeeper UnorderedTxKeeper
+	// MaxUnorderedTxTimeout is the maximum duration between the block time and
+	// the timeout of an unordered tx, DefaultMaxUnorderedTxTimeout if zero.
+	MaxUnorderedTxTimeout time.Duration
 }
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
@@ -38,11 +47,27 @@ func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
 		return nil, errorsmod.Wrap(sdkerrors.ErrLogic, "sign mode handler is required for ante builder")
 	}
 
+	extensionOptionChecker := options.ExtensionOptionChecker
+	if options.UnorderedTxKeeper != nil {
+		extensionOptionChecker = AcceptUnorderedExtensionOption(extensionOptionChecker)
+	}
+
 	anteDecorators := []sdk.AnteDecorator{
 		NewSetUpContextDecorator(), // outermost AnteDecorator. SetUpContext must be called first
-		NewExtensionOptionsDecorator(options.ExtensionOptionChecker),
+		NewExtensionOptionsDecorator(extensionOptionChecker),
 		NewValidateBasicDecorator(),
 		NewTxTimeoutHeightDecorator(),
+	}
+
+	if options.UnorderedTxKeeper != nil {
+		maxTimeout := options.MaxUnorderedTxTimeout
+		if maxTimeout == 0 {
+			maxTimeout = DefaultMaxUnorderedTxTimeout
+		}
+		anteDecorators = append(anteDecorators, NewUnorderedTxDecorator(maxTimeout, options.UnorderedTxKeeper))
+	}
+
+	anteDecorators = append(anteDecorators,
 		NewValidateMemoDecorator(options.AccountKeeper),
 		NewConsumeGasForTxSizeDecorator(options.AccountKeeper),
 		NewDeductFeeDecorator(options.AccountKeeper, options.BankKeeper, options.FeegrantKeeper, options.TxFeeChecker),
@@ -51,7 +76,7 @@ func NewAnteHandler(options HandlerOptions) (sdk.AnteHandler, error) {
 		NewSigGasConsumeDecorator(options.AccountKeeper, options.SigGasConsumer),
 		NewSigVerificationDecorator(options.AccountKeeper, options.SignModeHandler),
 		NewIncrementSequenceDecorator(options.AccountKeeper),
-	}
+	)
 
 	return sdk.ChainAnteDecorators(anteDecorators...), nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/frost"
 	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256r1"
@@ -90,6 +91,9 @@ func TestConsumeSignatureVerificationGas(t *testing.T) {
 		require.NoError(t, err)
 	}
 
+	frostShares, err := frost.GenerateKeyShares(2, 3)
+	require.NoError(t, err)
+
 	type args struct {
 		meter  storetypes.GasMeter
 		sig    signing.SignatureData
@@ -105,6 +109,7 @@ func TestConsumeSignatureVerificationGas(t *testing.T) {
 		{"PubKeyEd25519", args{storetypes.NewInfiniteGasMeter(), nil, ed25519.GenPrivKey().PubKey(), params}, p.SigVerifyCostED25519, true},
 		{"PubKeySecp256k1", args{storetypes.NewInfiniteGasMeter(), nil, secp256k1.GenPrivKey().PubKey(), params}, p.SigVerifyCostSecp256k1, false},
 		{"PubKeySecp256r1", args{storetypes.NewInfiniteGasMeter(), nil, skR1.PubKey(), params}, p.SigVerifyCostSecp256r1(), false},
+		{"PubKeyFrost", args{storetypes.NewInfiniteGasMeter(), nil, frostShares[0].PubKey(), params}, p.SigVerifyCostSecp256k1, false},
 		{"Multisig", args{storetypes.NewInfiniteGasMeter(), multisignature1, multisigKey1, params}, expectedCost1, false},
 		{"unknown key", args{storetypes.NewInfiniteGasMeter(), nil, nil, params}, 0, true},
 	}
@@ -267,6 +272,70 @@ func TestSigIntegration(t *testing.T) {
 	require.Equal(t, initialSigCost*uint64(len(privs)), doubleCost-initialCost)
 }
 
+func TestSigVerificationFrost(t *testing.T) {
+	suite := SetupTestSuite(t, true)
+	suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	shares, err := frost.GenerateKeyShares(2, 3)
+	require.NoError(t, err)
+	pubKey := shares[0].PubKey()
+	addr := sdk.AccAddress(pubKey.Address())
+	acc := suite.accountKeeper.NewAccountWithAddress(suite.ctx, addr)
+	suite.accountKeeper.SetAccount(suite.ctx, acc)
+
+	require.NoError(t, suite.txBuilder.SetMsgs(testdata.NewTestMsg(addr)))
+	suite.txBuilder.SetFeeAmount(testdata.NewTestFeeAmount())
+	suite.txBuilder.SetGasLimit(testdata.NewTestGasLimit())
+
+	signMode := signing.SignMode_SIGN_MODE_DIRECT
+	setSignature := func(sig []byte) {
+		require.NoError(t, suite.txBuilder.SetSignatures(signing.SignatureV2{
+			PubKey:   pubKey,
+			Data:     &signing.SingleSignatureData{SignMode: signMode, Signature: sig},
+			Sequence: 0,
+		}))
+	}
+	setSignature(nil)
+	signBytes, err := authsign.GetSignBytesAdapter(suite.ctx, suite.clientCtx.TxConfig.SignModeHandler(), signMode, authsign.SignerData{
+		Address:       addr.String(),
+		ChainID:       suite.ctx.ChainID(),
+		AccountNumber: acc.GetAccountNumber(),
+		PubKey:        pubKey,
+	}, suite.txBuilder.GetTx())
+	require.NoError(t, err)
+
+	// participants 1 and 3 sign the tx for the group
+	signers := []*frost.KeyShare{shares[0], shares[2]}
+	nonces := make([]*frost.SigningNonces, len(signers))
+	commitments := make([]frost.SigningCommitment, len(signers))
+	for i, ks := range signers {
+		nonces[i], commitments[i], err = ks.Commit()
+		require.NoError(t, err)
+	}
+	sigShares := make(map[uint32][]byte, len(signers))
+	for i, ks := range signers {
+		sigShares[ks.Index], err = ks.SignShare(nonces[i], signBytes, commitments)
+		require.NoError(t, err)
+	}
+	sig, err := shares[1].Aggregate(signBytes, commitments, sigShares)
+	require.NoError(t, err)
+
+	spkd := ante.NewSetPubKeyDecorator(suite.accountKeeper)
+	svgc := ante.NewSigGasConsumeDecorator(suite.accountKeeper, ante.DefaultSigVerificationGasConsumer)
+	svd := ante.NewSigVerificationDecorator(suite.accountKeeper, suite.clientCtx.TxConfig.SignModeHandler())
+	antehandler := sdk.ChainAnteDecorators(spkd, svgc, svd)
+
+	setSignature(sig)
+	_, err = antehandler(suite.ctx, suite.txBuilder.GetTx(), false)
+	require.NoError(t, err)
+
+	sig[len(sig)-1] ^= 1
+	setSignature(sig)
+	_, err = antehandler(suite.ctx, suite.txBuilder.GetTx(), false)
+	require.Error(t, err)
+}
+
 func runSigDecorators(t *testing.T, params types.Params, _ bool, privs ...cryptotypes.PrivKey) (storetypes.Gas, error) {
 	suite := SetupTestSuite(t, true)
 	suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
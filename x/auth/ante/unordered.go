@@ -0,0 +1,133 @@
+package ante
+
+import (
+	"context"
+	"crypto/sha256"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// DefaultMaxUnorderedTxTimeout is the default maximum duration between the
+// block time and the timeout of an unordered tx. It bounds the number of
+// unordered txs kept to reject their replays.
+const DefaultMaxUnorderedTxTimeout = 10 * time.Minute
+
+// UnorderedTxKeeper defines the contract needed to reject the replays of the
+// unordered txs, implemented by the x/auth keeper.
+type UnorderedTxKeeper interface {
+	ContainsUnorderedTx(ctx context.Context, timeout time.Time, txHash []byte) (bool, error)
+	AddUnorderedTx(ctx context.Context, timeout time.Time, txHash []byte) error
+}
+
+// unorderedTxKey is the context key marking the unordered txs accepted by the
+// UnorderedTxDecorator.
+type unorderedTxKey struct{}
+
+// IsUnorderedTx returns true if the tx of the context is an unordered tx
+// accepted by the UnorderedTxDecorator. The sequence of its signers is then
+// neither checked nor incremented.
+func IsUnorderedTx(ctx sdk.Context) bool {
+	unordered, _ := ctx.Value(unorderedTxKey{}).(bool)
+	return unordered
+}
+
+// UnorderedTxDecorator accepts the unordered txs, i.e. the txs carrying an
+// ExtensionOptionUnordered, whose timeout is after the block time and within
+// maxTimeout of it, and that weren't executed yet. It records their hash until
+// their timeout, when they are pruned by the x/auth PreBlock. Ordered txs are
+// passed through. It must be placed after the ExtensionOptionsDecorator.
+//
+// The hash covers the body and auth info of the tx but not its signatures, so
+// that a replay can't go through by changing the encoding of a signature.
+type UnorderedTxDecorator struct {
+	maxTimeout time.Duration
+	keeper     UnorderedTxKeeper
+}
+
+// NewUnorderedTxDecorator returns an UnorderedTxDecorator accepting the
+// unordered txs up to maxTimeout ahead of the block time.
+func NewUnorderedTxDecorator(maxTimeout time.Duration, keeper UnorderedTxKeeper) UnorderedTxDecorator {
+	return UnorderedTxDecorator{
+		maxTimeout: maxTimeout,
+		keeper:     keeper,
+	}
+}
+
+// AnteHandle implements the AnteDecorator.AnteHandle method
+func (d UnorderedTxDecorator) AnteHandle(ctx sdk.Context, sdkTx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	extTx, ok := sdkTx.(HasExtensionOptionsTx)
+	if !ok {
+		return next(ctx, sdkTx, simulate)
+	}
+
+	timeout, unordered, err := tx.GetUnorderedTimeout(extTx.GetExtensionOptions())
+	if err != nil {
+		return ctx, err
+	}
+	if !unordered {
+		return next(ctx, sdkTx, simulate)
+	}
+
+	blockTime := ctx.BlockTime()
+	if !timeout.After(blockTime) {
+		return ctx, errorsmod.Wrapf(sdkerrors.ErrTxTimeout, "block time: %s, timeout timestamp: %s", blockTime, timeout)
+	}
+	if timeout.After(blockTime.Add(d.maxTimeout)) {
+		return ctx, errorsmod.Wrapf(sdkerrors.ErrTxTimeout, "timeout timestamp %s is more than %s after the block time %s", timeout, d.maxTimeout, blockTime)
+	}
+
+	if !simulate {
+		txHash, err := unorderedTxHash(ctx.TxBytes())
+		if err != nil {
+			return ctx, err
+		}
+		executed, err := d.keeper.ContainsUnorderedTx(ctx, timeout, txHash)
+		if err != nil {
+			return ctx, err
+		}
+		if executed {
+			return ctx, errorsmod.Wrapf(sdkerrors.ErrInvalidRequest, "unordered tx %X was already executed", txHash)
+		}
+
+		if err := d.keeper.AddUnorderedTx(ctx, timeout, txHash); err != nil {
+			return ctx, err
+		}
+	}
+
+	return next(ctx.WithValue(unorderedTxKey{}, true), sdkTx, simulate)
+}
+
+// AcceptUnorderedExtensionOption returns an ExtensionOptionChecker accepting
+// the ExtensionOptionUnordered and the extension options accepted by checker.
+func AcceptUnorderedExtensionOption(checker ExtensionOptionChecker) ExtensionOptionChecker {
+	if checker == nil {
+		checker = rejectExtensionOption
+	}
+
+	return func(opt *codectypes.Any) bool {
+		return opt.TypeUrl == tx.UnorderedTypeURL || checker(opt)
+	}
+}
+
+// unorderedTxHash returns the hash of the signed content of a tx, i.e. of the
+// tx without its signatures.
+func unorderedTxHash(txBytes []byte) ([]byte, error) {
+	var raw tx.TxRaw
+	if err := raw.Unmarshal(txBytes); err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrTxDecode, err.Error())
+	}
+
+	bz, err := (&tx.TxRaw{BodyBytes: raw.BodyBytes, AuthInfoBytes: raw.AuthInfoBytes}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(bz)
+
+	return hash[:], nil
+}
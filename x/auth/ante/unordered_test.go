@@ -0,0 +1,76 @@
+package ante_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+)
+
+func TestUnorderedTxDecorator(t *testing.T) {
+	suite := SetupTestSuite(t, true)
+
+	antehandler := sdk.ChainAnteDecorators(ante.NewUnorderedTxDecorator(time.Minute, suite.accountKeeper))
+
+	priv1, _, addr1 := testdata.KeyTestPubAddr()
+	blockTime := time.Unix(1000, 0).UTC()
+	ctx := suite.ctx.WithBlockTime(blockTime)
+
+	newTx := func(timeout *time.Time) (sdk.Tx, []byte) {
+		suite.txBuilder = suite.clientCtx.TxConfig.NewTxBuilder()
+		require.NoError(t, suite.txBuilder.SetMsgs(testdata.NewTestMsg(addr1)))
+		suite.txBuilder.SetFeeAmount(testdata.NewTestFeeAmount())
+		suite.txBuilder.SetGasLimit(testdata.NewTestGasLimit())
+		if timeout != nil {
+			opt, err := txtypes.NewUnorderedExtensionOption(*timeout)
+			require.NoError(t, err)
+			suite.txBuilder.(client.ExtendedTxBuilder).SetExtensionOptions(opt)
+		}
+
+		privs, accNums, accSeqs := []cryptotypes.PrivKey{priv1}, []uint64{0}, []uint64{0}
+		tx, err := suite.CreateTestTx(ctx, privs, accNums, accSeqs, ctx.ChainID(), signing.SignMode_SIGN_MODE_DIRECT)
+		require.NoError(t, err)
+		bz, err := suite.clientCtx.TxConfig.TxEncoder()(tx)
+		require.NoError(t, err)
+
+		return tx, bz
+	}
+
+	tx, bz := newTx(nil)
+	newCtx, err := antehandler(ctx.WithTxBytes(bz), tx, false)
+	require.NoError(t, err)
+	require.False(t, ante.IsUnorderedTx(newCtx))
+
+	expired := blockTime
+	tx, bz = newTx(&expired)
+	_, err = antehandler(ctx.WithTxBytes(bz), tx, false)
+	require.ErrorIs(t, err, sdkerrors.ErrTxTimeout)
+
+	tooFar := blockTime.Add(2 * time.Minute)
+	tx, bz = newTx(&tooFar)
+	_, err = antehandler(ctx.WithTxBytes(bz), tx, false)
+	require.ErrorIs(t, err, sdkerrors.ErrTxTimeout)
+
+	valid := blockTime.Add(30 * time.Second)
+	tx, bz = newTx(&valid)
+	newCtx, err = antehandler(ctx.WithTxBytes(bz), tx, false)
+	require.NoError(t, err)
+	require.True(t, ante.IsUnorderedTx(newCtx))
+
+	// a replay is rejected until the tx is pruned
+	_, err = antehandler(ctx.WithTxBytes(bz), tx, false)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	require.NoError(t, suite.accountKeeper.RemoveExpiredUnorderedTxs(ctx.WithBlockTime(valid)))
+	_, err = antehandler(ctx.WithBlockTime(valid).WithTxBytes(bz), tx, false)
+	require.ErrorIs(t, err, sdkerrors.ErrTxTimeout)
+}
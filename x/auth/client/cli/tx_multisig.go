@@ -0,0 +1,358 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// GetMultisigCommand returns the multisig command group, coordinating the
+// signatures of a transaction with several signers through a bundle file.
+func GetMultisigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multisig",
+		Short: "Coordinate the offline signatures of a transaction with multiple signers",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Coordinate the offline signatures of a transaction with multiple signers through a bundle file,
+holding the unsigned transaction and the signatures collected so far.
+
+The signers of the transaction are either legacy multisigs, which need their threshold of member signatures,
+or single keys, e.g. the proposers of a x/group proposal submitted with --exec=try, which the chain counts as
+yes votes on the proposal.
+
+Example:
+$ %[1]s tx bank send k1k2k3 cosmos1... 10stake --generate-only > tx.json
+$ %[1]s tx multisig create tx.json bundle.json
+$ %[1]s tx multisig sign bundle.json --from k1
+$ %[1]s tx multisig sign bundle.json --from k3
+$ %[1]s tx multisig show bundle.json
+$ %[1]s tx multisig broadcast bundle.json
+
+Copies of the bundle signed in parallel are combined with the merge command.
+The signatures use the amino-json sign mode.
+`, version.AppName),
+		),
+		RunE: client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		GetMultisigCreateCommand(),
+		GetMultisigSignCommand(),
+		GetMultisigMergeCommand(),
+		GetMultisigShowCommand(),
+		GetMultisigBroadcastCommand(),
+	)
+
+	return cmd
+}
+
+// GetMultisigCreateCommand returns the command creating a multisig bundle.
+func GetMultisigCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create [unsigned_tx_file] [bundle_file]",
+		Short: "Create a bundle collecting the signatures of a transaction generated offline",
+		Long: `Create a bundle collecting the signatures of the signers of a transaction created with the --generate-only
+flag. The legacy multisig signers must be stored in the keyring, or have their public key known on chain.
+
+The account numbers and sequences of the signers are queried from the node. In offline mode, the transaction must
+have a single signer, whose account number and sequence are set with the --account-number and --sequence flags.
+`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			parsedTx, err := authclient.ReadTxFromFile(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			sigTx, ok := parsedTx.(authsigning.SigVerifiableTx)
+			if !ok {
+				return fmt.Errorf("expected a signable transaction, got %T", parsedTx)
+			}
+			if sigs, err := sigTx.GetSignaturesV2(); err != nil {
+				return err
+			} else if len(sigs) > 0 {
+				return errors.New("expected an unsigned transaction")
+			}
+			txSigners, err := sigTx.GetSigners()
+			if err != nil {
+				return err
+			}
+			if clientCtx.Offline && len(txSigners) != 1 {
+				return errors.New("the account numbers and sequences of multiple signers can't be set in offline mode")
+			}
+
+			signers := make([]*authclient.MultisigBundleSigner, len(txSigners))
+			for i, addr := range txSigners {
+				signer := &authclient.MultisigBundleSigner{Address: addr}
+				if clientCtx.Keyring != nil {
+					if k, err := clientCtx.Keyring.KeyByAddress(signer.Address); err == nil && k.GetType() == keyring.TypeMulti {
+						if signer.PubKey, err = k.GetPubKey(); err != nil {
+							return err
+						}
+					}
+				}
+
+				if clientCtx.Offline {
+					if !cmd.Flags().Changed(flags.FlagAccountNumber) || !cmd.Flags().Changed(flags.FlagSequence) {
+						return errors.New("account-number and sequence must be set in offline mode")
+					}
+					signer.AccountNumber, _ = cmd.Flags().GetUint64(flags.FlagAccountNumber)
+					signer.Sequence, _ = cmd.Flags().GetUint64(flags.FlagSequence)
+				} else {
+					acc, err := clientCtx.AccountRetriever.GetAccount(clientCtx, signer.Address)
+					if err != nil {
+						return err
+					}
+					signer.AccountNumber, signer.Sequence = acc.GetAccountNumber(), acc.GetSequence()
+					if signer.PubKey == nil {
+						signer.PubKey = acc.GetPubKey()
+					}
+				}
+
+				signers[i] = signer
+			}
+
+			bundle, err := authclient.NewMultisigBundle(clientCtx.ChainID, parsedTx, signers)
+			if err != nil {
+				return err
+			}
+
+			return authclient.WriteMultisigBundle(clientCtx, args[1], bundle)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetMultisigSignCommand returns the command adding a signature to a
+// multisig bundle.
+func GetMultisigSignCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign [bundle_file]",
+		Short: "Sign the transaction of a bundle with a local key",
+		Long: `Sign the transaction of a bundle with the local key given by --from, for every signer of the transaction
+it may sign for, and add the signatures to the bundle file. The signer data come from the bundle, so signing
+doesn't reach out to a node.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bundle, err := authclient.ReadMultisigBundle(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			// the account number and sequence come from the bundle, even offline
+			txf, err := tx.NewFactoryCLI(clientCtx.WithOffline(false), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			if err := bundle.Sign(clientCtx, txf, clientCtx.FromName); err != nil {
+				return err
+			}
+
+			return authclient.WriteMultisigBundle(clientCtx, args[0], bundle)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetMultisigMergeCommand returns the command merging the signatures of
+// copies of a multisig bundle.
+func GetMultisigMergeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge [bundle_file] [other_bundle_file]...",
+		Short: "Add the signatures of copies of a bundle signed in parallel",
+		Long: `Verify and add the signatures of the copies of a bundle signed in parallel to the bundle file. The copies
+must hold the same transaction for the same signers.
+`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bundle, err := authclient.ReadMultisigBundle(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			txBytes, err := clientCtx.TxConfig.TxEncoder()(bundle.Tx)
+			if err != nil {
+				return err
+			}
+
+			for _, filename := range args[1:] {
+				other, err := authclient.ReadMultisigBundle(clientCtx, filename)
+				if err != nil {
+					return err
+				}
+				otherTxBytes, err := clientCtx.TxConfig.TxEncoder()(other.Tx)
+				if err != nil {
+					return err
+				}
+				if other.ChainID != bundle.ChainID || string(otherTxBytes) != string(txBytes) {
+					return fmt.Errorf("%s doesn't hold the transaction of %s", filename, args[0])
+				}
+
+				for _, signer := range other.Signers {
+					for _, sig := range signer.Signatures {
+						if err := bundle.AddSignature(cmd.Context(), clientCtx.TxConfig, sig); err != nil {
+							return err
+						}
+					}
+				}
+			}
+
+			return authclient.WriteMultisigBundle(clientCtx, args[0], bundle)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetMultisigShowCommand returns the command showing the transaction of a
+// multisig bundle and the status of its signers.
+func GetMultisigShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [bundle_file]",
+		Short: "Show the transaction of a bundle and its missing signatures",
+		Long: `Show the transaction of a bundle, rendered as in the textual sign mode when it is enabled, and the
+signatures collected and still missing for each of its signers.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bundle, err := authclient.ReadMultisigBundle(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			rendered, err := bundle.RenderTextual(cmd.Context(), clientCtx.TxConfig)
+			if err != nil {
+				// fall back to the JSON encoding of the transaction
+				bz, err := clientCtx.TxConfig.TxJSONEncoder()(bundle.Tx)
+				if err != nil {
+					return err
+				}
+				rendered = string(bz) + "\n"
+			}
+			cmd.Printf("Transaction:\n%s\n", rendered)
+
+			cmd.Println("Signers:")
+			for _, signer := range bundle.Signers {
+				status := "missing"
+				if signer.IsComplete() {
+					status = "complete"
+				}
+
+				pubKey, ok := signer.Multisig()
+				if !ok {
+					cmd.Printf("  %s: %s\n", signer.Address, status)
+					continue
+				}
+
+				cmd.Printf("  %s (multisig %d/%d): %s, %d signature(s)\n", signer.Address, pubKey.Threshold, len(pubKey.PubKeys), status, len(signer.Signatures))
+				for _, sig := range signer.Signatures {
+					cmd.Printf("    signed: %s\n", sdk.AccAddress(sig.PubKey.Address()))
+				}
+				if !signer.IsComplete() {
+					for _, member := range signer.Missing() {
+						cmd.Printf("    missing: %s\n", sdk.AccAddress(member.Address()))
+					}
+				}
+			}
+
+			cmd.Printf("\nReady to broadcast: %t\n", bundle.IsComplete())
+			return nil
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetMultisigBroadcastCommand returns the command broadcasting the
+// transaction of a complete multisig bundle.
+func GetMultisigBroadcastCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "broadcast [bundle_file]",
+		Short: "Broadcast the transaction of a bundle once every signer is complete",
+		Long: `Assemble the signatures of a bundle into the transaction and broadcast it, once every signer has enough
+signatures. With the --generate-only flag, the signed transaction is printed instead.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bundle, err := authclient.ReadMultisigBundle(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			signedTx, err := bundle.SignedTx(clientCtx.TxConfig)
+			if err != nil {
+				return err
+			}
+
+			if clientCtx.GenerateOnly {
+				bz, err := clientCtx.TxConfig.TxJSONEncoder()(signedTx)
+				if err != nil {
+					return err
+				}
+				return clientCtx.PrintString(fmt.Sprintf("%s\n", bz))
+			}
+			if clientCtx.Offline {
+				return errors.New("cannot broadcast tx during offline mode")
+			}
+
+			txBytes, err := clientCtx.TxConfig.TxEncoder()(signedTx)
+			if err != nil {
+				return err
+			}
+			res, err := clientCtx.BroadcastTx(txBytes)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
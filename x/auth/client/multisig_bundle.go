@@ -0,0 +1,492 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	txsigning "cosmossdk.io/x/tx/signing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// MultisigBundleVersion is the version of the multisig bundle file format.
+const MultisigBundleVersion = 1
+
+// MultisigBundle is an unsigned transaction together with the signatures
+// collected so far for each of its signers, which are signed offline in any
+// order and broadcast once every signer is complete. A signer is either a
+// legacy multisig, complete once its threshold of member signatures is
+// reached, or a single key, e.g. one of the proposers of a x/group proposal.
+//
+// All the signatures use SIGN_MODE_LEGACY_AMINO_JSON, whose sign bytes don't
+// depend on the other signatures.
+type MultisigBundle struct {
+	ChainID string
+	Tx      sdk.Tx
+	Signers []*MultisigBundleSigner
+}
+
+// MultisigBundleSigner is a signer of the transaction of a MultisigBundle.
+type MultisigBundleSigner struct {
+	Address       sdk.AccAddress
+	AccountNumber uint64
+	Sequence      uint64
+	// PubKey is the LegacyAminoPubKey of a legacy multisig, or the public key
+	// of a single key, which may be unknown until it signs.
+	PubKey     cryptotypes.PubKey
+	Signatures []signing.SignatureV2
+}
+
+// multisigBundleJSON is the file format of a MultisigBundle.
+type multisigBundleJSON struct {
+	Version uint32                     `json:"version"`
+	ChainID string                     `json:"chain_id"`
+	Tx      json.RawMessage            `json:"tx"`
+	Signers []multisigBundleSignerJSON `json:"signers"`
+}
+
+type multisigBundleSignerJSON struct {
+	Address       string          `json:"address"`
+	AccountNumber uint64          `json:"account_number,string"`
+	Sequence      uint64          `json:"sequence,string"`
+	PubKey        json.RawMessage `json:"pub_key,omitempty"`
+	Signatures    json.RawMessage `json:"signatures,omitempty"`
+}
+
+// NewMultisigBundle returns a bundle collecting the signatures of the signers
+// of the unsigned transaction tx. The signers must be given in the order of
+// the signers of tx.
+func NewMultisigBundle(chainID string, tx sdk.Tx, signers []*MultisigBundleSigner) (*MultisigBundle, error) {
+	if chainID == "" {
+		return nil, errors.New("chain ID required")
+	}
+
+	txSigners, err := tx.(authsigning.SigVerifiableTx).GetSigners()
+	if err != nil {
+		return nil, err
+	}
+	if len(txSigners) != len(signers) {
+		return nil, fmt.Errorf("expected %d signers, got %d", len(txSigners), len(signers))
+	}
+	for i, signer := range signers {
+		if !bytes.Equal(signer.Address, txSigners[i]) {
+			return nil, fmt.Errorf("signer %d: expected %s, got %s", i, sdk.AccAddress(txSigners[i]), signer.Address)
+		}
+		if signer.PubKey != nil && !bytes.Equal(signer.PubKey.Address(), signer.Address) {
+			return nil, fmt.Errorf("signer %d: pubkey doesn't match address %s", i, signer.Address)
+		}
+	}
+
+	return &MultisigBundle{ChainID: chainID, Tx: tx, Signers: signers}, nil
+}
+
+// ReadMultisigBundle reads and decodes a MultisigBundle from the given file.
+func ReadMultisigBundle(clientCtx client.Context, filename string) (*MultisigBundle, error) {
+	bz, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw multisigBundleJSON
+	if err := json.Unmarshal(bz, &raw); err != nil {
+		return nil, fmt.Errorf("invalid multisig bundle: %w", err)
+	}
+	if raw.Version != MultisigBundleVersion {
+		return nil, fmt.Errorf("unsupported multisig bundle version %d, expected %d", raw.Version, MultisigBundleVersion)
+	}
+
+	parsedTx, err := clientCtx.TxConfig.TxJSONDecoder()(raw.Tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := make([]*MultisigBundleSigner, len(raw.Signers))
+	for i, s := range raw.Signers {
+		signer := &MultisigBundleSigner{AccountNumber: s.AccountNumber, Sequence: s.Sequence}
+		if signer.Address, err = sdk.AccAddressFromBech32(s.Address); err != nil {
+			return nil, err
+		}
+		if len(s.PubKey) > 0 {
+			if err := clientCtx.Codec.UnmarshalInterfaceJSON(s.PubKey, &signer.PubKey); err != nil {
+				return nil, err
+			}
+		}
+		if len(s.Signatures) > 0 {
+			if signer.Signatures, err = clientCtx.TxConfig.UnmarshalSignatureJSON(s.Signatures); err != nil {
+				return nil, err
+			}
+		}
+		signers[i] = signer
+	}
+
+	b, err := NewMultisigBundle(raw.ChainID, parsedTx, signers)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := clientCtx.CmdContext
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// check the signatures again, in case the file has been edited
+	for _, signer := range b.Signers {
+		sigs := signer.Signatures
+		signer.Signatures = nil
+		for _, sig := range sigs {
+			if err := b.AddSignature(ctx, clientCtx.TxConfig, sig); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return b, nil
+}
+
+// WriteMultisigBundle encodes and writes a MultisigBundle to the given file.
+func WriteMultisigBundle(clientCtx client.Context, filename string, b *MultisigBundle) error {
+	txJSON, err := clientCtx.TxConfig.TxJSONEncoder()(b.Tx)
+	if err != nil {
+		return err
+	}
+
+	raw := multisigBundleJSON{
+		Version: MultisigBundleVersion,
+		ChainID: b.ChainID,
+		Tx:      txJSON,
+		Signers: make([]multisigBundleSignerJSON, len(b.Signers)),
+	}
+	for i, signer := range b.Signers {
+		s := multisigBundleSignerJSON{
+			Address:       signer.Address.String(),
+			AccountNumber: signer.AccountNumber,
+			Sequence:      signer.Sequence,
+		}
+		if signer.PubKey != nil {
+			if s.PubKey, err = clientCtx.Codec.MarshalInterfaceJSON(signer.PubKey); err != nil {
+				return err
+			}
+		}
+		if len(signer.Signatures) > 0 {
+			if s.Signatures, err = clientCtx.TxConfig.MarshalSignatureJSON(signer.Signatures); err != nil {
+				return err
+			}
+		}
+		raw.Signers[i] = s
+	}
+
+	bz, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, append(bz, '\n'), 0o600)
+}
+
+// Multisig returns the public key of the signer if it is a legacy multisig.
+func (s *MultisigBundleSigner) Multisig() (*kmultisig.LegacyAminoPubKey, bool) {
+	pubKey, ok := s.PubKey.(*kmultisig.LegacyAminoPubKey)
+	return pubKey, ok
+}
+
+// Threshold returns the number of signatures required from the signer.
+func (s *MultisigBundleSigner) Threshold() int {
+	if pubKey, ok := s.Multisig(); ok {
+		return int(pubKey.Threshold)
+	}
+	return 1
+}
+
+// IsComplete returns true if the signer has enough signatures.
+func (s *MultisigBundleSigner) IsComplete() bool {
+	return len(s.Signatures) >= s.Threshold()
+}
+
+// Missing returns the public keys which can still sign for a legacy multisig,
+// or nil for a single key.
+func (s *MultisigBundleSigner) Missing() []cryptotypes.PubKey {
+	pubKey, ok := s.Multisig()
+	if !ok {
+		return nil
+	}
+
+	var missing []cryptotypes.PubKey
+	for _, member := range pubKey.GetPubKeys() {
+		if !s.hasSignatureOf(member) {
+			missing = append(missing, member)
+		}
+	}
+
+	return missing
+}
+
+// accepts returns true if pubKey may sign for the signer.
+func (s *MultisigBundleSigner) accepts(pubKey cryptotypes.PubKey) bool {
+	if multisigPubKey, ok := s.Multisig(); ok {
+		for _, member := range multisigPubKey.GetPubKeys() {
+			if member.Equals(pubKey) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return bytes.Equal(pubKey.Address(), s.Address)
+}
+
+func (s *MultisigBundleSigner) hasSignatureOf(pubKey cryptotypes.PubKey) bool {
+	for _, sig := range s.Signatures {
+		if sig.PubKey.Equals(pubKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsComplete returns true if every signer has enough signatures to
+// broadcast the transaction.
+func (b *MultisigBundle) IsComplete() bool {
+	for _, signer := range b.Signers {
+		if !signer.IsComplete() {
+			return false
+		}
+	}
+	return true
+}
+
+// AddSignature verifies and adds a signature of the transaction, by a single
+// signer or by a member of a legacy multisig signer. Adding a signature twice
+// is a no-op.
+func (b *MultisigBundle) AddSignature(ctx context.Context, txCfg client.TxConfig, sig signing.SignatureV2) error {
+	data, ok := sig.Data.(*signing.SingleSignatureData)
+	if !ok {
+		return errors.New("expected a single signature")
+	}
+	if data.SignMode != signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON {
+		return fmt.Errorf("expected a signature in %s, got %s", signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, data.SignMode)
+	}
+
+	added := false
+	for _, signer := range b.Signers {
+		if !signer.accepts(sig.PubKey) {
+			continue
+		}
+		if signer.hasSignatureOf(sig.PubKey) {
+			added = true
+			continue
+		}
+
+		if err := b.verifySignature(ctx, txCfg, signer, sig.PubKey, data); err != nil {
+			return err
+		}
+
+		sig.Sequence = signer.Sequence
+		if _, ok := signer.Multisig(); !ok {
+			signer.PubKey = sig.PubKey
+		}
+		signer.Signatures = append(signer.Signatures, sig)
+		added = true
+	}
+	if !added {
+		return fmt.Errorf("%s isn't a signer of the transaction", sdk.AccAddress(sig.PubKey.Address()))
+	}
+
+	return nil
+}
+
+func (b *MultisigBundle) verifySignature(ctx context.Context, txCfg client.TxConfig, signer *MultisigBundleSigner, pubKey cryptotypes.PubKey, data *signing.SingleSignatureData) error {
+	signerData, err := b.signerData(signer, pubKey)
+	if err != nil {
+		return err
+	}
+	adaptableTx, ok := b.Tx.(authsigning.V2AdaptableTx)
+	if !ok {
+		return fmt.Errorf("expected Tx to be signing.V2AdaptableTx, got %T", b.Tx)
+	}
+
+	if err := authsigning.VerifySignature(ctx, pubKey, signerData, data, txCfg.SignModeHandler(), adaptableTx.GetSigningTxData()); err != nil {
+		return fmt.Errorf("couldn't verify signature for address %s: %w", sdk.AccAddress(pubKey.Address()), err)
+	}
+
+	return nil
+}
+
+func (b *MultisigBundle) signerData(signer *MultisigBundleSigner, pubKey cryptotypes.PubKey) (txsigning.SignerData, error) {
+	anyPk, err := codectypes.NewAnyWithValue(pubKey)
+	if err != nil {
+		return txsigning.SignerData{}, err
+	}
+
+	return txsigning.SignerData{
+		ChainID:       b.ChainID,
+		AccountNumber: signer.AccountNumber,
+		Sequence:      signer.Sequence,
+		Address:       signer.Address.String(),
+		PubKey:        &anypb.Any{TypeUrl: anyPk.TypeUrl, Value: anyPk.Value},
+	}, nil
+}
+
+// Sign signs the transaction with the local key name, for every signer the
+// key may sign for, and adds the signatures to the bundle.
+func (b *MultisigBundle) Sign(clientCtx client.Context, txf tx.Factory, name string) error {
+	k, err := txf.Keybase().Key(name)
+	if err != nil {
+		return err
+	}
+	pubKey, err := k.GetPubKey()
+	if err != nil {
+		return err
+	}
+
+	signed := false
+	for _, signer := range b.Signers {
+		if !signer.accepts(pubKey) {
+			continue
+		}
+		signed = true
+		if signer.hasSignatureOf(pubKey) {
+			continue
+		}
+
+		txBuilder, err := b.newTxBuilder(clientCtx.TxConfig)
+		if err != nil {
+			return err
+		}
+		signerTxf := txf.
+			WithChainID(b.ChainID).
+			WithAccountNumber(signer.AccountNumber).
+			WithSequence(signer.Sequence).
+			WithSignMode(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+		if err := tx.Sign(clientCtx.CmdContext, signerTxf, name, txBuilder, true); err != nil {
+			return err
+		}
+
+		sigs, err := txBuilder.GetTx().GetSignaturesV2()
+		if err != nil {
+			return err
+		}
+		if err := b.AddSignature(clientCtx.CmdContext, clientCtx.TxConfig, sigs[0]); err != nil {
+			return err
+		}
+	}
+	if !signed {
+		return fmt.Errorf("key %s isn't a signer of the transaction", name)
+	}
+
+	return nil
+}
+
+// newTxBuilder returns a builder of a copy of the transaction.
+func (b *MultisigBundle) newTxBuilder(txCfg client.TxConfig) (client.TxBuilder, error) {
+	bz, err := txCfg.TxEncoder()(b.Tx)
+	if err != nil {
+		return nil, err
+	}
+	parsedTx, err := txCfg.TxDecoder()(bz)
+	if err != nil {
+		return nil, err
+	}
+
+	return txCfg.WrapTxBuilder(parsedTx)
+}
+
+// SignedTx returns the transaction with the signatures of every signer,
+// which must be complete.
+func (b *MultisigBundle) SignedTx(txCfg client.TxConfig) (sdk.Tx, error) {
+	sigs := make([]signing.SignatureV2, len(b.Signers))
+	for i, signer := range b.Signers {
+		if !signer.IsComplete() {
+			return nil, fmt.Errorf("signer %s has %d of the %d required signatures", signer.Address, len(signer.Signatures), signer.Threshold())
+		}
+
+		multisigPubKey, ok := signer.Multisig()
+		if !ok {
+			sigs[i] = signer.Signatures[0]
+			continue
+		}
+
+		multisigSig := multisig.NewMultisig(len(multisigPubKey.PubKeys))
+		for _, sig := range signer.Signatures {
+			if err := multisig.AddSignatureV2(multisigSig, sig, multisigPubKey.GetPubKeys()); err != nil {
+				return nil, err
+			}
+		}
+		sigs[i] = signing.SignatureV2{
+			PubKey:   multisigPubKey,
+			Data:     multisigSig,
+			Sequence: signer.Sequence,
+		}
+	}
+
+	txBuilder, err := b.newTxBuilder(txCfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := txBuilder.SetSignatures(sigs...); err != nil {
+		return nil, err
+	}
+
+	return txBuilder.GetTx(), nil
+}
+
+// RenderTextual renders the transaction as shown to the signers in
+// SIGN_MODE_TEXTUAL, which must be enabled in txCfg. It returns the screens
+// for the first signer with a known public key, leaving out the expert ones.
+func (b *MultisigBundle) RenderTextual(ctx context.Context, txCfg client.TxConfig) (string, error) {
+	var signer *MultisigBundleSigner
+	for _, s := range b.Signers {
+		if s.PubKey != nil {
+			signer = s
+			break
+		}
+	}
+	if signer == nil {
+		return "", errors.New("no signer public key known yet")
+	}
+
+	signerData, err := b.signerData(signer, signer.PubKey)
+	if err != nil {
+		return "", err
+	}
+	screens, err := authsigning.GetTextualScreensAdapter(ctx, txCfg.SignModeHandler(), authsigning.SignerData{
+		Address:       signerData.Address,
+		ChainID:       signerData.ChainID,
+		AccountNumber: signerData.AccountNumber,
+		Sequence:      signerData.Sequence,
+		PubKey:        signer.PubKey,
+	}, b.Tx)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, screen := range screens {
+		if screen.Expert {
+			continue
+		}
+		sb.WriteString(strings.Repeat("  ", screen.Indent))
+		if screen.Title != "" {
+			sb.WriteString(screen.Title)
+			sb.WriteString(": ")
+		}
+		sb.WriteString(screen.Content)
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), nil
+}
@@ -0,0 +1,148 @@
+package client_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	bankv1beta1 "cosmossdk.io/api/cosmos/bank/v1beta1"
+	txsigning "cosmossdk.io/x/tx/signing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+func TestMultisigBundle(t *testing.T) {
+	encCfg := moduletestutil.MakeTestEncodingConfig(auth.AppModuleBasic{}, bank.AppModuleBasic{})
+	txCfg, err := authtx.NewTxConfigWithOptions(encCfg.Codec, authtx.ConfigOptions{
+		EnabledSignModes: append(authtx.DefaultSignModes, signingtypes.SignMode_SIGN_MODE_TEXTUAL),
+		TextualCoinMetadataQueryFn: func(context.Context, string) (*bankv1beta1.Metadata, error) {
+			return nil, nil
+		},
+	})
+	require.NoError(t, err)
+
+	kr := keyring.NewInMemory(encCfg.Codec)
+	pubKeys := make([]cryptotypes.PubKey, 4)
+	for i, name := range []string{"k1", "k2", "k3", "single"} {
+		k, _, err := kr.NewMnemonic(name, keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+		require.NoError(t, err)
+		pubKeys[i], err = k.GetPubKey()
+		require.NoError(t, err)
+	}
+	_, _, err = kr.NewMnemonic("other", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+	multisigPubKey := kmultisig.NewLegacyAminoPubKey(2, pubKeys[:3])
+	_, err = kr.SaveMultisig("multi", multisigPubKey)
+	require.NoError(t, err)
+
+	multisigAddr := sdk.AccAddress(multisigPubKey.Address())
+	singleAddr := sdk.AccAddress(pubKeys[3].Address())
+	txBuilder := txCfg.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(
+		banktypes.NewMsgSend(multisigAddr, sdk.AccAddress("to"), sdk.NewCoins(sdk.NewInt64Coin("stake", 10))),
+		banktypes.NewMsgSend(singleAddr, sdk.AccAddress("to"), sdk.NewCoins(sdk.NewInt64Coin("stake", 20))),
+	))
+	txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin("stake", 1)))
+	txBuilder.SetGasLimit(200000)
+
+	// signers must follow the order of the tx
+	_, err = authclient.NewMultisigBundle("test-chain", txBuilder.GetTx(), []*authclient.MultisigBundleSigner{
+		{Address: singleAddr}, {Address: multisigAddr},
+	})
+	require.Error(t, err)
+	bundle, err := authclient.NewMultisigBundle("test-chain", txBuilder.GetTx(), []*authclient.MultisigBundleSigner{
+		{Address: multisigAddr, AccountNumber: 1, Sequence: 2, PubKey: multisigPubKey},
+		{Address: singleAddr, AccountNumber: 3, Sequence: 4},
+	})
+	require.NoError(t, err)
+
+	clientCtx := client.Context{}.
+		WithTxConfig(txCfg).
+		WithCodec(encCfg.Codec).
+		WithKeyring(kr).
+		WithCmdContext(context.Background())
+	txf := tx.Factory{}.WithTxConfig(txCfg).WithKeybase(kr)
+
+	rendered, err := bundle.RenderTextual(context.Background(), txCfg)
+	require.NoError(t, err)
+	require.Contains(t, rendered, "test-chain")
+	require.Contains(t, rendered, "/cosmos.bank.v1beta1.MsgSend")
+
+	// signatures are added in any order
+	require.NoError(t, bundle.Sign(clientCtx, txf, "k3"))
+	require.NoError(t, bundle.Sign(clientCtx, txf, "single"))
+	require.NoError(t, bundle.Sign(clientCtx, txf, "k3"))
+	require.Len(t, bundle.Signers[0].Signatures, 1)
+	require.False(t, bundle.Signers[0].IsComplete())
+	require.True(t, bundle.Signers[1].IsComplete())
+	require.True(t, pubKeys[3].Equals(bundle.Signers[1].PubKey))
+	require.False(t, bundle.IsComplete())
+	_, err = bundle.SignedTx(txCfg)
+	require.Error(t, err)
+	require.Error(t, bundle.Sign(clientCtx, txf, "other"))
+
+	// the bundle is passed around as a file
+	filename := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(t, authclient.WriteMultisigBundle(clientCtx, filename, bundle))
+	bundle, err = authclient.ReadMultisigBundle(clientCtx, filename)
+	require.NoError(t, err)
+	require.Len(t, bundle.Signers[0].Signatures, 1)
+	require.Len(t, bundle.Signers[0].Missing(), 2)
+
+	require.NoError(t, bundle.Sign(clientCtx, txf, "k1"))
+	require.True(t, bundle.IsComplete())
+	require.Equal(t, []cryptotypes.PubKey{pubKeys[1]}, bundle.Signers[0].Missing())
+
+	signedTx, err := bundle.SignedTx(txCfg)
+	require.NoError(t, err)
+	sigs, err := signedTx.(authsigning.SigVerifiableTx).GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+	for i, signer := range bundle.Signers {
+		anyPk, err := codectypes.NewAnyWithValue(sigs[i].PubKey)
+		require.NoError(t, err)
+		signerData := txsigning.SignerData{
+			ChainID:       "test-chain",
+			AccountNumber: signer.AccountNumber,
+			Sequence:      signer.Sequence,
+			Address:       signer.Address.String(),
+			PubKey:        &anypb.Any{TypeUrl: anyPk.TypeUrl, Value: anyPk.Value},
+		}
+		require.NoError(t, authsigning.VerifySignature(context.Background(), sigs[i].PubKey, signerData, sigs[i].Data,
+			txCfg.SignModeHandler(), signedTx.(authsigning.V2AdaptableTx).GetSigningTxData()))
+	}
+
+	// the signatures are verified when added
+	sig := bundle.Signers[1].Signatures[0]
+	data := *sig.Data.(*signingtypes.SingleSignatureData)
+	data.Signature = append([]byte{}, data.Signature...)
+	data.Signature[0] ^= 1
+	sig.Data = &data
+	bundle.Signers[1].Signatures = nil
+	require.Error(t, bundle.AddSignature(context.Background(), txCfg, sig))
+
+	bz, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filename, []byte(string(bz[:len(bz)-2])+`, "version": 2}`), 0o600))
+	_, err = authclient.ReadMultisigBundle(clientCtx, filename)
+	require.ErrorContains(t, err, "unsupported multisig bundle version")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"cosmossdk.io/collections"
 	"cosmossdk.io/collections/indexes"
@@ -102,6 +103,9 @@ type AccountKeeper struct {
 	Params        collections.Item[types.Params]
 	AccountNumber collections.Sequence
 	Accounts      *collections.IndexedMap[sdk.AccAddress, sdk.AccountI, AccountsIndexes]
+	// UnorderedTxs holds the (timeout, tx hash) pairs of the unordered txs
+	// until their timeout, to reject their replays.
+	UnorderedTxs collections.KeySet[collections.Pair[time.Time, []byte]]
 }
 
 var _ AccountKeeperI = &AccountKeeper{}
@@ -134,6 +138,7 @@ func NewAccountKeeper(
 		Params:        collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
 		AccountNumber: collections.NewSequence(sb, types.GlobalAccountNumberKey, "account_number"),
 		Accounts:      collections.NewIndexedMap(sb, types.AddressStoreKeyPrefix, "accounts", sdk.AccAddressKey, codec.CollInterfaceValue[sdk.AccountI](cdc), NewAccountIndexes(sb)),
+		UnorderedTxs:  collections.NewKeySet(sb, types.UnorderedTxsKeyPrefix, "unordered_txs", collections.PairKeyCodec(sdk.TimeKey, collections.BytesKey)),
 	}
 	schema, err := sb.Build()
 	if err != nil {
@@ -2,6 +2,7 @@ package keeper_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
@@ -92,6 +93,26 @@ func (suite *KeeperTestSuite) TestSupply_ValidatePermissions() {
 	suite.Require().Error(err)
 }
 
+func (suite *KeeperTestSuite) TestSequenceOverrider() {
+	suite.SetupTest() // reset
+
+	overrider := keeper.NewSequenceOverrider(suite.accountKeeper)
+	addr := sdk.AccAddress([]byte("override-sequence"))
+	// sequences can't be overridden outside of simulations
+	suite.Require().Error(overrider.OverrideSequence(suite.ctx, addr, 7))
+
+	simCtx := suite.ctx.WithExecMode(sdk.ExecModeSimulate)
+	suite.Require().NoError(overrider.OverrideSequence(simCtx, addr, 7))
+	acc := suite.accountKeeper.GetAccount(suite.ctx, addr)
+	suite.Require().NotNil(acc)
+	suite.Require().Equal(uint64(7), acc.GetSequence())
+
+	suite.Require().NoError(overrider.OverrideSequence(simCtx, addr, 3))
+	overridden := suite.accountKeeper.GetAccount(suite.ctx, addr)
+	suite.Require().Equal(uint64(3), overridden.GetSequence())
+	suite.Require().Equal(acc.GetAccountNumber(), overridden.GetAccountNumber())
+}
+
 func (suite *KeeperTestSuite) TestInitGenesis() {
 	suite.SetupTest() // reset
 
@@ -215,3 +236,24 @@ func (suite *KeeperTestSuite) TestInitGenesis() {
 	// we expect nextNum to be 2 because we initialize fee_collector as account number 1
 	suite.Require().Equal(2, int(nextNum))
 }
+
+func (suite *KeeperTestSuite) TestRemoveExpiredUnorderedTxs() {
+	ctx := suite.ctx
+	now := time.Unix(1000, 0).UTC()
+
+	suite.Require().NoError(suite.accountKeeper.AddUnorderedTx(ctx, now.Add(-time.Second), []byte("expired")))
+	suite.Require().NoError(suite.accountKeeper.AddUnorderedTx(ctx, now, []byte("expiring")))
+	suite.Require().NoError(suite.accountKeeper.AddUnorderedTx(ctx, now.Add(time.Second), []byte("pending")))
+
+	suite.Require().NoError(suite.accountKeeper.RemoveExpiredUnorderedTxs(ctx.WithBlockTime(now)))
+
+	has, err := suite.accountKeeper.ContainsUnorderedTx(ctx, now.Add(-time.Second), []byte("expired"))
+	suite.Require().NoError(err)
+	suite.Require().False(has)
+	has, err = suite.accountKeeper.ContainsUnorderedTx(ctx, now, []byte("expiring"))
+	suite.Require().NoError(err)
+	suite.Require().False(has)
+	has, err = suite.accountKeeper.ContainsUnorderedTx(ctx, now.Add(time.Second), []byte("pending"))
+	suite.Require().NoError(err)
+	suite.Require().True(has)
+}
@@ -0,0 +1,41 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// SequenceOverrider sets account sequences in the state of tx simulations. It
+// implements baseapp.SequenceOverrider and rejects any context that is not a
+// simulation context, so it can't be used in state transitions.
+type SequenceOverrider struct {
+	ak AccountKeeper
+}
+
+// NewSequenceOverrider returns a new SequenceOverrider.
+func NewSequenceOverrider(ak AccountKeeper) SequenceOverrider {
+	return SequenceOverrider{ak: ak}
+}
+
+// OverrideSequence sets the sequence of the account, creating the account if
+// it doesn't exist.
+func (o SequenceOverrider) OverrideSequence(ctx context.Context, addr sdk.AccAddress, sequence uint64) error {
+	if sdk.UnwrapSDKContext(ctx).ExecMode() != sdk.ExecModeSimulate {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "sequences can only be overridden in simulations")
+	}
+
+	acc := o.ak.GetAccount(ctx, addr)
+	if acc == nil {
+		acc = o.ak.NewAccountWithAddress(ctx, addr)
+	}
+	if err := acc.SetSequence(sequence); err != nil {
+		return err
+	}
+	o.ak.SetAccount(ctx, acc)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package keeper
+
+import (
+	"context"
+	"time"
+
+	"cosmossdk.io/collections"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ContainsUnorderedTx returns true if the unordered tx with the given timeout
+// and hash was already executed.
+func (ak AccountKeeper) ContainsUnorderedTx(ctx context.Context, timeout time.Time, txHash []byte) (bool, error) {
+	return ak.UnorderedTxs.Has(ctx, collections.Join(timeout, txHash))
+}
+
+// AddUnorderedTx records the unordered tx with the given timeout and hash
+// until its timeout.
+func (ak AccountKeeper) AddUnorderedTx(ctx context.Context, timeout time.Time, txHash []byte) error {
+	return ak.UnorderedTxs.Set(ctx, collections.Join(timeout, txHash))
+}
+
+// RemoveExpiredUnorderedTxs removes the unordered txs whose timeout is not
+// after the block time. They can't be replayed as they are rejected by their
+// timeout.
+func (ak AccountKeeper) RemoveExpiredUnorderedTxs(ctx context.Context) error {
+	blockTime := sdk.UnwrapSDKContext(ctx).BlockTime()
+
+	rng := collections.NewPrefixUntilPairRange[time.Time, []byte](blockTime)
+	iter, err := ak.UnorderedTxs.Iterate(ctx, rng)
+	if err != nil {
+		return err
+	}
+	keys, err := iter.Keys()
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if err := ak.UnorderedTxs.Remove(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
 
 	modulev1 "cosmossdk.io/api/cosmos/auth/module/v1"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/address"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/core/store"
@@ -32,12 +33,14 @@ const (
 )
 
 var (
-	_ module.AppModuleBasic      = AppModule{}
-	_ module.AppModuleSimulation = AppModule{}
-	_ module.HasGenesis          = AppModule{}
-	_ module.HasServices         = AppModule{}
-
-	_ appmodule.AppModule = AppModule{}
+	_ module.AppModuleBasic       = AppModule{}
+	_ module.AppModuleSimulation  = AppModule{}
+	_ module.HasGenesis           = AppModule{}
+	_ module.HasServices          = AppModule{}
+	_ module.HasCollectionsSchema = AppModule{}
+
+	_ appmodule.AppModule     = AppModule{}
+	_ appmodule.HasPreBlocker = AppModule{}
 )
 
 // AppModuleBasic defines the basic application module used by the auth module.
@@ -152,6 +155,21 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 // ConsensusVersion implements AppModule/ConsensusVersion.
 func (AppModule) ConsensusVersion() uint64 { return ConsensusVersion }
 
+// CollectionsSchemas implements module.HasCollectionsSchema.
+func (am AppModule) CollectionsSchemas() map[string]collections.Schema {
+	return map[string]collections.Schema{types.StoreKey: am.accountKeeper.Schema}
+}
+
+// PreBlock removes the unordered txs whose timeout is reached from the store
+// rejecting their replays.
+func (am AppModule) PreBlock(ctx context.Context) (appmodule.ResponsePreBlock, error) {
+	if err := am.accountKeeper.RemoveExpiredUnorderedTxs(ctx); err != nil {
+		return nil, err
+	}
+
+	return &sdk.ResponsePreBlock{ConsensusParamsChanged: false}, nil
+}
+
 // AppModuleSimulation functions
 
 // GenerateGenesisState creates a randomized GenState of the auth module
@@ -202,8 +220,9 @@ type ModuleInputs struct {
 type ModuleOutputs struct {
 	depinject.Out
 
-	AccountKeeper keeper.AccountKeeper
-	Module        appmodule.AppModule
+	AccountKeeper     keeper.AccountKeeper
+	SequenceOverrider keeper.SequenceOverrider
+	Module            appmodule.AppModule
 }
 
 func ProvideModule(in ModuleInputs) ModuleOutputs {
@@ -229,5 +248,5 @@ func ProvideModule(in ModuleInputs) ModuleOutputs {
 	k := keeper.NewAccountKeeper(in.Cdc, in.StoreService, in.AccountI, maccPerms, in.AddressCodec, in.Config.Bech32Prefix, authority.String())
 	m := NewAppModule(in.Cdc, k, in.RandomGenesisAccountsFn, in.LegacySubspace)
 
-	return ModuleOutputs{AccountKeeper: k, Module: m}
+	return ModuleOutputs{AccountKeeper: k, SequenceOverrider: keeper.NewSequenceOverrider(k), Module: m}
 }
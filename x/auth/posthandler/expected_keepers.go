@@ -0,0 +1,18 @@
+package posthandler
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BankKeeper defines the contract needed to refund the fees.
+type BankKeeper interface {
+	SendCoinsFromModuleToAccount(ctx context.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+}
+
+// FeegrantKeeper defines the contract needed to restore the refunded fees to
+// the fee allowances.
+type FeegrantKeeper interface {
+	RestoreGrantedFees(ctx context.Context, granter, grantee sdk.AccAddress, fees sdk.Coins) error
+}
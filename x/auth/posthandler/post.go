@@ -1,15 +1,44 @@
 package posthandler
 
 import (
+	"cosmossdk.io/math"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 // HandlerOptions are the options required for constructing a default SDK PostHandler.
-type HandlerOptions struct{}
+type HandlerOptions struct {
+	// BankKeeper enables the refund of the fee paid for the unused gas of the
+	// txs when set.
+	BankKeeper BankKeeper
+	// FeegrantKeeper restores the fees refunded to a fee granter to its
+	// allowance when set.
+	FeegrantKeeper FeegrantKeeper
+	// BurnedFee returns the part of the fee of a tx burnt by the ante handler,
+	// e.g. the x/feemarket base fee, which isn't refunded.
+	BurnedFee BurnedFeeFn
+	// GasRefundPenalty is the share of the fee paid for the unused gas which
+	// isn't refunded, from 0 to 1. It defaults to 0.
+	GasRefundPenalty math.LegacyDec
+}
 
-// NewPostHandler returns an empty PostHandler chain.
-func NewPostHandler(_ HandlerOptions) (sdk.PostHandler, error) {
+// NewPostHandler returns the PostHandler chain, refunding the fee paid for
+// the unused gas if a BankKeeper is set.
+func NewPostHandler(options HandlerOptions) (sdk.PostHandler, error) {
 	postDecorators := []sdk.PostDecorator{}
 
+	if options.BankKeeper != nil {
+		penalty := options.GasRefundPenalty
+		if penalty.IsNil() {
+			penalty = math.LegacyZeroDec()
+		}
+		if penalty.IsNegative() || penalty.GT(math.LegacyOneDec()) {
+			return nil, sdkerrors.ErrLogic.Wrapf("gas refund penalty must be between 0 and 1, got %s", penalty)
+		}
+
+		postDecorators = append(postDecorators, NewGasRefundDecorator(options.BankKeeper, options.FeegrantKeeper, options.BurnedFee, penalty))
+	}
+
 	return sdk.ChainPostDecorators(postDecorators...), nil
 }
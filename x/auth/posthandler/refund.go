@@ -0,0 +1,130 @@
+package posthandler
+
+import (
+	"bytes"
+
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+// AttributeKeyFeeRefund is the attribute of the tx event holding the fee
+// refunded for the unused gas.
+const AttributeKeyFeeRefund = "fee_refund"
+
+// BurnedFeeFn returns the part of the fee of a tx burnt by the ante handler.
+type BurnedFeeFn func(ctx sdk.Context, tx sdk.FeeTx) (sdk.Coins, error)
+
+// GasRefundDecorator refunds the share of the fee paid for the gas that a
+// successful tx didn't use, minus a penalty, from the fee collector to the
+// account which paid the fee, i.e. the fee granter if set, or else the fee
+// payer. The refund of a fee paid by a granter is restored to its allowance
+// if a FeegrantKeeper is set. The part of the fee burnt by the ante handler,
+// returned by burnedFee if set, isn't refunded.
+//
+// The refund is only made in FinalizeBlock, as CheckTx doesn't execute the
+// messages, and the failed txs aren't refunded as their post handler state is
+// discarded. It doesn't consume the gas of the tx, so that the gas used by
+// the tx is the same as when it is simulated, which doesn't refund it.
+type GasRefundDecorator struct {
+	bankKeeper     BankKeeper
+	feegrantKeeper FeegrantKeeper
+	burnedFee      BurnedFeeFn
+	penalty        math.LegacyDec
+}
+
+// NewGasRefundDecorator returns a GasRefundDecorator keeping the given share
+// of the fee paid for the unused gas, from 0 to 1. The feegrant keeper and
+// burnedFee are optional.
+func NewGasRefundDecorator(bk BankKeeper, fk FeegrantKeeper, burnedFee BurnedFeeFn, penalty math.LegacyDec) GasRefundDecorator {
+	return GasRefundDecorator{
+		bankKeeper:     bk,
+		feegrantKeeper: fk,
+		burnedFee:      burnedFee,
+		penalty:        penalty,
+	}
+}
+
+// PostHandle implements the PostDecorator.PostHandle method
+func (d GasRefundDecorator) PostHandle(ctx sdk.Context, tx sdk.Tx, simulate, success bool, next sdk.PostHandler) (sdk.Context, error) {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok || simulate || !success || ctx.ExecMode() != sdk.ExecModeFinalize {
+		return next(ctx, tx, simulate, success)
+	}
+
+	fee := feeTx.GetFee()
+	if d.burnedFee != nil {
+		burned, err := d.burnedFee(ctx, feeTx)
+		if err != nil {
+			return ctx, err
+		}
+		fee = unburnedFee(fee, burned)
+	}
+
+	refund := d.refund(fee, feeTx.GetGas(), ctx.GasMeter().GasConsumed())
+	if refund.IsZero() {
+		return next(ctx, tx, simulate, success)
+	}
+
+	// the refund runs under an infinite gas meter, which leaves the gas used
+	// by the tx as computed for the refund
+	refundCtx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+	feePayer := sdk.AccAddress(feeTx.FeePayer())
+	refundTo := feePayer
+	if feeGranter := feeTx.FeeGranter(); feeGranter != nil {
+		refundTo = feeGranter
+
+		// the ante handler only uses the allowance of a granter other than the payer
+		if d.feegrantKeeper != nil && !bytes.Equal(feeGranter, feePayer) {
+			if err := d.feegrantKeeper.RestoreGrantedFees(refundCtx, feeGranter, feePayer, refund); err != nil {
+				return ctx, err
+			}
+		}
+	}
+
+	if err := d.bankKeeper.SendCoinsFromModuleToAccount(refundCtx, types.FeeCollectorName, refundTo, refund); err != nil {
+		return ctx, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeTx,
+		sdk.NewAttribute(AttributeKeyFeeRefund, refund.String()),
+		sdk.NewAttribute(sdk.AttributeKeyFeePayer, refundTo.String()),
+	))
+
+	return next(ctx, tx, simulate, success)
+}
+
+// refund returns the share of fee paid for the unused gas, minus the penalty,
+// rounded down.
+func (d GasRefundDecorator) refund(fee sdk.Coins, gasLimit, gasUsed uint64) sdk.Coins {
+	if gasLimit == 0 || gasUsed >= gasLimit {
+		return sdk.NewCoins()
+	}
+
+	unused, limit := math.NewIntFromUint64(gasLimit-gasUsed), math.NewIntFromUint64(gasLimit)
+	kept := math.LegacyOneDec().Sub(d.penalty)
+
+	refund := sdk.NewCoins()
+	for _, coin := range fee {
+		amount := math.LegacyNewDecFromInt(coin.Amount.Mul(unused)).QuoInt(limit).Mul(kept).TruncateInt()
+		refund = refund.Add(sdk.NewCoin(coin.Denom, amount))
+	}
+
+	return refund
+}
+
+// unburnedFee returns the fee left once the burned coins are removed from it.
+func unburnedFee(fee, burned sdk.Coins) sdk.Coins {
+	left := sdk.NewCoins()
+	for _, coin := range fee {
+		if amount := coin.Amount.Sub(burned.AmountOf(coin.Denom)); amount.IsPositive() {
+			left = left.Add(sdk.NewCoin(coin.Denom, amount))
+		}
+	}
+
+	return left
+}
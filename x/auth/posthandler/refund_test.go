@@ -0,0 +1,181 @@
+package posthandler_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	protov2 "google.golang.org/protobuf/proto"
+
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/posthandler"
+	"github.com/cosmos/cosmos-sdk/x/auth/types"
+)
+
+type mockBankKeeper struct {
+	sent map[string]sdk.Coins
+}
+
+func (bk *mockBankKeeper) SendCoinsFromModuleToAccount(ctx context.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error {
+	if senderModule != types.FeeCollectorName {
+		panic("unexpected sender module " + senderModule)
+	}
+	sdk.UnwrapSDKContext(ctx).GasMeter().ConsumeGas(100, "send")
+	bk.sent[recipientAddr.String()] = bk.sent[recipientAddr.String()].Add(amt...)
+	return nil
+}
+
+type mockFeegrantKeeper struct {
+	restored map[string]sdk.Coins
+}
+
+func (fk *mockFeegrantKeeper) RestoreGrantedFees(ctx context.Context, granter, grantee sdk.AccAddress, fees sdk.Coins) error {
+	sdk.UnwrapSDKContext(ctx).GasMeter().ConsumeGas(100, "restore")
+	key := granter.String() + "/" + grantee.String()
+	fk.restored[key] = fk.restored[key].Add(fees...)
+	return nil
+}
+
+type feeTx struct {
+	gas        uint64
+	fee        sdk.Coins
+	payer      sdk.AccAddress
+	feeGranter sdk.AccAddress
+}
+
+func (feeTx) GetMsgs() []sdk.Msg                    { return nil }
+func (feeTx) GetMsgsV2() ([]protov2.Message, error) { return nil, nil }
+func (tx feeTx) GetGas() uint64                     { return tx.gas }
+func (tx feeTx) GetFee() sdk.Coins                  { return tx.fee }
+func (tx feeTx) FeePayer() []byte                   { return tx.payer }
+func (tx feeTx) FeeGranter() []byte                 { return tx.feeGranter }
+
+func TestGasRefundDecorator(t *testing.T) {
+	payer, granter := sdk.AccAddress("payer"), sdk.AccAddress("granter")
+	fee := sdk.NewCoins(sdk.NewInt64Coin("stake", 1000), sdk.NewInt64Coin("atom", 7))
+
+	testCases := []struct {
+		name        string
+		tx          feeTx
+		gasUsed     uint64
+		penalty     math.LegacyDec
+		burned      sdk.Coins
+		execMode    sdk.ExecMode
+		success     bool
+		expRefunds  map[string]sdk.Coins
+		expRestored map[string]sdk.Coins
+	}{
+		{
+			name:       "refund to the fee payer",
+			tx:         feeTx{gas: 1000, fee: fee, payer: payer},
+			gasUsed:    400,
+			execMode:   sdk.ExecModeFinalize,
+			success:    true,
+			expRefunds: map[string]sdk.Coins{payer.String(): sdk.NewCoins(sdk.NewInt64Coin("stake", 600), sdk.NewInt64Coin("atom", 4))},
+		},
+		{
+			name:       "refund minus the penalty to the fee granter",
+			tx:         feeTx{gas: 1000, fee: fee, payer: payer, feeGranter: granter},
+			gasUsed:    400,
+			penalty:    math.LegacyNewDecWithPrec(5, 1),
+			execMode:   sdk.ExecModeFinalize,
+			success:    true,
+			expRefunds: map[string]sdk.Coins{granter.String(): sdk.NewCoins(sdk.NewInt64Coin("stake", 300), sdk.NewInt64Coin("atom", 2))},
+			expRestored: map[string]sdk.Coins{
+				granter.String() + "/" + payer.String(): sdk.NewCoins(sdk.NewInt64Coin("stake", 300), sdk.NewInt64Coin("atom", 2)),
+			},
+		},
+		{
+			name:       "no allowance restored for a payer paying its own fee",
+			tx:         feeTx{gas: 1000, fee: fee, payer: payer, feeGranter: payer},
+			gasUsed:    400,
+			execMode:   sdk.ExecModeFinalize,
+			success:    true,
+			expRefunds: map[string]sdk.Coins{payer.String(): sdk.NewCoins(sdk.NewInt64Coin("stake", 600), sdk.NewInt64Coin("atom", 4))},
+		},
+		{
+			name:       "no refund of the burned fee",
+			tx:         feeTx{gas: 1000, fee: fee, payer: payer},
+			gasUsed:    400,
+			burned:     sdk.NewCoins(sdk.NewInt64Coin("stake", 500), sdk.NewInt64Coin("atom", 10)),
+			execMode:   sdk.ExecModeFinalize,
+			success:    true,
+			expRefunds: map[string]sdk.Coins{payer.String(): sdk.NewCoins(sdk.NewInt64Coin("stake", 300))},
+		},
+		{
+			name:       "refund of a tx using almost all its gas",
+			tx:         feeTx{gas: 1000, fee: fee, payer: payer},
+			gasUsed:    990,
+			execMode:   sdk.ExecModeFinalize,
+			success:    true,
+			expRefunds: map[string]sdk.Coins{payer.String(): sdk.NewCoins(sdk.NewInt64Coin("stake", 10))},
+		},
+		{
+			name:       "no refund without unused gas",
+			tx:         feeTx{gas: 1000, fee: fee, payer: payer},
+			gasUsed:    1000,
+			execMode:   sdk.ExecModeFinalize,
+			success:    true,
+			expRefunds: map[string]sdk.Coins{},
+		},
+		{
+			name:       "no refund of a failed tx",
+			tx:         feeTx{gas: 1000, fee: fee, payer: payer},
+			gasUsed:    400,
+			execMode:   sdk.ExecModeFinalize,
+			success:    false,
+			expRefunds: map[string]sdk.Coins{},
+		},
+		{
+			name:       "no refund in CheckTx",
+			tx:         feeTx{gas: 1000, fee: fee, payer: payer},
+			gasUsed:    400,
+			execMode:   sdk.ExecModeCheck,
+			success:    true,
+			expRefunds: map[string]sdk.Coins{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bk := &mockBankKeeper{sent: make(map[string]sdk.Coins)}
+			fk := &mockFeegrantKeeper{restored: make(map[string]sdk.Coins)}
+			postHandler, err := posthandler.NewPostHandler(posthandler.HandlerOptions{
+				BankKeeper:     bk,
+				FeegrantKeeper: fk,
+				BurnedFee: func(sdk.Context, sdk.FeeTx) (sdk.Coins, error) {
+					return tc.burned, nil
+				},
+				GasRefundPenalty: tc.penalty,
+			})
+			require.NoError(t, err)
+
+			ctx := testutil.DefaultContext(storetypes.NewKVStoreKey("test"), storetypes.NewTransientStoreKey("transient_test")).
+				WithGasMeter(storetypes.NewGasMeter(tc.tx.gas)).
+				WithExecMode(tc.execMode)
+			ctx.GasMeter().ConsumeGas(tc.gasUsed, "test")
+
+			_, err = postHandler(ctx, tc.tx, false, tc.success)
+			require.NoError(t, err)
+			require.Equal(t, tc.expRefunds, bk.sent)
+			// the refund doesn't consume the gas of the tx, as in simulate mode
+			require.Equal(t, tc.gasUsed, ctx.GasMeter().GasConsumed())
+			if tc.expRestored == nil {
+				tc.expRestored = map[string]sdk.Coins{}
+			}
+			require.Equal(t, tc.expRestored, fk.restored)
+		})
+	}
+}
+
+func TestNewPostHandlerInvalidPenalty(t *testing.T) {
+	_, err := posthandler.NewPostHandler(posthandler.HandlerOptions{
+		BankKeeper:       &mockBankKeeper{},
+		GasRefundPenalty: math.LegacyNewDecWithPrec(11, 1),
+	})
+	require.Error(t, err)
+}
@@ -6,7 +6,9 @@ import (
 
 	"google.golang.org/protobuf/types/known/anypb"
 
+	signingv1beta1 "cosmossdk.io/api/cosmos/tx/signing/v1beta1"
 	txsigning "cosmossdk.io/x/tx/signing"
+	"cosmossdk.io/x/tx/signing/textual"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -30,22 +32,56 @@ func GetSignBytesAdapter(
 	signerData SignerData,
 	tx sdk.Tx,
 ) ([]byte, error) {
-	adaptableTx, ok := tx.(V2AdaptableTx)
-	if !ok {
-		return nil, fmt.Errorf("expected tx to be V2AdaptableTx, got %T", tx)
+	txSignerData, txData, err := adaptSigningData(signerData, tx)
+	if err != nil {
+		return nil, err
 	}
-	txData := adaptableTx.GetSigningTxData()
 
 	txSignMode, err := internalSignModeToAPI(mode)
 	if err != nil {
 		return nil, err
 	}
 
+	// Generate the bytes to be signed.
+	return handlerMap.GetSignBytes(ctx, txSignMode, txSignerData, txData)
+}
+
+// GetTextualScreensAdapter returns the SIGN_MODE_TEXTUAL screens of a given transaction, which are encoded in its
+// sign bytes. Like GetSignBytesAdapter, it converts its arguments to the arguments expected by the textual sign mode
+// handler of the txsigning.HandlerMap, then applies SignModeHandler.GetScreens to get the screens.
+func GetTextualScreensAdapter(
+	ctx context.Context,
+	handlerMap *txsigning.HandlerMap,
+	signerData SignerData,
+	tx sdk.Tx,
+) ([]textual.Screen, error) {
+	handler, _ := handlerMap.GetHandler(signingv1beta1.SignMode_SIGN_MODE_TEXTUAL)
+	textualHandler, ok := handler.(*textual.SignModeHandler)
+	if !ok {
+		return nil, fmt.Errorf("unsupported sign mode %s", signing.SignMode_SIGN_MODE_TEXTUAL)
+	}
+
+	txSignerData, txData, err := adaptSigningData(signerData, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return textualHandler.GetScreens(ctx, txSignerData, txData)
+}
+
+// adaptSigningData converts the signer data and the transaction to their x/tx representations.
+func adaptSigningData(signerData SignerData, tx sdk.Tx) (txsigning.SignerData, txsigning.TxData, error) {
+	adaptableTx, ok := tx.(V2AdaptableTx)
+	if !ok {
+		return txsigning.SignerData{}, txsigning.TxData{}, fmt.Errorf("expected tx to be V2AdaptableTx, got %T", tx)
+	}
+	txData := adaptableTx.GetSigningTxData()
+
 	var pubKey *anypb.Any
 	if signerData.PubKey != nil {
 		anyPk, err := codectypes.NewAnyWithValue(signerData.PubKey)
 		if err != nil {
-			return nil, err
+			return txsigning.SignerData{}, txsigning.TxData{}, err
 		}
 
 		pubKey = &anypb.Any{
@@ -60,6 +96,6 @@ func GetSignBytesAdapter(
 		Address:       signerData.Address,
 		PubKey:        pubKey,
 	}
-	// Generate the bytes to be signed.
-	return handlerMap.GetSignBytes(ctx, txSignMode, txSignerData, txData)
+
+	return txSignerData, txData, nil
 }
@@ -53,6 +53,9 @@ type ModuleInputs struct {
 	FeeGrantKeeper         ante.FeegrantKeeper                `optional:"true"`
 	CustomSignModeHandlers func() []txsigning.SignModeHandler `optional:"true"`
 	CustomGetSigners       []txsigning.CustomGetSigner        `optional:"true"`
+	// PostHandlerOptions are the options of the default post handler, e.g. to
+	// enable the gas refund.
+	PostHandlerOptions func() posthandler.HandlerOptions `optional:"true"`
 }
 
 type ModuleOutputs struct {
@@ -119,14 +122,18 @@ func ProvideModule(in ModuleInputs) ModuleOutputs {
 			// meaning that both `runMsgs` and `postHandler` state will be committed if
 			// both are successful, and both will be reverted if any of the two fails.
 			//
-			// The SDK exposes a default empty postHandlers chain.
+			// The SDK exposes a default postHandlers chain, empty unless the app
+			// provides PostHandlerOptions.
 			//
 			// Please note that changing any of the anteHandler or postHandler chain is
 			// likely to be a state-machine breaking change, which needs a coordinated
 			// upgrade.
-			postHandler, err := posthandler.NewPostHandler(
-				posthandler.HandlerOptions{},
-			)
+			var postHandlerOptions posthandler.HandlerOptions
+			if in.PostHandlerOptions != nil {
+				postHandlerOptions = in.PostHandlerOptions()
+			}
+
+			postHandler, err := posthandler.NewPostHandler(postHandlerOptions)
 			if err != nil {
 				panic(err)
 			}
@@ -146,6 +153,8 @@ func newAnteHandler(txConfig client.TxConfig, in ModuleInputs) (sdk.AnteHandler,
 		return nil, fmt.Errorf("both AccountKeeper and BankKeeper are required")
 	}
 
+	// NOTE: the unordered txs are disabled in the default ante handler, apps
+	// enable them by setting the UnorderedTxKeeper of their own ante handler.
 	anteHandler, err := ante.NewAnteHandler(
 		ante.HandlerOptions{
 			AccountKeeper:   in.AccountKeeper,
@@ -24,6 +24,14 @@ import (
 // baseAppSimulateFn is the signature of the Baseapp#Simulate function.
 type baseAppSimulateFn func(txBytes []byte) (sdk.GasInfo, *sdk.Result, error)
 
+// baseAppSimulateWithOverridesFn is the signature of the
+// Baseapp#SimulateWithOverrides function.
+type baseAppSimulateWithOverridesFn func(
+	txBytes []byte,
+	height int64,
+	overrides txtypes.StateOverrides,
+) (sdk.GasInfo, *sdk.Result, []txtypes.StoreChange, error)
+
 // txServer is the server for the protobuf Tx service.
 type txServer struct {
 	clientCtx         client.Context
@@ -42,6 +50,19 @@ func NewTxServer(clientCtx client.Context, simulate baseAppSimulateFn, interface
 
 var _ txtypes.ServiceServer = txServer{}
 
+// simulateServer is the server for the protobuf SimulateService.
+type simulateServer struct {
+	simulateWithOverrides baseAppSimulateWithOverridesFn
+}
+
+// NewSimulateServer creates a new SimulateService server, simulating txs
+// against overridden state.
+func NewSimulateServer(simulateWithOverrides baseAppSimulateWithOverridesFn) txtypes.SimulateServiceServer {
+	return simulateServer{simulateWithOverrides: simulateWithOverrides}
+}
+
+var _ txtypes.SimulateServiceServer = simulateServer{}
+
 // GetTxsEvent implements the ServiceServer.TxsByEvents RPC method.
 func (s txServer) GetTxsEvent(ctx context.Context, req *txtypes.GetTxsEventRequest) (*txtypes.GetTxsEventResponse, error) {
 	if req == nil {
@@ -106,6 +127,28 @@ func (s txServer) Simulate(ctx context.Context, req *txtypes.SimulateRequest) (*
 	}, nil
 }
 
+// SimulateWithOverrides implements the
+// SimulateServiceServer.SimulateWithOverrides RPC method.
+func (s simulateServer) SimulateWithOverrides(ctx context.Context, req *txtypes.SimulateWithOverridesRequest) (*txtypes.SimulateWithOverridesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid empty tx")
+	}
+	if len(req.TxBytes) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "empty txBytes is not allowed")
+	}
+
+	gasInfo, result, diff, err := s.simulateWithOverrides(req.TxBytes, req.Height, req.Overrides)
+	if err != nil {
+		return nil, status.Errorf(codes.Unknown, "%v with gas used: '%d'", err, gasInfo.GasUsed)
+	}
+
+	return &txtypes.SimulateWithOverridesResponse{
+		GasInfo:   &gasInfo,
+		Result:    result,
+		StateDiff: diff,
+	}, nil
+}
+
 // GetTx implements the ServiceServer.GetTx RPC method.
 func (s txServer) GetTx(ctx context.Context, req *txtypes.GetTxRequest) (*txtypes.GetTxResponse, error) {
 	if req == nil {
@@ -317,10 +360,25 @@ func RegisterTxService(
 	)
 }
 
-// RegisterGRPCGatewayRoutes mounts the tx service's GRPC-gateway routes on the
-// given Mux.
+// RegisterTxServiceWithOverrides registers the tx service on the gRPC router,
+// and the simulate service serving SimulateWithOverrides with the given
+// function.
+func RegisterTxServiceWithOverrides(
+	qrt gogogrpc.Server,
+	clientCtx client.Context,
+	simulateFn baseAppSimulateFn,
+	simulateWithOverridesFn baseAppSimulateWithOverridesFn,
+	interfaceRegistry codectypes.InterfaceRegistry,
+) {
+	RegisterTxService(qrt, clientCtx, simulateFn, interfaceRegistry)
+	txtypes.RegisterSimulateServiceServer(qrt, NewSimulateServer(simulateWithOverridesFn))
+}
+
+// RegisterGRPCGatewayRoutes mounts the GRPC-gateway routes of the tx service
+// and of the simulate service on the given Mux.
 func RegisterGRPCGatewayRoutes(clientConn gogogrpc.ClientConn, mux *runtime.ServeMux) {
 	txtypes.RegisterServiceHandlerClient(context.Background(), mux, txtypes.NewServiceClient(clientConn))
+	txtypes.RegisterSimulateServiceHandlerClient(context.Background(), mux, txtypes.NewSimulateServiceClient(clientConn))
 }
 
 func parseOrderBy(orderBy txtypes.OrderBy) string {
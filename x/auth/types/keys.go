@@ -28,4 +28,8 @@ var (
 
 	// AccountNumberStoreKeyPrefix prefix for account-by-id store
 	AccountNumberStoreKeyPrefix = collections.NewPrefix("accountNumber")
+
+	// UnorderedTxsKeyPrefix prefix for the (timeout, tx hash) pairs of the
+	// unordered txs executed before their timeout
+	UnorderedTxsKeyPrefix = collections.NewPrefix(3)
 )
@@ -0,0 +1,61 @@
+package keeper
+
+import (
+	"fmt"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	"github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+// NewBalancesAccumulator returns a TxAccumulator of the balances of an
+// account, letting a ParallelTxExecutor execute the txs crediting the account
+// in parallel, e.g. the fee collector module account, credited with the fee
+// of every tx.
+//
+// The txs of the block must not spend the balances of the account, nor depend
+// on them.
+func NewBalancesAccumulator(addr sdk.AccAddress) baseapp.TxAccumulator {
+	return baseapp.TxAccumulator{
+		StoreName: types.StoreKey,
+		Prefix:    append(types.BalancesPrefix.Bytes(), address.MustLengthPrefix(addr)...),
+		Add:       addBalances,
+	}
+}
+
+// addBalances adds the difference between the balances to and from to a
+// balance.
+func addBalances(value, from, to []byte) ([]byte, error) {
+	decode := func(bz []byte) (math.Int, error) {
+		if bz == nil {
+			return math.ZeroInt(), nil
+		}
+		return types.BalanceValueCodec.Decode(bz)
+	}
+
+	balance, err := decode(value)
+	if err != nil {
+		return nil, err
+	}
+	fromBalance, err := decode(from)
+	if err != nil {
+		return nil, err
+	}
+	toBalance, err := decode(to)
+	if err != nil {
+		return nil, err
+	}
+
+	balance = balance.Add(toBalance).Sub(fromBalance)
+	switch {
+	case balance.IsNegative():
+		return nil, fmt.Errorf("negative balance %s", balance)
+	case balance.IsZero():
+		return nil, nil
+	default:
+		return types.BalanceValueCodec.Encode(balance)
+	}
+}
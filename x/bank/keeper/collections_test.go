@@ -1,6 +1,7 @@
 package keeper_test
 
 import (
+	"bytes"
 	"testing"
 
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
@@ -83,3 +84,62 @@ func TestBankStateCompatibility(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []byte{}, newRawValue)
 }
+
+func TestBalancesAccumulator(t *testing.T) {
+	key := storetypes.NewKVStoreKey(banktypes.StoreKey)
+	testCtx := testutil.DefaultContextWithDB(t, key, storetypes.NewTransientStoreKey("transient_test"))
+	encCfg := moduletestutil.MakeTestEncodingConfig()
+	storeService := runtime.NewKVStoreService(key)
+
+	ctrl := gomock.NewController(t)
+	authKeeper := banktestutil.NewMockAccountKeeper(ctrl)
+	authKeeper.EXPECT().AddressCodec().Return(address.NewBech32Codec("cosmos")).AnyTimes()
+
+	k := keeper.NewBaseKeeper(
+		encCfg.Codec,
+		storeService,
+		authKeeper,
+		map[string]bool{},
+		authtypes.NewModuleAddress("gov").String(),
+		log.NewNopLogger(),
+	)
+
+	feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName)
+	accumulator := keeper.NewBalancesAccumulator(feeCollector)
+	require.Equal(t, banktypes.StoreKey, accumulator.StoreName)
+
+	// the balances of the account, and only them, have the accumulated prefix
+	ctx := testCtx.Ctx
+	require.NoError(t, k.Balances.Set(ctx, collections.Join(feeCollector, "stake"), math.NewInt(100)))
+	require.NoError(t, k.Balances.Set(ctx, collections.Join(accAddrs[0], "stake"), math.NewInt(100)))
+
+	feeKey, err := collections.EncodeKeyWithPrefix(banktypes.BalancesPrefix, k.Balances.KeyCodec(), collections.Join(feeCollector, "stake"))
+	require.NoError(t, err)
+	otherKey, err := collections.EncodeKeyWithPrefix(banktypes.BalancesPrefix, k.Balances.KeyCodec(), collections.Join(accAddrs[0], "stake"))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(feeKey, accumulator.Prefix))
+	require.False(t, bytes.HasPrefix(otherKey, accumulator.Prefix))
+
+	encode := func(amount int64) []byte {
+		bz, err := banktypes.BalanceValueCodec.Encode(math.NewInt(amount))
+		require.NoError(t, err)
+		return bz
+	}
+
+	// a tx credited 10 on top of 100, another tx credited 5 meanwhile
+	sum, err := accumulator.Add(encode(110), encode(100), encode(105))
+	require.NoError(t, err)
+	require.Equal(t, encode(115), sum)
+
+	// nil balances are zero balances
+	sum, err = accumulator.Add(encode(10), nil, encode(5))
+	require.NoError(t, err)
+	require.Equal(t, encode(15), sum)
+
+	sum, err = accumulator.Add(encode(5), encode(5), nil)
+	require.NoError(t, err)
+	require.Nil(t, sum)
+
+	_, err = accumulator.Add(encode(5), encode(10), nil)
+	require.ErrorContains(t, err, "negative balance")
+}
@@ -1033,6 +1033,31 @@ func (suite *KeeperTestSuite) TestSendCoins() {
 	require.Equal(newBarCoin(25), coins[0], "expected only bar coins in the account balance, got: %v", coins)
 }
 
+func (suite *KeeperTestSuite) TestBalanceOverrider() {
+	ctx := suite.ctx
+	require := suite.Require()
+	overrider := keeper.NewBalanceOverrider(suite.bankKeeper)
+
+	suite.mockFundAccount(accAddrs[0])
+	require.NoError(banktestutil.FundAccount(ctx, suite.bankKeeper, accAddrs[0], sdk.NewCoins(newFooCoin(100), newBarCoin(50))))
+	supply := suite.bankKeeper.GetSupply(ctx, fooDenom)
+
+	override := sdk.NewCoins(newFooCoin(10), sdk.NewInt64Coin("baz", 5))
+	// balances can't be overridden outside of simulations
+	require.Error(overrider.OverrideBalance(ctx, accAddrs[0], override))
+
+	simCtx := sdk.UnwrapSDKContext(ctx).WithExecMode(sdk.ExecModeSimulate)
+	require.NoError(overrider.OverrideBalance(simCtx, accAddrs[0], override))
+	require.Equal(override, suite.bankKeeper.GetAllBalances(ctx, accAddrs[0]))
+	// the supply is not adjusted
+	require.Equal(supply, suite.bankKeeper.GetSupply(ctx, fooDenom))
+
+	require.NoError(overrider.OverrideBalance(simCtx, accAddrs[0], sdk.NewCoins()))
+	require.True(suite.bankKeeper.GetAllBalances(ctx, accAddrs[0]).IsZero())
+
+	require.Error(overrider.OverrideBalance(simCtx, accAddrs[0], sdk.Coins{sdk.Coin{Denom: "foo", Amount: math.NewInt(-1)}}))
+}
+
 func (suite *KeeperTestSuite) TestSendCoinsWithRestrictions() {
 	type restrictionArgs struct {
 		ctx      context.Context
@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/collections"
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// BalanceOverrider replaces account balances in the state of tx simulations.
+// It implements baseapp.BalanceOverrider and rejects any context that is not
+// a simulation context, so it can't be used in state transitions.
+type BalanceOverrider struct {
+	k BaseSendKeeper
+}
+
+// NewBalanceOverrider returns a new BalanceOverrider.
+func NewBalanceOverrider(k BaseKeeper) BalanceOverrider {
+	return BalanceOverrider{k: k.BaseSendKeeper}
+}
+
+// OverrideBalance replaces the balances of the account with the given coins,
+// without adjusting the supply.
+func (o BalanceOverrider) OverrideBalance(ctx context.Context, addr sdk.AccAddress, coins sdk.Coins) error {
+	if sdk.UnwrapSDKContext(ctx).ExecMode() != sdk.ExecModeSimulate {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "balances can only be overridden in simulations")
+	}
+	if !coins.IsValid() {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidCoins, coins.String())
+	}
+
+	for _, balance := range o.k.GetAllBalances(ctx, addr) {
+		if err := o.k.Balances.Remove(ctx, collections.Join(addr, balance.Denom)); err != nil {
+			return err
+		}
+	}
+
+	for _, coin := range coins {
+		if err := o.k.setBalance(ctx, addr, coin); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"golang.org/x/exp/maps"
 
 	modulev1 "cosmossdk.io/api/cosmos/bank/module/v1"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/address"
 	"cosmossdk.io/core/appmodule"
 	corestore "cosmossdk.io/core/store"
@@ -37,11 +38,12 @@ import (
 const ConsensusVersion = 4
 
 var (
-	_ module.AppModuleBasic      = AppModule{}
-	_ module.AppModuleSimulation = AppModule{}
-	_ module.HasGenesis          = AppModule{}
-	_ module.HasServices         = AppModule{}
-	_ module.HasInvariants       = AppModule{}
+	_ module.AppModuleBasic       = AppModule{}
+	_ module.AppModuleSimulation  = AppModule{}
+	_ module.HasGenesis           = AppModule{}
+	_ module.HasServices          = AppModule{}
+	_ module.HasCollectionsSchema = AppModule{}
+	_ module.HasInvariants        = AppModule{}
 
 	_ appmodule.AppModule = AppModule{}
 )
@@ -169,6 +171,11 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 // ConsensusVersion implements AppModule/ConsensusVersion.
 func (AppModule) ConsensusVersion() uint64 { return ConsensusVersion }
 
+// CollectionsSchemas implements module.HasCollectionsSchema.
+func (am AppModule) CollectionsSchemas() map[string]collections.Schema {
+	return map[string]collections.Schema{types.StoreKey: am.keeper.(keeper.BaseKeeper).Schema}
+}
+
 // AppModuleSimulation functions
 
 // GenerateGenesisState creates a randomized GenState of the bank module.
@@ -220,8 +227,9 @@ type ModuleInputs struct {
 type ModuleOutputs struct {
 	depinject.Out
 
-	BankKeeper keeper.BaseKeeper
-	Module     appmodule.AppModule
+	BankKeeper       keeper.BaseKeeper
+	BalanceOverrider keeper.BalanceOverrider
+	Module           appmodule.AppModule
 }
 
 func ProvideModule(in ModuleInputs) ModuleOutputs {
@@ -256,7 +264,7 @@ func ProvideModule(in ModuleInputs) ModuleOutputs {
 	)
 	m := NewAppModule(in.Cdc, bankKeeper, in.AccountKeeper, in.LegacySubspace)
 
-	return ModuleOutputs{BankKeeper: bankKeeper, Module: m}
+	return ModuleOutputs{BankKeeper: bankKeeper, BalanceOverrider: keeper.NewBalanceOverrider(bankKeeper), Module: m}
 }
 
 func InvokeSetSendRestrictions(
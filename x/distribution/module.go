@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	modulev1 "cosmossdk.io/api/cosmos/distribution/module/v1"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/address"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/core/store"
@@ -34,11 +35,12 @@ import (
 const ConsensusVersion = 3
 
 var (
-	_ module.AppModuleBasic      = AppModule{}
-	_ module.AppModuleSimulation = AppModule{}
-	_ module.HasGenesis          = AppModule{}
-	_ module.HasServices         = AppModule{}
-	_ module.HasInvariants       = AppModule{}
+	_ module.AppModuleBasic       = AppModule{}
+	_ module.AppModuleSimulation  = AppModule{}
+	_ module.HasGenesis           = AppModule{}
+	_ module.HasServices          = AppModule{}
+	_ module.HasCollectionsSchema = AppModule{}
+	_ module.HasInvariants        = AppModule{}
 
 	_ appmodule.AppModule       = AppModule{}
 	_ appmodule.HasBeginBlocker = AppModule{}
@@ -165,6 +167,11 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 // ConsensusVersion implements AppModule/ConsensusVersion.
 func (AppModule) ConsensusVersion() uint64 { return ConsensusVersion }
 
+// CollectionsSchemas implements module.HasCollectionsSchema.
+func (am AppModule) CollectionsSchemas() map[string]collections.Schema {
+	return map[string]collections.Schema{types.StoreKey: am.keeper.Schema}
+}
+
 // BeginBlock returns the begin blocker for the distribution module.
 func (am AppModule) BeginBlock(ctx context.Context) error {
 	c := sdk.UnwrapSDKContext(ctx)
@@ -29,3 +29,13 @@ type FeeAllowanceI interface {
 	// ExpiresAt returns the expiry time of the allowance.
 	ExpiresAt() (*time.Time, error)
 }
+
+// RestorableFeeAllowanceI is implemented by the fee allowances the spent fees
+// can be restored to, e.g. when the fee paid for the unused gas of a tx is
+// refunded.
+type RestorableFeeAllowanceI interface {
+	FeeAllowanceI
+
+	// Restore adds the given fees, previously accepted, back to the allowance.
+	Restore(fees sdk.Coins) error
+}
@@ -20,7 +20,7 @@ const (
 )
 
 var (
-	_ FeeAllowanceI                 = (*AllowedMsgAllowance)(nil)
+	_ RestorableFeeAllowanceI       = (*AllowedMsgAllowance)(nil)
 	_ types.UnpackInterfacesMessage = (*AllowedMsgAllowance)(nil)
 )
 
@@ -88,6 +88,25 @@ func (a *AllowedMsgAllowance) Accept(ctx context.Context, fee sdk.Coins, msgs []
 	return remove, err
 }
 
+// Restore adds the given fees back to the wrapped allowance, if it can be
+// restored.
+func (a *AllowedMsgAllowance) Restore(fees sdk.Coins) error {
+	allowance, err := a.GetAllowance()
+	if err != nil {
+		return err
+	}
+
+	restorable, ok := allowance.(RestorableFeeAllowanceI)
+	if !ok {
+		return nil
+	}
+	if err := restorable.Restore(fees); err != nil {
+		return err
+	}
+
+	return a.SetAllowance(restorable)
+}
+
 func (a *AllowedMsgAllowance) allowedMsgsToMap(ctx sdk.Context) map[string]bool {
 	msgsMap := make(map[string]bool, len(a.AllowedMessages))
 	for _, msg := range a.AllowedMessages {
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -274,6 +275,30 @@ func (k Keeper) UseGrantedFees(ctx context.Context, granter, grantee sdk.AccAddr
 	return k.UpdateAllowance(ctx, granter, grantee, grant)
 }
 
+// RestoreGrantedFees adds fees previously spent by grantee from the allowance
+// of granter back to it, e.g. when the fee paid for the unused gas of a tx is
+// refunded to the granter. It does nothing if the allowance was removed, e.g.
+// because it was used up, or if it can't be restored.
+func (k Keeper) RestoreGrantedFees(ctx context.Context, granter, grantee sdk.AccAddress, fees sdk.Coins) error {
+	grant, err := k.GetAllowance(ctx, granter, grantee)
+	if errors.Is(err, sdkerrors.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	restorable, ok := grant.(feegrant.RestorableFeeAllowanceI)
+	if !ok {
+		return nil
+	}
+	if err := restorable.Restore(fees); err != nil {
+		return err
+	}
+
+	return k.UpdateAllowance(ctx, granter, grantee, restorable)
+}
+
 func emitUseGrantEvent(ctx context.Context, granter, grantee string) {
 	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(
 		sdk.NewEvent(
@@ -2,6 +2,7 @@ package keeper_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
@@ -20,6 +21,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 )
 
 type KeeperTestSuite struct {
@@ -295,6 +297,84 @@ func (suite *KeeperTestSuite) TestUseGrantedFee() {
 	suite.Contains(err.Error(), "fee-grant not found")
 }
 
+func (suite *KeeperTestSuite) TestRestoreGrantedFees() {
+	blockTime := suite.ctx.BlockTime()
+	oneYear := blockTime.AddDate(1, 0, 0)
+	fee := sdk.NewCoins(sdk.NewInt64Coin("atom", 100))
+	refund := sdk.NewCoins(sdk.NewInt64Coin("atom", 40))
+
+	basic := &feegrant.BasicAllowance{
+		SpendLimit: suite.atom,
+		Expiration: &oneYear,
+	}
+	periodic := &feegrant.PeriodicAllowance{
+		Basic:            feegrant.BasicAllowance{SpendLimit: suite.atom},
+		Period:           time.Hour,
+		PeriodSpendLimit: sdk.NewCoins(sdk.NewInt64Coin("atom", 120)),
+	}
+	allowed, err := feegrant.NewAllowedMsgAllowance(&feegrant.BasicAllowance{SpendLimit: suite.atom}, []string{"/cosmos.bank.v1beta1.MsgSend"})
+	suite.Require().NoError(err)
+
+	cases := map[string]struct {
+		grantee   sdk.AccAddress
+		allowance feegrant.FeeAllowanceI
+		expLimit  sdk.Coins
+	}{
+		"basic allowance": {
+			grantee:   suite.addrs[1],
+			allowance: basic,
+			expLimit:  sdk.NewCoins(sdk.NewInt64Coin("atom", 495)),
+		},
+		"periodic allowance": {
+			grantee:   suite.addrs[2],
+			allowance: periodic,
+			expLimit:  sdk.NewCoins(sdk.NewInt64Coin("atom", 495)),
+		},
+		"allowed msg allowance": {
+			grantee:   suite.addrs[3],
+			allowance: allowed,
+			expLimit:  sdk.NewCoins(sdk.NewInt64Coin("atom", 495)),
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		suite.Run(name, func() {
+			granter := suite.addrs[0]
+			suite.Require().NoError(suite.feegrantKeeper.GrantAllowance(suite.ctx, granter, tc.grantee, tc.allowance))
+			suite.Require().NoError(suite.feegrantKeeper.UseGrantedFees(suite.ctx, granter, tc.grantee, fee, []sdk.Msg{&banktypes.MsgSend{}}))
+			suite.Require().NoError(suite.feegrantKeeper.RestoreGrantedFees(suite.ctx, granter, tc.grantee, refund))
+
+			loaded, err := suite.feegrantKeeper.GetAllowance(suite.ctx, granter, tc.grantee)
+			suite.Require().NoError(err)
+			if filtered, ok := loaded.(*feegrant.AllowedMsgAllowance); ok {
+				loaded, err = filtered.GetAllowance()
+				suite.Require().NoError(err)
+			}
+
+			switch allowance := loaded.(type) {
+			case *feegrant.BasicAllowance:
+				suite.Require().Equal(tc.expLimit, allowance.SpendLimit)
+			case *feegrant.PeriodicAllowance:
+				suite.Require().Equal(tc.expLimit, allowance.Basic.SpendLimit)
+				// the period can spend is restored up to the period spend limit
+				suite.Require().Equal(sdk.NewCoins(sdk.NewInt64Coin("atom", 60)), allowance.PeriodCanSpend)
+				suite.Require().NoError(suite.feegrantKeeper.RestoreGrantedFees(suite.ctx, granter, tc.grantee, fee))
+				loaded, err := suite.feegrantKeeper.GetAllowance(suite.ctx, granter, tc.grantee)
+				suite.Require().NoError(err)
+				suite.Require().Equal(allowance.PeriodSpendLimit, loaded.(*feegrant.PeriodicAllowance).PeriodCanSpend)
+			default:
+				suite.FailNowf("unexpected allowance", "%T", loaded)
+			}
+		})
+	}
+
+	// nothing is restored to a removed allowance
+	suite.Require().NoError(suite.feegrantKeeper.RestoreGrantedFees(suite.ctx, suite.addrs[1], suite.addrs[0], refund))
+	_, err = suite.feegrantKeeper.GetAllowance(suite.ctx, suite.addrs[1], suite.addrs[0])
+	suite.Require().Error(err)
+}
+
 func (suite *KeeperTestSuite) TestIterateGrants() {
 	eth := sdk.NewCoins(sdk.NewInt64Coin("eth", 123))
 	exp := suite.ctx.BlockTime().AddDate(1, 0, 0)
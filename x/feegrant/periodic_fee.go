@@ -10,7 +10,7 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
-var _ FeeAllowanceI = (*PeriodicAllowance)(nil)
+var _ RestorableFeeAllowanceI = (*PeriodicAllowance)(nil)
 
 // Accept can use fee payment requested as well as timestamp of the current block
 // to determine whether or not to process this. This is checked in
@@ -50,6 +50,14 @@ func (a *PeriodicAllowance) Accept(ctx context.Context, fee sdk.Coins, _ []sdk.M
 	return false, nil
 }
 
+// Restore adds the given fees back to the amount that can be spent in the
+// current period, up to the period spend limit, and to the absolute spend
+// limit, if any.
+func (a *PeriodicAllowance) Restore(fees sdk.Coins) error {
+	a.PeriodCanSpend = a.PeriodCanSpend.Add(fees...).Min(a.PeriodSpendLimit)
+	return a.Basic.Restore(fees)
+}
+
 // tryResetPeriod will check if the PeriodReset has been hit. If not, it is a no-op.
 // If we hit the reset period, it will top up the PeriodCanSpend amount to
 // min(PeriodSpendLimit, Basic.SpendLimit) so it is never more than the maximum allowed.
@@ -0,0 +1,135 @@
+package ante
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authante "github.com/cosmos/cosmos-sdk/x/auth/ante"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+)
+
+// FeeMarketKeeper defines the feemarket keeper methods used by the ante
+// handlers.
+type FeeMarketKeeper interface {
+	GetBaseFee(ctx context.Context) (sdk.DecCoins, error)
+	BurnFees(ctx context.Context, feeCollectorName string, fees sdk.Coins) error
+}
+
+// NewTxFeeChecker returns a TxFeeChecker, to be passed to the auth
+// DeductFeeDecorator, requiring txs to pay at least the base fee for their
+// gas limit. In CheckTx, the validator min gas prices are enforced as well.
+// The tx priority is its tip, i.e. the gas price paid above the base fee.
+func NewTxFeeChecker(k FeeMarketKeeper) authante.TxFeeChecker {
+	return func(ctx sdk.Context, tx sdk.Tx) (sdk.Coins, int64, error) {
+		feeTx, ok := tx.(sdk.FeeTx)
+		if !ok {
+			return nil, 0, errorsmod.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+		}
+
+		fee := feeTx.GetFee()
+		gas := feeTx.GetGas()
+
+		if ctx.IsCheckTx() {
+			if err := checkMinGasPrices(fee, gas, ctx.MinGasPrices()); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		baseFee, err := k.GetBaseFee(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		// the fee market is disabled, or gentxs are delivered
+		if baseFee.IsZero() || ctx.BlockHeight() == 0 {
+			return fee, 0, nil
+		}
+
+		denom := baseFee[0].Denom
+		required := types.RequiredFee(baseFee[0].Amount, gas, denom)
+		if fee.AmountOf(denom).LT(required.Amount) {
+			return nil, 0, errorsmod.Wrapf(sdkerrors.ErrInsufficientFee, "insufficient fees; got: %s required base fee: %s", fee, required)
+		}
+
+		tip := types.GasPrice(fee, gas, denom).Sub(baseFee[0].Amount)
+		return fee, types.TipPriority(tip), nil
+	}
+}
+
+// checkMinGasPrices ensures that the provided fees meet the validator min gas
+// prices, where fee = ceil(minGasPrice * gasLimit).
+func checkMinGasPrices(fee sdk.Coins, gas uint64, minGasPrices sdk.DecCoins) error {
+	if minGasPrices.IsZero() {
+		return nil
+	}
+
+	requiredFees := make(sdk.Coins, len(minGasPrices))
+	for i, gp := range minGasPrices {
+		requiredFees[i] = types.RequiredFee(gp.Amount, gas, gp.Denom)
+	}
+
+	if !fee.IsAnyGTE(requiredFees) {
+		return errorsmod.Wrapf(sdkerrors.ErrInsufficientFee, "insufficient fees; got: %s required: %s", fee, requiredFees)
+	}
+
+	return nil
+}
+
+// BurnBaseFeeDecorator burns the base fee part of the fees collected by the
+// DeductFeeDecorator, which must run before it. The tip is left to the fee
+// collector and distributed to validators.
+//
+// The base fee is burnt for the gas limit of the tx, not the gas it uses:
+// the gas used is only known once the msgs are executed, and a tx whose msgs
+// fail must still pay for the block space it took. A post handler refunding
+// unused gas must therefore only refund the fees left after the burn, see
+// BurnedFee.
+type BurnBaseFeeDecorator struct {
+	keeper FeeMarketKeeper
+}
+
+func NewBurnBaseFeeDecorator(k FeeMarketKeeper) BurnBaseFeeDecorator {
+	return BurnBaseFeeDecorator{keeper: k}
+}
+
+func (bbd BurnBaseFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return ctx, errorsmod.Wrap(sdkerrors.ErrTxDecode, "Tx must be a FeeTx")
+	}
+
+	burn, err := BurnedFee(ctx, bbd.keeper, feeTx)
+	if err != nil {
+		return ctx, err
+	}
+
+	if err := bbd.keeper.BurnFees(ctx, authtypes.FeeCollectorName, burn); err != nil {
+		return ctx, err
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// BurnedFee returns the part of the tx fee burnt by the BurnBaseFeeDecorator,
+// i.e. the base fee for the gas limit of the tx. The base fee doesn't change
+// within a block, so it can be computed again after the tx is executed.
+func BurnedFee(ctx sdk.Context, k FeeMarketKeeper, tx sdk.FeeTx) (sdk.Coins, error) {
+	baseFee, err := k.GetBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseFee.IsZero() || ctx.BlockHeight() == 0 {
+		return sdk.NewCoins(), nil
+	}
+
+	// the fees paid may be lower than the base fee when simulating
+	denom := baseFee[0].Denom
+	burn := types.RequiredFee(baseFee[0].Amount, tx.GetGas(), denom)
+	burn.Amount = math.MinInt(burn.Amount, tx.GetFee().AmountOf(denom))
+
+	return sdk.NewCoins(burn), nil
+}
@@ -0,0 +1,91 @@
+package ante_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	protov2 "google.golang.org/protobuf/proto"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/ante"
+)
+
+type feeTx struct {
+	fee sdk.Coins
+	gas uint64
+}
+
+func (feeTx) GetMsgs() []sdk.Msg                    { return nil }
+func (feeTx) GetMsgsV2() ([]protov2.Message, error) { return nil, nil }
+func (tx feeTx) GetGas() uint64                     { return tx.gas }
+func (tx feeTx) GetFee() sdk.Coins                  { return tx.fee }
+func (feeTx) FeePayer() []byte                      { return nil }
+func (feeTx) FeeGranter() []byte                    { return nil }
+
+type feeMarketKeeper struct {
+	baseFee sdk.DecCoins
+	burnt   sdk.Coins
+}
+
+func (k *feeMarketKeeper) GetBaseFee(context.Context) (sdk.DecCoins, error) {
+	return k.baseFee, nil
+}
+
+func (k *feeMarketKeeper) BurnFees(_ context.Context, _ string, fees sdk.Coins) error {
+	k.burnt = k.burnt.Add(fees...)
+	return nil
+}
+
+func TestTxFeeChecker(t *testing.T) {
+	k := &feeMarketKeeper{
+		baseFee: sdk.NewDecCoins(sdk.NewDecCoinFromDec("stake", math.LegacyMustNewDecFromStr("1.5"))),
+	}
+	checker := ante.NewTxFeeChecker(k)
+	ctx := sdk.Context{}.WithBlockHeight(1)
+
+	// below the base fee
+	_, _, err := checker(ctx, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin("stake", 149)), gas: 100})
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// at the base fee
+	fee := sdk.NewCoins(sdk.NewInt64Coin("stake", 150))
+	effectiveFee, priority, err := checker(ctx, feeTx{fee: fee, gas: 100})
+	require.NoError(t, err)
+	require.Equal(t, fee, effectiveFee)
+	require.Zero(t, priority)
+
+	// the priority is the tip
+	_, priority, err = checker(ctx, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin("stake", 250)), gas: 100})
+	require.NoError(t, err)
+	require.Equal(t, int64(1_000_000), priority)
+
+	// validator min gas prices are enforced in CheckTx
+	checkCtx := ctx.WithIsCheckTx(true).WithMinGasPrices(sdk.NewDecCoins(sdk.NewInt64DecCoin("stake", 2)))
+	_, _, err = checker(checkCtx, feeTx{fee: fee, gas: 100})
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// gentxs don't pay the base fee
+	_, _, err = checker(ctx.WithBlockHeight(0), feeTx{gas: 100})
+	require.NoError(t, err)
+}
+
+func TestBurnBaseFeeDecorator(t *testing.T) {
+	k := &feeMarketKeeper{
+		baseFee: sdk.NewDecCoins(sdk.NewDecCoinFromDec("stake", math.LegacyMustNewDecFromStr("1.5"))),
+	}
+	anteHandler := sdk.ChainAnteDecorators(ante.NewBurnBaseFeeDecorator(k))
+	ctx := sdk.Context{}.WithBlockHeight(1)
+
+	_, err := anteHandler(ctx, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin("stake", 250)), gas: 100}, false)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 150)), k.burnt)
+
+	// simulated txs burn at most the fees they pay
+	_, err = anteHandler(ctx, feeTx{fee: sdk.NewCoins(sdk.NewInt64Coin("stake", 50)), gas: 100}, true)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 200)), k.burnt)
+}
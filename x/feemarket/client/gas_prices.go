@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+)
+
+// GasPrices is a client.GasPricesFn returning the base fee of the fee market
+// with the Query/BaseFee RPC, or the minimum gas prices of the node when the
+// fee market is disabled, so that --fees=auto pays the current base fee.
+func GasPrices(clientCtx client.Context) (sdk.DecCoins, error) {
+	if clientCtx.Offline {
+		return nil, errors.New("cannot query the base fee in offline mode, set the gas prices instead")
+	}
+
+	res, err := types.NewQueryClient(clientCtx).BaseFee(context.Background(), &types.QueryBaseFeeRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if !res.BaseFee.IsZero() {
+		return res.BaseFee, nil
+	}
+
+	return tx.QueryMinGasPrices(clientCtx)
+}
@@ -0,0 +1,34 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+)
+
+// InitGenesis initializes the feemarket module's state from a given genesis
+// state.
+func (k Keeper) InitGenesis(ctx context.Context, data *types.GenesisState) {
+	if err := k.Params.Set(ctx, data.Params); err != nil {
+		panic(err)
+	}
+
+	if err := k.BaseFee.Set(ctx, data.BaseFee); err != nil {
+		panic(err)
+	}
+}
+
+// ExportGenesis returns the feemarket module's exported genesis.
+func (k Keeper) ExportGenesis(ctx context.Context) *types.GenesisState {
+	params, err := k.Params.Get(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	baseFee, err := k.BaseFee.Get(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	return types.NewGenesisState(params, baseFee)
+}
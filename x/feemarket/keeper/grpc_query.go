@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+)
+
+var _ types.QueryServer = queryServer{}
+
+type queryServer struct {
+	k Keeper
+}
+
+// NewQueryServerImpl returns an implementation of the x/feemarket QueryServer
+// interface for the provided Keeper.
+func NewQueryServerImpl(k Keeper) types.QueryServer {
+	return queryServer{k}
+}
+
+// Params returns the params of the module.
+func (q queryServer) Params(ctx context.Context, _ *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	params, err := q.k.Params.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.QueryParamsResponse{Params: params}, nil
+}
+
+// BaseFee returns the base fee of the current block.
+func (q queryServer) BaseFee(ctx context.Context, _ *types.QueryBaseFeeRequest) (*types.QueryBaseFeeResponse, error) {
+	baseFee, err := q.k.GetBaseFee(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.QueryBaseFeeResponse{BaseFee: baseFee}, nil
+}
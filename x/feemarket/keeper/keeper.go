@@ -0,0 +1,134 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	"cosmossdk.io/collections"
+	storetypes "cosmossdk.io/core/store"
+	"cosmossdk.io/log"
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+)
+
+// Keeper of the feemarket store
+type Keeper struct {
+	cdc          codec.BinaryCodec
+	storeService storetypes.KVStoreService
+	bankKeeper   types.BankKeeper
+
+	// the address capable of executing a MsgUpdateParams message. Typically, this
+	// should be the x/gov module account.
+	authority string
+
+	Schema  collections.Schema
+	Params  collections.Item[types.Params]
+	BaseFee collections.Item[math.LegacyDec]
+}
+
+// NewKeeper creates a new feemarket Keeper instance
+func NewKeeper(
+	cdc codec.BinaryCodec,
+	storeService storetypes.KVStoreService,
+	ak types.AccountKeeper,
+	bk types.BankKeeper,
+	authority string,
+) Keeper {
+	// ensure feemarket module account is set, base fees are burnt from it
+	if addr := ak.GetModuleAddress(types.ModuleName); addr == nil {
+		panic(fmt.Sprintf("the x/%s module account has not been set", types.ModuleName))
+	}
+
+	sb := collections.NewSchemaBuilder(storeService)
+	k := Keeper{
+		cdc:          cdc,
+		storeService: storeService,
+		bankKeeper:   bk,
+		authority:    authority,
+		Params:       collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
+		BaseFee:      collections.NewItem(sb, types.BaseFeeKey, "base_fee", sdk.LegacyDecValue),
+	}
+
+	schema, err := sb.Build()
+	if err != nil {
+		panic(err)
+	}
+	k.Schema = schema
+	return k
+}
+
+// GetAuthority returns the x/feemarket module's authority.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx context.Context) log.Logger {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	return sdkCtx.Logger().With("module", "x/"+types.ModuleName)
+}
+
+// GetBaseFee returns the base fee of the current block, or nil if the fee
+// market is disabled.
+func (k Keeper) GetBaseFee(ctx context.Context) (sdk.DecCoins, error) {
+	params, err := k.Params.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !params.Enabled {
+		return nil, nil
+	}
+
+	baseFee, err := k.BaseFee.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdk.NewDecCoins(sdk.NewDecCoinFromDec(params.Denom, baseFee)), nil
+}
+
+// BurnFees burns the given fees, which must have been collected by the fee
+// collector module account.
+func (k Keeper) BurnFees(ctx context.Context, feeCollectorName string, fees sdk.Coins) error {
+	if fees.IsZero() {
+		return nil
+	}
+
+	if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, feeCollectorName, types.ModuleName, fees); err != nil {
+		return err
+	}
+
+	return k.bankKeeper.BurnCoins(ctx, types.ModuleName, fees)
+}
+
+// EndBlocker adjusts the base fee of the next block to the gas consumed by
+// the current one.
+func (k Keeper) EndBlocker(ctx context.Context) error {
+	params, err := k.Params.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if !params.Enabled {
+		return nil
+	}
+
+	baseFee, err := k.BaseFee.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	var maxGas int64
+	if block := sdkCtx.ConsensusParams().Block; block != nil {
+		maxGas = block.MaxGas
+	}
+	var gasUsed uint64
+	if meter := sdkCtx.BlockGasMeter(); meter != nil {
+		gasUsed = meter.GasConsumed()
+	}
+
+	return k.BaseFee.Set(ctx, types.NextBaseFee(params, baseFee, gasUsed, maxGas))
+}
@@ -0,0 +1,128 @@
+package keeper_test
+
+import (
+	"context"
+	"testing"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/feemarket"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/keeper"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+var authority = authtypes.NewModuleAddress(govtypes.ModuleName).String()
+
+type accountKeeper struct{}
+
+func (accountKeeper) GetModuleAddress(name string) sdk.AccAddress {
+	return authtypes.NewModuleAddress(name)
+}
+
+type bankKeeper struct {
+	burnt sdk.Coins
+}
+
+func (bk *bankKeeper) SendCoinsFromModuleToModule(_ context.Context, _, _ string, _ sdk.Coins) error {
+	return nil
+}
+
+func (bk *bankKeeper) BurnCoins(_ context.Context, _ string, amt sdk.Coins) error {
+	bk.burnt = bk.burnt.Add(amt...)
+	return nil
+}
+
+func setupKeeper(t *testing.T) (keeper.Keeper, *bankKeeper, sdk.Context) {
+	t.Helper()
+
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	testCtx := testutil.DefaultContextWithDB(t, key, storetypes.NewTransientStoreKey("transient_test"))
+	bk := &bankKeeper{}
+	encCfg := moduletestutil.MakeTestEncodingConfig(feemarket.AppModuleBasic{})
+	k := keeper.NewKeeper(encCfg.Codec, runtime.NewKVStoreService(key), accountKeeper{}, bk, authority)
+
+	return k, bk, testCtx.Ctx
+}
+
+func TestEndBlocker(t *testing.T) {
+	k, _, ctx := setupKeeper(t)
+
+	gs := types.DefaultGenesisState()
+	gs.Params.Enabled = true
+	gs.BaseFee = math.LegacyNewDec(8)
+	k.InitGenesis(ctx, gs)
+
+	ctx = ctx.WithConsensusParams(cmtproto.ConsensusParams{Block: &cmtproto.BlockParams{MaxGas: 1000}})
+
+	// full block: +12.5%
+	meter := storetypes.NewGasMeter(1000)
+	meter.ConsumeGas(1000, "txs")
+	require.NoError(t, k.EndBlocker(ctx.WithBlockGasMeter(meter)))
+	baseFee, err := k.GetBaseFee(ctx)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecCoins(sdk.NewDecCoin(sdk.DefaultBondDenom, math.NewInt(9))), baseFee)
+
+	// empty block: -12.5%
+	require.NoError(t, k.EndBlocker(ctx.WithBlockGasMeter(storetypes.NewGasMeter(1000))))
+	exported := k.ExportGenesis(ctx)
+	require.Equal(t, math.LegacyMustNewDecFromStr("7.875").String(), exported.BaseFee.String())
+
+	// disabled fee market
+	gs.Params.Enabled = false
+	k.InitGenesis(ctx, gs)
+	require.NoError(t, k.EndBlocker(ctx.WithBlockGasMeter(meter)))
+	baseFee, err = k.GetBaseFee(ctx)
+	require.NoError(t, err)
+	require.Nil(t, baseFee)
+	require.Equal(t, gs.BaseFee.String(), k.ExportGenesis(ctx).BaseFee.String())
+}
+
+func TestBurnFees(t *testing.T) {
+	k, bk, ctx := setupKeeper(t)
+
+	require.NoError(t, k.BurnFees(ctx, authtypes.FeeCollectorName, sdk.NewCoins()))
+	require.Nil(t, bk.burnt)
+
+	fees := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10))
+	require.NoError(t, k.BurnFees(ctx, authtypes.FeeCollectorName, fees))
+	require.Equal(t, fees, bk.burnt)
+}
+
+func TestUpdateParams(t *testing.T) {
+	k, _, ctx := setupKeeper(t)
+	k.InitGenesis(ctx, types.DefaultGenesisState())
+	msgServer := keeper.NewMsgServerImpl(k)
+
+	params := types.DefaultParams()
+	params.Enabled = true
+	params.MinBaseFee = math.LegacyNewDec(2)
+
+	_, err := msgServer.UpdateParams(ctx, &types.MsgUpdateParams{Authority: sdk.AccAddress("invalid").String(), Params: params})
+	require.ErrorIs(t, err, govtypes.ErrInvalidSigner)
+
+	invalid := params
+	invalid.MinBaseFee = math.LegacyZeroDec()
+	_, err = msgServer.UpdateParams(ctx, &types.MsgUpdateParams{Authority: authority, Params: invalid})
+	require.Error(t, err)
+
+	_, err = msgServer.UpdateParams(ctx, &types.MsgUpdateParams{Authority: authority, Params: params})
+	require.NoError(t, err)
+
+	// the base fee is raised to the new min base fee
+	res, err := keeper.NewQueryServerImpl(k).Params(ctx, &types.QueryParamsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, params, res.Params)
+	baseFee, err := keeper.NewQueryServerImpl(k).BaseFee(ctx, &types.QueryBaseFeeRequest{})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecCoins(sdk.NewDecCoin(sdk.DefaultBondDenom, math.NewInt(2))), baseFee.BaseFee)
+}
@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+type msgServer struct {
+	Keeper
+}
+
+var _ types.MsgServer = msgServer{}
+
+// NewMsgServerImpl returns an implementation of the x/feemarket MsgServer
+// interface for the provided Keeper.
+func NewMsgServerImpl(k Keeper) types.MsgServer {
+	return &msgServer{Keeper: k}
+}
+
+// UpdateParams updates the params.
+func (ms msgServer) UpdateParams(ctx context.Context, msg *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	if ms.authority != msg.Authority {
+		return nil, errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", ms.authority, msg.Authority)
+	}
+
+	if err := msg.Params.Validate(); err != nil {
+		return nil, err
+	}
+
+	// the base fee never goes below the min base fee
+	baseFee, err := ms.BaseFee.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if baseFee.LT(msg.Params.MinBaseFee) {
+		if err := ms.BaseFee.Set(ctx, msg.Params.MinBaseFee); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ms.Params.Set(ctx, msg.Params); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
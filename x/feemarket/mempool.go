@@ -0,0 +1,47 @@
+package feemarket
+
+import (
+	"context"
+	"math"
+
+	"github.com/huandu/skiplist"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+)
+
+// NewTxPriority returns a TxPriority ordering txs by the gas price they pay in
+// the fee market denom. As the base fee is the same for all txs of a block,
+// this is the order of their effective tip, and it doesn't depend on the base
+// fee at insertion time.
+func NewTxPriority(denom string) mempool.TxPriority[int64] {
+	return mempool.TxPriority[int64]{
+		GetTxPriority: func(_ context.Context, tx sdk.Tx) int64 {
+			feeTx, ok := tx.(sdk.FeeTx)
+			if !ok {
+				return 0
+			}
+
+			return types.TipPriority(types.GasPrice(feeTx.GetFee(), feeTx.GetGas(), denom))
+		},
+		Compare: func(a, b int64) int {
+			return skiplist.Int64.Compare(a, b)
+		},
+		MinValue: math.MinInt64,
+	}
+}
+
+// NewMempool returns a priority nonce mempool ordering txs by effective tip
+// while respecting the nonces of each sender. A tx replaces a pending tx of
+// the same sender and nonce only if it pays a higher gas price.
+func NewMempool(denom string, maxTx int) *mempool.PriorityNonceMempool[int64] {
+	return mempool.NewPriorityMempool(mempool.PriorityNonceMempoolConfig[int64]{
+		TxPriority: NewTxPriority(denom),
+		TxReplacement: func(op, np int64, _, _ sdk.Tx) bool {
+			return np > op
+		},
+		MaxTx:           maxTx,
+		SignerExtractor: mempool.NewDefaultSignerExtractionAdapter(),
+	})
+}
@@ -0,0 +1,63 @@
+package feemarket_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	codectestutil "github.com/cosmos/cosmos-sdk/codec/testutil"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/cosmos/cosmos-sdk/x/feemarket"
+)
+
+func buildTx(t *testing.T, txConfig client.TxConfig, secret string, sequence uint64, fee int64) sdk.Tx {
+	t.Helper()
+
+	privKey := secp256k1.GenPrivKeyFromSecret([]byte(secret))
+	builder := txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(testdata.NewTestMsg(privKey.PubKey().Address().Bytes())))
+	builder.SetGasLimit(100)
+	builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin("stake", fee)))
+	require.NoError(t, builder.SetSignatures(signingtypes.SignatureV2{
+		PubKey:   privKey.PubKey(),
+		Sequence: sequence,
+		Data:     &signingtypes.SingleSignatureData{},
+	}))
+
+	return builder.GetTx()
+}
+
+func TestMempoolOrdering(t *testing.T) {
+	cdc := codectestutil.CodecOptions{}.NewCodec()
+	testdata.RegisterInterfaces(cdc.InterfaceRegistry())
+	txConfig := authtx.NewTxConfig(cdc, authtx.DefaultSignModes)
+
+	ctx := sdk.Context{}
+	mp := feemarket.NewMempool("stake", 0)
+
+	a0 := buildTx(t, txConfig, "a", 0, 150)
+	a1 := buildTx(t, txConfig, "a", 1, 500)
+	b0 := buildTx(t, txConfig, "b", 0, 300)
+	c0 := buildTx(t, txConfig, "c", 0, 200)
+	for _, tx := range []sdk.Tx{a1, c0, b0, a0} {
+		require.NoError(t, mp.Insert(ctx, tx))
+	}
+
+	var selected []sdk.Tx
+	for iter := mp.Select(ctx, nil); iter != nil; iter = iter.Next() {
+		selected = append(selected, iter.Tx())
+	}
+
+	// a1 pays the highest tip but can't be included before a0
+	require.Equal(t, []sdk.Tx{b0, c0, a0, a1}, selected)
+
+	// a pending tx is only replaced by a tx paying a higher gas price
+	require.Error(t, mp.Insert(ctx, buildTx(t, txConfig, "b", 0, 300)))
+	require.NoError(t, mp.Insert(ctx, buildTx(t, txConfig, "b", 0, 400)))
+	require.Equal(t, 4, mp.CountTx())
+}
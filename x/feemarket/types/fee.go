@@ -0,0 +1,69 @@
+package types
+
+import (
+	stdmath "math"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NextBaseFee returns the base fee of the next block given the base fee and
+// the gas used by the current block, as specified by EIP-1559. The gas
+// targeted by the adjustment is maxGas / ElasticityMultiplier. The base fee
+// is left unchanged if the block gas is not limited.
+func NextBaseFee(params Params, baseFee math.LegacyDec, gasUsed uint64, maxGas int64) math.LegacyDec {
+	if maxGas <= 0 {
+		return baseFee
+	}
+
+	target := uint64(maxGas) / params.ElasticityMultiplier
+	if target == 0 || gasUsed == target {
+		return baseFee
+	}
+
+	var delta math.LegacyDec
+	if gasUsed > target {
+		delta = baseFee.MulInt64(int64(gasUsed - target))
+	} else {
+		delta = baseFee.MulInt64(int64(target - gasUsed)).Neg()
+	}
+	delta = delta.QuoInt64(int64(target)).QuoInt64(int64(params.BaseFeeChangeDenominator))
+
+	return math.LegacyMaxDec(baseFee.Add(delta), params.MinBaseFee)
+}
+
+// GasPrice returns the gas price paid by a tx in the given denom.
+func GasPrice(fee sdk.Coins, gas uint64, denom string) math.LegacyDec {
+	if gas == 0 {
+		return math.LegacyZeroDec()
+	}
+
+	return math.LegacyNewDecFromInt(fee.AmountOf(denom)).QuoInt(math.NewIntFromUint64(gas))
+}
+
+// RequiredFee returns the fee a tx consuming the given gas must at least pay
+// at the given base fee, rounded up.
+func RequiredFee(baseFee math.LegacyDec, gas uint64, denom string) sdk.Coin {
+	amount := baseFee.MulInt(math.NewIntFromUint64(gas)).Ceil().RoundInt()
+	return sdk.NewCoin(denom, amount)
+}
+
+// tipPriorityScale is the number of tip units per gas unit priorities are
+// expressed in, so that fractional gas prices are still ordered.
+const tipPriorityScale = 1_000_000
+
+// TipPriority returns the priority of a tx paying the given tip per gas unit
+// above the base fee.
+func TipPriority(tip math.LegacyDec) int64 {
+	if !tip.IsPositive() {
+		return 0
+	}
+
+	priority := tip.MulInt64(tipPriorityScale).TruncateInt()
+	if !priority.IsInt64() {
+		return stdmath.MaxInt64
+	}
+
+	return priority.Int64()
+}
@@ -0,0 +1,70 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feemarket/types"
+)
+
+func TestNextBaseFee(t *testing.T) {
+	params := types.DefaultParams()
+	params.MinBaseFee = math.LegacyNewDec(10)
+	baseFee := math.LegacyNewDec(100)
+
+	testCases := map[string]struct {
+		gasUsed uint64
+		maxGas  int64
+		exp     math.LegacyDec
+	}{
+		"no block gas limit":      {gasUsed: 1000, maxGas: -1, exp: baseFee},
+		"at target":               {gasUsed: 500, maxGas: 1000, exp: baseFee},
+		"full block":              {gasUsed: 1000, maxGas: 1000, exp: math.LegacyMustNewDecFromStr("112.5")},
+		"empty block":             {gasUsed: 0, maxGas: 1000, exp: math.LegacyMustNewDecFromStr("87.5")},
+		"above target":            {gasUsed: 750, maxGas: 1000, exp: math.LegacyMustNewDecFromStr("106.25")},
+		"clamped to min base fee": {gasUsed: 0, maxGas: 1000, exp: params.MinBaseFee},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			current := baseFee
+			if name == "clamped to min base fee" {
+				current = math.LegacyNewDec(11)
+			}
+			require.Equal(t, tc.exp.String(), types.NextBaseFee(params, current, tc.gasUsed, tc.maxGas).String())
+		})
+	}
+}
+
+func TestTipPriority(t *testing.T) {
+	fee := sdk.NewCoins(sdk.NewInt64Coin("stake", 150))
+
+	gasPrice := types.GasPrice(fee, 100, "stake")
+	require.Equal(t, math.LegacyMustNewDecFromStr("1.5"), gasPrice)
+	require.Equal(t, int64(500_000), types.TipPriority(gasPrice.Sub(math.LegacyOneDec())))
+	require.Equal(t, int64(0), types.TipPriority(gasPrice.Sub(math.LegacyNewDec(2))))
+	require.Equal(t, types.RequiredFee(math.LegacyMustNewDecFromStr("1.001"), 1000, "stake"), sdk.NewInt64Coin("stake", 1001))
+	require.Equal(t, types.RequiredFee(math.LegacyMustNewDecFromStr("0.0011"), 1000, "stake"), sdk.NewInt64Coin("stake", 2))
+}
+
+func TestValidateGenesis(t *testing.T) {
+	require.NoError(t, types.ValidateGenesis(*types.DefaultGenesisState()))
+
+	gs := types.DefaultGenesisState()
+	gs.BaseFee = gs.Params.MinBaseFee.QuoInt64(2)
+	require.Error(t, types.ValidateGenesis(*gs))
+
+	gs = types.DefaultGenesisState()
+	gs.Params.ElasticityMultiplier = 0
+	require.Error(t, types.ValidateGenesis(*gs))
+
+	// a zero base fee would never rise again
+	gs = types.DefaultGenesisState()
+	gs.Params.MinBaseFee = math.LegacyZeroDec()
+	gs.BaseFee = math.LegacyZeroDec()
+	require.Error(t, types.ValidateGenesis(*gs))
+}
@@ -0,0 +1,501 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/feemarket/v1/feemarket.proto
+
+package types
+
+import (
+	cosmossdk_io_math "cosmossdk.io/math"
+	fmt "fmt"
+	_ "github.com/cosmos/cosmos-proto"
+	_ "github.com/cosmos/cosmos-sdk/types/tx/amino"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Params defines the parameters of the fee market.
+type Params struct {
+	// enabled turns the base fee enforcement and adjustment on.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// denom is the denomination the base fee is paid in.
+	Denom string `protobuf:"bytes,2,opt,name=denom,proto3" json:"denom,omitempty"`
+	// min_base_fee is the gas price the base fee never goes below. It must be
+	// positive, as a zero base fee would never rise again.
+	MinBaseFee cosmossdk_io_math.LegacyDec `protobuf:"bytes,3,opt,name=min_base_fee,json=minBaseFee,proto3,customtype=cosmossdk.io/math.LegacyDec" json:"min_base_fee"`
+	// base_fee_change_denominator bounds the change of the base fee between two
+	// blocks to 1/base_fee_change_denominator.
+	BaseFeeChangeDenominator uint64 `protobuf:"varint,4,opt,name=base_fee_change_denominator,json=baseFeeChangeDenominator,proto3" json:"base_fee_change_denominator,omitempty"`
+	// elasticity_multiplier is the ratio of the block max gas to the gas
+	// targeted by the base fee adjustment.
+	ElasticityMultiplier uint64 `protobuf:"varint,5,opt,name=elasticity_multiplier,json=elasticityMultiplier,proto3" json:"elasticity_multiplier,omitempty"`
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_481036a621b23787, []int{0}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+func (m *Params) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *Params) GetDenom() string {
+	if m != nil {
+		return m.Denom
+	}
+	return ""
+}
+
+func (m *Params) GetBaseFeeChangeDenominator() uint64 {
+	if m != nil {
+		return m.BaseFeeChangeDenominator
+	}
+	return 0
+}
+
+func (m *Params) GetElasticityMultiplier() uint64 {
+	if m != nil {
+		return m.ElasticityMultiplier
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Params)(nil), "cosmos.feemarket.v1.Params")
+}
+
+func init() {
+	proto.RegisterFile("cosmos/feemarket/v1/feemarket.proto", fileDescriptor_481036a621b23787)
+}
+
+var fileDescriptor_481036a621b23787 = []byte{
+	// 369 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x91, 0x4f, 0x6b, 0xe2, 0x40,
+	0x18, 0xc6, 0x33, 0xae, 0xba, 0xbb, 0xc3, 0x5e, 0x36, 0xeb, 0x42, 0xaa, 0x34, 0x8a, 0xbd, 0x88,
+	0x60, 0x82, 0x08, 0x3d, 0x14, 0x7a, 0xb1, 0x52, 0x7a, 0x68, 0xa1, 0xe4, 0x54, 0x7a, 0x09, 0x93,
+	0xe4, 0x35, 0x0e, 0x66, 0x32, 0x92, 0x19, 0xa5, 0x7e, 0x85, 0x9e, 0xfa, 0x31, 0x4a, 0x4f, 0x1e,
+	0xfa, 0x21, 0x3c, 0x4a, 0x4f, 0xa5, 0x07, 0x29, 0x7a, 0xf0, 0x6b, 0x14, 0x33, 0xda, 0xf4, 0xd2,
+	0x4b, 0xc8, 0xf3, 0xfe, 0x9e, 0xf7, 0x0f, 0xcf, 0xe0, 0x23, 0x9f, 0x0b, 0xc6, 0x85, 0xdd, 0x07,
+	0x60, 0x24, 0x19, 0x82, 0xb4, 0x27, 0xed, 0x4c, 0x58, 0xa3, 0x84, 0x4b, 0xae, 0xff, 0x53, 0x26,
+	0x2b, 0xab, 0x4f, 0xda, 0xe5, 0x52, 0xc8, 0x43, 0x9e, 0x72, 0x7b, 0xfb, 0xa7, 0xac, 0xe5, 0x03,
+	0x65, 0x75, 0x15, 0xd8, 0xf5, 0x29, 0xf4, 0x97, 0x30, 0x1a, 0x73, 0x3b, 0xfd, 0xaa, 0x52, 0xfd,
+	0x29, 0x87, 0x8b, 0xd7, 0x24, 0x21, 0x4c, 0xe8, 0x06, 0xfe, 0x09, 0x31, 0xf1, 0x22, 0x08, 0x0c,
+	0x54, 0x43, 0x8d, 0x5f, 0xce, 0x5e, 0xea, 0x25, 0x5c, 0x08, 0x20, 0xe6, 0xcc, 0xc8, 0xd5, 0x50,
+	0xe3, 0xb7, 0xa3, 0x84, 0x7e, 0x83, 0xff, 0x30, 0x1a, 0xbb, 0x1e, 0x11, 0xe0, 0xf6, 0x01, 0x8c,
+	0x1f, 0x5b, 0xd8, 0x3d, 0x9e, 0x2f, 0xab, 0xda, 0xdb, 0xb2, 0x5a, 0x51, 0x9b, 0x45, 0x30, 0xb4,
+	0x28, 0xb7, 0x19, 0x91, 0x03, 0xeb, 0x12, 0x42, 0xe2, 0x4f, 0x7b, 0xe0, 0xbf, 0x3c, 0xb7, 0xf0,
+	0xee, 0xb0, 0x1e, 0xf8, 0x8f, 0x9b, 0x59, 0x13, 0x39, 0x98, 0xd1, 0xb8, 0x4b, 0x04, 0x9c, 0x03,
+	0xe8, 0xa7, 0xb8, 0xb2, 0x9f, 0xea, 0xfa, 0x03, 0x12, 0x87, 0xe0, 0xa6, 0x2b, 0x69, 0x4c, 0x24,
+	0x4f, 0x8c, 0x7c, 0x0d, 0x35, 0xf2, 0x8e, 0xe1, 0x29, 0xf7, 0x59, 0x6a, 0xe8, 0x65, 0x5c, 0xef,
+	0xe0, 0xff, 0x10, 0x11, 0x21, 0xa9, 0x4f, 0xe5, 0xd4, 0x65, 0xe3, 0x48, 0xd2, 0x51, 0x44, 0x21,
+	0x31, 0x0a, 0x69, 0x63, 0x29, 0x83, 0x57, 0x9f, 0xec, 0xa4, 0x7e, 0xbf, 0x99, 0x35, 0x0f, 0xd5,
+	0x55, 0x2d, 0x11, 0x0c, 0xed, 0xbb, 0x2f, 0x2f, 0xa2, 0x12, 0xea, 0x5e, 0xcc, 0x57, 0x26, 0x5a,
+	0xac, 0x4c, 0xf4, 0xbe, 0x32, 0xd1, 0xc3, 0xda, 0xd4, 0x16, 0x6b, 0x53, 0x7b, 0x5d, 0x9b, 0xda,
+	0xad, 0x15, 0x52, 0x39, 0x18, 0x7b, 0x96, 0xcf, 0xd9, 0x2e, 0x72, 0xfb, 0x9b, 0x51, 0x72, 0x3a,
+	0x02, 0xe1, 0x15, 0xd3, 0xf4, 0x3b, 0x1f, 0x03, 0x00, 0x05, 0xb5, 0x25, 0x60, 0xfd, 0x01, 0x00,
+	0x00,
+}
+
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ElasticityMultiplier != 0 {
+		i = encodeVarintFeemarket(dAtA, i, uint64(m.ElasticityMultiplier))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.BaseFeeChangeDenominator != 0 {
+		i = encodeVarintFeemarket(dAtA, i, uint64(m.BaseFeeChangeDenominator))
+		i--
+		dAtA[i] = 0x20
+	}
+	{
+		size := m.MinBaseFee.Size()
+		i -= size
+		if _, err := m.MinBaseFee.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintFeemarket(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if len(m.Denom) > 0 {
+		i -= len(m.Denom)
+		copy(dAtA[i:], m.Denom)
+		i = encodeVarintFeemarket(dAtA, i, uint64(len(m.Denom)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Enabled {
+		i--
+		if m.Enabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintFeemarket(dAtA []byte, offset int, v uint64) int {
+	offset -= sovFeemarket(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Enabled {
+		n += 2
+	}
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovFeemarket(uint64(l))
+	}
+	l = m.MinBaseFee.Size()
+	n += 1 + l + sovFeemarket(uint64(l))
+	if m.BaseFeeChangeDenominator != 0 {
+		n += 1 + sovFeemarket(uint64(m.BaseFeeChangeDenominator))
+	}
+	if m.ElasticityMultiplier != 0 {
+		n += 1 + sovFeemarket(uint64(m.ElasticityMultiplier))
+	}
+	return n
+}
+
+func sovFeemarket(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozFeemarket(x uint64) (n int) {
+	return sovFeemarket(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFeemarket
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Enabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeemarket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Enabled = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeemarket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeemarket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeemarket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinBaseFee", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeemarket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFeemarket
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFeemarket
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MinBaseFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseFeeChangeDenominator", wireType)
+			}
+			m.BaseFeeChangeDenominator = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeemarket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseFeeChangeDenominator |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElasticityMultiplier", wireType)
+			}
+			m.ElasticityMultiplier = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFeemarket
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ElasticityMultiplier |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFeemarket(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFeemarket
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipFeemarket(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowFeemarket
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFeemarket
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowFeemarket
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthFeemarket
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupFeemarket
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthFeemarket
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthFeemarket        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowFeemarket          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupFeemarket = fmt.Errorf("proto: unexpected end of group")
+)
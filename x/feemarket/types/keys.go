@@ -0,0 +1,18 @@
+package types
+
+import "cosmossdk.io/collections"
+
+var (
+	// BaseFeeKey is the key of the current base fee.
+	BaseFeeKey = collections.NewPrefix(0)
+	// ParamsKey is the key of the module parameters.
+	ParamsKey = collections.NewPrefix(1)
+)
+
+const (
+	// ModuleName defines the module name
+	ModuleName = "feemarket"
+
+	// StoreKey is the default store key for feemarket
+	StoreKey = ModuleName
+)
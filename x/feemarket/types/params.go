@@ -0,0 +1,48 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewParams creates a new Params instance.
+func NewParams(enabled bool, denom string, minBaseFee math.LegacyDec, baseFeeChangeDenominator, elasticityMultiplier uint64) Params {
+	return Params{
+		Enabled:                  enabled,
+		Denom:                    denom,
+		MinBaseFee:               minBaseFee,
+		BaseFeeChangeDenominator: baseFeeChangeDenominator,
+		ElasticityMultiplier:     elasticityMultiplier,
+	}
+}
+
+// DefaultParams returns default x/feemarket module parameters, mirroring
+// EIP-1559. The fee market is disabled by default, chains opt in through
+// genesis or a MsgUpdateParams governance proposal.
+func DefaultParams() Params {
+	return NewParams(false, sdk.DefaultBondDenom, math.LegacyNewDecWithPrec(1, 3), 8, 2)
+}
+
+// Validate does the sanity check on the params.
+func (p Params) Validate() error {
+	if err := sdk.ValidateDenom(p.Denom); err != nil {
+		return fmt.Errorf("invalid fee market denom: %w", err)
+	}
+	// the adjustment is proportional to the base fee, a zero base fee would
+	// never rise again
+	if p.MinBaseFee.IsNil() || !p.MinBaseFee.IsPositive() {
+		return fmt.Errorf("min base fee must be positive: %s", p.MinBaseFee)
+	}
+	if p.BaseFeeChangeDenominator == 0 {
+		return errors.New("base fee change denominator must be positive")
+	}
+	if p.ElasticityMultiplier == 0 {
+		return errors.New("elasticity multiplier must be positive")
+	}
+
+	return nil
+}
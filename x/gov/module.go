@@ -13,6 +13,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	modulev1 "cosmossdk.io/api/cosmos/gov/module/v1"
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/address"
 	"cosmossdk.io/core/appmodule"
 	store "cosmossdk.io/core/store"
@@ -39,11 +40,12 @@ import (
 const ConsensusVersion = 5
 
 var (
-	_ module.AppModuleBasic      = AppModuleBasic{}
-	_ module.AppModuleSimulation = AppModule{}
-	_ module.HasGenesis          = AppModule{}
-	_ module.HasServices         = AppModule{}
-	_ module.HasInvariants       = AppModule{}
+	_ module.AppModuleBasic       = AppModuleBasic{}
+	_ module.AppModuleSimulation  = AppModule{}
+	_ module.HasGenesis           = AppModule{}
+	_ module.HasServices          = AppModule{}
+	_ module.HasCollectionsSchema = AppModule{}
+	_ module.HasInvariants        = AppModule{}
 
 	_ appmodule.AppModule     = AppModule{}
 	_ appmodule.HasEndBlocker = AppModule{}
@@ -315,6 +317,11 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 // ConsensusVersion implements AppModule/ConsensusVersion.
 func (AppModule) ConsensusVersion() uint64 { return ConsensusVersion }
 
+// CollectionsSchemas implements module.HasCollectionsSchema.
+func (am AppModule) CollectionsSchemas() map[string]collections.Schema {
+	return map[string]collections.Schema{govtypes.StoreKey: am.keeper.Schema}
+}
+
 // EndBlock returns the end blocker for the gov module. It returns no validator
 // updates.
 func (am AppModule) EndBlock(ctx context.Context) error {
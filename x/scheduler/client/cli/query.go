@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/scheduler/types"
+)
+
+// FlagOwner filters the scheduled txs by owner.
+const FlagOwner = "owner"
+
+// GetQueryCmd returns the parent command for all x/scheduler CLI query
+// commands.
+func GetQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the scheduler module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		GetCmdQueryScheduledTx(),
+		GetCmdQueryScheduledTxs(),
+		GetCmdQueryParams(),
+	)
+
+	return cmd
+}
+
+// GetCmdQueryScheduledTx returns the command querying a scheduled tx by id.
+func GetCmdQueryScheduledTx() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "scheduled-tx [id]",
+		Short:   "Query a pending scheduled tx by its id",
+		Example: fmt.Sprintf("%s query %s scheduled-tx 1", version.AppName, types.ModuleName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid scheduled tx id: %w", err)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ScheduledTx(cmd.Context(), &types.QueryScheduledTxRequest{Id: id})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryScheduledTxs returns the command querying the pending scheduled
+// txs.
+func GetCmdQueryScheduledTxs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "scheduled-txs",
+		Short:   "Query the pending scheduled txs, optionally of an owner",
+		Example: fmt.Sprintf("%s query %s scheduled-txs --%s cosmos1...", version.AppName, types.ModuleName, FlagOwner),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			owner, _ := cmd.Flags().GetString(FlagOwner)
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ScheduledTxs(cmd.Context(), &types.QueryScheduledTxsRequest{
+				Owner:      owner,
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+
+	cmd.Flags().String(FlagOwner, "", "Only return the scheduled txs of this owner")
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "scheduled-txs")
+
+	return cmd
+}
+
+// GetCmdQueryParams returns the command querying the module parameters.
+func GetCmdQueryParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Query the current scheduler parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.Params(cmd.Context(), &types.QueryParamsRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(&res.Params)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
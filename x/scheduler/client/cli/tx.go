@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
+	"github.com/cosmos/cosmos-sdk/x/scheduler/types"
+)
+
+// Flags of the schedule command.
+const (
+	FlagExecuteHeight = "execute-height"
+	FlagExecuteTime   = "execute-time"
+	FlagExecGasLimit  = "exec-gas-limit"
+	FlagExecFee       = "exec-fee"
+)
+
+// GetTxCmd returns the transaction commands for the scheduler module.
+func GetTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Scheduler transactions subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		NewCmdSchedule(),
+		NewCmdCancel(),
+	)
+
+	return cmd
+}
+
+// NewCmdSchedule returns a CLI command handler for creating a MsgSchedule
+// transaction.
+func NewCmdSchedule() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule [tx-json-file] --from [owner]",
+		Short: "Schedule the messages of a tx for execution at a block height or time",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Schedule the messages of a tx generated with --generate-only for execution in the EndBlock
+of the first block reaching --%s or --%s. The messages must be signed by the owner only. The fee given with
+--%s must be at least the execution gas limit times the min gas prices of the module params. It is escrowed
+until the messages are executed, then paid to the fee collector, and refunded if the scheduled tx is canceled.
+
+Example:
+ $ %s tx bank send <owner> <recipient> 10stake --generate-only > tx.json
+ $ %s tx %s schedule tx.json --%s 1000 --%s 200000 --%s 10stake --from <owner>
+`, FlagExecuteHeight, FlagExecuteTime, FlagExecFee, version.AppName, version.AppName, types.ModuleName, FlagExecuteHeight, FlagExecGasLimit, FlagExecFee),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			theTx, err := authclient.ReadTxFromFile(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			height, err := cmd.Flags().GetInt64(FlagExecuteHeight)
+			if err != nil {
+				return err
+			}
+			var executeTime *time.Time
+			if s, _ := cmd.Flags().GetString(FlagExecuteTime); s != "" {
+				t, err := time.Parse(time.RFC3339, s)
+				if err != nil {
+					return fmt.Errorf("invalid --%s, expected RFC3339: %w", FlagExecuteTime, err)
+				}
+				executeTime = &t
+			}
+			if (height != 0) == (executeTime != nil) {
+				return fmt.Errorf("exactly one of --%s and --%s must be set", FlagExecuteHeight, FlagExecuteTime)
+			}
+
+			gasLimit, err := cmd.Flags().GetUint64(FlagExecGasLimit)
+			if err != nil {
+				return err
+			}
+			if gasLimit == 0 {
+				return errors.New("the gas limit of the scheduled messages must be positive")
+			}
+
+			feeStr, _ := cmd.Flags().GetString(FlagExecFee)
+			fee, err := sdk.ParseCoinsNormalized(feeStr)
+			if err != nil {
+				return err
+			}
+
+			msg, err := types.NewMsgSchedule(clientCtx.GetFromAddress().String(), theTx.GetMsgs(), height, executeTime, gasLimit, fee)
+			if err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	cmd.Flags().Int64(FlagExecuteHeight, 0, "Block height at which the messages are executed")
+	cmd.Flags().String(FlagExecuteTime, "", "Block time from which the messages are executed, in RFC3339 format")
+	cmd.Flags().Uint64(FlagExecGasLimit, 200000, "Gas limit of the scheduled messages")
+	cmd.Flags().String(FlagExecFee, "", "Fee escrowed for the execution of the scheduled messages")
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewCmdCancel returns a CLI command handler for creating a MsgCancel
+// transaction.
+func NewCmdCancel() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cancel [id] --from [owner]",
+		Short:   "Cancel a scheduled tx and refund its fee",
+		Example: fmt.Sprintf("%s tx %s cancel 1 --from <owner>", version.AppName, types.ModuleName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid scheduled tx id: %w", err)
+			}
+
+			msg := &types.MsgCancel{
+				Owner: clientCtx.GetFromAddress().String(),
+				Id:    id,
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,53 @@
+package keeper
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/x/scheduler/types"
+)
+
+// InitGenesis initializes the scheduler module's state from a given genesis
+// state. The fees of the scheduled txs must be held by the module account.
+func (k Keeper) InitGenesis(ctx context.Context, data *types.GenesisState) {
+	if err := k.Params.Set(ctx, data.Params); err != nil {
+		panic(err)
+	}
+
+	if err := k.NextID.Set(ctx, data.NextId); err != nil {
+		panic(err)
+	}
+
+	for _, tx := range data.ScheduledTxs {
+		owner, err := k.addressCodec.StringToBytes(tx.Owner)
+		if err != nil {
+			panic(err)
+		}
+		if err := k.setScheduledTx(ctx, owner, tx); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ExportGenesis returns the scheduler module's exported genesis.
+func (k Keeper) ExportGenesis(ctx context.Context) *types.GenesisState {
+	params, err := k.Params.Get(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	nextID, err := k.NextID.Peek(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	var txs []types.ScheduledTx
+	err = k.ScheduledTxs.Walk(ctx, nil, func(_ uint64, tx types.ScheduledTx) (bool, error) {
+		txs = append(txs, tx)
+		return false, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return types.NewGenesisState(params, txs, nextID)
+}
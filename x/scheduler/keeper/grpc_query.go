@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"context"
+	"errors"
+
+	"cosmossdk.io/collections"
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/scheduler/types"
+)
+
+var _ types.QueryServer = queryServer{}
+
+// NewQueryServerImpl returns an implementation of the x/scheduler QueryServer
+// interface.
+func NewQueryServerImpl(k Keeper) types.QueryServer {
+	return queryServer{k}
+}
+
+type queryServer struct {
+	k Keeper
+}
+
+// ScheduledTx returns a pending scheduled tx by its id.
+func (q queryServer) ScheduledTx(ctx context.Context, req *types.QueryScheduledTxRequest) (*types.QueryScheduledTxResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.ErrInvalidRequest.Wrap("empty request")
+	}
+
+	tx, err := q.k.ScheduledTxs.Get(ctx, req.Id)
+	if err != nil {
+		if errors.Is(err, collections.ErrNotFound) {
+			return nil, errorsmod.Wrapf(types.ErrScheduledTxNotFound, "id %d", req.Id)
+		}
+		return nil, err
+	}
+
+	return &types.QueryScheduledTxResponse{ScheduledTx: tx}, nil
+}
+
+// ScheduledTxs returns the pending scheduled txs, of an owner if set.
+func (q queryServer) ScheduledTxs(ctx context.Context, req *types.QueryScheduledTxsRequest) (*types.QueryScheduledTxsResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.ErrInvalidRequest.Wrap("empty request")
+	}
+
+	if req.Owner == "" {
+		txs, pageRes, err := query.CollectionPaginate(ctx, q.k.ScheduledTxs, req.Pagination,
+			func(_ uint64, tx types.ScheduledTx) (types.ScheduledTx, error) {
+				return tx, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.QueryScheduledTxsResponse{ScheduledTxs: txs, Pagination: pageRes}, nil
+	}
+
+	owner, err := q.k.addressCodec.StringToBytes(req.Owner)
+	if err != nil {
+		return nil, sdkerrors.ErrInvalidAddress.Wrapf("invalid owner address: %s", err)
+	}
+
+	txs, pageRes, err := query.CollectionPaginate(ctx, q.k.OwnerIndex, req.Pagination,
+		func(key collections.Pair[sdk.AccAddress, uint64], _ collections.NoValue) (types.ScheduledTx, error) {
+			return q.k.ScheduledTxs.Get(ctx, key.K2())
+		}, query.WithCollectionPaginationPairPrefix[sdk.AccAddress, uint64](owner))
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.QueryScheduledTxsResponse{ScheduledTxs: txs, Pagination: pageRes}, nil
+}
+
+// Params returns params of the scheduler module.
+func (q queryServer) Params(ctx context.Context, _ *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
+	params, err := q.k.Params.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.QueryParamsResponse{Params: params}, nil
+}
@@ -0,0 +1,363 @@
+package keeper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/core/address"
+	corestoretypes "cosmossdk.io/core/store"
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/log"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/scheduler/types"
+)
+
+// Keeper of the scheduler store
+type Keeper struct {
+	cdc              codec.Codec
+	storeService     corestoretypes.KVStoreService
+	router           baseapp.MessageRouter
+	bankKeeper       types.BankKeeper
+	addressCodec     address.Codec
+	feeCollectorName string
+
+	// the address capable of executing a MsgUpdateParams message. Typically, this
+	// should be the x/gov module account.
+	authority string
+
+	Schema       collections.Schema
+	Params       collections.Item[types.Params]
+	NextID       collections.Sequence
+	ScheduledTxs collections.Map[uint64, types.ScheduledTx]
+	// HeightQueue holds the (execution height, id) pairs of the txs triggered
+	// by a block height.
+	HeightQueue collections.KeySet[collections.Pair[int64, uint64]]
+	// TimeQueue holds the (execution time, id) pairs of the txs triggered by
+	// a block time.
+	TimeQueue collections.KeySet[collections.Pair[time.Time, uint64]]
+	// OwnerIndex holds the (owner, id) pairs of the scheduled txs.
+	OwnerIndex collections.KeySet[collections.Pair[sdk.AccAddress, uint64]]
+}
+
+// NewKeeper creates a new scheduler Keeper instance
+func NewKeeper(
+	cdc codec.Codec,
+	storeService corestoretypes.KVStoreService,
+	router baseapp.MessageRouter,
+	ak types.AccountKeeper,
+	bk types.BankKeeper,
+	feeCollectorName string,
+	authority string,
+) Keeper {
+	// ensure scheduler module account is set, the fees are escrowed in it
+	if addr := ak.GetModuleAddress(types.ModuleName); addr == nil {
+		panic(fmt.Sprintf("the x/%s module account has not been set", types.ModuleName))
+	}
+
+	sb := collections.NewSchemaBuilder(storeService)
+	k := Keeper{
+		cdc:              cdc,
+		storeService:     storeService,
+		router:           router,
+		bankKeeper:       bk,
+		addressCodec:     ak.AddressCodec(),
+		feeCollectorName: feeCollectorName,
+		authority:        authority,
+		Params:           collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
+		NextID:           collections.NewSequence(sb, types.NextIDKey, "next_id"),
+		ScheduledTxs:     collections.NewMap(sb, types.ScheduledTxsPrefix, "scheduled_txs", collections.Uint64Key, codec.CollValue[types.ScheduledTx](cdc)),
+		HeightQueue:      collections.NewKeySet(sb, types.HeightQueuePrefix, "height_queue", collections.PairKeyCodec(collections.Int64Key, collections.Uint64Key)),
+		TimeQueue:        collections.NewKeySet(sb, types.TimeQueuePrefix, "time_queue", collections.PairKeyCodec(sdk.TimeKey, collections.Uint64Key)),
+		OwnerIndex:       collections.NewKeySet(sb, types.OwnerIndexPrefix, "owner_index", collections.PairKeyCodec(sdk.AccAddressKey, collections.Uint64Key)),
+	}
+
+	schema, err := sb.Build()
+	if err != nil {
+		panic(err)
+	}
+	k.Schema = schema
+	return k
+}
+
+// GetAuthority returns the x/scheduler module's authority.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx context.Context) log.Logger {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	return sdkCtx.Logger().With("module", "x/"+types.ModuleName)
+}
+
+// ValidateMsgs checks that the msgs can be scheduled by the owner: like in a
+// tx, all the signers of the msgs must have signed it, so the owner must be
+// their only signer. The msgs must pass their ValidateBasic and be routable.
+func (k Keeper) ValidateMsgs(owner sdk.AccAddress, msgs []sdk.Msg) error {
+	if len(msgs) == 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "must contain at least one message")
+	}
+
+	for _, msg := range msgs {
+		signers, _, err := k.cdc.GetMsgV1Signers(msg)
+		if err != nil {
+			return err
+		}
+		if len(signers) == 0 {
+			return errorsmod.Wrapf(types.ErrInvalidSigner, "%s has no signer", sdk.MsgTypeURL(msg))
+		}
+		for _, signer := range signers {
+			if !bytes.Equal(signer, owner) {
+				return errorsmod.Wrapf(types.ErrInvalidSigner, "%s is signed by %s", sdk.MsgTypeURL(msg), sdk.AccAddress(signer))
+			}
+		}
+
+		if m, ok := msg.(sdk.HasValidateBasic); ok {
+			if err := m.ValidateBasic(); err != nil {
+				return err
+			}
+		}
+
+		if k.router.Handler(msg) == nil {
+			return sdkerrors.ErrUnknownRequest.Wrapf("unrecognized message route: %s", sdk.MsgTypeURL(msg))
+		}
+	}
+
+	return nil
+}
+
+// Schedule stores the tx, assigning it the next id, and escrows its fee from
+// the owner. The tx is expected to be valid.
+func (k Keeper) Schedule(ctx context.Context, owner sdk.AccAddress, tx types.ScheduledTx) (uint64, error) {
+	id, err := k.NextID.Next(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tx.Id = id
+
+	if !tx.Fee.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, owner, types.ModuleName, tx.Fee); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := k.setScheduledTx(ctx, owner, tx); err != nil {
+		return 0, err
+	}
+
+	return id, sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventScheduled{
+		Id:    id,
+		Owner: tx.Owner,
+	})
+}
+
+// Cancel removes a pending tx of the owner and refunds its fee.
+func (k Keeper) Cancel(ctx context.Context, owner sdk.AccAddress, id uint64) error {
+	tx, err := k.ScheduledTxs.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, collections.ErrNotFound) {
+			return errorsmod.Wrapf(types.ErrScheduledTxNotFound, "id %d", id)
+		}
+		return err
+	}
+
+	txOwner, err := k.addressCodec.StringToBytes(tx.Owner)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(txOwner, owner) {
+		return errorsmod.Wrapf(sdkerrors.ErrUnauthorized, "scheduled tx %d is owned by %s", id, tx.Owner)
+	}
+
+	if !tx.Fee.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, owner, tx.Fee); err != nil {
+			return err
+		}
+	}
+
+	if err := k.removeScheduledTx(ctx, txOwner, tx); err != nil {
+		return err
+	}
+
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventCanceled{
+		Id:    id,
+		Owner: tx.Owner,
+	})
+}
+
+// EndBlocker executes the due scheduled txs, the height triggered ones first,
+// at most Params.MaxExecutionsPerBlock of them and within Params.MaxGasPerBlock
+// for the sum of their gas limits.
+func (k Keeper) EndBlocker(ctx context.Context) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+
+	params, err := k.Params.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	ids, err := k.dueTxs(sdkCtx, int(params.MaxExecutionsPerBlock))
+	if err != nil {
+		return err
+	}
+
+	var blockGas uint64
+	for _, id := range ids {
+		tx, err := k.ScheduledTxs.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		// the txs are executed in order, the ones not fitting in the block gas
+		// remain due for the next blocks. The first one always runs, so that a
+		// tx scheduled before the limit was lowered doesn't block the queue.
+		if blockGas > 0 && blockGas+tx.GasLimit > params.MaxGasPerBlock {
+			break
+		}
+		blockGas += tx.GasLimit
+
+		owner, err := k.addressCodec.StringToBytes(tx.Owner)
+		if err != nil {
+			return err
+		}
+
+		if err := k.removeScheduledTx(ctx, owner, tx); err != nil {
+			return err
+		}
+		if !tx.Fee.IsZero() {
+			if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, k.feeCollectorName, tx.Fee); err != nil {
+				return err
+			}
+		}
+
+		gasUsed, execErr := k.execute(sdkCtx, tx)
+		event := &types.EventExecuted{
+			Id:      tx.Id,
+			Owner:   tx.Owner,
+			Success: execErr == nil,
+			GasUsed: gasUsed,
+		}
+		if execErr != nil {
+			event.Error = execErr.Error()
+			k.Logger(ctx).Info("scheduled tx failed", "id", tx.Id, "err", execErr)
+		}
+		if err := sdkCtx.EventManager().EmitTypedEvent(event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dueTxs returns the ids of the txs due at the height and time of the block,
+// at most limit of them.
+func (k Keeper) dueTxs(ctx sdk.Context, limit int) ([]uint64, error) {
+	var ids []uint64
+
+	heightRng := collections.NewPrefixUntilPairRange[int64, uint64](ctx.BlockHeight())
+	err := k.HeightQueue.Walk(ctx, heightRng, func(key collections.Pair[int64, uint64]) (bool, error) {
+		ids = append(ids, key.K2())
+		return len(ids) >= limit, nil
+	})
+	if err != nil || len(ids) >= limit {
+		return ids, err
+	}
+
+	timeRng := collections.NewPrefixUntilPairRange[time.Time, uint64](ctx.BlockTime())
+	err = k.TimeQueue.Walk(ctx, timeRng, func(key collections.Pair[time.Time, uint64]) (bool, error) {
+		ids = append(ids, key.K2())
+		return len(ids) >= limit, nil
+	})
+
+	return ids, err
+}
+
+// execute runs the msgs of the tx atomically within its gas limit. Their
+// state changes and events are kept only if all of them succeed.
+func (k Keeper) execute(ctx sdk.Context, tx types.ScheduledTx) (gasUsed uint64, err error) {
+	cacheCtx, write := ctx.CacheContext()
+	gasMeter := storetypes.NewGasMeter(tx.GasLimit)
+	cacheCtx = cacheCtx.WithGasMeter(gasMeter)
+
+	defer func() {
+		gasUsed = gasMeter.GasConsumed()
+		if r := recover(); r != nil {
+			if oog, ok := r.(storetypes.ErrorOutOfGas); ok {
+				err = errorsmod.Wrapf(sdkerrors.ErrOutOfGas, "out of gas in location: %v; gasLimit: %d", oog.Descriptor, tx.GasLimit)
+			} else {
+				err = errorsmod.Wrapf(sdkerrors.ErrPanic, "recovered: %v", r)
+			}
+		}
+	}()
+
+	msgs, err := tx.GetMessages()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, msg := range msgs {
+		handler := k.router.Handler(msg)
+		if handler == nil {
+			return 0, sdkerrors.ErrUnknownRequest.Wrapf("unrecognized message route: %s", sdk.MsgTypeURL(msg))
+		}
+
+		msgResp, err := handler(cacheCtx, msg)
+		if err != nil {
+			return 0, errorsmod.Wrapf(err, "failed to execute message; message index: %d", i)
+		}
+
+		// emit the events of the msgs, like runMsgs does for a tx
+		events := make([]sdk.Event, 0, len(msgResp.Events))
+		for _, event := range msgResp.Events {
+			e := event
+			e.Attributes = append(e.Attributes,
+				abci.EventAttribute{Key: "scheduled_tx_id", Value: strconv.FormatUint(tx.Id, 10)},
+				abci.EventAttribute{Key: "msg_index", Value: strconv.Itoa(i)},
+			)
+			events = append(events, sdk.Event(e))
+		}
+		cacheCtx.EventManager().EmitEvents(events)
+	}
+
+	write()
+	return 0, nil
+}
+
+func (k Keeper) setScheduledTx(ctx context.Context, owner sdk.AccAddress, tx types.ScheduledTx) error {
+	if err := k.ScheduledTxs.Set(ctx, tx.Id, tx); err != nil {
+		return err
+	}
+	if err := k.OwnerIndex.Set(ctx, collections.Join(owner, tx.Id)); err != nil {
+		return err
+	}
+
+	if tx.ExecuteTime != nil {
+		return k.TimeQueue.Set(ctx, collections.Join(*tx.ExecuteTime, tx.Id))
+	}
+	return k.HeightQueue.Set(ctx, collections.Join(tx.ExecuteHeight, tx.Id))
+}
+
+func (k Keeper) removeScheduledTx(ctx context.Context, owner sdk.AccAddress, tx types.ScheduledTx) error {
+	if err := k.ScheduledTxs.Remove(ctx, tx.Id); err != nil {
+		return err
+	}
+	if err := k.OwnerIndex.Remove(ctx, collections.Join(owner, tx.Id)); err != nil {
+		return err
+	}
+
+	if tx.ExecuteTime != nil {
+		return k.TimeQueue.Remove(ctx, collections.Join(*tx.ExecuteTime, tx.Id))
+	}
+	return k.HeightQueue.Remove(ctx, collections.Join(tx.ExecuteHeight, tx.Id))
+}
@@ -0,0 +1,346 @@
+package keeper_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/core/address"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	addresscodec "github.com/cosmos/cosmos-sdk/codec/address"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	moduletestutil "github.com/cosmos/cosmos-sdk/types/module/testutil"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/scheduler"
+	"github.com/cosmos/cosmos-sdk/x/scheduler/keeper"
+	"github.com/cosmos/cosmos-sdk/x/scheduler/types"
+)
+
+type accountKeeper struct{}
+
+func (accountKeeper) AddressCodec() address.Codec {
+	return addresscodec.NewBech32Codec("cosmos")
+}
+
+func (accountKeeper) GetModuleAddress(name string) sdk.AccAddress {
+	return authtypes.NewModuleAddress(name)
+}
+
+// bankKeeper tracks the balances of the accounts and modules by address or
+// module name.
+type bankKeeper struct {
+	balances map[string]sdk.Coins
+}
+
+func (bk *bankKeeper) send(from, to string, amt sdk.Coins) error {
+	balance, negative := bk.balances[from].SafeSub(amt...)
+	if negative {
+		return sdkerrors.ErrInsufficientFunds
+	}
+	bk.balances[from] = balance
+	bk.balances[to] = bk.balances[to].Add(amt...)
+	return nil
+}
+
+func (bk *bankKeeper) SendCoinsFromAccountToModule(_ context.Context, addr sdk.AccAddress, module string, amt sdk.Coins) error {
+	return bk.send(addr.String(), module, amt)
+}
+
+func (bk *bankKeeper) SendCoinsFromModuleToAccount(_ context.Context, module string, addr sdk.AccAddress, amt sdk.Coins) error {
+	return bk.send(module, addr.String(), amt)
+}
+
+func (bk *bankKeeper) SendCoinsFromModuleToModule(_ context.Context, from, to string, amt sdk.Coins) error {
+	return bk.send(from, to, amt)
+}
+
+type fixture struct {
+	ctx       sdk.Context
+	keeper    keeper.Keeper
+	msgServer types.MsgServer
+	bank      *bankKeeper
+	owner     sdk.AccAddress
+}
+
+func setupKeeper(t *testing.T) fixture {
+	t.Helper()
+
+	key := storetypes.NewKVStoreKey(types.StoreKey)
+	testCtx := testutil.DefaultContextWithDB(t, key, storetypes.NewTransientStoreKey("transient_test"))
+	encCfg := moduletestutil.MakeTestEncodingConfig(scheduler.AppModuleBasic{})
+	encCfg.InterfaceRegistry.RegisterImplementations((*sdk.Msg)(nil), &testdata.MsgCreateDog{})
+
+	router := baseapp.NewMsgServiceRouter()
+	router.SetInterfaceRegistry(encCfg.InterfaceRegistry)
+
+	owner := sdk.AccAddress("owner_______________")
+	bk := &bankKeeper{balances: map[string]sdk.Coins{
+		owner.String(): sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)),
+	}}
+	k := keeper.NewKeeper(encCfg.Codec, runtime.NewKVStoreService(key), router, accountKeeper{}, bk, authtypes.FeeCollectorName, authtypes.NewModuleAddress("gov").String())
+	k.InitGenesis(testCtx.Ctx, types.DefaultGenesisState())
+
+	msgServer := keeper.NewMsgServerImpl(k)
+	testdata.RegisterMsgServer(router, testdata.MsgServerImpl{})
+	types.RegisterMsgServer(router, msgServer)
+
+	ctx := testCtx.Ctx.WithBlockHeight(10).WithBlockTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	return fixture{ctx: ctx, keeper: k, msgServer: msgServer, bank: bk, owner: owner}
+}
+
+func (f fixture) schedule(t *testing.T, msgs []sdk.Msg, height int64, at *time.Time, gasLimit uint64, fee sdk.Coins) (uint64, error) {
+	t.Helper()
+
+	msg, err := types.NewMsgSchedule(f.owner.String(), msgs, height, at, gasLimit, fee)
+	require.NoError(t, err)
+	res, err := f.msgServer.Schedule(f.ctx, msg)
+	if err != nil {
+		return 0, err
+	}
+	return res.Id, nil
+}
+
+func TestSchedule(t *testing.T) {
+	f := setupKeeper(t)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10))
+	dog := &testdata.MsgCreateDog{Dog: &testdata.Dog{Name: "rex"}, Owner: f.owner.String()}
+
+	id, err := f.schedule(t, []sdk.Msg{dog}, 12, nil, 10000, fee)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), id)
+	require.Equal(t, fee, f.bank.balances[types.ModuleName])
+	require.Equal(t, int64(90), f.bank.balances[f.owner.String()].AmountOf(sdk.DefaultBondDenom).Int64())
+
+	tx, err := f.keeper.ScheduledTxs.Get(f.ctx, id)
+	require.NoError(t, err)
+	msgs, err := tx.GetMessages()
+	require.NoError(t, err)
+	require.Equal(t, []sdk.Msg{dog}, msgs)
+
+	testCases := []struct {
+		name   string
+		msgs   []sdk.Msg
+		height int64
+		at     *time.Time
+		gas    uint64
+		fee    sdk.Coins
+		errIs  error
+	}{
+		{
+			name:   "signed by another account",
+			msgs:   []sdk.Msg{&testdata.MsgCreateDog{Dog: &testdata.Dog{Name: "rex"}, Owner: sdk.AccAddress("other").String()}},
+			height: 12,
+			gas:    1000,
+			errIs:  types.ErrInvalidSigner,
+		},
+		{
+			name:  "no trigger",
+			msgs:  []sdk.Msg{dog},
+			gas:   1000,
+			errIs: types.ErrInvalidTrigger,
+		},
+		{
+			name:   "past height",
+			msgs:   []sdk.Msg{dog},
+			height: 10,
+			gas:    1000,
+			errIs:  types.ErrInvalidTrigger,
+		},
+		{
+			name:  "past time",
+			msgs:  []sdk.Msg{dog},
+			at:    &time.Time{},
+			gas:   1000,
+			errIs: types.ErrInvalidTrigger,
+		},
+		{
+			name:   "gas limit above the maximum",
+			msgs:   []sdk.Msg{dog},
+			height: 12,
+			gas:    types.DefaultParams().MaxGasLimit + 1,
+			errIs:  types.ErrGasLimitExceeded,
+		},
+		{
+			name:   "insufficient funds",
+			msgs:   []sdk.Msg{dog},
+			height: 12,
+			gas:    1000,
+			fee:    sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000)),
+			errIs:  sdkerrors.ErrInsufficientFunds,
+		},
+		{
+			name:   "no fee",
+			msgs:   []sdk.Msg{dog},
+			height: 12,
+			gas:    1000,
+			fee:    sdk.Coins{},
+			errIs:  sdkerrors.ErrInsufficientFee,
+		},
+		{
+			name:   "fee below the gas limit times the min gas price",
+			msgs:   []sdk.Msg{dog},
+			height: 12,
+			gas:    10001,
+			fee:    fee,
+			errIs:  sdkerrors.ErrInsufficientFee,
+		},
+		{
+			name:   "fee in another denom",
+			msgs:   []sdk.Msg{dog},
+			height: 12,
+			gas:    1000,
+			fee:    sdk.NewCoins(sdk.NewInt64Coin("foo", 10)),
+			errIs:  sdkerrors.ErrInsufficientFee,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fee := tc.fee
+			if fee == nil {
+				fee = types.DefaultParams().MinFee(tc.gas)
+			}
+			_, err := f.schedule(t, tc.msgs, tc.height, tc.at, tc.gas, fee)
+			require.ErrorIs(t, err, tc.errIs)
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	f := setupKeeper(t)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10))
+	dog := &testdata.MsgCreateDog{Dog: &testdata.Dog{Name: "rex"}, Owner: f.owner.String()}
+
+	id, err := f.schedule(t, []sdk.Msg{dog}, 12, nil, 10000, fee)
+	require.NoError(t, err)
+
+	_, err = f.msgServer.Cancel(f.ctx, &types.MsgCancel{Owner: sdk.AccAddress("other").String(), Id: id})
+	require.Error(t, err)
+
+	_, err = f.msgServer.Cancel(f.ctx, &types.MsgCancel{Owner: f.owner.String(), Id: id})
+	require.NoError(t, err)
+	require.Equal(t, int64(100), f.bank.balances[f.owner.String()].AmountOf(sdk.DefaultBondDenom).Int64())
+
+	has, err := f.keeper.ScheduledTxs.Has(f.ctx, id)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	_, err = f.msgServer.Cancel(f.ctx, &types.MsgCancel{Owner: f.owner.String(), Id: id})
+	require.ErrorIs(t, err, types.ErrScheduledTxNotFound)
+}
+
+func TestEndBlocker(t *testing.T) {
+	f := setupKeeper(t)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10))
+	dog := &testdata.MsgCreateDog{Dog: &testdata.Dog{Name: "rex"}, Owner: f.owner.String()}
+	at := f.ctx.BlockTime().Add(time.Hour)
+
+	// 1 is canceled by 2, 3 fails and 4 runs out of gas
+	first, err := f.schedule(t, []sdk.Msg{dog}, 0, &at, 10000, fee)
+	require.NoError(t, err)
+	cancelFirst := &types.MsgCancel{Owner: f.owner.String(), Id: first}
+	_, err = f.schedule(t, []sdk.Msg{cancelFirst}, 11, nil, 10000, fee)
+	require.NoError(t, err)
+	_, err = f.schedule(t, []sdk.Msg{&types.MsgCancel{Owner: f.owner.String(), Id: 100}}, 11, nil, 10000, fee)
+	require.NoError(t, err)
+	oneStake := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1))
+	_, err = f.schedule(t, []sdk.Msg{cancelFirst}, 11, nil, 1, oneStake)
+	require.NoError(t, err)
+	// 5 isn't due yet
+	_, err = f.schedule(t, []sdk.Msg{dog}, 12, nil, 10000, fee)
+	require.NoError(t, err)
+
+	ctx := f.ctx.WithBlockHeight(11).WithEventManager(sdk.NewEventManager())
+	require.NoError(t, f.keeper.EndBlocker(ctx))
+
+	var executed []types.EventExecuted
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type != "cosmos.scheduler.v1.EventExecuted" {
+			continue
+		}
+		msg, err := sdk.ParseTypedEvent(abci.Event(event))
+		require.NoError(t, err)
+		executed = append(executed, *msg.(*types.EventExecuted))
+	}
+	require.Len(t, executed, 3)
+	require.True(t, executed[0].Success)
+	require.False(t, executed[1].Success)
+	require.Contains(t, executed[1].Error, types.ErrScheduledTxNotFound.Error())
+	require.False(t, executed[2].Success)
+	require.Contains(t, executed[2].Error, "out of gas")
+
+	// the fees of 2, 3 and 4 are paid, the one of 1 refunded and the one of 5
+	// still escrowed
+	require.Equal(t, int64(21), f.bank.balances[authtypes.FeeCollectorName].AmountOf(sdk.DefaultBondDenom).Int64())
+	require.Equal(t, int64(69), f.bank.balances[f.owner.String()].AmountOf(sdk.DefaultBondDenom).Int64())
+	require.Equal(t, fee, f.bank.balances[types.ModuleName])
+
+	gs := f.keeper.ExportGenesis(ctx)
+	require.Len(t, gs.ScheduledTxs, 1)
+	require.Equal(t, uint64(5), gs.ScheduledTxs[0].Id)
+	require.Equal(t, uint64(6), gs.NextId)
+}
+
+func TestEndBlockerMaxExecutions(t *testing.T) {
+	f := setupKeeper(t)
+	params := types.DefaultParams()
+	params.MaxExecutionsPerBlock = 2
+	require.NoError(t, f.keeper.Params.Set(f.ctx, params))
+
+	dog := &testdata.MsgCreateDog{Dog: &testdata.Dog{Name: "rex"}, Owner: f.owner.String()}
+	at := f.ctx.BlockTime().Add(time.Second)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10))
+	for i := 0; i < 2; i++ {
+		_, err := f.schedule(t, []sdk.Msg{dog}, 0, &at, 10000, fee)
+		require.NoError(t, err)
+		_, err = f.schedule(t, []sdk.Msg{dog}, 11, nil, 10000, fee)
+		require.NoError(t, err)
+	}
+
+	ctx := f.ctx.WithBlockHeight(11).WithBlockTime(at)
+	require.NoError(t, f.keeper.EndBlocker(ctx))
+	// the height triggered txs run first
+	gs := f.keeper.ExportGenesis(ctx)
+	require.Len(t, gs.ScheduledTxs, 2)
+	require.NotNil(t, gs.ScheduledTxs[0].ExecuteTime)
+	require.NotNil(t, gs.ScheduledTxs[1].ExecuteTime)
+
+	require.NoError(t, f.keeper.EndBlocker(ctx.WithBlockHeight(12)))
+	require.Empty(t, f.keeper.ExportGenesis(ctx).ScheduledTxs)
+}
+
+func TestEndBlockerMaxGasPerBlock(t *testing.T) {
+	f := setupKeeper(t)
+	params := types.DefaultParams()
+	params.MaxGasLimit = 20000
+	params.MaxGasPerBlock = 25000
+	require.NoError(t, f.keeper.Params.Set(f.ctx, params))
+
+	dog := &testdata.MsgCreateDog{Dog: &testdata.Dog{Name: "rex"}, Owner: f.owner.String()}
+	for _, gas := range []uint64{10000, 10000, 20000} {
+		_, err := f.schedule(t, []sdk.Msg{dog}, 11, nil, gas, params.MinFee(gas))
+		require.NoError(t, err)
+	}
+
+	// the third tx doesn't fit in the block gas
+	ctx := f.ctx.WithBlockHeight(11)
+	require.NoError(t, f.keeper.EndBlocker(ctx))
+	gs := f.keeper.ExportGenesis(ctx)
+	require.Len(t, gs.ScheduledTxs, 1)
+	require.Equal(t, uint64(3), gs.ScheduledTxs[0].Id)
+
+	// the first due tx runs even if it doesn't fit in a lowered block gas
+	params.MaxGasLimit = 5000
+	params.MaxGasPerBlock = 5000
+	require.NoError(t, f.keeper.Params.Set(f.ctx, params))
+	require.NoError(t, f.keeper.EndBlocker(ctx.WithBlockHeight(12)))
+	require.Empty(t, f.keeper.ExportGenesis(ctx).ScheduledTxs)
+}
@@ -0,0 +1,118 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/cosmos/cosmos-sdk/x/scheduler/types"
+)
+
+var _ types.MsgServer = msgServer{}
+
+// msgServer is a wrapper of Keeper.
+type msgServer struct {
+	Keeper
+}
+
+// NewMsgServerImpl returns an implementation of the x/scheduler MsgServer interface.
+func NewMsgServerImpl(k Keeper) types.MsgServer {
+	return &msgServer{
+		Keeper: k,
+	}
+}
+
+// Schedule escrows the fee and schedules the msgs.
+func (ms msgServer) Schedule(ctx context.Context, msg *types.MsgSchedule) (*types.MsgScheduleResponse, error) {
+	owner, err := ms.addressCodec.StringToBytes(msg.Owner)
+	if err != nil {
+		return nil, sdkerrors.ErrInvalidAddress.Wrapf("invalid owner address: %s", err)
+	}
+
+	params, err := ms.Params.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(msg.Msgs); n > int(params.MaxMsgs) {
+		return nil, errorsmod.Wrapf(types.ErrTooManyMsgs, "got %d, maximum is %d", n, params.MaxMsgs)
+	}
+	if msg.GasLimit == 0 {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "gas limit must be positive")
+	}
+	if msg.GasLimit > params.MaxGasLimit {
+		return nil, errorsmod.Wrapf(types.ErrGasLimitExceeded, "got %d, maximum is %d", msg.GasLimit, params.MaxGasLimit)
+	}
+	if err := msg.Fee.Validate(); err != nil {
+		return nil, sdkerrors.ErrInvalidCoins.Wrap(err.Error())
+	}
+	// the execution gas isn't paid by the tx scheduling it, the fee must cover it
+	if minFee := params.MinFee(msg.GasLimit); !minFee.IsZero() && !msg.Fee.IsAnyGTE(minFee) {
+		return nil, errorsmod.Wrapf(sdkerrors.ErrInsufficientFee, "got %s, required at least %s", msg.Fee, minFee)
+	}
+
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	switch {
+	case (msg.ExecuteHeight != 0) == (msg.ExecuteTime != nil):
+		return nil, errorsmod.Wrap(types.ErrInvalidTrigger, "exactly one of the execution height and time must be set")
+	case msg.ExecuteTime != nil && !msg.ExecuteTime.After(sdkCtx.BlockTime()):
+		return nil, errorsmod.Wrapf(types.ErrInvalidTrigger, "execution time %s is not after the block time %s", msg.ExecuteTime, sdkCtx.BlockTime())
+	case msg.ExecuteTime == nil && msg.ExecuteHeight <= sdkCtx.BlockHeight():
+		return nil, errorsmod.Wrapf(types.ErrInvalidTrigger, "execution height %d is not above the block height %d", msg.ExecuteHeight, sdkCtx.BlockHeight())
+	}
+
+	msgs, err := msg.GetMessages()
+	if err != nil {
+		return nil, err
+	}
+	if err := ms.ValidateMsgs(owner, msgs); err != nil {
+		return nil, err
+	}
+
+	id, err := ms.Keeper.Schedule(ctx, owner, types.ScheduledTx{
+		Owner:         msg.Owner,
+		Msgs:          msg.Msgs,
+		ExecuteHeight: msg.ExecuteHeight,
+		ExecuteTime:   msg.ExecuteTime,
+		GasLimit:      msg.GasLimit,
+		Fee:           msg.Fee,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgScheduleResponse{Id: id}, nil
+}
+
+// Cancel cancels a scheduled tx of the owner and refunds its fee.
+func (ms msgServer) Cancel(ctx context.Context, msg *types.MsgCancel) (*types.MsgCancelResponse, error) {
+	owner, err := ms.addressCodec.StringToBytes(msg.Owner)
+	if err != nil {
+		return nil, sdkerrors.ErrInvalidAddress.Wrapf("invalid owner address: %s", err)
+	}
+
+	if err := ms.Keeper.Cancel(ctx, owner, msg.Id); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgCancelResponse{}, nil
+}
+
+// UpdateParams updates the params.
+func (ms msgServer) UpdateParams(ctx context.Context, msg *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	if ms.authority != msg.Authority {
+		return nil, errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", ms.authority, msg.Authority)
+	}
+
+	if err := msg.Params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := ms.Params.Set(ctx, msg.Params); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
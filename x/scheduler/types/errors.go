@@ -0,0 +1,21 @@
+package types
+
+import "cosmossdk.io/errors"
+
+// x/scheduler module sentinel errors
+var (
+	// ErrInvalidTrigger error if the execution height or time is missing, both
+	// set or not in the future
+	ErrInvalidTrigger = errors.Register(ModuleName, 2, "invalid execution trigger")
+	// ErrInvalidSigner error if a scheduled message is not signed by the owner
+	// only
+	ErrInvalidSigner = errors.Register(ModuleName, 3, "scheduled messages must be signed by the owner only")
+	// ErrScheduledTxNotFound error if there is no pending scheduled tx with the
+	// given id
+	ErrScheduledTxNotFound = errors.Register(ModuleName, 4, "scheduled tx not found")
+	// ErrGasLimitExceeded error if the gas limit of a scheduled tx is above the
+	// maximum
+	ErrGasLimitExceeded = errors.Register(ModuleName, 5, "gas limit exceeds the maximum")
+	// ErrTooManyMsgs error if a scheduled tx has more messages than the maximum
+	ErrTooManyMsgs = errors.Register(ModuleName, 6, "too many messages")
+)
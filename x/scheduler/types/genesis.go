@@ -0,0 +1,54 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NewGenesisState creates a new genesis state.
+func NewGenesisState(params Params, txs []ScheduledTx, nextID uint64) *GenesisState {
+	return &GenesisState{
+		Params:       params,
+		ScheduledTxs: txs,
+		NextId:       nextID,
+	}
+}
+
+// DefaultGenesisState returns a default genesis state.
+func DefaultGenesisState() *GenesisState {
+	return NewGenesisState(DefaultParams(), nil, 1)
+}
+
+// ValidateGenesis validates the provided genesis state to ensure the
+// expected invariants holds.
+func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+	if data.NextId == 0 {
+		return errors.New("next scheduled tx id must be positive")
+	}
+
+	seen := make(map[uint64]bool, len(data.ScheduledTxs))
+	for _, tx := range data.ScheduledTxs {
+		if tx.Id == 0 || tx.Id >= data.NextId {
+			return fmt.Errorf("scheduled tx id %d must be positive and below the next id %d", tx.Id, data.NextId)
+		}
+		if seen[tx.Id] {
+			return fmt.Errorf("duplicate scheduled tx id %d", tx.Id)
+		}
+		seen[tx.Id] = true
+
+		if (tx.ExecuteHeight > 0) == (tx.ExecuteTime != nil) {
+			return fmt.Errorf("scheduled tx %d must have exactly one of an execution height and time", tx.Id)
+		}
+		if len(tx.Msgs) == 0 {
+			return fmt.Errorf("scheduled tx %d has no messages", tx.Id)
+		}
+		if err := tx.Fee.Validate(); err != nil {
+			return fmt.Errorf("invalid fee of scheduled tx %d: %w", tx.Id, err)
+		}
+	}
+
+	return nil
+}
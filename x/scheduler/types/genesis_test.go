@@ -0,0 +1,54 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/x/scheduler/types"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	require.NoError(t, types.ValidateGenesis(*types.DefaultGenesisState()))
+
+	at := time.Unix(100, 0)
+	tx := types.ScheduledTx{Id: 1, ExecuteHeight: 10, Msgs: make([]*cdctypes.Any, 1)}
+
+	testCases := []struct {
+		name   string
+		mutate func(*types.GenesisState)
+		expErr string
+	}{
+		{"valid", func(gs *types.GenesisState) { gs.ScheduledTxs = []types.ScheduledTx{tx}; gs.NextId = 2 }, ""},
+		{"invalid params", func(gs *types.GenesisState) { gs.Params.MaxMsgs = 0 }, "max msgs"},
+		{"zero next id", func(gs *types.GenesisState) { gs.NextId = 0 }, "next scheduled tx id"},
+		{"id above next id", func(gs *types.GenesisState) { gs.ScheduledTxs = []types.ScheduledTx{tx} }, "below the next id"},
+		{"duplicate id", func(gs *types.GenesisState) { gs.ScheduledTxs = []types.ScheduledTx{tx, tx}; gs.NextId = 2 }, "duplicate"},
+		{"both triggers", func(gs *types.GenesisState) {
+			both := tx
+			both.ExecuteTime = &at
+			gs.ScheduledTxs = []types.ScheduledTx{both}
+			gs.NextId = 2
+		}, "exactly one"},
+		{"no msgs", func(gs *types.GenesisState) {
+			empty := tx
+			empty.Msgs = nil
+			gs.ScheduledTxs = []types.ScheduledTx{empty}
+			gs.NextId = 2
+		}, "no messages"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gs := types.DefaultGenesisState()
+			tc.mutate(gs)
+			err := types.ValidateGenesis(*gs)
+			if tc.expErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expErr)
+			}
+		})
+	}
+}
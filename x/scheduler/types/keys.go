@@ -0,0 +1,29 @@
+package types
+
+import "cosmossdk.io/collections"
+
+const (
+	// ModuleName defines the module name
+	ModuleName = "scheduler"
+
+	// StoreKey is the default store key for scheduler
+	StoreKey = ModuleName
+)
+
+var (
+	// ParamsKey is the key of the module parameters.
+	ParamsKey = collections.NewPrefix(0)
+	// NextIDKey is the key of the sequence of the scheduled tx ids.
+	NextIDKey = collections.NewPrefix(1)
+	// ScheduledTxsPrefix is the prefix of the scheduled txs by id.
+	ScheduledTxsPrefix = collections.NewPrefix(2)
+	// HeightQueuePrefix is the prefix of the (height, id) pairs of the txs
+	// triggered by a block height.
+	HeightQueuePrefix = collections.NewPrefix(3)
+	// TimeQueuePrefix is the prefix of the (time, id) pairs of the txs
+	// triggered by a block time.
+	TimeQueuePrefix = collections.NewPrefix(4)
+	// OwnerIndexPrefix is the prefix of the (owner, id) pairs of the scheduled
+	// txs.
+	OwnerIndexPrefix = collections.NewPrefix(5)
+)
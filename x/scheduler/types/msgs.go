@@ -0,0 +1,105 @@
+package types
+
+import (
+	"time"
+
+	cdctypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+var (
+	_ sdk.Msg = &MsgSchedule{}
+	_ sdk.Msg = &MsgCancel{}
+	_ sdk.Msg = &MsgUpdateParams{}
+
+	_ cdctypes.UnpackInterfacesMessage = &MsgSchedule{}
+	_ cdctypes.UnpackInterfacesMessage = &ScheduledTx{}
+	_ cdctypes.UnpackInterfacesMessage = &GenesisState{}
+)
+
+// NewMsgSchedule creates a new MsgSchedule executing the msgs at the given
+// height, or time if height is zero.
+func NewMsgSchedule(owner string, msgs []sdk.Msg, height int64, t *time.Time, gasLimit uint64, fee sdk.Coins) (*MsgSchedule, error) {
+	anys, err := packMsgs(msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MsgSchedule{
+		Owner:         owner,
+		Msgs:          anys,
+		ExecuteHeight: height,
+		ExecuteTime:   t,
+		GasLimit:      gasLimit,
+		Fee:           fee,
+	}, nil
+}
+
+// GetMessages returns the cached values of the msgs.
+func (msg MsgSchedule) GetMessages() ([]sdk.Msg, error) {
+	return unpackedMsgs(msg.Msgs)
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
+func (msg MsgSchedule) UnpackInterfaces(unpacker cdctypes.AnyUnpacker) error {
+	return unpackMsgs(unpacker, msg.Msgs)
+}
+
+// GetMessages returns the cached values of the msgs.
+func (tx ScheduledTx) GetMessages() ([]sdk.Msg, error) {
+	return unpackedMsgs(tx.Msgs)
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
+func (tx ScheduledTx) UnpackInterfaces(unpacker cdctypes.AnyUnpacker) error {
+	return unpackMsgs(unpacker, tx.Msgs)
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
+func (gs GenesisState) UnpackInterfaces(unpacker cdctypes.AnyUnpacker) error {
+	for _, tx := range gs.ScheduledTxs {
+		if err := tx.UnpackInterfaces(unpacker); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func packMsgs(msgs []sdk.Msg) ([]*cdctypes.Any, error) {
+	anys := make([]*cdctypes.Any, len(msgs))
+	for i, msg := range msgs {
+		any, err := cdctypes.NewAnyWithValue(msg)
+		if err != nil {
+			return nil, err
+		}
+		anys[i] = any
+	}
+
+	return anys, nil
+}
+
+func unpackMsgs(unpacker cdctypes.AnyUnpacker, anys []*cdctypes.Any) error {
+	for _, any := range anys {
+		var msg sdk.Msg
+		if err := unpacker.UnpackAny(any, &msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unpackedMsgs(anys []*cdctypes.Any) ([]sdk.Msg, error) {
+	msgs := make([]sdk.Msg, len(anys))
+	for i, any := range anys {
+		msg, ok := any.GetCachedValue().(sdk.Msg)
+		if !ok {
+			return nil, sdkerrors.ErrInvalidRequest.Wrapf("messages contains %T which is not a sdk.Msg", any)
+		}
+		msgs[i] = msg
+	}
+
+	return msgs, nil
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultParams returns default x/scheduler module parameters.
+func DefaultParams() Params {
+	return Params{
+		MaxGasLimit:           10_000_000,
+		MaxMsgs:               10,
+		MaxExecutionsPerBlock: 100,
+		MinGasPrices:          sdk.NewDecCoins(sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, math.LegacyNewDecWithPrec(1, 3))),
+		MaxGasPerBlock:        50_000_000,
+	}
+}
+
+// Validate does the sanity check on the params.
+func (p Params) Validate() error {
+	if p.MaxGasLimit == 0 {
+		return errors.New("max gas limit must be positive")
+	}
+	if p.MaxMsgs == 0 {
+		return errors.New("max msgs must be positive")
+	}
+	if p.MaxExecutionsPerBlock == 0 {
+		return errors.New("max executions per block must be positive")
+	}
+	if err := p.MinGasPrices.Validate(); err != nil {
+		return fmt.Errorf("invalid min gas prices: %w", err)
+	}
+	// a tx with the max gas limit must fit in a block
+	if p.MaxGasPerBlock < p.MaxGasLimit {
+		return fmt.Errorf("max gas per block %d must be at least the max gas limit %d", p.MaxGasPerBlock, p.MaxGasLimit)
+	}
+
+	return nil
+}
+
+// MinFee returns the minimum fee of a scheduled tx with the given gas limit,
+// its gas limit times each of the min gas prices.
+func (p Params) MinFee(gasLimit uint64) sdk.Coins {
+	minFee := make(sdk.Coins, len(p.MinGasPrices))
+	gas := math.LegacyNewDecFromInt(math.NewIntFromUint64(gasLimit))
+	for i, gp := range p.MinGasPrices {
+		minFee[i] = sdk.NewCoin(gp.Denom, gp.Amount.Mul(gas).Ceil().RoundInt())
+	}
+
+	return minFee
+}
@@ -0,0 +1,1740 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cosmos/scheduler/v1/scheduler.proto
+
+package types
+
+import (
+	fmt "fmt"
+	_ "github.com/cosmos/cosmos-proto"
+	types "github.com/cosmos/cosmos-sdk/codec/types"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types1 "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/cosmos/cosmos-sdk/types/tx/amino"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	proto "github.com/cosmos/gogoproto/proto"
+	github_com_cosmos_gogoproto_types "github.com/cosmos/gogoproto/types"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+	time "time"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+var _ = time.Kitchen
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// ScheduledTx is a set of messages executed on behalf of their owner in the
+// EndBlock of the first block reaching execute_height or execute_time.
+type ScheduledTx struct {
+	// id is the unique identifier of the scheduled tx.
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// owner is the account which scheduled the tx, the only signer of its
+	// messages.
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// msgs are the messages executed atomically when the tx is due.
+	Msgs []*types.Any `protobuf:"bytes,3,rep,name=msgs,proto3" json:"msgs,omitempty"`
+	// execute_height is the block height at which the tx is executed, zero if
+	// the tx is triggered by execute_time.
+	ExecuteHeight int64 `protobuf:"varint,4,opt,name=execute_height,json=executeHeight,proto3" json:"execute_height,omitempty"`
+	// execute_time is the block time from which the tx is executed, unset if the
+	// tx is triggered by execute_height.
+	ExecuteTime *time.Time `protobuf:"bytes,5,opt,name=execute_time,json=executeTime,proto3,stdtime" json:"execute_time,omitempty"`
+	// gas_limit is the gas available to the messages.
+	GasLimit uint64 `protobuf:"varint,6,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	// fee is escrowed by the module when the tx is scheduled and paid to the fee
+	// collector when it is executed, or refunded to the owner when it is
+	// canceled.
+	Fee github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,7,rep,name=fee,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"fee"`
+}
+
+func (m *ScheduledTx) Reset()         { *m = ScheduledTx{} }
+func (m *ScheduledTx) String() string { return proto.CompactTextString(m) }
+func (*ScheduledTx) ProtoMessage()    {}
+func (*ScheduledTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ec30ab3633a79a7, []int{0}
+}
+func (m *ScheduledTx) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScheduledTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScheduledTx.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScheduledTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduledTx.Merge(m, src)
+}
+func (m *ScheduledTx) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScheduledTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduledTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduledTx proto.InternalMessageInfo
+
+func (m *ScheduledTx) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *ScheduledTx) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *ScheduledTx) GetMsgs() []*types.Any {
+	if m != nil {
+		return m.Msgs
+	}
+	return nil
+}
+
+func (m *ScheduledTx) GetExecuteHeight() int64 {
+	if m != nil {
+		return m.ExecuteHeight
+	}
+	return 0
+}
+
+func (m *ScheduledTx) GetExecuteTime() *time.Time {
+	if m != nil {
+		return m.ExecuteTime
+	}
+	return nil
+}
+
+func (m *ScheduledTx) GetGasLimit() uint64 {
+	if m != nil {
+		return m.GasLimit
+	}
+	return 0
+}
+
+func (m *ScheduledTx) GetFee() github_com_cosmos_cosmos_sdk_types.Coins {
+	if m != nil {
+		return m.Fee
+	}
+	return nil
+}
+
+// Params defines the parameters of the scheduler module.
+type Params struct {
+	// max_gas_limit is the maximum gas limit of a scheduled tx.
+	MaxGasLimit uint64 `protobuf:"varint,1,opt,name=max_gas_limit,json=maxGasLimit,proto3" json:"max_gas_limit,omitempty"`
+	// max_msgs is the maximum number of messages of a scheduled tx.
+	MaxMsgs uint32 `protobuf:"varint,2,opt,name=max_msgs,json=maxMsgs,proto3" json:"max_msgs,omitempty"`
+	// max_executions_per_block is the maximum number of scheduled txs executed
+	// in a block. Due txs above the limit are executed in the next blocks.
+	MaxExecutionsPerBlock uint32 `protobuf:"varint,3,opt,name=max_executions_per_block,json=maxExecutionsPerBlock,proto3" json:"max_executions_per_block,omitempty"`
+	// min_gas_prices are the minimum gas prices of a scheduled tx, its fee must
+	// be at least its gas limit times one of them. No minimum is enforced if
+	// empty.
+	MinGasPrices github_com_cosmos_cosmos_sdk_types.DecCoins `protobuf:"bytes,4,rep,name=min_gas_prices,json=minGasPrices,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.DecCoins" json:"min_gas_prices"`
+	// max_gas_per_block is the maximum sum of the gas limits of the scheduled
+	// txs executed in a block. Due txs above the limit are executed in the next
+	// blocks.
+	MaxGasPerBlock uint64 `protobuf:"varint,5,opt,name=max_gas_per_block,json=maxGasPerBlock,proto3" json:"max_gas_per_block,omitempty"`
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ec30ab3633a79a7, []int{1}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+func (m *Params) GetMaxGasLimit() uint64 {
+	if m != nil {
+		return m.MaxGasLimit
+	}
+	return 0
+}
+
+func (m *Params) GetMaxMsgs() uint32 {
+	if m != nil {
+		return m.MaxMsgs
+	}
+	return 0
+}
+
+func (m *Params) GetMaxExecutionsPerBlock() uint32 {
+	if m != nil {
+		return m.MaxExecutionsPerBlock
+	}
+	return 0
+}
+
+func (m *Params) GetMinGasPrices() github_com_cosmos_cosmos_sdk_types.DecCoins {
+	if m != nil {
+		return m.MinGasPrices
+	}
+	return nil
+}
+
+func (m *Params) GetMaxGasPerBlock() uint64 {
+	if m != nil {
+		return m.MaxGasPerBlock
+	}
+	return 0
+}
+
+// EventScheduled is emitted when a tx is scheduled.
+type EventScheduled struct {
+	Id    uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (m *EventScheduled) Reset()         { *m = EventScheduled{} }
+func (m *EventScheduled) String() string { return proto.CompactTextString(m) }
+func (*EventScheduled) ProtoMessage()    {}
+func (*EventScheduled) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ec30ab3633a79a7, []int{2}
+}
+func (m *EventScheduled) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventScheduled) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventScheduled.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventScheduled) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventScheduled.Merge(m, src)
+}
+func (m *EventScheduled) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventScheduled) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventScheduled.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventScheduled proto.InternalMessageInfo
+
+func (m *EventScheduled) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *EventScheduled) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+// EventExecuted is emitted when a scheduled tx is executed.
+type EventExecuted struct {
+	Id    uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	// success is false if a message failed or the tx ran out of gas, in which
+	// case none of the messages is applied.
+	Success bool `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	// error is the error of the failed message.
+	Error   string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	GasUsed uint64 `protobuf:"varint,5,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+}
+
+func (m *EventExecuted) Reset()         { *m = EventExecuted{} }
+func (m *EventExecuted) String() string { return proto.CompactTextString(m) }
+func (*EventExecuted) ProtoMessage()    {}
+func (*EventExecuted) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ec30ab3633a79a7, []int{3}
+}
+func (m *EventExecuted) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventExecuted) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventExecuted.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventExecuted) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventExecuted.Merge(m, src)
+}
+func (m *EventExecuted) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventExecuted) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventExecuted.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventExecuted proto.InternalMessageInfo
+
+func (m *EventExecuted) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *EventExecuted) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func (m *EventExecuted) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *EventExecuted) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *EventExecuted) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+// EventCanceled is emitted when a scheduled tx is canceled by its owner.
+type EventCanceled struct {
+	Id    uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner string `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+}
+
+func (m *EventCanceled) Reset()         { *m = EventCanceled{} }
+func (m *EventCanceled) String() string { return proto.CompactTextString(m) }
+func (*EventCanceled) ProtoMessage()    {}
+func (*EventCanceled) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9ec30ab3633a79a7, []int{4}
+}
+func (m *EventCanceled) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventCanceled) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventCanceled.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EventCanceled) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventCanceled.Merge(m, src)
+}
+func (m *EventCanceled) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventCanceled) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventCanceled.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventCanceled proto.InternalMessageInfo
+
+func (m *EventCanceled) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *EventCanceled) GetOwner() string {
+	if m != nil {
+		return m.Owner
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ScheduledTx)(nil), "cosmos.scheduler.v1.ScheduledTx")
+	proto.RegisterType((*Params)(nil), "cosmos.scheduler.v1.Params")
+	proto.RegisterType((*EventScheduled)(nil), "cosmos.scheduler.v1.EventScheduled")
+	proto.RegisterType((*EventExecuted)(nil), "cosmos.scheduler.v1.EventExecuted")
+	proto.RegisterType((*EventCanceled)(nil), "cosmos.scheduler.v1.EventCanceled")
+}
+
+func init() {
+	proto.RegisterFile("cosmos/scheduler/v1/scheduler.proto", fileDescriptor_9ec30ab3633a79a7)
+}
+
+var fileDescriptor_9ec30ab3633a79a7 = []byte{
+	// 677 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0x8e, 0xf3, 0xd3, 0xa4, 0x9b, 0x26, 0x52, 0x4d, 0x10, 0x4e, 0x8b, 0x92, 0x28, 0x08, 0x29,
+	0x14, 0xd5, 0x56, 0xca, 0x01, 0xae, 0x4d, 0x29, 0xed, 0x81, 0x8a, 0xc8, 0x2d, 0x17, 0x2e, 0xd1,
+	0xc6, 0xde, 0x3a, 0xab, 0x66, 0xbd, 0x91, 0xc7, 0x09, 0x8e, 0xc4, 0x43, 0xf4, 0xc4, 0xa1, 0x4f,
+	0x00, 0x9c, 0x7a, 0xe8, 0x43, 0x54, 0x9c, 0x2a, 0x4e, 0x9c, 0x5a, 0xd4, 0x1e, 0xfa, 0x1a, 0x68,
+	0xd7, 0xeb, 0x06, 0x41, 0x85, 0x90, 0xca, 0x25, 0xf1, 0xcc, 0x7c, 0xb3, 0xf3, 0x7d, 0xdf, 0xac,
+	0x8d, 0x1e, 0x39, 0x1c, 0x18, 0x07, 0x0b, 0x9c, 0x01, 0x71, 0xc7, 0x43, 0x12, 0x58, 0x93, 0xf6,
+	0x2c, 0x30, 0x47, 0x01, 0x0f, 0xb9, 0x7e, 0x2f, 0x06, 0x99, 0xb3, 0xfc, 0xa4, 0xbd, 0x54, 0x8d,
+	0x93, 0x3d, 0x09, 0xb1, 0x14, 0x42, 0x06, 0x4b, 0x15, 0x8f, 0x7b, 0x3c, 0xce, 0x8b, 0x27, 0x95,
+	0xad, 0x7a, 0x9c, 0x7b, 0x43, 0x62, 0xc9, 0xa8, 0x3f, 0xde, 0xb7, 0xb0, 0x3f, 0x55, 0xa5, 0xfa,
+	0xef, 0xa5, 0x90, 0x32, 0x02, 0x21, 0x66, 0x23, 0x05, 0xa8, 0x29, 0x9a, 0x7d, 0x0c, 0xc4, 0x9a,
+	0xb4, 0xfb, 0x24, 0xc4, 0x6d, 0xcb, 0xe1, 0xd4, 0x57, 0xf5, 0x45, 0xcc, 0xa8, 0xcf, 0x2d, 0xf9,
+	0x1b, 0xa7, 0x9a, 0x1f, 0x33, 0xa8, 0xb8, 0xab, 0x08, 0xbb, 0x7b, 0x91, 0x5e, 0x46, 0x69, 0xea,
+	0x1a, 0x5a, 0x43, 0x6b, 0x65, 0xed, 0x34, 0x75, 0x75, 0x13, 0xe5, 0xf8, 0x7b, 0x9f, 0x04, 0x46,
+	0xba, 0xa1, 0xb5, 0xe6, 0x3b, 0xc6, 0xb7, 0x93, 0xd5, 0x8a, 0x52, 0xb1, 0xee, 0xba, 0x01, 0x01,
+	0xd8, 0x0d, 0x03, 0xea, 0x7b, 0x76, 0x0c, 0xd3, 0x37, 0x51, 0x96, 0x81, 0x07, 0x46, 0xa6, 0x91,
+	0x69, 0x15, 0xd7, 0x2a, 0x66, 0x4c, 0xd9, 0x4c, 0x28, 0x9b, 0xeb, 0xfe, 0xb4, 0xb3, 0xfc, 0xf5,
+	0x64, 0xf5, 0x81, 0x3a, 0x44, 0x50, 0x35, 0x15, 0x55, 0x73, 0x07, 0x3c, 0x5b, 0xb6, 0xeb, 0x8f,
+	0x51, 0x99, 0x44, 0xc4, 0x19, 0x87, 0xa4, 0x37, 0x20, 0xd4, 0x1b, 0x84, 0x46, 0xb6, 0xa1, 0xb5,
+	0x32, 0x76, 0x49, 0x65, 0xb7, 0x65, 0x52, 0xdf, 0x42, 0x0b, 0x09, 0x4c, 0x78, 0x61, 0xe4, 0x1a,
+	0x5a, 0xab, 0xb8, 0xb6, 0xf4, 0xc7, 0xd4, 0xbd, 0xc4, 0xa8, 0x4e, 0xe1, 0xf4, 0xbc, 0xae, 0x1d,
+	0x5e, 0xd4, 0x35, 0xbb, 0xa8, 0x3a, 0x45, 0x4d, 0x5f, 0x46, 0xf3, 0x1e, 0x86, 0xde, 0x90, 0x32,
+	0x1a, 0x1a, 0x73, 0x52, 0x7d, 0xc1, 0xc3, 0xf0, 0x5a, 0xc4, 0x3a, 0xa0, 0xcc, 0x3e, 0x21, 0x46,
+	0x5e, 0x4a, 0xaa, 0x9a, 0xb7, 0x31, 0xdf, 0xe0, 0xd4, 0xef, 0xbc, 0x3a, 0x3d, 0xaf, 0xa7, 0xbe,
+	0x5c, 0xd4, 0x5b, 0x1e, 0x0d, 0x07, 0xe3, 0xbe, 0xe9, 0x70, 0xa6, 0x36, 0xae, 0xfe, 0x56, 0xc1,
+	0x3d, 0xb0, 0xc2, 0xe9, 0x88, 0x80, 0x6c, 0x80, 0xa3, 0xeb, 0xe3, 0x95, 0x85, 0x21, 0xf1, 0xb0,
+	0x33, 0xed, 0x89, 0x35, 0xc1, 0xa7, 0xeb, 0xe3, 0x15, 0xcd, 0x16, 0xd3, 0x9a, 0x9f, 0xd3, 0x68,
+	0xae, 0x8b, 0x03, 0xcc, 0x40, 0x6f, 0xa2, 0x12, 0xc3, 0x51, 0x6f, 0x46, 0x30, 0x5e, 0x4f, 0x91,
+	0xe1, 0x68, 0x2b, 0xe1, 0x58, 0x45, 0x05, 0x81, 0x91, 0xde, 0x8b, 0x55, 0x95, 0xec, 0x3c, 0xc3,
+	0xd1, 0x8e, 0xf0, 0xf2, 0x39, 0x32, 0x44, 0x29, 0x96, 0x4b, 0xb9, 0x0f, 0xbd, 0x11, 0x09, 0x7a,
+	0xfd, 0x21, 0x77, 0x0e, 0x8c, 0x8c, 0x84, 0xde, 0x67, 0x38, 0xda, 0xbc, 0x29, 0x77, 0x49, 0xd0,
+	0x11, 0x45, 0xfd, 0x03, 0x2a, 0x33, 0xea, 0xcb, 0xb9, 0xa3, 0x80, 0x3a, 0x04, 0x8c, 0xac, 0xb4,
+	0xe0, 0xe1, 0xad, 0x16, 0xbc, 0x24, 0x8e, 0x74, 0xe1, 0x85, 0x72, 0xe1, 0xe9, 0x3f, 0xb8, 0xa0,
+	0x7a, 0x94, 0xee, 0x05, 0x46, 0xfd, 0x2d, 0x0c, 0x5d, 0x39, 0x4b, 0x7f, 0x82, 0x16, 0x13, 0xd5,
+	0x33, 0xbe, 0x39, 0xa9, 0xbc, 0x1c, 0x2b, 0x4f, 0x88, 0x36, 0xbb, 0xa8, 0xbc, 0x39, 0x21, 0x7e,
+	0x78, 0x73, 0x91, 0xef, 0x7a, 0x8d, 0x9b, 0x47, 0x1a, 0x2a, 0xc9, 0x23, 0x63, 0x5b, 0xee, 0x7e,
+	0xa2, 0x6e, 0xa0, 0x3c, 0x8c, 0x1d, 0x87, 0x00, 0x48, 0xd3, 0x0b, 0x76, 0x12, 0xea, 0x15, 0x94,
+	0x23, 0x41, 0xc0, 0x03, 0x79, 0xc5, 0xe7, 0xed, 0x38, 0x10, 0x0b, 0x15, 0xd2, 0xc7, 0x40, 0x5c,
+	0xa5, 0x3a, 0xef, 0x61, 0x78, 0x0b, 0xc4, 0x6d, 0xbe, 0x51, 0xdc, 0x36, 0xb0, 0xef, 0x90, 0xff,
+	0xa0, 0xb6, 0xb3, 0x7d, 0x7a, 0x59, 0xd3, 0xce, 0x2e, 0x6b, 0xda, 0x8f, 0xcb, 0x9a, 0x76, 0x78,
+	0x55, 0x4b, 0x9d, 0x5d, 0xd5, 0x52, 0xdf, 0xaf, 0x6a, 0xa9, 0x77, 0xe6, 0x5f, 0x97, 0x18, 0xfd,
+	0xf2, 0x41, 0x94, 0x0b, 0xed, 0xcf, 0xc9, 0x57, 0xee, 0xd9, 0xcf, 0x01, 0x00, 0x26, 0x9e, 0x18,
+	0x44, 0x31, 0x05, 0x00, 0x00,
+}
+
+func (m *ScheduledTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScheduledTx) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduledTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Fee) > 0 {
+		for iNdEx := len(m.Fee) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Fee[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintScheduler(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.GasLimit != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.GasLimit))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ExecuteTime != nil {
+		n1, err1 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(*m.ExecuteTime, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(*m.ExecuteTime):])
+		if err1 != nil {
+			return 0, err1
+		}
+		i -= n1
+		i = encodeVarintScheduler(dAtA, i, uint64(n1))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.ExecuteHeight != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.ExecuteHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Msgs) > 0 {
+		for iNdEx := len(m.Msgs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Msgs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintScheduler(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintScheduler(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxGasPerBlock != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.MaxGasPerBlock))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.MinGasPrices) > 0 {
+		for iNdEx := len(m.MinGasPrices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MinGasPrices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintScheduler(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.MaxExecutionsPerBlock != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.MaxExecutionsPerBlock))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxMsgs != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.MaxMsgs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MaxGasLimit != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.MaxGasLimit))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventScheduled) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventScheduled) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventScheduled) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintScheduler(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventExecuted) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventExecuted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventExecuted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GasUsed != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.GasUsed))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintScheduler(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Success {
+		i--
+		if m.Success {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintScheduler(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventCanceled) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventCanceled) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventCanceled) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintScheduler(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != 0 {
+		i = encodeVarintScheduler(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintScheduler(dAtA []byte, offset int, v uint64) int {
+	offset -= sovScheduler(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ScheduledTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovScheduler(uint64(m.Id))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovScheduler(uint64(l))
+	}
+	if len(m.Msgs) > 0 {
+		for _, e := range m.Msgs {
+			l = e.Size()
+			n += 1 + l + sovScheduler(uint64(l))
+		}
+	}
+	if m.ExecuteHeight != 0 {
+		n += 1 + sovScheduler(uint64(m.ExecuteHeight))
+	}
+	if m.ExecuteTime != nil {
+		l = github_com_cosmos_gogoproto_types.SizeOfStdTime(*m.ExecuteTime)
+		n += 1 + l + sovScheduler(uint64(l))
+	}
+	if m.GasLimit != 0 {
+		n += 1 + sovScheduler(uint64(m.GasLimit))
+	}
+	if len(m.Fee) > 0 {
+		for _, e := range m.Fee {
+			l = e.Size()
+			n += 1 + l + sovScheduler(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxGasLimit != 0 {
+		n += 1 + sovScheduler(uint64(m.MaxGasLimit))
+	}
+	if m.MaxMsgs != 0 {
+		n += 1 + sovScheduler(uint64(m.MaxMsgs))
+	}
+	if m.MaxExecutionsPerBlock != 0 {
+		n += 1 + sovScheduler(uint64(m.MaxExecutionsPerBlock))
+	}
+	if len(m.MinGasPrices) > 0 {
+		for _, e := range m.MinGasPrices {
+			l = e.Size()
+			n += 1 + l + sovScheduler(uint64(l))
+		}
+	}
+	if m.MaxGasPerBlock != 0 {
+		n += 1 + sovScheduler(uint64(m.MaxGasPerBlock))
+	}
+	return n
+}
+
+func (m *EventScheduled) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovScheduler(uint64(m.Id))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovScheduler(uint64(l))
+	}
+	return n
+}
+
+func (m *EventExecuted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovScheduler(uint64(m.Id))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovScheduler(uint64(l))
+	}
+	if m.Success {
+		n += 2
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovScheduler(uint64(l))
+	}
+	if m.GasUsed != 0 {
+		n += 1 + sovScheduler(uint64(m.GasUsed))
+	}
+	return n
+}
+
+func (m *EventCanceled) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovScheduler(uint64(m.Id))
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovScheduler(uint64(l))
+	}
+	return n
+}
+
+func sovScheduler(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozScheduler(x uint64) (n int) {
+	return sovScheduler(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ScheduledTx) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowScheduler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScheduledTx: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScheduledTx: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msgs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msgs = append(m.Msgs, &types.Any{})
+			if err := m.Msgs[len(m.Msgs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteHeight", wireType)
+			}
+			m.ExecuteHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExecuteHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ExecuteTime == nil {
+				m.ExecuteTime = new(time.Time)
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(m.ExecuteTime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasLimit", wireType)
+			}
+			m.GasLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fee = append(m.Fee, types1.Coin{})
+			if err := m.Fee[len(m.Fee)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipScheduler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowScheduler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGasLimit", wireType)
+			}
+			m.MaxGasLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGasLimit |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxMsgs", wireType)
+			}
+			m.MaxMsgs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxMsgs |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxExecutionsPerBlock", wireType)
+			}
+			m.MaxExecutionsPerBlock = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxExecutionsPerBlock |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinGasPrices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MinGasPrices = append(m.MinGasPrices, types1.DecCoin{})
+			if err := m.MinGasPrices[len(m.MinGasPrices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGasPerBlock", wireType)
+			}
+			m.MaxGasPerBlock = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGasPerBlock |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipScheduler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventScheduled) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowScheduler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventScheduled: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventScheduled: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipScheduler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventExecuted) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowScheduler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventExecuted: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventExecuted: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Success = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+			}
+			m.GasUsed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasUsed |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipScheduler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventCanceled) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowScheduler
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventCanceled: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventCanceled: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipScheduler(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthScheduler
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipScheduler(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowScheduler
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowScheduler
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthScheduler
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupScheduler
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthScheduler
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthScheduler        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowScheduler          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupScheduler = fmt.Errorf("proto: unexpected end of group")
+)
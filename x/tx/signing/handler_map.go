@@ -49,6 +49,12 @@ func (h *HandlerMap) DefaultMode() signingv1beta1.SignMode {
 	return h.defaultMode
 }
 
+// GetHandler returns the handler of the requested mode, if supported.
+func (h *HandlerMap) GetHandler(signMode signingv1beta1.SignMode) (SignModeHandler, bool) {
+	handler, ok := h.signModeHandlers[signMode]
+	return handler, ok
+}
+
 // GetSignBytes returns the sign bytes for the transaction for the requested mode.
 func (h *HandlerMap) GetSignBytes(ctx context.Context, signMode signingv1beta1.SignMode, signerData SignerData, txData TxData) ([]byte, error) {
 	handler, ok := h.signModeHandlers[signMode]
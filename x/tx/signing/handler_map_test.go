@@ -47,4 +47,10 @@ func TestNewHandlerMap(t *testing.T) {
 	handlerMap := signing.NewHandlerMap(dh, aminoJSONHandler{})
 	require.Equal(t, dh.Mode(), handlerMap.DefaultMode())
 	require.NotEqual(t, ah.Mode(), handlerMap.DefaultMode())
+
+	handler, ok := handlerMap.GetHandler(ah.Mode())
+	require.True(t, ok)
+	require.Equal(t, ah, handler)
+	_, ok = handlerMap.GetHandler(signingv1beta1.SignMode_SIGN_MODE_TEXTUAL)
+	require.False(t, ok)
 }
@@ -65,6 +65,14 @@ func TestE2EJSONTestcases(t *testing.T) {
 			require.NoError(t, err)
 			require.Equal(t, tc.Screens, screens)
 
+			// Make sure the handler renders the same screens.
+			screens, err = tr.GetScreens(ctx, signerData, signing.TxData{
+				BodyBytes:     bodyBz,
+				AuthInfoBytes: authInfoBz,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.Screens, screens)
+
 			// Make sure CBOR match.
 			signDoc, err := tr.GetSignBytes(ctx, signerData, signing.TxData{
 				BodyBytes:     bodyBz,
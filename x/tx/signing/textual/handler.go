@@ -191,9 +191,9 @@ func (r *SignModeHandler) DefineMessageRenderer(name protoreflect.FullName, vr V
 	r.messages[name] = vr
 }
 
-// GetSignBytes returns the transaction sign bytes which is the CBOR representation
-// of a list of screens created from the TX data.
-func (r *SignModeHandler) GetSignBytes(ctx context.Context, signerData signing.SignerData, txData signing.TxData) ([]byte, error) {
+// GetScreens returns the screens of the transaction shown to the signer, which
+// are the screens encoded in its sign bytes.
+func (r *SignModeHandler) GetScreens(ctx context.Context, signerData signing.SignerData, txData signing.TxData) ([]Screen, error) {
 	data := &textualpb.TextualData{
 		BodyBytes:     txData.BodyBytes,
 		AuthInfoBytes: txData.AuthInfoBytes,
@@ -206,7 +206,13 @@ func (r *SignModeHandler) GetSignBytes(ctx context.Context, signerData signing.S
 		},
 	}
 
-	screens, err := NewTxValueRenderer(r).Format(ctx, protoreflect.ValueOf(data.ProtoReflect()))
+	return NewTxValueRenderer(r).Format(ctx, protoreflect.ValueOf(data.ProtoReflect()))
+}
+
+// GetSignBytes returns the transaction sign bytes which is the CBOR representation
+// of a list of screens created from the TX data.
+func (r *SignModeHandler) GetSignBytes(ctx context.Context, signerData signing.SignerData, txData signing.TxData) ([]byte, error) {
+	screens, err := r.GetScreens(ctx, signerData, txData)
 	if err != nil {
 		return nil, err
 	}